@@ -6,6 +6,7 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
@@ -17,13 +18,16 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/smartbch/moeingevm/ebp"
 	"github.com/smartbch/moeingevm/types"
 	"github.com/smartbch/smartbch/app"
 	"github.com/smartbch/smartbch/crosschain"
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
+	"github.com/smartbch/smartbch/internal/ethutils"
 	"github.com/smartbch/smartbch/param"
 	"github.com/smartbch/smartbch/staking"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/stateproof"
 )
 
 var _ BackendService = &apiBackend{}
@@ -32,6 +36,12 @@ const (
 	// Ethereum Wire Protocol
 	// https://github.com/ethereum/devp2p/blob/master/caps/eth.md
 	protocolVersion = 63
+
+	// how many times GetProof retries when the account is changed by new blocks
+	maxGetProofTries = 3
+
+	// how many TXs in mempool are decoded by the txpool RPCs at most
+	maxTxPoolTxs = 10000
 )
 
 var SEP206ContractAddress [20]byte = [20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x27, 0x11}
@@ -45,7 +55,6 @@ type apiBackend struct {
 	//chainSideFeed event.Feed
 	//chainHeadFeed event.Feed
 	//blockProcFeed event.Feed
-	txFeed event.Feed
 	//logsFeed   event.Feed
 	rmLogsFeed event.Feed
 	//pendingLogsFeed event.Feed
@@ -80,6 +89,76 @@ func (backend *apiBackend) GetStorageAt(address common.Address, key string, heig
 	return ctx.GetStorageAt(acc.Sequence(), key)
 }
 
+// GetProof proves the account, its code hash and storage slots in the latest world state. The sequence
+// indexing the slots is read before the proofs are generated, so it retries if a new block changes it.
+func (backend *apiBackend) GetProof(address common.Address, slots []common.Hash) (*StateProof, error) {
+	for i := 0; i < maxGetProofTries; i++ {
+		ctx := backend.app.GetRpcContext()
+		acc := ctx.GetAccount(address)
+		ctx.Close(false)
+
+		isSEP206 := address == common.Address(SEP206ContractAddress)
+		seq := uint64(2000)
+		if !isSEP206 && acc != nil {
+			seq = acc.Sequence()
+		}
+		proof, err := backend.getProof(address, seq, slots, isSEP206 || acc != nil)
+		if err != nil {
+			return nil, err
+		}
+		if isSEP206 || (acc == nil && proof.Account == nil) ||
+			(acc != nil && proof.Account != nil && proof.Account.Sequence() == seq) {
+			return proof, nil
+		}
+	}
+	return nil, errors.New("the account is changing, please retry later")
+}
+
+func (backend *apiBackend) getProof(address common.Address, seq uint64, slots []common.Hash, withStorage bool) (*StateProof, error) {
+	keys := [][]byte{types.GetAccountKey(address), types.GetBytecodeKey(address)}
+	if withStorage {
+		for _, slot := range slots {
+			keys = append(keys, types.GetValueKey(seq, string(slot[:])))
+		}
+	}
+	appHash, proofs, err := backend.app.GetStateProofs(keys)
+	if err != nil {
+		return nil, err
+	}
+	result := &StateProof{
+		AppHash:       appHash,
+		AccountProof:  proofs[0],
+		CodeProof:     proofs[1],
+		StorageSeq:    seq,
+		StorageValues: make([][]byte, len(slots)),
+		StorageProofs: make([][]byte, len(slots)),
+	}
+	if result.AccountProof != nil {
+		if result.Account, err = stateproof.VerifyAccount(appHash, address, result.AccountProof); err != nil {
+			return nil, err
+		}
+	}
+	if result.CodeProof != nil {
+		if result.CodeHash, err = stateproof.VerifyCodeHash(appHash, address, result.CodeProof); err != nil {
+			return nil, err
+		}
+	}
+	if !withStorage {
+		return result, nil
+	}
+	for i, slot := range slots {
+		result.StorageProofs[i] = proofs[2+i]
+		if result.StorageProofs[i] == nil {
+			continue
+		}
+		result.StorageValues[i], err = stateproof.VerifyStorage(appHash, seq, slot, result.StorageProofs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 func (backend *apiBackend) GetCode(contract common.Address, height int64) (bytecode []byte, codeHash []byte) {
 	ctx := backend.app.GetRpcContextAtHeight(height)
 	defer ctx.Close(false)
@@ -125,6 +204,25 @@ func (backend *apiBackend) GetTransaction(txHash common.Hash) (tx *types.Transac
 	return
 }
 
+// GetTypedTxFields returns nil if the TX is a legacy one or its typed fields are not recorded
+func (backend *apiBackend) GetTypedTxFields(txHash common.Hash) (fields *ethutils.TypedTxFields) {
+	ctx := backend.app.GetHistoryOnlyContext()
+	defer ctx.Close(false)
+
+	ctx.Db.GetTxByHash(txHash, func(b []byte) bool {
+		tx := &types.Transaction{}
+		leftover, err := tx.UnmarshalMsg(b[65:])
+		if err != nil || tx.Hash != txHash {
+			return false
+		}
+		if len(leftover) != 0 {
+			fields, _ = ethutils.DecodeTypedTxFields(leftover)
+		}
+		return true // stop retry
+	})
+	return
+}
+
 func (backend *apiBackend) BlockByHash(hash common.Hash) (*types.Block, error) {
 	ctx := backend.app.GetHistoryOnlyContext()
 	defer ctx.Close(false)
@@ -170,6 +268,34 @@ func (backend *apiBackend) SendRawTx(signedTx []byte) (common.Hash, error) {
 // CallForSbch use app.RunTxForSbchRpc and returns more detailed result info
 func (backend *apiBackend) CallForSbch(tx *gethtypes.Transaction, sender common.Address, height int64) *CallDetail {
 	runner, _ := backend.app.RunTxForSbchRpc(tx, sender, height)
+	return newCallDetail(runner)
+}
+
+// CallForTrace is like CallForSbch, but runs the tx under context of block#height, just like Call
+func (backend *apiBackend) CallForTrace(tx *gethtypes.Transaction, sender common.Address, height int64) *CallDetail {
+	runner, _ := backend.app.RunTxForRpc(tx, sender, false, height)
+	return newCallDetail(runner)
+}
+
+// CallsForTrace re-executes the leading TXs of block#height in order, each of which runs on the changes made
+// by the ones before it. Nil is returned if the block is not found.
+func (backend *apiBackend) CallsForTrace(txs []*types.Transaction, height int64) []*CallDetail {
+	runners := backend.app.RunTxsForTrace(txs, height)
+	if runners == nil {
+		return nil
+	}
+	details := make([]*CallDetail, len(runners))
+	for i, runner := range runners {
+		details[i] = newCallDetail(runner)
+	}
+	return details
+}
+
+// newCallDetail returns nil if the runner is nil, which means the block to run the tx is not found
+func newCallDetail(runner *ebp.TxRunner) *CallDetail {
+	if runner == nil {
+		return nil
+	}
 	return &CallDetail{
 		Status:                 runner.Status,
 		GasUsed:                runner.GasUsed,
@@ -196,7 +322,21 @@ func (backend *apiBackend) QueryLogs(addresses []common.Address, topics [][]comm
 	ctx := backend.app.GetHistoryOnlyContext()
 	defer ctx.Close(false)
 
-	return ctx.QueryLogs(addresses, topics, startHeight, endHeight, filter)
+	logs, err := ctx.QueryLogs(addresses, topics, startHeight, endHeight, filter)
+	if err != nil {
+		return nil, err
+	}
+	stakingLogs, err := backend.app.GetStakingLogs(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range stakingLogs {
+		if filter == nil || filter(log.Address, types.ToGethHashes(log.Topics), addresses, topics) {
+			logs = append(logs, log)
+		}
+	}
+	sortLogsByBlock(logs)
+	return logs, nil
 }
 
 func (backend *apiBackend) QueryTxBySrc(addr common.Address, startHeight, endHeight, limit uint32) (tx []*types.Transaction, sigs [][65]byte, err error) {
@@ -221,7 +361,69 @@ func (backend *apiBackend) SbchQueryLogs(addr common.Address, topics []common.Ha
 	ctx := backend.app.GetHistoryOnlyContext()
 	defer ctx.Close(false)
 
-	return ctx.BasicQueryLogs(addr, topics, startHeight, endHeight, limit)
+	logs, err := ctx.BasicQueryLogs(addr, topics, startHeight, endHeight, limit)
+	if err != nil || addr != staking.StakingContractAddress {
+		return logs, err
+	}
+	reverse := startHeight > endHeight // moeingdb returns the logs in descending order
+	if reverse {
+		startHeight, endHeight = endHeight, startHeight
+	}
+	stakingLogs, err := backend.app.GetStakingLogs(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+	var matched []types.Log
+	for _, log := range stakingLogs {
+		if containsAllTopics(log.Topics, topics) {
+			matched = append(matched, log)
+		}
+	}
+	if reverse { // the staking logs are the last ones of their blocks
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+		logs = append(matched, logs...)
+		sort.SliceStable(logs, func(i, j int) bool {
+			return logs[i].BlockNumber > logs[j].BlockNumber
+		})
+	} else {
+		logs = append(logs, matched...)
+		sortLogsByBlock(logs)
+	}
+	if limit != 0 && len(logs) > int(limit) {
+		logs = logs[:limit]
+	}
+	return logs, nil
+}
+
+// GetStakingLogs returns the engine-side staking events of the blocks in [startHeight, endHeight), which
+// belong to no TX
+func (backend *apiBackend) GetStakingLogs(startHeight, endHeight uint32) ([]types.Log, error) {
+	return backend.app.GetStakingLogs(startHeight, endHeight)
+}
+
+// sortLogsByBlock moves the staking logs, which are appended after the TXs' logs, into their blocks
+func sortLogsByBlock(logs []types.Log) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].BlockNumber < logs[j].BlockNumber
+	})
+}
+
+func containsAllTopics(logTopics [][32]byte, topics []common.Hash) bool {
+	for _, topic := range topics {
+		found := false
+		for _, t := range logTopics {
+			if t == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 func (backend *apiBackend) GetTxListByHeight(height uint32) (txs []*types.Transaction, sigs [][65]byte, err error) {
@@ -314,6 +516,30 @@ func (backend *apiBackend) GetCCEpochs(start, end uint64) ([]*cctypes.CCEpoch, e
 	return result, nil
 }
 
+func (backend *apiBackend) GetCCUTXOs(offset, limit uint64) ([]*crosschain.UTXOInfo, error) {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	return crosschain.GetUTXOInfos(ctx, offset, limit)
+}
+
+func (backend *apiBackend) GetCCUTXO(utxo [36]byte) *crosschain.UTXOInfo {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	return crosschain.GetUTXOInfo(ctx, utxo)
+}
+
+func (backend *apiBackend) GetCCPegStatus() *crosschain.PegStatus {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	return crosschain.GetPegStatus(ctx)
+}
+
+func (backend *apiBackend) GetCCRedemptions(txHash [32]byte) []*cctypes.Redemption {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	return crosschain.GetRedemptionsByTx(ctx, txHash)
+}
+
 func (backend *apiBackend) HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Header, error) {
 	if blockNr == rpc.LatestBlockNumber {
 		blockNr = rpc.BlockNumber(backend.app.GetLatestBlockNum())
@@ -393,6 +619,10 @@ func (backend *apiBackend) GetLogs(ctx context.Context, blockHash common.Hash) (
 				logs = append(logs, txLogs)
 			}
 		}
+		stakingLogs, err := backend.app.GetStakingLogs(uint32(block.Number), uint32(block.Number)+1)
+		if err == nil && len(stakingLogs) != 0 {
+			logs = append(logs, types.ToGethLogs(stakingLogs))
+		}
 	}
 
 	return logs, nil
@@ -405,7 +635,7 @@ func (backend *apiBackend) SubscribeLogsEvent(ch chan<- []*gethtypes.Log) event.
 	return backend.app.SubscribeLogsEvent(ch)
 }
 func (backend *apiBackend) SubscribeNewTxsEvent(ch chan<- gethcore.NewTxsEvent) event.Subscription {
-	return backend.txFeed.Subscribe(ch)
+	return backend.app.SubscribeNewTxsEvent(ch)
 }
 func (backend *apiBackend) SubscribeRemovedLogsEvent(ch chan<- gethcore.RemovedLogsEvent) event.Subscription {
 	return backend.rmLogsFeed.Subscribe(ch)
@@ -447,6 +677,28 @@ func (backend *apiBackend) ValidatorWatchInfos() stakingtypes.ValidatorWatchInfo
 	return staking.LoadValidatorWatchInfo(ctx)
 }
 
+func (backend *apiBackend) UnbondingEntries(addr common.Address) []*stakingtypes.UnbondingEntry {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	info := staking.LoadStakingInfo(ctx)
+	return info.GetUnbondingEntriesOf(addr)
+}
+
+// [start, end)
+func (backend *apiBackend) RewardLedgers(start, end uint64) ([]*stakingtypes.RewardLedger, error) {
+	if start >= end {
+		return nil, errors.New("invalid start or empty reward ledgers")
+	}
+	if end > math.MaxInt64 {
+		end = math.MaxInt64
+	}
+	return backend.app.GetRewardLedgers(int64(start), int64(end)), nil
+}
+
+func (backend *apiBackend) SlashEvents(addr common.Address) []*stakingtypes.SlashEvent {
+	return backend.app.GetSlashEvents(addr, backend.app.GetRpcMaxLogResults())
+}
+
 func (backend *apiBackend) IsArchiveMode() bool {
 	return backend.app.IsArchiveMode()
 }
@@ -14,7 +14,9 @@ import (
 
 	motypes "github.com/smartbch/moeingevm/types"
 	"github.com/smartbch/smartbch/app"
+	"github.com/smartbch/smartbch/crosschain"
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
+	"github.com/smartbch/smartbch/internal/ethutils"
 	"github.com/smartbch/smartbch/staking/types"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
 )
@@ -30,6 +32,20 @@ type CallDetail struct {
 	RwLists                *motypes.ReadWriteLists
 }
 
+// StateProof holds an account, its code hash and storage slots, with their proofs against AppHash, which
+// can be verified by package stateproof. A proof proves the value or the absence of a KV pair, and a nil
+// proof means the KV pair does not exist but a non-archive node cannot prove it.
+type StateProof struct {
+	AppHash       []byte
+	Account       *motypes.AccountInfo // nil if the account does not exist
+	AccountProof  []byte
+	CodeHash      common.Hash
+	CodeProof     []byte
+	StorageSeq    uint64 // the sequence indexing the storage slots
+	StorageValues [][]byte
+	StorageProofs [][]byte
+}
+
 type FilterService interface {
 	HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*motypes.Header, error)
 	HeaderByHash(ctx context.Context, blockHash common.Hash) (*motypes.Header, error)
@@ -80,11 +96,13 @@ type BackendService interface {
 	// Transaction pool API
 	SendRawTx(signedTx []byte) (common.Hash, error)
 	GetTransaction(txHash common.Hash) (tx *motypes.Transaction, sig [65]byte, err error)
+	GetTypedTxFields(txHash common.Hash) *ethutils.TypedTxFields
 	//GetPoolTransactions() (types.Transactions, error)
 	//GetPoolTransaction(txHash common.Hash) *types.Transaction
 	//GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error)
-	//Stats() (pending int, queued int)
-	//TxPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	TxPoolStats() (pending int, queued int)
+	TxPoolContent() (pending, queued map[common.Address]gethtypes.Transactions)
+	TxPoolContentFrom(addr common.Address) (pending, queued gethtypes.Transactions)
 
 	// Filter API
 	//BloomStatus() (uint64, uint64)
@@ -105,14 +123,18 @@ type BackendService interface {
 	GetBalance(address common.Address, height int64) (*big.Int, error)
 	GetCode(contract common.Address, height int64) (bytecode []byte, codeHash []byte)
 	GetStorageAt(address common.Address, key string, height int64) []byte
+	GetProof(address common.Address, slots []common.Hash) (*StateProof, error)
 	Call(tx *gethtypes.Transaction, from common.Address, height int64) (statusCode int, retData []byte)
 	CallForSbch(tx *gethtypes.Transaction, sender common.Address, height int64) *CallDetail
+	CallForTrace(tx *gethtypes.Transaction, sender common.Address, height int64) *CallDetail
+	CallsForTrace(txs []*motypes.Transaction, height int64) []*CallDetail
 	EstimateGas(tx *gethtypes.Transaction, from common.Address, height int64) (statusCode int, retData []byte, gas int64)
 	QueryLogs(addresses []common.Address, topics [][]common.Hash, startHeight, endHeight uint32, filter motypes.FilterFunc) ([]motypes.Log, error)
 	QueryTxBySrc(address common.Address, startHeight, endHeight, limit uint32) (tx []*motypes.Transaction, sigs [][65]byte, err error)
 	QueryTxByDst(address common.Address, startHeight, endHeight, limit uint32) (tx []*motypes.Transaction, sigs [][65]byte, err error)
 	QueryTxByAddr(address common.Address, startHeight, endHeight, limit uint32) (tx []*motypes.Transaction, sigs [][65]byte, err error)
 	SbchQueryLogs(addr common.Address, topics []common.Hash, startHeight, endHeight, limit uint32) ([]motypes.Log, error)
+	GetStakingLogs(startHeight, endHeight uint32) ([]motypes.Log, error)
 	GetTxListByHeight(height uint32) (tx []*motypes.Transaction, sigs [][65]byte, err error)
 	GetTxListByHeightWithRange(height uint32, start, end int) (tx []*motypes.Transaction, sigs [][65]byte, err error)
 	GetFromAddressCount(addr common.Address) int64
@@ -123,6 +145,10 @@ type BackendService interface {
 	GetEpochList(from string) ([]*types.Epoch, error)
 	GetCurrEpoch() *types.Epoch
 	GetCCEpochs(start, end uint64) ([]*cctypes.CCEpoch, error)
+	GetCCUTXOs(offset, limit uint64) ([]*crosschain.UTXOInfo, error)
+	GetCCUTXO(utxo [36]byte) *crosschain.UTXOInfo
+	GetCCPegStatus() *crosschain.PegStatus
+	GetCCRedemptions(txHash [32]byte) []*cctypes.Redemption
 	GetSeq(address common.Address) uint64
 	GetPosVotes() map[[32]byte]*big.Int
 	GetSyncBlock(height int64) (blk []byte, err error)
@@ -133,6 +159,9 @@ type BackendService interface {
 	ValidatorsInfo(height int64) app.ValidatorsInfo
 	ValidatorOnlineInfos() (int64, stakingtypes.ValidatorOnlineInfos)
 	ValidatorWatchInfos() stakingtypes.ValidatorWatchInfos
+	UnbondingEntries(addr common.Address) []*stakingtypes.UnbondingEntry
+	RewardLedgers(start, end uint64) ([]*stakingtypes.RewardLedger, error)
+	SlashEvents(addr common.Address) []*stakingtypes.SlashEvent
 
 	IsArchiveMode() bool
 
@@ -38,6 +38,7 @@ type Info struct {
 type ITmNode interface {
 	BroadcastTxSync(tx tmtypes.Tx) (common.Hash, error)
 	GetNodeInfo() Info
+	GetUnconfirmedTxs(max int) tmtypes.Txs
 }
 
 type tmNode struct {
@@ -93,3 +94,8 @@ func (tmNode *tmNode) GetNodeInfo() Info {
 	//i.NextBlock.Hash = bi.Hash
 	return i
 }
+
+// GetUnconfirmedTxs returns at most 'max' TXs in mempool, in the order they are reaped for a block
+func (tmNode *tmNode) GetUnconfirmedTxs(max int) tmtypes.Txs {
+	return tmNode.node.Mempool().ReapMaxTxs(max)
+}
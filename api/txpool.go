@@ -0,0 +1,102 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartbch/smartbch/internal/ethutils"
+)
+
+/*-----------------------txpool----------------------------*/
+
+// The TXs in tendermint's mempool are grouped by their senders and sorted by nonce. A TX is "pending"
+// if it has been accepted by CheckTx against the frontier (or, if its sender is not in the frontier
+// yet, if its nonce follows the sender's nonce without gap). The other TXs are "queued": they cannot
+// be executed until the missing nonces are filled.
+//
+// Only the first maxTxPoolTxs TXs in mempool are looked at, such that a crowded mempool does not make each call
+// decode all of its TXs.
+
+func (backend *apiBackend) TxPoolStats() (pending int, queued int) {
+	pendingTxs, queuedTxs := backend.TxPoolContent()
+	for _, txs := range pendingTxs {
+		pending += len(txs)
+	}
+	for _, txs := range queuedTxs {
+		queued += len(txs)
+	}
+	return
+}
+
+func (backend *apiBackend) TxPoolContent() (pending, queued map[common.Address]gethtypes.Transactions) {
+	pending = make(map[common.Address]gethtypes.Transactions)
+	queued = make(map[common.Address]gethtypes.Transactions)
+	for sender, txs := range backend.getPoolTxsBySender() {
+		pending[sender], queued[sender] = backend.splitPoolTxs(sender, txs)
+		if len(pending[sender]) == 0 {
+			delete(pending, sender)
+		}
+		if len(queued[sender]) == 0 {
+			delete(queued, sender)
+		}
+	}
+	return
+}
+
+func (backend *apiBackend) TxPoolContentFrom(addr common.Address) (pending, queued gethtypes.Transactions) {
+	txs := backend.getPoolTxsBySender()[addr]
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	return backend.splitPoolTxs(addr, txs)
+}
+
+// getPoolTxsBySender decodes the TXs in mempool and groups them by their senders, which are cached by CheckTx
+// so the signatures are not recovered again
+func (backend *apiBackend) getPoolTxsBySender() map[common.Address]gethtypes.Transactions {
+	result := make(map[common.Address]gethtypes.Transactions)
+	if backend.node == nil {
+		return result
+	}
+	for _, rawTx := range backend.node.GetUnconfirmedTxs(maxTxPoolTxs) {
+		tx, err := ethutils.DecodeTx(rawTx)
+		if err != nil {
+			continue
+		}
+		sender, err := backend.app.GetTxSender(tx)
+		if err != nil {
+			continue
+		}
+		result[sender] = append(result[sender], tx)
+	}
+	for _, txs := range result {
+		sort.Sort(gethtypes.TxByNonce(txs))
+	}
+	return result
+}
+
+// splitPoolTxs splits the TXs of sender, which are sorted by nonce, into pending ones and queued ones
+func (backend *apiBackend) splitPoolTxs(sender common.Address, txs gethtypes.Transactions) (pending, queued gethtypes.Transactions) {
+	nextNonce, inFrontier := backend.app.GetFrontierNonce(sender)
+	if inFrontier {
+		for _, tx := range txs {
+			if tx.Nonce() < nextNonce {
+				pending = append(pending, tx)
+			} else {
+				queued = append(queued, tx)
+			}
+		}
+		return
+	}
+	nextNonce, _ = backend.GetNonce(sender, -1)
+	for i, tx := range txs {
+		if tx.Nonce() != nextNonce {
+			return pending, txs[i:]
+		}
+		pending = append(pending, tx)
+		nextNonce++
+	}
+	return
+}
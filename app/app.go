@@ -10,6 +10,7 @@ import (
 	"math"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -18,10 +19,12 @@ import (
 	gethcmn "github.com/ethereum/go-ethereum/common"
 	gethcore "github.com/ethereum/go-ethereum/core"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
-	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/holiman/uint256"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/tecbot/gorocksdb"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
@@ -36,10 +39,13 @@ import (
 	"github.com/smartbch/moeingevm/ebp"
 	"github.com/smartbch/moeingevm/types"
 
+	"github.com/smartbch/smartbch/crosschain"
+	cctypes "github.com/smartbch/smartbch/crosschain/types"
 	"github.com/smartbch/smartbch/internal/ethutils"
 	"github.com/smartbch/smartbch/param"
 	"github.com/smartbch/smartbch/staking"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/stateproof"
 	"github.com/smartbch/smartbch/watcher"
 )
 
@@ -59,6 +65,7 @@ const (
 	HasPendingTx         uint32 = 108
 	MempoolBusy          uint32 = 109
 	GasLimitTooSmall     uint32 = 110
+	GasTipAboveFeeCap    uint32 = 111
 )
 
 var (
@@ -66,6 +73,9 @@ var (
 	errNoSyncBlock = errors.New("syncdb block is not ready")
 )
 
+// the accepted TXs waiting to be sent to the subscribers, the notifications of more TXs are dropped
+const newTxChanSize = 4096
+
 const (
 	// for height=8000000 chain stuck fix
 	customValidatorUpdateBeginHeight = 8_000_001
@@ -84,17 +94,25 @@ type IApp interface {
 	GetHistoryOnlyContext() *types.Context
 	RunTxForRpc(gethTx *gethtypes.Transaction, sender gethcmn.Address, estimateGas bool, height int64) (*ebp.TxRunner, int64)
 	RunTxForSbchRpc(gethTx *gethtypes.Transaction, sender gethcmn.Address, height int64) (*ebp.TxRunner, int64)
+	RunTxsForTrace(txs []*types.Transaction, height int64) []*ebp.TxRunner
 	GetCurrEpoch() *stakingtypes.Epoch
 	GetWatcherEpochList() []*stakingtypes.Epoch
 	GetAppEpochList() []*stakingtypes.Epoch
 	GetLatestBlockNum() int64
 	SubscribeChainEvent(ch chan<- types.ChainEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*gethtypes.Log) event.Subscription
+	SubscribeNewTxsEvent(ch chan<- gethcore.NewTxsEvent) event.Subscription
 	LoadBlockInfo() *types.BlockInfo
 	GetValidatorsInfo(height int64) ValidatorsInfo
 	IsArchiveMode() bool
 	GetBlockForSync(height int64) (blk []byte, err error)
 	GetRpcMaxLogResults() int
+	GetFrontierNonce(addr gethcmn.Address) (nonce uint64, exist bool)
+	GetTxSender(tx *gethtypes.Transaction) (gethcmn.Address, error)
+	GetStateProofs(keys [][]byte) (appHash []byte, proofs [][]byte, err error)
+	GetStakingLogs(startHeight, endHeight uint32) ([]types.Log, error)
+	GetRewardLedgers(startEpoch, endEpoch int64) []*stakingtypes.RewardLedger
+	GetSlashEvents(validator gethcmn.Address, limit int) []*stakingtypes.SlashEvent
 }
 
 type App struct {
@@ -109,8 +127,9 @@ type App struct {
 	root         *store.RootStore
 	historyStore modbtypes.DB
 	syncDB       *syncdb.SyncDB
+	ledgerDB     *rewardLedgerDB // the side index of reward ledgers and slash events
 
-	currHeight int64
+	currHeight int64 // written atomically, since the signer reads it from the goroutines of CheckTx and RPC
 	trunk      *store.TrunkStore
 	checkTrunk *store.TrunkStore
 	// 'block' contains some meta information of a block. It is collected during BeginBlock&DeliverTx,
@@ -129,21 +148,28 @@ type App struct {
 	lastMinGasPrice uint64      // updated in refresh, used in next block's CheckTx and Commit. It needs
 	// to be reloaded in NewApp
 	txid2sigMap map[[32]byte][65]byte //updated in DeliverTx, flushed in refresh
+	// updated in DeliverTx, moved into world state in Commit and removed from it when the typed TX is committed
+	txid2typedTxMap map[[32]byte][]byte
 
 	// feeds
-	chainFeed event.Feed // For pub&sub new blocks
-	logsFeed  event.Feed // For pub&sub new logs
+	chainFeed event.Feed                  // For pub&sub new blocks
+	logsFeed  event.Feed                  // For pub&sub new logs
+	txFeed    event.Feed                  // For pub&sub new TXs accepted by mempool
+	newTxChan chan *gethtypes.Transaction // sent to txFeed by dispatchNewTxs, so slow subscribers do not block CheckTx
 	scope     event.SubscriptionScope
 
 	//engine
 	txEngine    ebp.TxExecutor
 	reorderSeed int64        // recorded in BeginBlock, used in Commit
 	frontier    ebp.Frontier // recorded in Commit, used in next block's CheckTx
+	frontierMtx sync.RWMutex // protects frontier from being read by RPC during CheckTx and Commit
 
 	//watcher
-	watcher   *watcher.Watcher
-	epochList []*stakingtypes.Epoch // caches the epochs collected by the watcher
-	//ccEpochList []*cctypes.CCEpoch
+	watcher         *watcher.Watcher
+	watcherMetrics  *watcher.Metrics
+	genesisCCHeight int64                 // used to create the watcher before the first cc epoch is switched
+	epochList       []*stakingtypes.Epoch // caches the epochs collected by the watcher
+	ccEpochList     []*cctypes.CCEpoch    // caches the cc epochs collected by the watcher
 
 	//util
 	signer gethtypes.Signer
@@ -155,12 +181,30 @@ type App struct {
 	// tendermint wants to know validators whose voting power change
 	// it is loaded from ctx in Commit and used in EndBlock
 	validatorUpdate []*stakingtypes.Validator
+	// the block max gas known by tendermint, and the governed new value which is found in Commit and
+	// sent to tendermint in EndBlock
+	blockMaxGas       int64
+	blockMaxGasUpdate int64
 
 	//signature cache, cache ecrecovery's resulting sender addresses, to speed up checktx
-	sigCache map[gethcmn.Hash]SenderAndHeight
+	sigCache    map[gethcmn.Hash]SenderAndHeight
+	sigCacheMtx sync.RWMutex // protects sigCache from being read by RPC during CheckTx
 
 	// it shows how many tx remains in the mempool after committing a new block
 	recheckCounter int
+
+	//state-sync snapshot
+	snapshotting int32             // set to 1 when a snapshot is being packed
+	restorer     *snapshotRestorer // not nil during restoring a snapshot
+
+	//state proofs
+	proofDBMtx sync.Mutex
+	proofDB    *gorocksdb.DB                    // the read-only rocksdb of moeingads, nil if outdated
+	shardRoots *[stateproof.ShardCount][32]byte // cached by getShardRoots, nil if outdated
+
+	//metrics
+	metrics         *Metrics
+	metricsRegistry *stdprometheus.Registry // nil if the metrics are disabled
 }
 
 // The value entry of signature cache. The Height helps in evicting old entries.
@@ -176,13 +220,26 @@ func NewApp(config *param.ChainConfig, chainId *uint256.Int, genesisWatcherHeigh
 	app.config = config
 	app.chainId = chainId
 
+	/*------new TXs notification------*/
+	app.newTxChan = make(chan *gethtypes.Transaction, newTxChanSize)
+	go app.dispatchNewTxs()
+
 	/*------signature cache------*/
 	app.sigCache = make(map[gethcmn.Hash]SenderAndHeight, config.AppConfig.SigCacheSize)
 
 	/*------set util------*/
-	app.signer = gethtypes.NewEIP155Signer(app.chainId.ToBig())
+	app.signer = newForkSigner(app.chainId.ToBig(), func() int64 { return atomic.LoadInt64(&app.currHeight) })
 	app.logger = logger.With("module", "app")
 
+	/*------set metrics------*/
+	app.metrics = NopMetrics()
+	app.watcherMetrics = watcher.NopMetrics()
+	if config.AppConfig.PrometheusListenAddr != "" {
+		app.metricsRegistry = stdprometheus.NewRegistry()
+		app.metrics = PrometheusMetrics(app.metricsRegistry, MetricsNamespace)
+		app.watcherMetrics = watcher.PrometheusMetrics(app.metricsRegistry, MetricsNamespace)
+	}
+
 	/*------set store------*/
 	app.root, app.mads = CreateRootStore(config.AppConfig.AppDataPath, config.AppConfig.ArchiveMode)
 	app.historyStore = CreateHistoryStore(config.AppConfig.ModbDataPath, config.AppConfig.UseLiteDB, config.AppConfig.RpcEthGetLogsMaxResults,
@@ -190,8 +247,11 @@ func NewApp(config *param.ChainConfig, chainId *uint256.Int, genesisWatcherHeigh
 	if config.AppConfig.WithSyncDB {
 		app.syncDB = syncdb.NewSyncDB(config.AppConfig.SyncdbDataPath)
 	}
-	app.trunk = app.root.GetTrunkStore(config.AppConfig.TrunkCacheSize).(*store.TrunkStore)
-	app.checkTrunk = app.root.GetReadOnlyTrunkStore(config.AppConfig.TrunkCacheSize).(*store.TrunkStore)
+	var err error
+	if app.ledgerDB, err = openRewardLedgerDB(config.AppConfig.LedgerDataPath); err != nil {
+		panic(err)
+	}
+	app.resetTrunks()
 
 	/*------set engine------*/
 	app.txEngine = ebp.NewEbpTxExec(
@@ -205,19 +265,64 @@ func NewApp(config *param.ChainConfig, chainId *uint256.Int, genesisWatcherHeigh
 
 	/*------set system contract------*/
 	ctx := app.GetRunTxContext()
-
-	ebp.RegisterPredefinedContract(ctx, staking.StakingContractAddress, staking.NewStakingContractExecutor(app.logger.With("module", "staking")))
+	app.registerSystemContracts(ctx)
 
 	// We assign empty maps to them just to avoid accessing nil-maps.
 	// Commit will assign meaningful contents to them
 	app.txid2sigMap = make(map[[32]byte][65]byte)
+	app.txid2typedTxMap = make(map[[32]byte][]byte)
 	app.frontier = ebp.GetEmptyFrontier()
 
 	/*------set refresh field------*/
+	app.loadCurrBlock(ctx)
+
+	/*------set stakingInfo------*/
+	stakingInfo := app.loadStakingStates(ctx)
+	if stakingInfo.CurrEpochNum == 0 && stakingInfo.GenesisMainnetBlockHeight == 0 {
+		stakingInfo.GenesisMainnetBlockHeight = genesisWatcherHeight
+		staking.SaveStakingInfo(ctx, stakingInfo) // only executed at genesis
+	}
+
+	/*------set watcher------*/
+	app.genesisCCHeight = genesisCCHeight
+	app.startWatcher(ctx, stakingInfo, skipSanityCheck)
+	app.watcher.WaitCatchup()
+	ctx.Close(true)
+	return app
+}
+
+// registerSystemContracts registers the executors of system contracts, which are initialized with
+// the states in ctx
+func (app *App) registerSystemContracts(ctx *types.Context) {
+	ebp.RegisterPredefinedContract(ctx, staking.StakingContractAddress, staking.NewStakingContractExecutor(app.logger.With("module", "staking")))
+	if param.ShaGateSwitch {
+		ebp.RegisterPredefinedContract(ctx, crosschain.CCContractAddress, crosschain.NewCcContractExecutor(app.logger.With("module", "crosschain")))
+	}
+}
+
+// startWatcher creates a watcher which continues from the epochs and cc epochs recorded in world state,
+// and runs it without waiting for it to catch up with mainnet
+func (app *App) startWatcher(ctx *types.Context, stakingInfo stakingtypes.StakingInfo, skipSanityCheck bool) {
+	lastEpochEndHeight := stakingInfo.GenesisMainnetBlockHeight + param.StakingNumBlocksInEpoch*stakingInfo.CurrEpochNum
+	// the cc genesis height is recorded when the first cc epoch is switched
+	lastCCEpochEndHeight := app.genesisCCHeight
+	if ccInfo := crosschain.LoadCCInfo(ctx); ccInfo.GenesisMainnetBlockHeight != 0 {
+		lastCCEpochEndHeight = ccInfo.GenesisMainnetBlockHeight + param.BlocksInCCEpoch*ccInfo.CurrEpochNum
+	}
+	app.watcher = watcher.NewWatcher(app.logger.With("module", "watcher"), lastEpochEndHeight, lastCCEpochEndHeight, stakingInfo.CurrEpochNum, app.config)
+	app.watcher.SetMetrics(app.watcherMetrics)
+	app.logger.Debug(fmt.Sprintf("New watcher: mainnet url(%s), epochNum(%d), lastEpochEndHeight(%d), speedUp(%v)\n",
+		app.config.AppConfig.MainnetRPCUrl, stakingInfo.CurrEpochNum, lastEpochEndHeight, app.config.AppConfig.Speedup))
+	app.watcher.CheckSanity(app.config.AppConfig.DisableBchClient, skipSanityCheck)
+	go app.watcher.Run()
+}
+
+// loadCurrBlock reloads the last committed block from world state, and restarts its postCommit
+func (app *App) loadCurrBlock(ctx *types.Context) {
 	prevBlk := ctx.GetCurrBlockBasicInfo()
 	if prevBlk != nil {
 		app.block = prevBlk //will be overwritten in BeginBlock soon
-		app.currHeight = app.block.Number
+		atomic.StoreInt64(&app.currHeight, app.block.Number)
 		app.lastProposer = app.block.Miner
 	} else {
 		app.block = &types.Block{}
@@ -232,8 +337,10 @@ func NewApp(config *param.ChainConfig, chainId *uint256.Int, genesisWatcherHeigh
 		app.mtx.Lock()
 		app.postCommit(app.syncBlockInfo())
 	}
+}
 
-	/*------set stakingInfo------*/
+// loadStakingStates reloads the pending validator updates, the last block's min gas price and the block max gas
+func (app *App) loadStakingStates(ctx *types.Context) stakingtypes.StakingInfo {
 	stakingInfo := staking.LoadStakingInfo(ctx)
 	currValidators := staking.GetActiveValidators(ctx, stakingInfo.Validators)
 	app.validatorUpdate = stakingInfo.ValidatorsUpdate
@@ -245,22 +352,15 @@ func NewApp(config *param.ChainConfig, chainId *uint256.Int, genesisWatcherHeigh
 		app.logger.Debug(fmt.Sprintf("Load validator in NewApp: address(%s), pubkey(%s), votingPower(%d)",
 			gethcmn.Address(val.Address).String(), ed25519.PubKey(val.Pubkey[:]).String(), val.VotingPower))
 	}
-	if stakingInfo.CurrEpochNum == 0 && stakingInfo.GenesisMainnetBlockHeight == 0 {
-		stakingInfo.GenesisMainnetBlockHeight = genesisWatcherHeight
-		staking.SaveStakingInfo(ctx, stakingInfo) // only executed at genesis
-	}
-
-	/*------set watcher------*/
-	lastEpochEndHeight := stakingInfo.GenesisMainnetBlockHeight + param.StakingNumBlocksInEpoch*stakingInfo.CurrEpochNum
-	app.watcher = watcher.NewWatcher(app.logger.With("module", "watcher"), lastEpochEndHeight, 0, stakingInfo.CurrEpochNum, app.config)
-	app.logger.Debug(fmt.Sprintf("New watcher: mainnet url(%s), epochNum(%d), lastEpochEndHeight(%d), speedUp(%v)\n",
-		config.AppConfig.MainnetRPCUrl, stakingInfo.CurrEpochNum, lastEpochEndHeight, config.AppConfig.Speedup))
-	app.watcher.CheckSanity(config.AppConfig.DisableBchClient, skipSanityCheck)
-	go app.watcher.Run()
-	app.watcher.WaitCatchup()
 	app.lastMinGasPrice = staking.LoadMinGasPrice(ctx, true)
-	ctx.Close(true)
-	return app
+	app.blockMaxGas = staking.GetBlockMaxGas(ctx, app.currHeight+1)
+	return stakingInfo
+}
+
+// resetTrunks creates new trunk stores on app.root, and makes the engine use the new trunk
+func (app *App) resetTrunks() {
+	app.trunk = app.root.GetTrunkStore(app.config.AppConfig.TrunkCacheSize).(*store.TrunkStore)
+	app.checkTrunk = app.root.GetReadOnlyTrunkStore(app.config.AppConfig.TrunkCacheSize).(*store.TrunkStore)
 }
 
 func CreateRootStore(dataPath string, isArchiveMode bool) (*store.RootStore, *moeingads.MoeingADS) {
@@ -310,7 +410,16 @@ func (app *App) Query(req abcitypes.RequestQuery) abcitypes.ResponseQuery {
 	return abcitypes.ResponseQuery{Code: abcitypes.CodeTypeOK} // take it as a nop
 }
 
+func (app *App) sigCacheGet(txid gethcmn.Hash) (value SenderAndHeight, ok bool) {
+	app.sigCacheMtx.RLock()
+	defer app.sigCacheMtx.RUnlock()
+	value, ok = app.sigCache[txid]
+	return
+}
+
 func (app *App) sigCacheAdd(txid gethcmn.Hash, value SenderAndHeight) {
+	app.sigCacheMtx.Lock()
+	defer app.sigCacheMtx.Unlock()
 	if len(app.sigCache) > app.config.AppConfig.SigCacheSize { //select one old entry to evict
 		delKey, minHeight, count := gethcmn.Hash{}, int64(math.MaxInt64), 6 /*iterate 6 steps*/
 		for key, value := range app.sigCache {                              //pseudo-random iterate
@@ -334,17 +443,18 @@ func (app *App) CheckTx(req abcitypes.RequestCheckTx) abcitypes.ResponseCheckTx
 		// Refuse to accept new TXs on P2P to drain the remain TXs in mempool
 		return abcitypes.ResponseCheckTx{Code: MempoolBusy, Info: "mempool is too busy"}
 	}
-	tx := &gethtypes.Transaction{}
-	err := tx.DecodeRLP(rlp.NewStream(bytes.NewReader(req.Tx), 0))
+	tx, err := ethutils.DecodeTx(req.Tx)
 	if err != nil {
 		return abcitypes.ResponseCheckTx{Code: CannotDecodeTx}
 	}
 	txid := tx.Hash()
 	var sender gethcmn.Address
-	senderAndHeight, ok := app.sigCache[txid]
+	senderAndHeight, ok := app.sigCacheGet(txid)
 	if ok { // cache hit
+		app.metrics.SigCacheHits.Add(1)
 		sender = senderAndHeight.Sender
 	} else { // cache miss
+		app.metrics.SigCacheMisses.Add(1)
 		sender, err = app.signer.Sender(tx)
 		if err != nil {
 			return abcitypes.ResponseCheckTx{Code: CannotRecoverSender, Info: "invalid sender: " + err.Error()}
@@ -354,12 +464,34 @@ func (app *App) CheckTx(req abcitypes.RequestCheckTx) abcitypes.ResponseCheckTx
 	if sender == ebp.BlockedAddress {
 		return abcitypes.ResponseCheckTx{Code: CannotRecoverSender, Info: "invalid sender: " + sender.String()}
 	}
-	return app.checkTxWithContext(tx, sender, req.Type)
+	res := app.checkTxWithContext(tx, sender, req.Type)
+	if res.Code == abcitypes.CodeTypeOK && req.Type != abcitypes.CheckTxType_Recheck {
+		app.notifyNewTx(tx)
+	}
+	return res
+}
+
+// notifyNewTx queues tx for dispatchNewTxs without blocking, the notification is dropped if the queue is full
+func (app *App) notifyNewTx(tx *gethtypes.Transaction) {
+	select {
+	case app.newTxChan <- tx:
+	default:
+		app.logger.Debug("Drop the notification of new tx", "hash", tx.Hash().Hex())
+	}
+}
+
+// dispatchNewTxs sends the TXs accepted by CheckTx to the subscribers of txFeed, until app.newTxChan is closed
+func (app *App) dispatchNewTxs() {
+	for tx := range app.newTxChan {
+		app.txFeed.Send(gethcore.NewTxsEvent{Txs: []*gethtypes.Transaction{tx}})
+	}
 }
 
 func (app *App) checkTxWithContext(tx *gethtypes.Transaction, sender gethcmn.Address, txType abcitypes.CheckTxType) abcitypes.ResponseCheckTx {
 	ctx := app.GetCheckTxContext()
 	defer ctx.Close(false)
+	app.frontierMtx.Lock()
+	defer app.frontierMtx.Unlock()
 	if ok, res := checkGasLimit(tx); !ok {
 		return res
 	}
@@ -384,6 +516,10 @@ func (app *App) checkTxWithContext(tx *gethtypes.Transaction, sender gethcmn.Add
 	} else if tx.Nonce() < targetNonce {
 		return abcitypes.ResponseCheckTx{Code: AccountNonceMismatch, Info: "bad nonce: " + types.ErrNonceTooSmall.Error()}
 	}
+	if tx.GasTipCap().Cmp(tx.GasFeeCap()) > 0 {
+		return abcitypes.ResponseCheckTx{Code: GasTipAboveFeeCap, Info: gethcore.ErrTipAboveFeeCap.Error()}
+	}
+	// there is no base fee, so a dynamic-fee TX pays its GasFeeCap, which is returned by GasPrice()
 	gasPrice, _ := uint256.FromBig(tx.GasPrice())
 	if gasPrice.GtUint64(ebp.MaxGasPrice) {
 		gasPrice = uint256.NewInt(ebp.MaxGasPrice)
@@ -425,7 +561,7 @@ func (app *App) checkTxWithContext(tx *gethtypes.Transaction, sender gethcmn.Add
 }
 
 func checkGasLimit(tx *gethtypes.Transaction) (ok bool, res abcitypes.ResponseCheckTx) {
-	intrinsicGas, err2 := gethcore.IntrinsicGas(tx.Data(), nil, tx.To() == nil, true, true)
+	intrinsicGas, err2 := gethcore.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, true)
 	if err2 != nil || tx.Gas() < intrinsicGas {
 		return false, abcitypes.ResponseCheckTx{Code: GasLimitTooSmall, Info: "gas limit too small"}
 	}
@@ -458,9 +594,9 @@ func (app *App) InitChain(req abcitypes.RequestInitChain) abcitypes.ResponseInit
 	//store all genesis validators even if it is inactive
 	ctx := app.GetRunTxContext()
 	staking.AddGenesisValidatorsIntoStakingInfo(ctx, genesisValidators)
+	currValidators := staking.GetActiveValidators(ctx, genesisValidators)
 	ctx.Close(true)
 
-	currValidators := staking.GetActiveValidators(ctx, genesisValidators)
 	valSet := make([]abcitypes.ValidatorUpdate, len(currValidators))
 	for i, v := range currValidators {
 		p, _ := cryptoenc.PubKeyToProto(ed25519.PubKey(v.Pubkey[:]))
@@ -478,6 +614,7 @@ func (app *App) InitChain(req abcitypes.RequestInitChain) abcitypes.ResponseInit
 			MaxGas:   param.BlockMaxGas,
 		},
 	}
+	app.blockMaxGas = param.BlockMaxGas
 	return abcitypes.ResponseInitChain{
 		ConsensusParams: params,
 		Validators:      valSet,
@@ -492,14 +629,46 @@ func (app *App) createGenesisAccounts(alloc gethcore.GenesisAlloc) {
 	rbt := rabbit.NewRabbitStore(app.trunk)
 
 	app.logger.Info("air drop", "accounts", len(alloc))
-	for addr, acc := range alloc {
+	addrs := make([]gethcmn.Address, 0, len(alloc))
+	for addr := range alloc {
+		addrs = append(addrs, addr)
+	}
+	// contracts get their sequences in the order of addresses, to make all the nodes agree on them
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	var creationCounters [256]uint64
+	for _, addr := range addrs {
+		acc := alloc[addr]
 		amt, _ := uint256.FromBig(acc.Balance)
 		k := types.GetAccountKey(addr)
 		v := types.ZeroAccountInfo()
 		v.UpdateBalance(amt)
+		v.UpdateNonce(acc.Nonce)
+		if len(acc.Code) != 0 { // a contract forked from an exported state
+			// the same rule as moeingevm uses to assign sequences to new contracts
+			creationCounters[addr[0]]++
+			seq := creationCounters[addr[0]]<<8 | uint64(addr[0])
+			v.UpdateSequence(seq)
+			bz := make([]byte, 33, 33+len(acc.Code))
+			copy(bz[1:33], gethcrypto.Keccak256(acc.Code))
+			rbt.Set(types.GetBytecodeKey(addr), append(bz, acc.Code...))
+			for slot, value := range acc.Storage {
+				if value != (gethcmn.Hash{}) {
+					rbt.Set(types.GetValueKey(seq, string(slot[:])), append([]byte{}, value[:]...))
+				}
+			}
+		}
 		rbt.Set(k, v.Bytes())
 		//app.logger.Info("Air drop " + amt.String() + " to " + addr.Hex())
 	}
+	for lsb, counter := range creationCounters {
+		if counter != 0 {
+			var buf [8]byte
+			binary.BigEndian.PutUint64(buf[:], counter)
+			rbt.Set(types.GetCreationCounterKey(uint8(lsb)), buf[:])
+		}
+	}
 
 	rbt.Close()
 	rbt.WriteBack()
@@ -532,7 +701,7 @@ func (app *App) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.ResponseBe
 	}
 	copy(app.block.Hash[:], req.Hash) // Just use tendermint's block hash
 	copy(app.block.StateRoot[:], req.Header.AppHash)
-	app.currHeight = req.Header.Height
+	atomic.StoreInt64(&app.currHeight, req.Header.Height)
 	// collect slash info, currently only double signing is slashed
 	var addr [20]byte
 	for _, val := range req.ByzantineValidators {
@@ -547,8 +716,22 @@ func (app *App) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.ResponseBe
 
 func (app *App) DeliverTx(req abcitypes.RequestDeliverTx) abcitypes.ResponseDeliverTx {
 	app.block.Size += int64(req.Size())
-	tx, err := ethutils.DecodeTx(req.Tx)
+	var tx *gethtypes.Transaction
+	var err error
+	if app.currHeight < param.EIP1559ForkHeight {
+		tx, err = ethutils.DecodeTxRLP(req.Tx)
+	} else {
+		tx, err = ethutils.DecodeTx(req.Tx)
+	}
 	if err == nil {
+		if tx.Type() != gethtypes.LegacyTxType {
+			// the engine only knows the intrinsic gas of legacy TXs, so a typed TX whose gas limit can not
+			// cover its access list is dropped before execution, as CheckTx does
+			if ok, res := checkGasLimit(tx); !ok {
+				return abcitypes.ResponseDeliverTx{Code: res.Code, Info: res.Info}
+			}
+			app.txid2typedTxMap[tx.Hash()] = ethutils.EncodeTypedTxFields(tx)
+		}
 		app.txEngine.CollectTx(tx)
 		app.txid2sigMap[tx.Hash()] = ethutils.EncodeVRS(tx)
 	}
@@ -594,15 +777,30 @@ func (app *App) EndBlock(req abcitypes.RequestEndBlock) abcitypes.ResponseEndBlo
 		app.logger.Debug(fmt.Sprintf("Validator updated in EndBlock: pubkey(%s) votingPower(%d)",
 			hex.EncodeToString(v.Pubkey[:]), v.VotingPower))
 	}
+	var paramUpdates *abcitypes.ConsensusParams
+	if app.blockMaxGasUpdate != 0 {
+		paramUpdates = &abcitypes.ConsensusParams{
+			Block: &abcitypes.BlockParams{
+				MaxBytes: param.BlockMaxBytes,
+				MaxGas:   app.blockMaxGasUpdate,
+			},
+		}
+		app.logger.Info("Block max gas updated in EndBlock", "maxGas", app.blockMaxGasUpdate)
+		app.blockMaxGas = app.blockMaxGasUpdate
+		app.blockMaxGasUpdate = 0
+	}
 	return abcitypes.ResponseEndBlock{
-		ValidatorUpdates: valSet,
+		ValidatorUpdates:      valSet,
+		ConsensusParamUpdates: paramUpdates,
 	}
 }
 
 func (app *App) Commit() abcitypes.ResponseCommit {
 	app.logger.Debug("Enter commit!", "collected txs", app.txEngine.CollectedTxsCount())
+	app.metrics.CollectedTxs.Set(float64(app.txEngine.CollectedTxsCount()))
 	app.mtx.Lock()
 	app.updateValidatorsAndStakingInfo()
+	app.switchCCEpochs()
 	// something should be executed in block, not tx, leave it here:
 	if app.currHeight == param.SymbolSbchForkHeight {
 		ctx := app.GetRunTxContext()
@@ -613,7 +811,12 @@ func (app *App) Commit() abcitypes.ResponseCommit {
 		_ = ebp.SubSenderAccBalance(ctx, ebp.BlockedAddress, acc.Balance())
 		ctx.Close(true)
 	}
-	app.frontier = app.txEngine.Prepare(app.reorderSeed, 0, param.MaxTxGasLimit)
+	app.saveTypedTxFields()
+	frontier := app.txEngine.Prepare(app.reorderSeed, 0, param.MaxTxGasLimit)
+	app.metrics.StandbyQueueLen.Set(float64(app.txEngine.StandbyQLen()))
+	app.frontierMtx.Lock()
+	app.frontier = frontier
+	app.frontierMtx.Unlock()
 	appHash := app.refresh()
 	if app.currHeight >= param.SymbolSbchForkHeight {
 		app.txEngine.SetCheckRWInLoading(true)
@@ -667,9 +870,16 @@ func (app *App) buildCommitResponse(appHash []byte) abcitypes.ResponseCommit {
 func (app *App) updateValidatorsAndStakingInfo() {
 	ctx := app.GetRunTxContext()
 	defer ctx.Close(true) // context must be written back such that txEngine can read it in 'Prepare'
-	currValidators, newValidators, currEpochNum := staking.SlashAndReward(ctx, app.slashValidators, app.block.Miner,
-		app.lastProposer, app.lastVoters, app.getBlockRewardAndUpdateSysAcc(ctx))
+	bookings := &staking.RewardBookings{}
+	currValidators, newValidators, currEpochNum, logs := staking.SlashAndReward(ctx, app.slashValidators, app.block.Miner,
+		app.lastProposer, app.lastVoters, app.getBlockRewardAndUpdateSysAcc(ctx), bookings)
+	stakingLogs := logs
+	// deferred after ctx.Close, so it runs before it, also on the early return below
+	defer func() { app.saveStakingLogs(ctx, stakingLogs) }()
+	// booked before the world state is committed in refresh
+	defer app.ledgerDB.book(app.block.Number, bookings)
 	app.slashValidators = app.slashValidators[:0]
+	app.metrics.CurrEpochNum.Set(float64(currEpochNum))
 
 	if param.IsAmber && ctx.IsXHedgeFork() {
 		// make fake epoch after xHedgeFork, change amber to pure pos
@@ -738,7 +948,8 @@ func (app *App) updateValidatorsAndStakingInfo() {
 				//deploy xHedge contract before fork
 				posVotes = staking.GetAndClearPosVotes(ctx, xHedgeSequence)
 			}
-			newValidators = staking.SwitchEpoch(ctx, app.epochList[0], posVotes, app.logger)
+			newValidators, logs = staking.SwitchEpoch(ctx, app.epochList[0], posVotes, bookings, app.logger)
+			stakingLogs = append(stakingLogs, logs...)
 			app.epochList = app.epochList[1:] // possible memory leak here, but the length would not be very large
 			if ctx.IsXHedgeFork() {
 				staking.CreateInitVotes(ctx, xHedgeSequence, newValidators)
@@ -761,6 +972,12 @@ func (app *App) updateValidatorsAndStakingInfo() {
 	newInfo := staking.LoadStakingInfo(ctx)
 	newInfo.ValidatorsUpdate = app.validatorUpdate
 	staking.SaveStakingInfo(ctx, newInfo)
+	// the contracts in next block read the staking states updated in this block
+	staking.UpdateReadonlyStakingView(ctx)
+	// the update returned by next block's EndBlock is used by tendermint since the block after next block
+	if maxGas := staking.GetBlockMaxGas(ctx, app.currHeight+2); maxGas != app.blockMaxGas {
+		app.blockMaxGasUpdate = maxGas
+	}
 	//only amber need this
 	app.currValidators = newValidators
 	//log all validators info when validator set update
@@ -774,6 +991,41 @@ func (app *App) updateValidatorsAndStakingInfo() {
 	}
 }
 
+// switchCCEpochs switches the cc epochs collected by the watcher after ShaGate fork, each one is switched in
+// the first block after CCEpochSwitchDelay has passed since its end time. The delay covers the watcher's
+// finalize depth with a margin, so the validators whose watchers deliver the epoch in time switch it at the
+// same height.
+func (app *App) switchCCEpochs() {
+	for len(app.watcher.CCEpochChan) != 0 {
+		epoch := <-app.watcher.CCEpochChan
+		app.ccEpochList = append(app.ccEpochList, epoch)
+		app.logger.Debug(fmt.Sprintf("Get new cc epoch, startHeight(%d), ccEpochListLens(%d)",
+			epoch.StartHeight, len(app.ccEpochList)))
+	}
+	ctx := app.GetRunTxContext()
+	defer ctx.Close(true)
+	if !ctx.IsShaGateFork() {
+		return
+	}
+	var dueEpochs []*cctypes.CCEpoch
+	dueEpochs, app.ccEpochList = splitDueCCEpochs(app.ccEpochList, app.block.Timestamp)
+	for _, epoch := range dueEpochs {
+		app.logger.Debug(fmt.Sprintf("Switch cc epoch at block(%d), startHeight(%d)",
+			app.block.Number, epoch.StartHeight))
+		crosschain.SwitchCCEpoch(ctx, epoch)
+	}
+}
+
+// splitDueCCEpochs splits off the cc epochs due to be switched in the block at 'blockTime'. It depends only on
+// the block and the epochs, not on when they were delivered by the watcher.
+func splitDueCCEpochs(ccEpochList []*cctypes.CCEpoch, blockTime int64) (due, remained []*cctypes.CCEpoch) {
+	n := 0
+	for n < len(ccEpochList) && blockTime > ccEpochList[n].EndTime+param.CCEpochSwitchDelay {
+		n++
+	}
+	return ccEpochList[:n], ccEpochList[n:]
+}
+
 func (app *App) syncBlockInfo() *types.BlockInfo {
 	bi := &types.BlockInfo{
 		Coinbase:  app.block.Miner,
@@ -801,8 +1053,13 @@ func (app *App) postCommit(bi *types.BlockInfo) {
 			}
 		}
 	}
+	startTime := time.Now()
 	app.txEngine.Execute(bi)
+	app.metrics.BlockExecutionSeconds.Observe(time.Since(startTime).Seconds())
 	app.lastGasUsed, app.lastGasRefund, app.lastGasFee = app.txEngine.GasUsedInfo()
+	if bi != nil {
+		app.takeSnapshot(bi.Number)
+	}
 }
 
 func (app *App) refresh() (appHash []byte) {
@@ -822,6 +1079,12 @@ func (app *App) refresh() (appHash []byte) {
 	mGP := staking.LoadMinGasPrice(ctx, false) // load current block's gas price
 	staking.SaveMinGasPrice(ctx, mGP, true)    // save it as last block's gas price
 	app.lastMinGasPrice = mGP
+	app.metrics.LastMinGasPrice.Set(float64(mGP))
+	txid2typedFields := app.takeTypedTxFields(ctx)
+	var stakingLogs []byte
+	if prevBlkInfo != nil {
+		stakingLogs = takeStakingLogs(ctx, prevBlkInfo.Number)
+	}
 	ctx.Close(true)
 
 	lastCacheSize := app.trunk.CacheSize() // predict the next truck's cache size with the last one
@@ -844,13 +1107,15 @@ func (app *App) refresh() (appHash []byte) {
 			Height: prevBlkInfo.Number,
 		}
 		prevBlkInfo.Transactions = app.txEngine.CommittedTxIds()
+		prevBlk4MoDB.TxList = app.txEngine.CommittedTxsForMoDB()
+		appendTypedTxFields(prevBlk4MoDB.TxList, txid2typedFields)
 		blkInfo, err := prevBlkInfo.MarshalMsg(nil)
 		if err != nil {
 			panic(err)
 		}
+		blkInfo = append(blkInfo, stakingLogs...)
 		copy(prevBlk4MoDB.BlockHash[:], prevBlkInfo.Hash[:])
 		prevBlk4MoDB.BlockInfo = blkInfo
-		prevBlk4MoDB.TxList = app.txEngine.CommittedTxsForMoDB()
 		if app.config.AppConfig.NumKeptBlocksInMoDB > 0 && app.currHeight > app.config.AppConfig.NumKeptBlocksInMoDB {
 			app.historyStore.AddBlock(&prevBlk4MoDB, app.currHeight-app.config.AppConfig.NumKeptBlocksInMoDB, app.txid2sigMap)
 		} else {
@@ -864,6 +1129,7 @@ func (app *App) refresh() (appHash []byte) {
 		app.publishNewBlock(&prevBlk4MoDB)
 	}
 	//make new
+	app.metrics.RecheckedTxs.Set(float64(app.recheckCounter))
 	app.recheckCounter = 0 // reset counter before counting the remained TXs which need rechecking
 	app.lastProposer = app.block.Miner
 	app.lastVoters = app.lastVoters[:0]
@@ -880,29 +1146,19 @@ func (app *App) publishNewBlock(mdbBlock *modbtypes.Block) {
 	}
 	chainEvent := types.BlockToChainEvent(mdbBlock)
 	app.chainFeed.Send(chainEvent)
+	chainEvent.Logs = append(chainEvent.Logs, stakingGethLogs(mdbBlock)...)
 	if len(chainEvent.Logs) > 0 {
 		app.logsFeed.Send(chainEvent.Logs)
 	}
 }
 
-func (app *App) ListSnapshots(snapshots abcitypes.RequestListSnapshots) abcitypes.ResponseListSnapshots {
-	return abcitypes.ResponseListSnapshots{}
-}
-
-func (app *App) OfferSnapshot(snapshot abcitypes.RequestOfferSnapshot) abcitypes.ResponseOfferSnapshot {
-	return abcitypes.ResponseOfferSnapshot{}
-}
-
-func (app *App) LoadSnapshotChunk(chunk abcitypes.RequestLoadSnapshotChunk) abcitypes.ResponseLoadSnapshotChunk {
-	return abcitypes.ResponseLoadSnapshotChunk{}
-}
-
-func (app *App) ApplySnapshotChunk(chunk abcitypes.RequestApplySnapshotChunk) abcitypes.ResponseApplySnapshotChunk {
-	return abcitypes.ResponseApplySnapshotChunk{}
-}
-
 func (app *App) Stop() {
+	close(app.newTxChan)
 	app.historyStore.Close()
+	app.ledgerDB.close()
+	app.proofDBMtx.Lock()
+	app.closeProofDB()
+	app.proofDBMtx.Unlock()
 	app.root.Close()
 	app.scope.Close()
 }
@@ -1021,6 +1277,52 @@ func (app *App) RunTxForSbchRpc(gethTx *gethtypes.Transaction, sender gethcmn.Ad
 	return runner, estimateResult
 }
 
+// RunTxsForTrace re-executes txs, which must be the leading TXs of block#height in their order, on a sandbox
+// of the world state of block#height-1. Each TX runs on the changes made by the ones before it, and the gas
+// fee it paid is deducted from its sender after it runs. Nil is returned if the block is not found.
+func (app *App) RunTxsForTrace(txs []*types.Transaction, height int64) []*ebp.TxRunner {
+	if height < 1 {
+		return nil
+	}
+	ctx := app.GetRpcContextAtHeight(height - 1)
+	defer ctx.Close(false)
+	blk, err := ctx.GetBlockByHeight(uint64(height))
+	if err != nil {
+		return nil
+	}
+	bi := &types.BlockInfo{
+		Coinbase:  blk.Miner,
+		Number:    blk.Number,
+		Timestamp: blk.Timestamp,
+		ChainId:   app.chainId.Bytes32(),
+		Hash:      blk.Hash,
+	}
+	runners := make([]*ebp.TxRunner, len(txs))
+	for i, tx := range txs {
+		txToRun := &types.TxToRun{
+			BasicTx: types.BasicTx{
+				From:     tx.From,
+				To:       tx.To,
+				Value:    tx.Value,
+				GasPrice: tx.GasPrice,
+				Gas:      tx.Gas,
+				Data:     tx.Input,
+				Nonce:    tx.Nonce,
+			},
+			HashID: tx.Hash,
+			Height: uint64(app.currHeight),
+		}
+		runners[i] = ebp.NewTxRunner(ctx, txToRun)
+		ebp.RunTxForRpc(bi, false, runners[i])
+		gasPrice := uint256.NewInt(0).SetBytes32(tx.GasPrice[:])
+		if gasPrice.GtUint64(ebp.MaxGasPrice) {
+			gasPrice = uint256.NewInt(ebp.MaxGasPrice)
+		}
+		_ = ebp.SubSenderAccBalance(ctx, tx.From, uint256.NewInt(0).Mul(uint256.NewInt(tx.GasUsed), gasPrice))
+	}
+	return runners
+}
+
 // SubscribeChainEvent registers a subscription of ChainEvent.
 func (app *App) SubscribeChainEvent(ch chan<- types.ChainEvent) event.Subscription {
 	return app.scope.Track(app.chainFeed.Subscribe(ch))
@@ -1031,6 +1333,11 @@ func (app *App) SubscribeLogsEvent(ch chan<- []*gethtypes.Log) event.Subscriptio
 	return app.scope.Track(app.logsFeed.Subscribe(ch))
 }
 
+// SubscribeNewTxsEvent registers a subscription of NewTxsEvent, which is sent when a new TX is accepted by CheckTx.
+func (app *App) SubscribeNewTxsEvent(ch chan<- gethcore.NewTxsEvent) event.Subscription {
+	return app.scope.Track(app.txFeed.Subscribe(ch))
+}
+
 func (app *App) GetLastGasUsed() uint64 {
 	return app.lastGasUsed
 }
@@ -1072,6 +1379,11 @@ func (app *App) GetWatcherEpochList() []*stakingtypes.Epoch {
 	return app.watcher.GetEpochList()
 }
 
+// MetricsRegistry returns the registry of the prometheus metrics, or nil if the metrics are disabled
+func (app *App) MetricsRegistry() *stdprometheus.Registry {
+	return app.metricsRegistry
+}
+
 func (app *App) GetBlockForSync(height int64) (blk []byte, err error) {
 	if app.syncDB == nil {
 		return nil, errNoSyncDB
@@ -1084,6 +1396,22 @@ func (app *App) GetBlockForSync(height int64) (blk []byte, err error) {
 	return
 }
 
+// GetFrontierNonce returns the next nonce of addr expected by CheckTx, which counts in the TXs in the
+// standby queue and the mempool. It returns false if addr has not sent any TX since the last Commit.
+func (app *App) GetFrontierNonce(addr gethcmn.Address) (nonce uint64, exist bool) {
+	app.frontierMtx.RLock()
+	defer app.frontierMtx.RUnlock()
+	return app.frontier.GetLatestNonce(addr)
+}
+
+// GetTxSender returns the sender of tx, which is usually cached by CheckTx if tx is in the mempool
+func (app *App) GetTxSender(tx *gethtypes.Transaction) (gethcmn.Address, error) {
+	if senderAndHeight, ok := app.sigCacheGet(tx.Hash()); ok {
+		return senderAndHeight.Sender, nil
+	}
+	return app.signer.Sender(tx)
+}
+
 func (app *App) GetRpcMaxLogResults() int {
 	return app.config.AppConfig.RpcEthGetLogsMaxResults
 }
@@ -4,16 +4,19 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethcore "github.com/ethereum/go-ethereum/core"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/smartbch/moeingevm/evmwrap/testcase"
 	"github.com/smartbch/moeingevm/types"
+	cctypes "github.com/smartbch/smartbch/crosschain/types"
 	"github.com/smartbch/smartbch/internal/ethutils"
 	"github.com/smartbch/smartbch/param"
 )
@@ -24,12 +27,14 @@ func init() {
 	p = param.DefaultConfig()
 	p.AppConfig.ModbDataPath = "./testDb"
 	p.AppConfig.AppDataPath = "./testAppDb"
+	p.AppConfig.LedgerDataPath = "./testLedgerDb"
 }
 
 func removeTestDB(_app *App) {
 	_app.Stop()
 	_ = os.RemoveAll(p.AppConfig.ModbDataPath)
 	_ = os.RemoveAll(p.AppConfig.AppDataPath)
+	_ = os.RemoveAll(p.AppConfig.LedgerDataPath)
 }
 
 func TestAppReload(t *testing.T) {
@@ -87,6 +92,9 @@ func TestCheckTx(t *testing.T) {
 	}
 	_app.CheckTx(r)
 	require.Equal(t, _app.sigCache[signedTx.Hash()].Sender, addr)
+	sender, err := _app.GetTxSender(signedTx)
+	require.NoError(t, err)
+	require.Equal(t, addr, sender)
 
 	//test recheck counter
 	r.Type = abcitypes.CheckTxType_Recheck
@@ -117,3 +125,54 @@ func TestCheckTx(t *testing.T) {
 	res = _app.CheckTx(r)
 	require.Equal(t, GasLimitInvalid, res.Code)
 }
+
+func TestNotifyNewTxWithSlowSubscriber(t *testing.T) {
+	_app := NewApp(p, uint256.NewInt(1), 0, 0, log.NewNopLogger(), true)
+	defer removeTestDB(_app)
+	ch := make(chan gethcore.NewTxsEvent) // never read until all the TXs are notified
+	sub := _app.SubscribeNewTxsEvent(ch)
+	defer sub.Unsubscribe()
+
+	addr := common.Address{0x01}
+	tx := ethutils.NewTx(0, &addr, big.NewInt(100), 100000, big.NewInt(10), nil)
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < newTxChanSize+10; i++ {
+			_app.notifyNewTx(tx)
+		}
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifyNewTx is blocked by the subscriber")
+	}
+	ev := <-ch
+	require.Equal(t, tx.Hash(), ev.Txs[0].Hash())
+}
+
+func TestCCEpochSwitchHeight(t *testing.T) {
+	epochs := []*cctypes.CCEpoch{{StartHeight: 100, EndTime: 10000}, {StartHeight: 107, EndTime: 14200}}
+	// two watchers deliver the same epochs at different times, both before their deadlines
+	switchHeights := func(delays []int64) (heights []int64) {
+		var list []*cctypes.CCEpoch
+		delivered := 0
+		for h, blockTime := int64(1), int64(10000); len(heights) < len(epochs); h, blockTime = h+1, blockTime+5 {
+			for delivered < len(epochs) && blockTime >= epochs[delivered].EndTime+delays[delivered] {
+				list = append(list, epochs[delivered])
+				delivered++
+			}
+			var due []*cctypes.CCEpoch
+			due, list = splitDueCCEpochs(list, blockTime)
+			for range due {
+				heights = append(heights, h)
+			}
+		}
+		return
+	}
+	early := switchHeights([]int64{param.CCEpochSwitchDelay / 4, 0})
+	late := switchHeights([]int64{param.CCEpochSwitchDelay * 3 / 4, param.CCEpochSwitchDelay})
+	require.Equal(t, early, late)
+	require.Len(t, early, 2)
+	require.Less(t, early[0], early[1])
+}
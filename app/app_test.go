@@ -197,6 +197,54 @@ func TestCheckTx_badGasPrice(t *testing.T) {
 	require.Equal(t, app.InvalidMinGasPrice, _app.CheckNewTxABCI(tx))
 }
 
+func TestCheckTx_dynamicFeeTx(t *testing.T) {
+	key1, addr1 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key1)
+	defer _app.Destroy()
+
+	_app.SetMinGasPrice(100)
+	tx, _ := _app.MakeAndSignDynamicFeeTx(key1, &addr1, 1, nil, 101, 100, nil)
+	require.Equal(t, app.GasTipAboveFeeCap, _app.CheckNewTxABCI(tx))
+	tx, _ = _app.MakeAndSignDynamicFeeTx(key1, &addr1, 1, nil, 99, 99, nil)
+	require.Equal(t, app.InvalidMinGasPrice, _app.CheckNewTxABCI(tx))
+	tx, _ = _app.MakeAndSignDynamicFeeTx(key1, &addr1, 1, nil, 1, 100, nil)
+	require.Equal(t, abci.CodeTypeOK, _app.CheckNewTxABCI(tx))
+
+	// the gas limit must cover the intrinsic gas of the access list
+	accessList := make(gethtypes.AccessList, testutils.DefaultGasLimit/2400)
+	tx, _ = _app.MakeAndSignDynamicFeeTx(key1, &addr1, 1, nil, 1, 100, accessList)
+	require.Equal(t, app.GasLimitTooSmall, _app.CheckNewTxABCI(tx))
+}
+
+func TestDynamicFeeTx(t *testing.T) {
+	oldAdjustGasUsed := ebp.AdjustGasUsed
+	ebp.AdjustGasUsed = false
+	defer func() { ebp.AdjustGasUsed = oldAdjustGasUsed }()
+
+	key1, addr1 := testutils.GenKeyAndAddr()
+	key2, addr2 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key1, key2)
+	defer _app.Destroy()
+
+	accessList := gethtypes.AccessList{{Address: addr2, StorageKeys: []common.Hash{{0x12}}}}
+	tx, _ := _app.MakeAndSignDynamicFeeTx(key1, &addr2, 100, nil, 1, 2, accessList)
+	_app.ExecTxInBlock(tx)
+	_app.EnsureTxSuccess(tx.Hash())
+
+	initBal := testutils.DefaultInitBalance
+	gasUsed := _app.GetTx(tx.Hash()).GasUsed
+	require.Equal(t, uint64(21000), gasUsed) // the listed slots are not warmed, so the access list is not charged
+	require.Equal(t, initBal-100-2*gasUsed, _app.GetBalance(addr1).Uint64())
+	require.Equal(t, initBal+100, _app.GetBalance(addr2).Uint64())
+
+	// a TX whose gas limit can not cover its access list is dropped before execution
+	accessList = make(gethtypes.AccessList, testutils.DefaultGasLimit/2400)
+	tx, _ = _app.MakeAndSignDynamicFeeTx(key1, &addr2, 100, nil, 1, 2, accessList)
+	_app.ExecTxInBlock(tx)
+	require.Equal(t, uint64(1), _app.GetNonce(addr1))
+	require.Equal(t, initBal-100-2*gasUsed, _app.GetBalance(addr1).Uint64())
+}
+
 func TestCheckTx_manyTxInMempool(t *testing.T) {
 	key1, _ := testutils.GenKeyAndAddr()
 	key2, addr2 := testutils.GenKeyAndAddr()
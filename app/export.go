@@ -0,0 +1,114 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/tecbot/gorocksdb"
+
+	"github.com/smartbch/moeingads"
+	"github.com/smartbch/moeingads/store/rabbit"
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/crosschain"
+	"github.com/smartbch/smartbch/staking"
+	"github.com/smartbch/smartbch/stateexport"
+)
+
+// ExportState walks the world state kept in moeingads at the given height and writes it into w.
+// It must run offline, when no smartbchd is using dataPath. Zero height means the latest height,
+// and only the nodes in archive mode can export the states at older heights.
+func ExportState(dataPath string, isArchiveMode bool, height int64, w stateexport.Writer) error {
+	root, mads := CreateRootStore(dataPath, isArchiveMode)
+	defer root.Close()
+	latestHeight := mads.GetCurrHeight()
+	if height == 0 {
+		height = latestHeight
+	}
+	if height <= 0 || height > latestHeight {
+		return fmt.Errorf("invalid height %d, the latest height is %d", height, latestHeight)
+	}
+	if height != latestHeight && !isArchiveMode {
+		return fmt.Errorf("only the latest height %d can be exported without archive mode", latestHeight)
+	}
+
+	err := w.Write(&stateexport.StateEntry{Kind: stateexport.KindHeader, Height: height})
+	if err != nil {
+		return err
+	}
+	exportKV := func(key, value []byte) {
+		// skip the guard keys and the standby queue, which is not stored by rabbit
+		if err != nil || len(key) != 8 || key[0] >= 128+64 {
+			return
+		}
+		cv := rabbit.BytesToCachedValue(value)
+		if cv == nil || cv.IsEmpty() {
+			return
+		}
+		err = w.Write(stateexport.NewStateEntry(cv.GetKey(), cv.GetValue()))
+	}
+	if height == latestHeight {
+		mads.ScanAll(exportKV)
+	} else if scanErr := scanAllAtHeight(dataPath, mads, uint64(height), exportKV); scanErr != nil {
+		return scanErr
+	}
+	if err != nil {
+		return err
+	}
+
+	var rbt rabbit.RabbitStore
+	if height == latestHeight {
+		rbt = rabbit.NewReadOnlyRabbitStore(root)
+	} else {
+		rbt = rabbit.NewReadOnlyRabbitStoreAtHeight(root, uint64(height))
+	}
+	ctx := types.NewContext(&rbt, nil)
+	stakingInfo := staking.LoadStakingInfo(ctx)
+	ccInfo := crosschain.LoadCCInfo(ctx)
+	bz, _ := stakingInfo.MarshalMsg(nil)
+	if err = w.Write(&stateexport.StateEntry{Kind: stateexport.KindStakingInfo, Value: bz}); err != nil {
+		return err
+	}
+	bz, _ = ccInfo.MarshalMsg(nil)
+	if err = w.Write(&stateexport.StateEntry{Kind: stateexport.KindCCInfo, Value: bz}); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// scanAllAtHeight is like moeingads' ScanAll, but visits the KV pairs at an old height. The short keys
+// are enumerated from the history index in rocksdb, whose keys are: 0 + shortKey(8 bytes) + height(8 bytes)
+func scanAllAtHeight(dataPath string, mads *moeingads.MoeingADS, height uint64, fn func(key, value []byte)) error {
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	db, err := gorocksdb.OpenDbForReadOnly(opts, filepath.Join(dataPath, "rocksdb.db"), false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	ro := gorocksdb.NewDefaultReadOptions()
+	ro.SetFillCache(false)
+	defer ro.Destroy()
+	iter := db.NewIterator(ro)
+	defer iter.Close()
+	var lastKey []byte
+	for iter.Seek([]byte{0}); iter.Valid(); iter.Next() {
+		k := iter.Key()
+		data := k.Data()
+		if len(data) == 0 || data[0] != 0 {
+			k.Free()
+			break
+		}
+		if len(data) != 1+8+8 || bytes.Equal(data[1:9], lastKey) {
+			k.Free()
+			continue
+		}
+		lastKey = append([]byte{}, data[1:9]...)
+		k.Free()
+		if entry := mads.GetEntryAtHeight(lastKey, height); entry != nil {
+			fn(lastKey, entry.Value)
+		}
+	}
+	return iter.Err()
+}
@@ -17,6 +17,8 @@ type Validator struct {
 	Introduction string          `json:"introduction"`
 	StakedCoins  gethcmn.Hash    `json:"staked_coins"`
 	IsRetiring   bool            `json:"is_retiring"`
+	IsJailed     bool            `json:"is_jailed"`
+	UnjailHeight int64           `json:"unjail_height"`
 	MinerAddress crypto.Address  `json:"miner_address"`
 }
 
@@ -36,6 +38,8 @@ func (g GenesisData) StakingValidators() []*stakingtypes.Validator {
 			Introduction: v.Introduction,
 			StakedCoins:  v.StakedCoins,
 			IsRetiring:   v.IsRetiring,
+			IsJailed:     v.IsJailed,
+			UnjailHeight: v.UnjailHeight,
 		}
 	}
 	return ret
@@ -58,6 +62,8 @@ func FromStakingValidator(v *stakingtypes.Validator) *Validator {
 		Introduction: v.Introduction,
 		StakedCoins:  v.StakedCoins,
 		IsRetiring:   v.IsRetiring,
+		IsJailed:     v.IsJailed,
+		UnjailHeight: v.UnjailHeight,
 		MinerAddress: ed25519.PubKey(v.Pubkey[:]).Address(),
 	}
 }
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsNamespace is the namespace of all the metrics exposed by smartbchd
+	MetricsNamespace = "smartbch"
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this package.
+	MetricsSubsystem = "app"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Time spent on executing the committed TXs of a block in postCommit, in seconds.
+	BlockExecutionSeconds metrics.Histogram
+	// Number of TXs collected by the TX engine in the last block.
+	CollectedTxs metrics.Gauge
+	// Number of TXs waiting in the standby queue of the TX engine.
+	StandbyQueueLen metrics.Gauge
+	// Number of TXs rechecked after the last block.
+	RecheckedTxs metrics.Gauge
+	// Number of hits and misses of the signature cache in CheckTx.
+	SigCacheHits   metrics.Counter
+	SigCacheMisses metrics.Counter
+	// The minimum gas price of the last block.
+	LastMinGasPrice metrics.Gauge
+	// The current epoch number of staking.
+	CurrEpochNum metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library, which are
+// registered into the given registerer.
+func PrometheusMetrics(registerer stdprometheus.Registerer, namespace string) *Metrics {
+	blockExecutionSeconds := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "block_execution_seconds",
+		Help:      "Time spent on executing the committed transactions of a block.",
+		Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 16),
+	}, nil)
+	collectedTxs := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "collected_txs",
+		Help:      "Number of transactions collected by the transaction engine in the last block.",
+	}, nil)
+	standbyQueueLen := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "standby_queue_len",
+		Help:      "Number of transactions waiting in the standby queue.",
+	}, nil)
+	recheckedTxs := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "rechecked_txs",
+		Help:      "Number of mempool transactions rechecked after the last block.",
+	}, nil)
+	sigCacheHits := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "sig_cache_hits",
+		Help:      "Number of signature cache hits in CheckTx.",
+	}, nil)
+	sigCacheMisses := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "sig_cache_misses",
+		Help:      "Number of signature cache misses in CheckTx.",
+	}, nil)
+	lastMinGasPrice := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "last_min_gas_price",
+		Help:      "The minimum gas price of the last block.",
+	}, nil)
+	currEpochNum := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "curr_epoch_num",
+		Help:      "The current epoch number of staking.",
+	}, nil)
+	registerer.MustRegister(blockExecutionSeconds, collectedTxs, standbyQueueLen, recheckedTxs,
+		sigCacheHits, sigCacheMisses, lastMinGasPrice, currEpochNum)
+	return &Metrics{
+		BlockExecutionSeconds: prometheus.NewHistogram(blockExecutionSeconds),
+		CollectedTxs:          prometheus.NewGauge(collectedTxs),
+		StandbyQueueLen:       prometheus.NewGauge(standbyQueueLen),
+		RecheckedTxs:          prometheus.NewGauge(recheckedTxs),
+		SigCacheHits:          prometheus.NewCounter(sigCacheHits),
+		SigCacheMisses:        prometheus.NewCounter(sigCacheMisses),
+		LastMinGasPrice:       prometheus.NewGauge(lastMinGasPrice),
+		CurrEpochNum:          prometheus.NewGauge(currEpochNum),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		BlockExecutionSeconds: discard.NewHistogram(),
+		CollectedTxs:          discard.NewGauge(),
+		StandbyQueueLen:       discard.NewGauge(),
+		RecheckedTxs:          discard.NewGauge(),
+		SigCacheHits:          discard.NewCounter(),
+		SigCacheMisses:        discard.NewCounter(),
+		LastMinGasPrice:       discard.NewGauge(),
+		CurrEpochNum:          discard.NewGauge(),
+	}
+}
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+
+	"github.com/tecbot/gorocksdb"
+
+	"github.com/smartbch/moeingads/store/rabbit"
+
+	"github.com/smartbch/smartbch/stateproof"
+)
+
+const (
+	// the key prefix used by moeingads' metadb to persist the root hashes of the shards
+	metaByteRootHash = byte(0x19)
+	// the key prefix used by moeingads' index tree to persist the positions of entries in archive mode,
+	// followed by the short key and the height
+	indexKeyPrefix = byte(0)
+	// how many times GetStateProofs retries when a new block is committed during it
+	maxStateProofTries = 3
+)
+
+// GetStateProofs generates the proofs of the KV pairs of the latest world state, which can be verified by
+// package stateproof against the returned app hash. The app hash is the stateRoot of the latest block
+// in moeingdb, and the AppHash in the header of the next tendermint block. The proof of a missing key
+// proves its absence. But when the last short key of its rabbit path is missing, the proof needs the
+// predecessor of this short key, which can only be found in archive mode, because otherwise moeingads
+// keeps its index in memory only. So the proof of such a key is nil on a non-archive node.
+func (app *App) GetStateProofs(keys [][]byte) (appHash []byte, proofs [][]byte, err error) {
+	for i := 0; i < maxStateProofTries; i++ {
+		appHash, proofs, err = app.getStateProofs(keys)
+		if err == nil {
+			return appHash, proofs, nil
+		}
+		app.logger.Debug("failed to get state proofs", "error", err.Error())
+	}
+	return nil, nil, err
+}
+
+func (app *App) getStateProofs(keys [][]byte) (appHash []byte, proofs [][]byte, err error) {
+	app.proofDBMtx.Lock()
+	defer app.proofDBMtx.Unlock()
+	roots, err := app.getShardRoots()
+	if err != nil {
+		return nil, nil, err
+	}
+	hash := stateproof.AppHashOfShardRoots(roots)
+	appHash = hash[:]
+	proofs = make([][]byte, len(keys))
+	for i, key := range keys {
+		if proofs[i], err = app.getStateProof(roots, appHash, key); err != nil {
+			if errors.Is(err, stateproof.ErrRootMismatch) {
+				app.closeProofDB() // outdated
+			}
+			return nil, nil, err
+		}
+	}
+	return
+}
+
+// getStateProof proves one key. The rabbit store blocks the writing of app.root until closed, so it is
+// only kept during the proving of a single key, and each key checks that app.root is still at appHash.
+func (app *App) getStateProof(roots [stateproof.ShardCount][32]byte, appHash, key []byte) ([]byte, error) {
+	rbt := rabbit.NewReadOnlyRabbitStore(app.root)
+	defer rbt.Close()
+	if !bytes.Equal(app.root.GetRootHash(), appHash) {
+		return nil, stateproof.ErrRootMismatch
+	}
+	path, _ := rbt.GetShortKeyPath(key)
+	proof := &stateproof.Proof{ShardRoots: roots}
+	for _, shortKey := range path {
+		k := shortKey[:]
+		if app.mads.GetEntry(k) == nil { // only the last short key can be missing
+			var ok bool
+			if k, ok = app.findPrevShortKey(k); !ok {
+				return nil, nil
+			}
+		}
+		entryBz, pathBz, err := app.mads.GetProof(k)
+		if err != nil {
+			return nil, err
+		}
+		proof.Entries = append(proof.Entries, stateproof.EntryProof{Entry: entryBz, Path: pathBz})
+	}
+	bz := proof.ToBytes()
+	if _, err := stateproof.Verify(appHash, key, bz); err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
+
+// findPrevShortKey finds the largest existing short key less than shortKey, from the history of the
+// index tree persisted in rocksdb in archive mode. The latest record of a short key is the last one
+// because the records are sorted by height, and it is empty if the short key was deleted.
+func (app *App) findPrevShortKey(shortKey []byte) ([]byte, bool) {
+	if !app.config.AppConfig.ArchiveMode {
+		return nil, false
+	}
+	ro := gorocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+	iter := app.proofDB.NewIterator(ro)
+	defer iter.Close()
+	target := append([]byte{indexKeyPrefix}, shortKey...)
+	for iter.SeekForPrev(target); iter.Valid(); iter.SeekForPrev(target) {
+		k, v := iter.Key(), iter.Value()
+		key, deleted := k.Data(), v.Size() == 0
+		if len(key) != 1+rabbit.KeySize+8 || key[0] != indexKeyPrefix {
+			k.Free()
+			v.Free()
+			break
+		}
+		target = append([]byte{indexKeyPrefix}, key[1:1+rabbit.KeySize]...)
+		k.Free()
+		v.Free()
+		if !deleted {
+			return target[1:], true
+		}
+	}
+	if iter.Err() != nil {
+		return nil, false
+	}
+	return make([]byte, rabbit.KeySize), true // the start guard, which is less than any short key
+}
+
+// getShardRoots returns the root hashes of moeingads' shards, which are not exported by moeingads but
+// persisted in its rocksdb at the end of each block. The rocksdb is opened read-only and kept for the
+// following proofs, until a new block is committed: a read-only handle cannot see the later writes,
+// so getStateProofs closes it once the roots are outdated. It must be called with proofDBMtx locked.
+func (app *App) getShardRoots() (roots [stateproof.ShardCount][32]byte, err error) {
+	if app.shardRoots != nil {
+		return *app.shardRoots, nil
+	}
+	if app.proofDB == nil {
+		opts := gorocksdb.NewDefaultOptions()
+		defer opts.Destroy()
+		dbPath := filepath.Join(app.config.AppConfig.AppDataPath, "rocksdb.db")
+		if app.proofDB, err = gorocksdb.OpenDbForReadOnly(opts, dbPath, false); err != nil {
+			return roots, err
+		}
+	}
+	ro := gorocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+	for i := range roots {
+		bz, err := app.proofDB.GetBytes(ro, []byte{metaByteRootHash, byte(i)})
+		if err != nil {
+			return roots, err
+		}
+		if len(bz) != 32 {
+			return roots, errors.New("cannot find the root hash of shard in rocksdb")
+		}
+		copy(roots[i][:], bz)
+	}
+	app.shardRoots = &roots
+	return roots, nil
+}
+
+// closeProofDB closes the read-only rocksdb and drops the cached shard roots. It must be called with
+// proofDBMtx locked.
+func (app *App) closeProofDB() {
+	if app.proofDB != nil {
+		app.proofDB.Close()
+		app.proofDB = nil
+	}
+	app.shardRoots = nil
+}
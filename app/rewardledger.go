@@ -0,0 +1,201 @@
+package app
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/tecbot/gorocksdb"
+
+	"github.com/smartbch/smartbch/staking"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+// The reward ledgers and the slash events booked by the staking engine are not consensus data, so they are
+// kept in a side index, a rocksdb at LedgerDataPath, instead of world state. The bookings of a block are
+// written before its world state is committed, together with its height: a block replayed after a crash
+// has the same bookings, which are skipped. Its keys are:
+//   'h'                               -> the height of the last booked block
+//   'l' + epochNum(8)                 -> the msgp-encoded RewardLedger of the epoch
+//   's' + validator(20) + height(8) + index(4) -> the msgp-encoded SlashEvent
+// All the numbers are big-endian, such that the ledgers are sorted by epoch, and the slash events of a
+// validator are sorted by height.
+
+const (
+	ledgerKeyLastHeight  = 'h'
+	ledgerKeyLedger      = 'l'
+	ledgerKeySlashEvents = 's'
+)
+
+type rewardLedgerDB struct {
+	db *gorocksdb.DB
+}
+
+func openRewardLedgerDB(dataPath string) (*rewardLedgerDB, error) {
+	if err := os.MkdirAll(dataPath, 0700); err != nil {
+		return nil, err
+	}
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetCreateIfMissing(true)
+	db, err := gorocksdb.OpenDb(opts, dataPath)
+	if err != nil {
+		return nil, err
+	}
+	return &rewardLedgerDB{db: db}, nil
+}
+
+// openRewardLedgerDBForReadOnly opens the side index of an offline node, nil is returned if it is missing
+func openRewardLedgerDBForReadOnly(dataPath string) *rewardLedgerDB {
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	db, err := gorocksdb.OpenDbForReadOnly(opts, dataPath, false)
+	if err != nil {
+		return nil
+	}
+	return &rewardLedgerDB{db: db}
+}
+
+func (ldb *rewardLedgerDB) close() {
+	ldb.db.Close()
+}
+
+func ledgerKey(epochNum int64) []byte {
+	key := make([]byte, 9)
+	key[0] = ledgerKeyLedger
+	binary.BigEndian.PutUint64(key[1:], uint64(epochNum))
+	return key
+}
+
+func slashEventKey(event *stakingtypes.SlashEvent, index int) []byte {
+	key := make([]byte, 1+20+8+4)
+	key[0] = ledgerKeySlashEvents
+	copy(key[1:], event.Validator[:])
+	binary.BigEndian.PutUint64(key[21:], uint64(event.Height))
+	binary.BigEndian.PutUint32(key[29:], uint32(index))
+	return key
+}
+
+func (ldb *rewardLedgerDB) getBytes(key []byte) []byte {
+	ro := gorocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+	bz, err := ldb.db.GetBytes(ro, key)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (ldb *rewardLedgerDB) lastHeight() int64 {
+	bz := ldb.getBytes([]byte{ledgerKeyLastHeight})
+	if len(bz) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// book merges the bookings of the block at 'height' into the side index
+func (ldb *rewardLedgerDB) book(height int64, bookings *staking.RewardBookings) {
+	if len(bookings.Ledgers) == 0 && len(bookings.SlashEvents) == 0 {
+		return
+	}
+	if height <= ldb.lastHeight() {
+		return // booked before the node crashed
+	}
+	batch := gorocksdb.NewWriteBatch()
+	defer batch.Destroy()
+	for _, booked := range bookings.Ledgers {
+		ledger, _ := ldb.loadLedger(booked.EpochNum)
+		ledger.Merge(booked)
+		bz, err := ledger.MarshalMsg(nil)
+		if err != nil {
+			panic(err)
+		}
+		batch.Put(ledgerKey(ledger.EpochNum), bz)
+	}
+	for i, event := range bookings.SlashEvents {
+		bz, err := event.MarshalMsg(nil)
+		if err != nil {
+			panic(err)
+		}
+		batch.Put(slashEventKey(event, i), bz)
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(height))
+	batch.Put([]byte{ledgerKeyLastHeight}, buf[:])
+	wo := gorocksdb.NewDefaultWriteOptions()
+	defer wo.Destroy()
+	if err := ldb.db.Write(wo, batch); err != nil {
+		panic(err)
+	}
+}
+
+// loadLedger loads the ledger of epoch 'epochNum', an empty one is returned if it is not found
+func (ldb *rewardLedgerDB) loadLedger(epochNum int64) (ledger stakingtypes.RewardLedger, found bool) {
+	bz := ldb.getBytes(ledgerKey(epochNum))
+	if len(bz) == 0 {
+		ledger.EpochNum = epochNum
+		return
+	}
+	if _, err := ledger.UnmarshalMsg(bz); err != nil {
+		panic(err)
+	}
+	return ledger, true
+}
+
+// iterate calls fn with the values whose keys are in [start, end), until fn returns false
+func (ldb *rewardLedgerDB) iterate(start, end []byte, fn func(value []byte) bool) {
+	ro := gorocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+	ro.SetIterateUpperBound(end)
+	iter := ldb.db.NewIterator(ro)
+	defer iter.Close()
+	for iter.Seek(start); iter.Valid(); iter.Next() {
+		v := iter.Value()
+		goOn := fn(v.Data())
+		v.Free()
+		if !goOn {
+			break
+		}
+	}
+}
+
+// ledgers returns the ledgers of the epochs in [start, end), the epochs in which nothing is booked are skipped
+func (ldb *rewardLedgerDB) ledgers(start, end int64) (ledgers []*stakingtypes.RewardLedger) {
+	ldb.iterate(ledgerKey(start), ledgerKey(end), func(value []byte) bool {
+		ledger := &stakingtypes.RewardLedger{}
+		if _, err := ledger.UnmarshalMsg(value); err != nil {
+			panic(err)
+		}
+		ledgers = append(ledgers, ledger)
+		return true
+	})
+	return
+}
+
+// GetRewardLedgers returns the reward ledgers of the epochs in [startEpoch, endEpoch), the epochs in which
+// nothing is booked are skipped
+func (app *App) GetRewardLedgers(startEpoch, endEpoch int64) []*stakingtypes.RewardLedger {
+	return app.ledgerDB.ledgers(startEpoch, endEpoch)
+}
+
+// GetSlashEvents returns at most 'limit' slash events of 'validator', in ascending order of height
+func (app *App) GetSlashEvents(validator gethcmn.Address, limit int) []*stakingtypes.SlashEvent {
+	return app.ledgerDB.slashEvents(validator, limit)
+}
+
+// slashEvents returns at most 'limit' slash events of 'validator', in ascending order of height
+func (ldb *rewardLedgerDB) slashEvents(validator [20]byte, limit int) (events []*stakingtypes.SlashEvent) {
+	start := append([]byte{ledgerKeySlashEvents}, validator[:]...)
+	end := append(append([]byte{ledgerKeySlashEvents}, validator[:]...), bytes.Repeat([]byte{0xff}, 8+4+1)...)
+	ldb.iterate(start, end, func(value []byte) bool {
+		event := &stakingtypes.SlashEvent{}
+		if _, err := event.UnmarshalMsg(value); err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+		return len(events) < limit
+	})
+	return
+}
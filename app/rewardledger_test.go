@@ -0,0 +1,63 @@
+package app
+
+import (
+	"os"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/smartbch/staking"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestRewardLedgerDB(t *testing.T) {
+	dataPath := "./testLedgerDb"
+	_ = os.RemoveAll(dataPath)
+	defer os.RemoveAll(dataPath)
+	ldb, err := openRewardLedgerDB(dataPath)
+	require.NoError(t, err)
+
+	val1, val2 := [20]byte{0xad, 0x01}, [20]byte{0xad, 0x02}
+	newBookings := func(epochNum int64, height int64) *staking.RewardBookings {
+		ledger := &stakingtypes.RewardLedger{EpochNum: epochNum}
+		ledger.GetOrAddEntry(val1).VoterReward = uint256.NewInt(100).Bytes32()
+		return &staking.RewardBookings{
+			Ledgers: []*stakingtypes.RewardLedger{ledger},
+			SlashEvents: []*stakingtypes.SlashEvent{
+				{Height: height, Validator: val2, Reason: 1, Amount: uint256.NewInt(7).Bytes32()},
+			},
+		}
+	}
+	ldb.book(10, newBookings(3, 10))
+	ldb.book(11, newBookings(3, 11))
+	ldb.book(11, newBookings(3, 11)) // replayed after a crash
+	ldb.book(12, &staking.RewardBookings{})
+	ldb.book(13, newBookings(5, 13))
+
+	ledger, found := ldb.loadLedger(3)
+	require.True(t, found)
+	require.Equal(t, uint256.NewInt(200).Bytes32(), ledger.GetEntry(val1).VoterReward)
+	_, found = ldb.loadLedger(4)
+	require.False(t, found)
+	ledgers := ldb.ledgers(0, 100)
+	require.Len(t, ledgers, 2)
+	require.Equal(t, int64(3), ledgers[0].EpochNum)
+	require.Equal(t, int64(5), ledgers[1].EpochNum)
+	require.Len(t, ldb.ledgers(4, 5), 0)
+
+	events := ldb.slashEvents(val2, 10)
+	require.Len(t, events, 3)
+	require.Equal(t, int64(10), events[0].Height)
+	require.Equal(t, int64(13), events[2].Height)
+	require.Len(t, ldb.slashEvents(val2, 2), 2)
+	require.Len(t, ldb.slashEvents(val1, 10), 0)
+
+	// the bookings are kept after reopening
+	ldb.close()
+	ldb = openRewardLedgerDBForReadOnly(dataPath)
+	require.NotNil(t, ldb)
+	require.Equal(t, int64(13), ldb.lastHeight())
+	ldb.close()
+	require.Nil(t, openRewardLedgerDBForReadOnly("./noSuchLedgerDb"))
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"math/big"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+// forkSigner accepts EIP-2930 and EIP-1559 typed TXs since param.EIP1559ForkHeight, and behaves
+// exactly like the EIP155 signer before it, such that the old blocks are replayed in the same way.
+type forkSigner struct {
+	gethtypes.Signer // the London signer
+	legacy           gethtypes.Signer
+	getHeight        func() int64
+}
+
+var _ gethtypes.Signer = (*forkSigner)(nil)
+
+func newForkSigner(chainId *big.Int, getHeight func() int64) *forkSigner {
+	return &forkSigner{
+		Signer:    gethtypes.NewLondonSigner(chainId),
+		legacy:    gethtypes.NewEIP155Signer(chainId),
+		getHeight: getHeight,
+	}
+}
+
+func (s *forkSigner) current() gethtypes.Signer {
+	if s.getHeight() < param.EIP1559ForkHeight {
+		return s.legacy
+	}
+	return s.Signer
+}
+
+func (s *forkSigner) Sender(tx *gethtypes.Transaction) (gethcmn.Address, error) {
+	return s.current().Sender(tx)
+}
+
+func (s *forkSigner) SignatureValues(tx *gethtypes.Transaction, sig []byte) (r, sv, v *big.Int, err error) {
+	return s.current().SignatureValues(tx, sig)
+}
+
+func (s *forkSigner) Hash(tx *gethtypes.Transaction) gethcmn.Hash {
+	return s.current().Hash(tx)
+}
+
+func (s *forkSigner) Equal(s2 gethtypes.Signer) bool {
+	x, ok := s2.(*forkSigner)
+	return ok && x.Signer.Equal(s.Signer)
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/holiman/uint256"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/moeingads/store"
+	"github.com/smartbch/moeingads/store/rabbit"
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+// An EpochSimulation shows an epoch switch replayed under the params in use and under the alternative ones
+type EpochSimulation struct {
+	Baseline  *staking.SimEpochResult
+	Simulated *staking.SimEpochResult
+	// the fee rewards booked in the epoch's ledger, nil if the ledger is not found in the side index
+	BookedRewards map[[20]byte]*uint256.Int
+}
+
+// SimulateEpochs replays the switches to 'epochs' in turn over the world state at 'height', twice: one with the
+// params in use and the other with 'simParams'. The epochs are loaded from the latest state if 'epochs' is nil,
+// from 'fromEpoch' (included) to 'toEpoch' (excluded). Like ExportState, it must run offline, and only the
+// nodes in archive mode can start from older heights. The replays are never written back. The booked rewards
+// are read from the side index at 'ledgerDataPath', and they are left nil if it is missing.
+func SimulateEpochs(dataPath, ledgerDataPath string, isArchiveMode bool, height int64, epochs []*stakingtypes.Epoch,
+	fromEpoch, toEpoch int64, simParams staking.SimParams, logger log.Logger) ([]*EpochSimulation, error) {
+	root, mads := CreateRootStore(dataPath, isArchiveMode)
+	defer root.Close()
+	latestHeight := mads.GetCurrHeight()
+	if height == 0 {
+		height = latestHeight
+	}
+	if height <= 0 || height > latestHeight {
+		return nil, fmt.Errorf("invalid height %d, the latest height is %d", height, latestHeight)
+	}
+	if height != latestHeight && !isArchiveMode {
+		return nil, fmt.Errorf("only the latest height %d can be simulated without archive mode", latestHeight)
+	}
+
+	// the epochs are read from the latest state, since they are kept forever
+	latestCtx := newSimulationContext(root, latestHeight, latestHeight)
+	defer latestCtx.Close(false)
+	ledgerDB := openRewardLedgerDBForReadOnly(ledgerDataPath)
+	if ledgerDB != nil {
+		defer ledgerDB.close()
+	}
+	loadLedger := func(epochNum int64) *stakingtypes.RewardLedger {
+		if ledgerDB == nil {
+			return nil
+		}
+		if ledger, found := ledgerDB.loadLedger(epochNum); found {
+			return &ledger
+		}
+		return nil
+	}
+	if epochs == nil {
+		for epochNum := fromEpoch; epochNum < toEpoch; epochNum++ {
+			epoch, ok := staking.LoadEpoch(latestCtx, epochNum)
+			if !ok {
+				return nil, fmt.Errorf("epoch %d is not found", epochNum)
+			}
+			epochs = append(epochs, &epoch)
+		}
+	}
+	if len(epochs) == 0 {
+		return nil, fmt.Errorf("no epoch to simulate")
+	}
+
+	replay := func(simParams *staking.SimParams) []*staking.SimEpochResult {
+		ctx := newSimulationContext(root, height, latestHeight)
+		defer ctx.Close(false)
+		if simParams != nil {
+			staking.ApplySimParams(ctx, *simParams)
+		}
+		var posVotes map[[32]byte]int64
+		if ctx.IsXHedgeFork() {
+			posVotes = staking.GetAndClearPosVotes(ctx, param.XHedgeContractSequence)
+		}
+		results := make([]*staking.SimEpochResult, len(epochs))
+		for i, epoch := range epochs {
+			// the fee rewards are booked in the ledger of the new epoch, whose number is not changed by replays
+			totalReward := staking.TotalFeeReward(loadLedger(staking.LoadStakingInfo(ctx).CurrEpochNum + 1))
+			results[i] = staking.SimulateSwitchEpoch(ctx, epoch, posVotes, totalReward, logger)
+		}
+		return results
+	}
+	baseline := replay(nil)
+	simulated := replay(&simParams)
+
+	sims := make([]*EpochSimulation, len(epochs))
+	for i := range epochs {
+		sims[i] = &EpochSimulation{Baseline: baseline[i], Simulated: simulated[i]}
+		ledger := loadLedger(baseline[i].EpochNum)
+		if ledger == nil {
+			continue
+		}
+		sims[i].BookedRewards = make(map[[20]byte]*uint256.Int, len(ledger.Entries))
+		for _, entry := range ledger.Entries {
+			sims[i].BookedRewards[entry.Validator] = entry.FeeReward()
+		}
+	}
+	return sims, nil
+}
+
+// a sandbox context over the world state at 'height', whose changes must be discarded
+func newSimulationContext(root *store.RootStore, height, latestHeight int64) *types.Context {
+	var rbt rabbit.RabbitStore
+	if height == latestHeight {
+		rbt = rabbit.NewReadOnlyRabbitStore(root)
+	} else {
+		rbt = rabbit.NewReadOnlyRabbitStoreAtHeight(root, uint64(height))
+	}
+	ctx := types.NewContext(&rbt, nil)
+	ctx.SetStakingForkBlock(param.StakingForkHeight)
+	ctx.SetXHedgeForkBlock(param.XHedgeForkBlock)
+	ctx.SetSymbolSbchBlock(param.SymbolSbchForkHeight)
+	ctx.SetCurrentHeight(height)
+	return ctx
+}
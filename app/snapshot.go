@@ -0,0 +1,632 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/tecbot/gorocksdb"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/smartbch/moeingads/indextree"
+	modbtypes "github.com/smartbch/moeingdb/types"
+	"github.com/smartbch/moeingevm/types"
+)
+
+// A state-sync snapshot is a copy of moeingads' data directory at some height. The datatree files
+// ("entries.N" and "twigmt.N") are copied as they are, while the rocksdb (which holds the meta info
+// and, in archive mode, the history index) is dumped as a list of KV pairs. Reproducing the files
+// instead of the KV pairs of the world state ensures the restored moeingads has the same root hash.
+//
+// All the files are serialized into one stream, in which each file is a record:
+//   nameLen(2 bytes) + name + size(8 bytes) + content
+// and then the stream is cut into chunks of snapshotChunkSize. The hash of a snapshot is the sha256
+// of the concatenated chunk hashes, and the chunk hashes are kept in the snapshot's metadata, so a
+// bad chunk can be detected before it is applied.
+
+const (
+	snapshotFormat      uint32 = 1
+	snapshotChunkSize          = 10 * 1024 * 1024
+	snapshotInfoFile           = "snapshot.json"
+	snapshotRocksDBDump        = "rocksdb.kv"
+	snapshotRestoreDir         = "restoring"
+)
+
+var (
+	errSnapshotNotFound   = errors.New("snapshot not found")
+	errInvalidSnapshotRec = errors.New("invalid record in snapshot")
+)
+
+type snapshotMetadata struct {
+	AppHash     []byte   `json:"app_hash"`
+	ChunkHashes [][]byte `json:"chunk_hashes"`
+}
+
+// snapshotRestorer keeps the states of an ongoing restoration, which is created in OfferSnapshot
+// and is fed by ApplySnapshotChunk
+type snapshotRestorer struct {
+	snapshot *abcitypes.Snapshot
+	meta     snapshotMetadata
+	dir      string // the received chunks are saved here
+}
+
+func (app *App) ListSnapshots(req abcitypes.RequestListSnapshots) abcitypes.ResponseListSnapshots {
+	res := abcitypes.ResponseListSnapshots{}
+	for _, height := range app.listSnapshotHeights() {
+		snapshot, err := app.loadSnapshotInfo(height)
+		if err != nil {
+			app.logger.Error("Cannot load snapshot", "height", height, "err", err.Error())
+			continue
+		}
+		res.Snapshots = append(res.Snapshots, snapshot)
+	}
+	return res
+}
+
+func (app *App) LoadSnapshotChunk(req abcitypes.RequestLoadSnapshotChunk) abcitypes.ResponseLoadSnapshotChunk {
+	if req.Format != snapshotFormat {
+		return abcitypes.ResponseLoadSnapshotChunk{}
+	}
+	chunk, err := os.ReadFile(filepath.Join(app.snapshotDir(int64(req.Height)), strconv.Itoa(int(req.Chunk))))
+	if err != nil {
+		app.logger.Error("Cannot load snapshot chunk", "height", req.Height, "chunk", req.Chunk, "err", err.Error())
+		return abcitypes.ResponseLoadSnapshotChunk{}
+	}
+	return abcitypes.ResponseLoadSnapshotChunk{Chunk: chunk}
+}
+
+func (app *App) OfferSnapshot(req abcitypes.RequestOfferSnapshot) abcitypes.ResponseOfferSnapshot {
+	if req.Snapshot == nil {
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT}
+	}
+	if req.Snapshot.Format != snapshotFormat {
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT_FORMAT}
+	}
+	var meta snapshotMetadata
+	if err := json.Unmarshal(req.Snapshot.Metadata, &meta); err != nil ||
+		len(meta.ChunkHashes) != int(req.Snapshot.Chunks) ||
+		!bytes.Equal(hashOfChunkHashes(meta.ChunkHashes), req.Snapshot.Hash) ||
+		!bytes.Equal(meta.AppHash, req.AppHash) {
+		app.logger.Info("Reject snapshot with invalid metadata", "height", req.Snapshot.Height)
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT}
+	}
+	if app.currHeight != 0 {
+		app.logger.Error("Cannot restore snapshot into a non-empty node", "height", app.currHeight)
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ABORT}
+	}
+	dir := filepath.Join(app.config.AppConfig.SnapshotDir, snapshotRestoreDir)
+	_ = os.RemoveAll(dir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		app.logger.Error("Cannot create snapshot restoring directory", "err", err.Error())
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ABORT}
+	}
+	app.restorer = &snapshotRestorer{
+		snapshot: req.Snapshot,
+		meta:     meta,
+		dir:      dir,
+	}
+	app.logger.Info("Accept snapshot", "height", req.Snapshot.Height, "chunks", req.Snapshot.Chunks)
+	return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ACCEPT}
+}
+
+func (app *App) ApplySnapshotChunk(req abcitypes.RequestApplySnapshotChunk) abcitypes.ResponseApplySnapshotChunk {
+	r := app.restorer
+	if r == nil || req.Index >= r.snapshot.Chunks {
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+	}
+	hash := sha256.Sum256(req.Chunk)
+	if !bytes.Equal(hash[:], r.meta.ChunkHashes[req.Index]) {
+		app.logger.Info("Refetch bad snapshot chunk", "index", req.Index, "sender", req.Sender)
+		return abcitypes.ResponseApplySnapshotChunk{
+			Result:        abcitypes.ResponseApplySnapshotChunk_RETRY,
+			RefetchChunks: []uint32{req.Index},
+			RejectSenders: []string{req.Sender},
+		}
+	}
+	err := os.WriteFile(filepath.Join(r.dir, strconv.Itoa(int(req.Index))), req.Chunk, 0600)
+	if err != nil {
+		app.logger.Error("Cannot save snapshot chunk", "index", req.Index, "err", err.Error())
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+	}
+	if req.Index+1 < r.snapshot.Chunks { // tendermint applies the chunks in order
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ACCEPT}
+	}
+	app.restorer = nil
+	defer os.RemoveAll(r.dir)
+	if err = app.restoreSnapshot(r); err != nil {
+		app.logger.Error("Cannot restore snapshot", "height", r.snapshot.Height, "err", err.Error())
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+	app.logger.Info("Snapshot restored", "height", app.currHeight)
+	return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+func (app *App) snapshotDir(height int64) string {
+	return filepath.Join(app.config.AppConfig.SnapshotDir, strconv.FormatInt(height, 10))
+}
+
+// listSnapshotHeights returns the heights of the finished snapshots, in ascending order
+func (app *App) listSnapshotHeights() []int64 {
+	entries, err := os.ReadDir(app.config.AppConfig.SnapshotDir)
+	if err != nil {
+		return nil
+	}
+	heights := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || !entry.IsDir() {
+			continue // staging directories or other files
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+func (app *App) loadSnapshotInfo(height int64) (*abcitypes.Snapshot, error) {
+	bz, err := os.ReadFile(filepath.Join(app.snapshotDir(height), snapshotInfoFile))
+	if os.IsNotExist(err) {
+		return nil, errSnapshotNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	snapshot := &abcitypes.Snapshot{}
+	err = json.Unmarshal(bz, snapshot)
+	return snapshot, err
+}
+
+// takeSnapshot is called in postCommit, when app.root will not be written until the next Commit.
+// It only links (or copies) the files of moeingads while holding app.mtx, and serializes them into
+// chunks in another goroutine.
+func (app *App) takeSnapshot(height int64) {
+	interval := app.config.AppConfig.SnapshotInterval
+	if interval <= 0 || height <= 0 || height%interval != 0 {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&app.snapshotting, 0, 1) {
+		app.logger.Info("Skip snapshot because the last one is not finished", "height", height)
+		return
+	}
+	if _, err := os.Stat(app.snapshotDir(height)); err == nil {
+		atomic.StoreInt32(&app.snapshotting, 0)
+		return // already taken before restart
+	}
+	appHash := append([]byte{}, app.root.GetRootHash()...)
+	stagingDir := app.snapshotDir(height) + ".staging"
+	err := stageSnapshotFiles(app.config.AppConfig.AppDataPath, stagingDir)
+	if err != nil {
+		_ = os.RemoveAll(stagingDir)
+		atomic.StoreInt32(&app.snapshotting, 0)
+		app.logger.Error("Cannot take snapshot", "height", height, "err", err.Error())
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&app.snapshotting, 0)
+		defer os.RemoveAll(stagingDir)
+		if err := app.packSnapshot(height, appHash, stagingDir); err != nil {
+			app.logger.Error("Cannot pack snapshot", "height", height, "err", err.Error())
+			return
+		}
+		app.logger.Info("Snapshot taken", "height", height)
+		app.pruneSnapshots()
+	}()
+}
+
+func (app *App) packSnapshot(height int64, appHash []byte, stagingDir string) error {
+	tmpDir := app.snapshotDir(height) + ".tmp"
+	_ = os.RemoveAll(tmpDir)
+	if err := os.MkdirAll(tmpDir, 0700); err != nil {
+		return err
+	}
+	w := &chunkWriter{dir: tmpDir}
+	if err := writeSnapshotStream(w, stagingDir); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	meta := snapshotMetadata{AppHash: appHash, ChunkHashes: w.hashes}
+	metaBz, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	snapshot := abcitypes.Snapshot{
+		Height:   uint64(height),
+		Format:   snapshotFormat,
+		Chunks:   uint32(len(w.hashes)),
+		Hash:     hashOfChunkHashes(w.hashes),
+		Metadata: metaBz,
+	}
+	bz, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(tmpDir, snapshotInfoFile), bz, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, app.snapshotDir(height))
+}
+
+// pruneSnapshots removes the old snapshots such that only SnapshotKeepRecent ones are kept
+func (app *App) pruneSnapshots() {
+	heights := app.listSnapshotHeights()
+	keep := app.config.AppConfig.SnapshotKeepRecent
+	if keep <= 0 || len(heights) <= keep {
+		return
+	}
+	for _, height := range heights[:len(heights)-keep] {
+		if err := os.RemoveAll(app.snapshotDir(height)); err != nil {
+			app.logger.Error("Cannot remove old snapshot", "height", height, "err", err.Error())
+		}
+	}
+}
+
+// restoreSnapshot rebuilds moeingads' data directory from the received chunks, and then reloads
+// the app's states from it. It must be called before the node processes any block.
+func (app *App) restoreSnapshot(r *snapshotRestorer) error {
+	files := make([]io.Reader, 0, r.snapshot.Chunks)
+	for i := 0; i < int(r.snapshot.Chunks); i++ {
+		f, err := os.Open(filepath.Join(r.dir, strconv.Itoa(i)))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		files = append(files, f)
+	}
+	dataPath := app.config.AppConfig.AppDataPath
+	newDataPath := dataPath + ".restoring"
+	_ = os.RemoveAll(newDataPath)
+	if err := readSnapshotStream(bufio.NewReader(io.MultiReader(files...)), newDataPath); err != nil {
+		_ = os.RemoveAll(newDataPath)
+		return err
+	}
+
+	app.checkTrunk.Close(false)
+	app.trunk.Close(false)
+	app.root.Close()
+	if err := os.RemoveAll(dataPath); err != nil {
+		return err
+	}
+	if err := os.Rename(newDataPath, dataPath); err != nil {
+		return err
+	}
+	app.root, app.mads = CreateRootStore(dataPath, app.config.AppConfig.ArchiveMode)
+	app.resetTrunks()
+
+	ctx := app.GetRunTxContext()
+	defer ctx.Close(false)
+	if blk := ctx.GetCurrBlockBasicInfo(); blk == nil || blk.Number != int64(r.snapshot.Height) {
+		return fmt.Errorf("cannot find block %d in snapshot", r.snapshot.Height)
+	}
+	if rootHash := app.root.GetRootHash(); !bytes.Equal(rootHash, r.meta.AppHash) {
+		return fmt.Errorf("app hash mismatch: %X != %X", rootHash, r.meta.AppHash)
+	}
+	// the system contracts and the watcher were initialized with the empty states before restoring
+	app.registerSystemContracts(ctx)
+	app.addRestoredBlockToHistory(ctx.GetCurrBlockBasicInfo())
+	app.loadCurrBlock(ctx)
+	stakingInfo := app.loadStakingStates(ctx)
+	app.watcher.Stop()
+	app.epochList, app.ccEpochList = nil, nil
+	// the watcher catches up in the background, an ABCI call must not block on mainnet; the epochs it
+	// collects are consumed by the later blocks
+	app.startWatcher(ctx, stakingInfo, true)
+	return nil
+}
+
+// addRestoredBlockToHistory adds the restored block into the empty historyStore, such that the latest
+// height and the parent hash of the next block are known. Its TXs are added again by next block's refresh,
+// while the history before it is not available on this node.
+func (app *App) addRestoredBlockToHistory(blk *types.Block) {
+	blkInfo, err := blk.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	app.historyStore.AddBlock(&modbtypes.Block{
+		Height:    blk.Number,
+		BlockHash: blk.Hash,
+		BlockInfo: blkInfo,
+	}, -1, nil)
+	app.logger.Info("No history before the restored block", "height", blk.Number)
+}
+
+func hashOfChunkHashes(hashes [][]byte) []byte {
+	h := sha256.New()
+	for _, hash := range hashes {
+		h.Write(hash)
+	}
+	return h.Sum(nil)
+}
+
+// isSnapshotFile returns whether a file or directory of moeingads must be included in snapshots
+func isSnapshotFile(name string) bool {
+	return strings.HasPrefix(name, "entries.") || strings.HasPrefix(name, "twigmt.")
+}
+
+// stageSnapshotFiles hard-links the datatree files into stagingDir and dumps the rocksdb there.
+// The datatree files are append-only and are truncated to the sizes recorded in rocksdb when
+// moeingads is reopened, so the tails appended after this function returns do not matter.
+func stageSnapshotFiles(dataPath, stagingDir string) error {
+	_ = os.RemoveAll(stagingDir)
+	err := filepath.Walk(dataPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dataPath, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return os.MkdirAll(stagingDir, 0700)
+		}
+		if !isSnapshotFile(strings.Split(rel, string(filepath.Separator))[0]) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		target := filepath.Join(stagingDir, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		if os.Link(path, target) == nil {
+			return nil
+		}
+		return copyFile(path, target)
+	})
+	if err != nil {
+		return err
+	}
+	return dumpRocksDB(filepath.Join(dataPath, "rocksdb.db"), filepath.Join(stagingDir, snapshotRocksDBDump))
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// dumpRocksDB opens the rocksdb used by moeingads in read-only mode and dumps all its KV pairs
+// into outFile, each of which is: keyLen(4 bytes) + key + valueLen(4 bytes) + value
+func dumpRocksDB(dbPath, outFile string) error {
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	db, err := gorocksdb.OpenDbForReadOnly(opts, dbPath, false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	f, err := os.OpenFile(outFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	ro := gorocksdb.NewDefaultReadOptions()
+	ro.SetFillCache(false)
+	defer ro.Destroy()
+	iter := db.NewIterator(ro)
+	defer iter.Close()
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		k, v := iter.Key(), iter.Value()
+		err = writeBytesWithLen(w, k.Data())
+		if err == nil {
+			err = writeBytesWithLen(w, v.Data())
+		}
+		k.Free()
+		v.Free()
+		if err != nil {
+			return err
+		}
+	}
+	if err = iter.Err(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// loadRocksDB creates a rocksdb for moeingads in dataPath and fills it with the dumped KV pairs
+func loadRocksDB(r io.Reader, size int64, dataPath string) error {
+	db, err := indextree.NewRocksDB("rocksdb", dataPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	lr := &io.LimitedReader{R: r, N: size}
+	batch := db.NewBatch()
+	for count := 1; lr.N > 0; count++ {
+		k, err := readBytesWithLen(lr)
+		if err != nil {
+			return err
+		}
+		v, err := readBytesWithLen(lr)
+		if err != nil {
+			return err
+		}
+		batch.Set(k, v)
+		if count%10000 == 0 {
+			batch.WriteSync()
+			batch.Close()
+			batch = db.NewBatch()
+		}
+	}
+	batch.WriteSync()
+	batch.Close()
+	return nil
+}
+
+func writeBytesWithLen(w io.Writer, bz []byte) error {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(bz)))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(bz)
+	return err
+}
+
+// readBytesWithLen reads a length-prefixed byte slice, whose length must not exceed the remained bytes of lr,
+// such that a forged length can not make it allocate a huge buffer
+func readBytesWithLen(lr *io.LimitedReader) ([]byte, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(lr, buf[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(buf[:])
+	if int64(length) > lr.N {
+		return nil, errInvalidSnapshotRec
+	}
+	bz := make([]byte, length)
+	_, err := io.ReadFull(lr, bz)
+	return bz, err
+}
+
+// writeSnapshotStream serializes all the files under dir into w, in lexical order
+func writeSnapshotStream(w io.Writer, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		var head [8]byte
+		binary.BigEndian.PutUint16(head[:2], uint16(len(name)))
+		if _, err = w.Write(head[:2]); err != nil {
+			return err
+		}
+		if _, err = w.Write([]byte(filepath.ToSlash(name))); err != nil {
+			return err
+		}
+		binary.BigEndian.PutUint64(head[:], uint64(info.Size()))
+		if _, err = w.Write(head[:]); err != nil {
+			return err
+		}
+		// the file may grow after Stat, but we only take info.Size() bytes of it
+		_, err = io.CopyN(w, f, info.Size())
+		return err
+	})
+}
+
+// readSnapshotStream deserializes the files from r into dir, and loads the rocksdb dump
+func readSnapshotStream(r io.Reader, dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	var head [8]byte
+	for {
+		_, err := io.ReadFull(r, head[:2])
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		nameBz := make([]byte, binary.BigEndian.Uint16(head[:2]))
+		if _, err = io.ReadFull(r, nameBz); err != nil {
+			return err
+		}
+		if _, err = io.ReadFull(r, head[:]); err != nil {
+			return err
+		}
+		name := filepath.FromSlash(string(nameBz))
+		size := int64(binary.BigEndian.Uint64(head[:]))
+		if name == snapshotRocksDBDump {
+			if err = loadRocksDB(r, size, dir); err != nil {
+				return err
+			}
+			continue
+		}
+		if filepath.IsAbs(name) || !isSnapshotFile(name) || strings.Contains(name, "..") {
+			return errInvalidSnapshotRec
+		}
+		if err = writeFileFromStream(r, size, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+}
+
+func writeFileFromStream(r io.Reader, size int64, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.CopyN(f, r, size); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// chunkWriter cuts the written stream into chunk files of snapshotChunkSize and records their hashes
+type chunkWriter struct {
+	dir    string
+	buf    []byte
+	hashes [][]byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		free := snapshotChunkSize - len(w.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		w.buf = append(w.buf, p[:free]...)
+		p = p[free:]
+		if len(w.buf) == snapshotChunkSize {
+			if err := w.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (w *chunkWriter) Close() error {
+	if len(w.buf) == 0 && len(w.hashes) != 0 {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *chunkWriter) flush() error {
+	hash := sha256.Sum256(w.buf)
+	err := os.WriteFile(filepath.Join(w.dir, strconv.Itoa(len(w.hashes))), w.buf, 0600)
+	if err != nil {
+		return err
+	}
+	w.hashes = append(w.hashes, hash[:])
+	w.buf = w.buf[:0]
+	return nil
+}
@@ -0,0 +1,218 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking"
+)
+
+func TestSnapshotStream(t *testing.T) {
+	srcDir, dstDir, chunkDir := "./testSnapshotSrc", "./testSnapshotDst", "./testSnapshotChunks"
+	defer func() {
+		_ = os.RemoveAll(srcDir)
+		_ = os.RemoveAll(dstDir)
+		_ = os.RemoveAll(chunkDir)
+	}()
+	files := map[string][]byte{
+		"entries.0/0-4096": make([]byte, snapshotChunkSize+100),
+		"entries.1/0-4096": make([]byte, 300),
+		"twigmt.0/0-4096":  make([]byte, 200),
+	}
+	for name, content := range files {
+		rand.Read(content)
+		require.NoError(t, writeFileFromStream(bytes.NewReader(content), int64(len(content)), filepath.Join(srcDir, name)))
+	}
+
+	require.NoError(t, os.MkdirAll(chunkDir, 0700))
+	w := &chunkWriter{dir: chunkDir}
+	require.NoError(t, writeSnapshotStream(w, srcDir))
+	require.NoError(t, w.Close())
+	require.Equal(t, 2, len(w.hashes))
+	chunk, err := os.ReadFile(filepath.Join(chunkDir, "1"))
+	require.NoError(t, err)
+	hash := sha256.Sum256(chunk)
+	require.Equal(t, hash[:], w.hashes[1])
+
+	f0, err := os.Open(filepath.Join(chunkDir, "0"))
+	require.NoError(t, err)
+	defer f0.Close()
+	f1, err := os.Open(filepath.Join(chunkDir, "1"))
+	require.NoError(t, err)
+	defer f1.Close()
+	require.NoError(t, readSnapshotStream(io.MultiReader(f0, f1), dstDir))
+	for name, content := range files {
+		bz, err := os.ReadFile(filepath.Join(dstDir, name))
+		require.NoError(t, err)
+		require.Equal(t, content, bz)
+	}
+}
+
+func TestReadBytesWithLen(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeBytesWithLen(&buf, []byte("key")))
+	bz, err := readBytesWithLen(&io.LimitedReader{R: bytes.NewReader(buf.Bytes()), N: int64(buf.Len())})
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), bz)
+
+	// a length beyond the dumped bytes is rejected before allocating
+	forged := []byte{0xff, 0xff, 0xff, 0xff, 0x01}
+	_, err = readBytesWithLen(&io.LimitedReader{R: bytes.NewReader(forged), N: int64(len(forged))})
+	require.Equal(t, errInvalidSnapshotRec, err)
+}
+
+func TestOfferAndApplySnapshot(t *testing.T) {
+	_app := NewApp(p, uint256.NewInt(1), 0, 0, log.NewNopLogger(), true)
+	defer removeTestDB(_app)
+	_app.config.AppConfig.SnapshotDir = "./testSnapshots"
+	defer os.RemoveAll(_app.config.AppConfig.SnapshotDir)
+
+	chunk := []byte{1, 2, 3}
+	chunkHash := sha256.Sum256(chunk)
+	meta := snapshotMetadata{AppHash: []byte{0x12}, ChunkHashes: [][]byte{chunkHash[:], chunkHash[:]}}
+	metaBz, _ := json.Marshal(meta)
+	snapshot := &abcitypes.Snapshot{
+		Height:   100,
+		Format:   snapshotFormat,
+		Chunks:   2,
+		Hash:     hashOfChunkHashes(meta.ChunkHashes),
+		Metadata: metaBz,
+	}
+
+	res := _app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: &abcitypes.Snapshot{Format: 2}})
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_REJECT_FORMAT, res.Result)
+	res = _app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: []byte{0x13}})
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_REJECT, res.Result)
+	res = _app.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: []byte{0x12}})
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_ACCEPT, res.Result)
+
+	applyRes := _app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 0, Chunk: []byte{1}, Sender: "bad"})
+	require.Equal(t, abcitypes.ResponseApplySnapshotChunk_RETRY, applyRes.Result)
+	require.Equal(t, []uint32{0}, applyRes.RefetchChunks)
+	require.Equal(t, []string{"bad"}, applyRes.RejectSenders)
+	applyRes = _app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 0, Chunk: chunk})
+	require.Equal(t, abcitypes.ResponseApplySnapshotChunk_ACCEPT, applyRes.Result)
+	applyRes = _app.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: 2, Chunk: chunk})
+	require.Equal(t, abcitypes.ResponseApplySnapshotChunk_ABORT, applyRes.Result)
+}
+
+func TestListAndLoadSnapshots(t *testing.T) {
+	_app := NewApp(p, uint256.NewInt(1), 0, 0, log.NewNopLogger(), true)
+	defer removeTestDB(_app)
+	_app.config.AppConfig.SnapshotDir = "./testSnapshots"
+	defer os.RemoveAll(_app.config.AppConfig.SnapshotDir)
+
+	for _, height := range []int64{10, 20, 30} {
+		tmpDir := _app.snapshotDir(height) + ".staging"
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "entries.0"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "entries.0", "0-4096"), []byte{byte(height)}, 0600))
+		require.NoError(t, _app.packSnapshot(height, []byte{0x12}, tmpDir))
+		_ = os.RemoveAll(tmpDir)
+	}
+	_app.config.AppConfig.SnapshotKeepRecent = 2
+	_app.pruneSnapshots()
+
+	snapshots := _app.ListSnapshots(abcitypes.RequestListSnapshots{}).Snapshots
+	require.Equal(t, 2, len(snapshots))
+	require.Equal(t, uint64(20), snapshots[0].Height)
+	require.Equal(t, uint64(30), snapshots[1].Height)
+	require.Equal(t, uint32(1), snapshots[1].Chunks)
+
+	res := _app.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{Height: 30, Format: snapshotFormat, Chunk: 0})
+	hash := sha256.Sum256(res.Chunk)
+	require.Equal(t, hashOfChunkHashes([][]byte{hash[:]}), snapshots[1].Hash)
+	res = _app.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{Height: 10, Format: snapshotFormat, Chunk: 0})
+	require.Nil(t, res.Chunk)
+}
+
+func TestTakeAndRestoreSnapshot(t *testing.T) {
+	newConfig := func(suffix string) *param.ChainConfig {
+		config := param.DefaultConfig()
+		config.AppConfig.AppDataPath = "./testAppDb" + suffix
+		config.AppConfig.ModbDataPath = "./testDb" + suffix
+		config.AppConfig.LedgerDataPath = "./testLedgerDb" + suffix
+		config.AppConfig.SnapshotDir = "./testSnapshots" + suffix
+		config.AppConfig.SnapshotInterval = 2
+		return config
+	}
+	srcConfig, dstConfig := newConfig("Src"), newConfig("Dst")
+	defer func() {
+		for _, config := range []*param.ChainConfig{srcConfig, dstConfig} {
+			_ = os.RemoveAll(config.AppConfig.AppDataPath)
+			_ = os.RemoveAll(config.AppConfig.ModbDataPath)
+			_ = os.RemoveAll(config.AppConfig.LedgerDataPath)
+			_ = os.RemoveAll(config.AppConfig.SnapshotDir)
+		}
+	}()
+
+	valPubKey := ed25519.GenPrivKey().PubKey()
+	val := &Validator{VotingPower: 1, Introduction: "val0"}
+	copy(val.Address[:], valPubKey.Address().Bytes())
+	copy(val.Pubkey[:], valPubKey.Bytes())
+	copy(val.StakedCoins[:], staking.MinimumStakingAmount.Bytes())
+	appStateBytes, _ := json.Marshal(GenesisData{Validators: []*Validator{val}})
+
+	srcApp := NewApp(srcConfig, uint256.NewInt(1), 0, 0, log.NewNopLogger(), true)
+	defer srcApp.Stop()
+	srcApp.InitChain(abcitypes.RequestInitChain{AppStateBytes: appStateBytes})
+	appHashes := make(map[int64][]byte)
+	startTime := time.Now()
+	for height := int64(1); height <= 3; height++ {
+		srcApp.BeginBlock(abcitypes.RequestBeginBlock{Header: tmproto.Header{
+			Height:          height,
+			Time:            startTime.Add(time.Duration(height) * time.Second),
+			ProposerAddress: valPubKey.Address(),
+		}})
+		srcApp.EndBlock(abcitypes.RequestEndBlock{Height: height})
+		appHashes[height] = srcApp.Commit().Data
+	}
+	require.Eventually(t, func() bool {
+		return len(srcApp.ListSnapshots(abcitypes.RequestListSnapshots{}).Snapshots) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+	snapshot := srcApp.ListSnapshots(abcitypes.RequestListSnapshots{}).Snapshots[0]
+	require.Equal(t, uint64(2), snapshot.Height)
+
+	dstApp := NewApp(dstConfig, uint256.NewInt(1), 0, 0, log.NewNopLogger(), true)
+	defer dstApp.Stop()
+	oldWatcher := dstApp.watcher
+	res := dstApp.OfferSnapshot(abcitypes.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHashes[2]})
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_ACCEPT, res.Result)
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk := srcApp.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{
+			Height: snapshot.Height, Format: snapshot.Format, Chunk: i}).Chunk
+		applyRes := dstApp.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: i, Chunk: chunk})
+		require.Equal(t, abcitypes.ResponseApplySnapshotChunk_ACCEPT, applyRes.Result)
+	}
+	dstApp.WaitLock()
+	require.Equal(t, int64(2), dstApp.currHeight)
+	require.Equal(t, appHashes[2], dstApp.root.GetRootHash())
+	require.Equal(t, int64(2), dstApp.historyStore.GetLatestHeight())
+	require.NotEqual(t, oldWatcher, dstApp.watcher)
+	info := dstApp.Info(abcitypes.RequestInfo{})
+	require.Equal(t, int64(2), info.LastBlockHeight)
+	require.Equal(t, appHashes[2], info.LastBlockAppHash)
+
+	// the restored node reaches the same app hash as the source node
+	dstApp.BeginBlock(abcitypes.RequestBeginBlock{Header: tmproto.Header{
+		Height:          3,
+		Time:            startTime.Add(3 * time.Second),
+		ProposerAddress: valPubKey.Address(),
+	}})
+	dstApp.EndBlock(abcitypes.RequestEndBlock{Height: 3})
+	require.Equal(t, appHashes[3], dstApp.Commit().Data)
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	modbtypes "github.com/smartbch/moeingdb/types"
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+// The engine-side staking events (Slash, SwitchEpoch, DeliverReward, Jail and Unbond) are emitted in the
+// Commit of a block, but they belong to no TX. Since StakingLogsForkHeight, they are saved in world state
+// in the Commit, keyed by the block's height, such that a restarted node does not lose them. When the block
+// is flushed into moeingdb in next block's refresh, they are appended after its msgp-encoded block info,
+// which is ignored by the readers only caring about moeingevm's Block. These logs are block-level: their
+// TxHash is zero and their Index counts from zero in the block.
+//
+// Each entry starts with the height of the previous block carrying staking logs, so the logs can be queried
+// by walking the entries backward from the latest one, without scanning all the blocks. An entry is removed
+// from world state when the next entry is flushed, by then the moeingdb has its copy.
+
+// the storage sequence of the staking logs, which are keyed by the 8-byte big-endian height of their blocks
+const stakingLogsSequence uint64 = math.MaxUint64 - 6 /*uint64(-7)*/
+
+// the key of the height of the latest block carrying staking logs
+const lastStakingLogsHeightKey = "last"
+
+var errInvalidStakingLogs = errors.New("invalid staking logs")
+
+func stakingLogsKey(height int64) string {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(height))
+	return string(buf[:])
+}
+
+func loadLastStakingLogsHeight(ctx *types.Context) int64 {
+	bz := ctx.GetStorageAt(stakingLogsSequence, lastStakingLogsHeightKey)
+	if len(bz) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// saveStakingLogs saves the staking events emitted in current block's Commit into world state
+func (app *App) saveStakingLogs(ctx *types.Context, evmLogs []types.EvmLog) {
+	if len(evmLogs) == 0 || app.currHeight < param.StakingLogsForkHeight {
+		return
+	}
+	entry := make([]byte, 8, 256)
+	binary.BigEndian.PutUint64(entry, uint64(loadLastStakingLogsHeight(ctx)))
+	for i, l := range evmLogs {
+		log := types.Log{
+			Address:     l.Address,
+			Topics:      make([][32]byte, len(l.Topics)),
+			Data:        l.Data,
+			BlockNumber: uint64(app.block.Number),
+			BlockHash:   app.block.Hash,
+			Index:       uint(i),
+		}
+		for j, topic := range l.Topics {
+			log.Topics[j] = topic
+		}
+		var err error
+		entry, err = log.MarshalMsg(entry)
+		if err != nil {
+			panic(err)
+		}
+	}
+	ctx.SetStorageAt(stakingLogsSequence, stakingLogsKey(app.block.Number), entry)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(app.block.Number))
+	ctx.SetStorageAt(stakingLogsSequence, lastStakingLogsHeightKey, buf[:])
+}
+
+// takeStakingLogs returns the staking logs of the block to be flushed into moeingdb, and removes the logs
+// of the previous block carrying staking logs from world state, which have been flushed.
+func takeStakingLogs(ctx *types.Context, height int64) []byte {
+	entry := ctx.GetStorageAt(stakingLogsSequence, stakingLogsKey(height))
+	if len(entry) < 8 {
+		return nil
+	}
+	if prevHeight := int64(binary.BigEndian.Uint64(entry[:8])); prevHeight != 0 {
+		ctx.DeleteStorageAt(stakingLogsSequence, stakingLogsKey(prevHeight))
+	}
+	return entry
+}
+
+func decodeStakingLogs(entry []byte) (prevHeight int64, logs []types.Log, err error) {
+	if len(entry) < 8 {
+		return 0, nil, errInvalidStakingLogs
+	}
+	prevHeight = int64(binary.BigEndian.Uint64(entry[:8]))
+	for bz := entry[8:]; len(bz) != 0; {
+		var log types.Log
+		if bz, err = log.UnmarshalMsg(bz); err != nil {
+			return 0, nil, err
+		}
+		logs = append(logs, log)
+	}
+	return
+}
+
+// stakingLogsOfFlushedBlock returns the staking logs appended after the block info in moeingdb
+func stakingLogsOfFlushedBlock(blkInfo []byte) ([]byte, error) {
+	var blk types.Block
+	return blk.UnmarshalMsg(blkInfo)
+}
+
+// GetStakingLogs returns the engine-side staking events of the blocks in [startHeight, endHeight) which have
+// been flushed into moeingdb, in ascending order. The walk stops at the blocks pruned from moeingdb.
+func (app *App) GetStakingLogs(startHeight, endHeight uint32) ([]types.Log, error) {
+	ctx := app.GetRpcContext()
+	defer ctx.Close(false)
+	latestHeight := app.historyStore.GetLatestHeight()
+	var logs []types.Log
+	for height := loadLastStakingLogsHeight(ctx); height != 0 && height >= int64(startHeight); {
+		entry := ctx.GetStorageAt(stakingLogsSequence, stakingLogsKey(height))
+		if len(entry) == 0 {
+			blkInfo := app.historyStore.GetBlockByHeight(height)
+			if len(blkInfo) == 0 {
+				break
+			}
+			var err error
+			if entry, err = stakingLogsOfFlushedBlock(blkInfo); err != nil {
+				return nil, err
+			}
+		}
+		prevHeight, blkLogs, err := decodeStakingLogs(entry)
+		if err != nil {
+			return nil, err
+		}
+		if height <= latestHeight && height < int64(endHeight) {
+			logs = append(blkLogs, logs...)
+		}
+		height = prevHeight
+	}
+	return logs, nil
+}
+
+// stakingGethLogs converts the staking logs appended after the block info for the subscribers of new logs
+func stakingGethLogs(mdbBlock *modbtypes.Block) []*gethtypes.Log {
+	entry, err := stakingLogsOfFlushedBlock(mdbBlock.BlockInfo)
+	if err != nil || len(entry) == 0 {
+		return nil
+	}
+	_, logs, err := decodeStakingLogs(entry)
+	if err != nil {
+		return nil
+	}
+	return types.ToGethLogs(logs)
+}
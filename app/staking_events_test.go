@@ -0,0 +1,86 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/smartbch/smartbch/internal/testutils"
+	"github.com/smartbch/smartbch/staking"
+)
+
+// slashInBlock makes block h, in whose Commit the validator is slashed for double signing
+func slashInBlock(_app *testutils.TestApp, h int64) {
+	_app.BeginBlock(abci.RequestBeginBlock{
+		Hash: testutils.UintToBytes32(uint64(h)),
+		Header: tmproto.Header{
+			Height:          h,
+			Time:            _app.StartTime.Add(testutils.BlockInterval * time.Duration(h)),
+			ProposerAddress: _app.TestPubkey.Address(),
+		},
+		ByzantineValidators: []abci.Evidence{{
+			Type:      abci.EvidenceType_DUPLICATE_VOTE,
+			Validator: abci.Validator{Address: _app.TestPubkey.Address()},
+		}},
+	})
+	_app.EndBlock(abci.RequestEndBlock{Height: h})
+	_app.StateRoot = _app.Commit().Data
+	_app.WaitLock()
+}
+
+func TestStakingLogs(t *testing.T) {
+	key, _ := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+
+	h := _app.BlockNum() + 1
+	slashInBlock(_app, h)
+	// block h is not flushed into moeingdb yet
+	logs, err := _app.GetStakingLogs(0, uint32(h+1))
+	require.NoError(t, err)
+	require.Len(t, logs, 0)
+
+	// the logs are kept in world state across restarts
+	_app.Stop()
+	reloaded := _app.ReloadApp()
+	reloaded.StartTime, reloaded.TestPubkey = _app.StartTime, _app.TestPubkey
+	_app = reloaded
+	defer _app.Destroy()
+
+	_app.WaitNextBlock(h)
+	logs, err = _app.GetStakingLogs(0, uint32(h+1))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, staking.StakingContractAddress, gethcmn.Address(logs[0].Address))
+	require.Equal(t, staking.HashOfEventSlash, gethcmn.Hash(logs[0].Topics[0]))
+	require.Equal(t, uint64(h), logs[0].BlockNumber)
+	logs, err = _app.GetStakingLogs(uint32(h+1), uint32(h+2))
+	require.NoError(t, err)
+	require.Len(t, logs, 0)
+
+	// no pseudo TX carries the logs
+	require.Len(t, _app.GetBlock(h).Transactions, 0)
+	ctx := _app.GetHistoryOnlyContext()
+	txs, _, err := ctx.GetTxListByHeight(uint32(h))
+	ctx.Close(false)
+	require.NoError(t, err)
+	require.Len(t, txs, 0)
+
+	// flushing the logs of block h2 removes the logs of block h from world state, which are then
+	// read from moeingdb
+	h2 := h + 2
+	slashInBlock(_app, h2)
+	_app.WaitNextBlock(h2)
+	logs, err = _app.GetStakingLogs(0, uint32(h2+1))
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, uint64(h), logs[0].BlockNumber)
+	require.Equal(t, uint64(h2), logs[1].BlockNumber)
+	logs, err = _app.GetStakingLogs(uint32(h+1), uint32(h2+1))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, uint64(h2), logs[0].BlockNumber)
+}
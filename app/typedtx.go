@@ -0,0 +1,71 @@
+package app
+
+import (
+	"bytes"
+	"math"
+	"sort"
+
+	modbtypes "github.com/smartbch/moeingdb/types"
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+// The engine knows nothing about the fields of typed TXs, such as their access lists. These fields are kept
+// in world state from the block delivering a typed TX to the block committing it, which may be several blocks
+// later, such that all the nodes, including the restarted ones, record the same fields in moeingdb.
+// The gas limit of a typed TX must cover the EIP-2930 intrinsic gas of its access list, which is checked in
+// both CheckTx and DeliverTx. The access list is not charged on top of the gas used by the engine, since the
+// engine does not warm the listed addresses and slots: their accesses are charged at cold prices instead.
+
+// the storage sequence of the typed TXs' fields, which are keyed by txid
+const typedTxSequence uint64 = math.MaxUint64 - 5 /*uint64(-6)*/
+
+// saveTypedTxFields moves the fields of the typed TXs delivered in current block into world state
+func (app *App) saveTypedTxFields() {
+	if len(app.txid2typedTxMap) == 0 {
+		return
+	}
+	txids := make([][32]byte, 0, len(app.txid2typedTxMap))
+	for txid := range app.txid2typedTxMap {
+		txids = append(txids, txid)
+	}
+	sort.Slice(txids, func(i, j int) bool {
+		return bytes.Compare(txids[i][:], txids[j][:]) < 0
+	})
+	ctx := app.GetRunTxContext()
+	for _, txid := range txids {
+		ctx.SetStorageAt(typedTxSequence, string(txid[:]), app.txid2typedTxMap[txid])
+	}
+	ctx.Close(true)
+	app.txid2typedTxMap = make(map[[32]byte][]byte)
+}
+
+// takeTypedTxFields removes the fields of the committed typed TXs from world state, and returns them
+func (app *App) takeTypedTxFields(ctx *types.Context) map[[32]byte][]byte {
+	if app.currHeight < param.EIP1559ForkHeight {
+		return nil
+	}
+	txid2fields := make(map[[32]byte][]byte)
+	for _, tx := range app.txEngine.CommittedTxs() {
+		key := string(tx.Hash[:])
+		if bz := ctx.GetStorageAt(typedTxSequence, key); len(bz) != 0 {
+			txid2fields[tx.Hash] = bz
+			ctx.DeleteStorageAt(typedTxSequence, key)
+		}
+	}
+	return txid2fields
+}
+
+// appendTypedTxFields appends the fields of typed TXs after their msgp-encoded contents, which are
+// ignored by the readers only caring about moeingevm's Transaction.
+func appendTypedTxFields(txList []modbtypes.Tx, txid2fields map[[32]byte][]byte) {
+	if len(txid2fields) == 0 {
+		return
+	}
+	for i := range txList {
+		if fields, ok := txid2fields[txList[i].HashId]; ok {
+			txList[i].Content = append(txList[i].Content, fields...)
+		}
+	}
+}
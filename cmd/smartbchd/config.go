@@ -70,7 +70,8 @@ func runConfigCmd(cmd *cobra.Command, args []string) error {
 		}
 	} else /*update app.toml*/ {
 		switch key {
-		case "mainnet-rpc-url", "mainnet-rpc-username", "mainnet-rpc-password", "smartbch-rpc-url":
+		case "mainnet-data-source", "mainnet-rpc-url", "mainnet-rpc-username", "mainnet-rpc-password",
+			"mainnet-electrum-url", "mainnet-blocks-dir", "smartbch-rpc-url", "prometheus-listen-addr":
 			tree.Set(key, value)
 
 		case "watcher-speedup", "use_litedb", "log-validators":
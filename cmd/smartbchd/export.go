@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/smartbch/smartbch/app"
+	"github.com/smartbch/smartbch/stateexport"
+)
+
+const (
+	flagHeight       = "height"
+	flagExportFormat = "format"
+)
+
+func ExportCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [output-file]",
+		Short: "Export the world state into a file",
+		Long: `Export the accounts, bytecodes, storage slots, staking info and cc info at some height into a file.
+It must run when smartbchd is stopped. Only the nodes in archive mode can export the states before the latest height.
+The exported file can be used by 'smartbchd init --from-export' to fork the state into a new chain.`,
+		Args: cobra.ExactArgs(1),
+		Example: `
+smartbchd export state.json --height=100000
+smartbchd export state.msgp --format=msgpack
+`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			f, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			bufW := bufio.NewWriter(f)
+			w, err := stateexport.NewWriter(bufW, viper.GetString(flagExportFormat))
+			if err != nil {
+				return err
+			}
+			appCfg := ctx.Config.AppConfig
+			err = app.ExportState(appCfg.AppDataPath, appCfg.ArchiveMode, viper.GetInt64(flagHeight), w)
+			if err == nil {
+				err = bufW.Flush()
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Println("exported to", args[0])
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "height of the state to export, 0 means the latest height")
+	cmd.Flags().String(flagExportFormat, stateexport.FormatJSON, "format of the exported file: json or msgpack")
+	cmd.Flags().Bool(flagArchiveMode, false, "the node is running in archive-mode")
+	return cmd
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	gethcore "github.com/ethereum/go-ethereum/core"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/smartbch/smartbch/internal/bigutils"
 	"github.com/smartbch/smartbch/internal/testutils"
 	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/stateexport"
 )
 
 const (
@@ -31,6 +34,8 @@ const (
 	flagTestKeysFile = "test-keys-file"
 	flagInitBal      = "init-balance"
 	flagMainnet      = "mainnet"
+	flagFromExport   = "from-export"
+	flagImportFormat = "export-format"
 )
 
 const (
@@ -196,6 +201,8 @@ func InitCmd(ctx *Context, defaultNodeHome string) *cobra.Command { // nolint: g
 	cmd.Flags().String(flagTestKeysFile, "", "file contains hex private keys, one key per line")
 	cmd.Flags().String(flagInitBal, "1000000000000000000", "initial balance for test accounts")
 	cmd.Flags().Bool(flagMainnet, false, "init for mainent node")
+	cmd.Flags().String(flagFromExport, "", "file exported by 'smartbchd export', whose accounts are added into genesis alloc")
+	cmd.Flags().String(flagImportFormat, stateexport.FormatJSON, "format of the exported file: json or msgpack")
 	return cmd
 }
 
@@ -210,6 +217,11 @@ func getAppState() ([]byte, error) {
 
 	fmt.Println("preparing genesis file ...")
 	alloc := testutils.KeysToGenesisAlloc(initBal, testKeys)
+	if exportFile := viper.GetString(flagFromExport); exportFile != "" {
+		if err := loadAllocFromExport(alloc, exportFile); err != nil {
+			return nil, err
+		}
+	}
 	genData := app.GenesisData{Alloc: alloc}
 	appState, err := json.Marshal(genData)
 	if err != nil {
@@ -218,6 +230,30 @@ func getAppState() ([]byte, error) {
 	return appState, nil
 }
 
+// loadAllocFromExport adds the accounts in an exported state into alloc, the test accounts are overwritten
+// if they also exist in the exported state
+func loadAllocFromExport(alloc gethcore.GenesisAlloc, exportFile string) error {
+	f, err := os.Open(exportFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r, err := stateexport.NewReader(bufio.NewReader(f), viper.GetString(flagImportFormat))
+	if err != nil {
+		return err
+	}
+	exported, stats, err := stateexport.ToGenesisAlloc(r)
+	if err != nil {
+		return fmt.Errorf("failed to load exported state: %w", err)
+	}
+	for addr, acc := range exported {
+		alloc[addr] = acc
+	}
+	fmt.Printf("loaded state at height %d: %d accounts, %d contracts, %d storage slots, %d accounts and %d slots skipped\n",
+		stats.Height, stats.Accounts, stats.Contracts, stats.Slots, stats.SkippedAccounts, stats.SkippedSlots)
+	return nil
+}
+
 func getTestKeys() []string {
 	testKeysCSV := viper.GetString(flagTestKeys)
 	if testKeysCSV != "" {
@@ -39,6 +39,7 @@ func createSmartbchdCmd() *cobra.Command {
 	rootCmd.AddCommand(GenerateGenesisValidatorCmd(ctx))
 	rootCmd.AddCommand(AddGenesisValidatorCmd(ctx))
 	rootCmd.AddCommand(StakingCmd(ctx))
+	rootCmd.AddCommand(ExportCmd(ctx))
 	rootCmd.AddCommand(VersionCmd())
 	return rootCmd
 }
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/smartbch/app"
+	"github.com/smartbch/smartbch/internal/bigutils"
+	"github.com/smartbch/smartbch/staking"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+const (
+	flagFromEpoch           = "from-epoch"
+	flagToEpoch             = "to-epoch"
+	flagEpochsFile          = "epochs-file"
+	flagMaxActiveValidators = "max-active-validators"
+	flagMinStakingAmount    = "min-staking-amount"
+	flagVotingPowerPerBCH   = "voting-power-per-bch"
+)
+
+func StakingSimulateCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Replay the epoch switches under alternative params",
+		Long: `Replay the election of the epochs with the staking code of this binary over the world state at some height,
+once with the params in use and once with the alternative params, and print the resulting validator sets, voting
+powers and fee rewards side by side. It must run when smartbchd is stopped, and nothing is written back.
+
+The epochs are loaded from the state, or from a file with the result of sbch_getEpochs. To replay past epochs,
+the node must be in archive mode and --height must be a height before the first replayed epoch switch. The
+changes made by transactions between the switches are not replayed, nor are the slashes.
+
+The booked rewards are the fee rewards in the epoch's reward ledger, if it is found in the node's side index.
+The simulated rewards share the same total by voting power, assuming all the active validators vote for every
+block.`,
+		Example: `
+smartbchd staking simulate --from-epoch=10 --to-epoch=20 --max-active-validators=30
+smartbchd staking simulate --epochs-file=epochs.json --height=11000000 --min-staking-amount=10000000000000000000
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			simParams := staking.SimParams{
+				MaxActiveValidatorCount: viper.GetUint64(flagMaxActiveValidators),
+				VotingPowerPerBCH:       viper.GetInt64(flagVotingPowerPerBCH),
+			}
+			if s := viper.GetString(flagMinStakingAmount); s != "" {
+				amount, ok := bigutils.ParseU256(s)
+				if !ok {
+					return fmt.Errorf("min staking amount parse failed")
+				}
+				simParams.MinStakingAmount = amount
+			}
+			var epochs []*stakingtypes.Epoch
+			if file := viper.GetString(flagEpochsFile); file != "" {
+				var err error
+				if epochs, err = readEpochsFile(file); err != nil {
+					return err
+				}
+			}
+
+			appCfg := ctx.Config.AppConfig
+			sims, err := app.SimulateEpochs(appCfg.AppDataPath, appCfg.LedgerDataPath, appCfg.ArchiveMode, viper.GetInt64(flagHeight), epochs,
+				viper.GetInt64(flagFromEpoch), viper.GetInt64(flagToEpoch), simParams, log.NewNopLogger())
+			if err != nil {
+				return err
+			}
+			for _, sim := range sims {
+				printEpochSimulation(sim)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "height of the state to start from, 0 means the latest height")
+	cmd.Flags().Int64(flagFromEpoch, 0, "the first epoch loaded from the state")
+	cmd.Flags().Int64(flagToEpoch, 0, "the epoch after the last one loaded from the state")
+	cmd.Flags().String(flagEpochsFile, "", "the file with the epochs returned by sbch_getEpochs, instead of the state")
+	cmd.Flags().Uint64(flagMaxActiveValidators, 0, "alternative max count of active validators")
+	cmd.Flags().String(flagMinStakingAmount, "", "alternative minimum staked coins of an active validator, in wei")
+	cmd.Flags().Int64(flagVotingPowerPerBCH, 0, "alternative voting power per staked BCH after the delegation fork")
+	cmd.Flags().Bool(flagArchiveMode, false, "the node is running in archive-mode")
+	return cmd
+}
+
+// the file contains a JSON array of epochs, or a JSON-RPC response whose result is such an array
+func readEpochsFile(file string) ([]*stakingtypes.Epoch, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Result []*stakingtypes.Epoch `json:"result"`
+	}
+	if err = json.Unmarshal(bz, &resp); err == nil && resp.Result != nil {
+		return resp.Result, nil
+	}
+	var epochs []*stakingtypes.Epoch
+	if err = json.Unmarshal(bz, &epochs); err != nil {
+		return nil, fmt.Errorf("parse epochs file error: %s", err.Error())
+	}
+	return epochs, nil
+}
+
+func printEpochSimulation(sim *app.EpochSimulation) {
+	fmt.Printf("epoch %d: valid %v / %v\n", sim.Baseline.EpochNum, sim.Baseline.Valid, sim.Simulated.Valid)
+	fmt.Printf("  %-42s %12s %12s %24s %24s\n", "validator", "power", "sim power", "booked reward", "sim reward")
+	baselineMap := make(map[[20]byte]*staking.SimValidator, len(sim.Baseline.Validators))
+	for _, val := range sim.Baseline.Validators {
+		baselineMap[val.Address] = val
+	}
+	simulatedMap := make(map[[20]byte]*staking.SimValidator, len(sim.Simulated.Validators))
+	for _, val := range sim.Simulated.Validators {
+		simulatedMap[val.Address] = val
+	}
+	// the validators elected by the baseline go first, followed by the ones only elected by the simulation
+	addrs := make([][20]byte, 0, len(baselineMap)+len(simulatedMap))
+	for _, val := range sim.Baseline.Validators {
+		addrs = append(addrs, val.Address)
+	}
+	for _, val := range sim.Simulated.Validators {
+		if _, ok := baselineMap[val.Address]; !ok {
+			addrs = append(addrs, val.Address)
+		}
+	}
+	for _, addr := range addrs {
+		power, simPower, simReward := "-", "-", "-"
+		if val, ok := baselineMap[addr]; ok {
+			power = fmt.Sprintf("%d", val.VotingPower)
+		}
+		if val, ok := simulatedMap[addr]; ok {
+			simPower = fmt.Sprintf("%d", val.VotingPower)
+			if sim.BookedRewards != nil {
+				simReward = val.Reward.ToBig().String()
+			}
+		}
+		bookedReward := "-"
+		if sim.BookedRewards != nil {
+			rwd, ok := sim.BookedRewards[addr]
+			if !ok {
+				rwd = uint256.NewInt(0)
+			}
+			bookedReward = rwd.ToBig().String()
+		}
+		fmt.Printf("  %-42s %12s %12s %24s %24s\n", common.Address(addr).String(), power, simPower, bookedReward, simReward)
+	}
+}
@@ -1,17 +1,25 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/tendermint/tendermint/libs/cli"
 
 	"github.com/smartbch/smartbch/internal/bigutils"
@@ -20,20 +28,37 @@ import (
 )
 
 const (
-	flagRewardTo = "reward-to"
-	flagType     = "type"
+	flagRewardTo     = "reward-to"
+	flagType         = "type"
+	flagTarget       = "target"
+	flagKeystore     = "keystore"
+	flagPasswordFile = "password-file"
+	flagRpcUrl       = "rpc-url"
+	flagBroadcast    = "broadcast"
+	flagWaitReceipt  = "wait-receipt"
 
 	create              = "create"
 	edit                = "edit"
 	retire              = "retire"
 	increaseMinGasPrice = "increase"
 	decreaseMinGasPrice = "decrease"
+	proposal            = "proposal"
+	vote                = "vote"
+	executeProposal     = "executeProposal"
+	getVote             = "getVote"
+
+	receiptPollInterval = 2 * time.Second
+	receiptWaitTimeout  = 2 * time.Minute
 )
 
 func StakingCmd(ctx *Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "staking",
 		Short: "call staking contract method",
+		Long: `Build and sign a TX calling the staking contract, and print it or broadcast it.
+The key is given by --validator-key, or by --keystore, whose password is read from --password-file or stdin.
+If --rpc-url is given, the nonce, chain id and gas price which are not specified are fetched from it,
+and getVote is answered by eth_call without signing a TX.`,
 		Example: `
 smartbchd staking \
 --validator-key=07427a59913df1ae8af709f60f536ddba122b0afa8908291471ca58c603a7447 \
@@ -46,73 +71,60 @@ smartbchd staking \
 --gas-price=1000 \
 --type="create" \
 --verbose
+
+smartbchd staking \
+--keystore=./UTC--2021-07-01T00-00-00.000000000Z--9887310499db9e65411fc0a57689b4429755c372 \
+--password-file=./password.txt \
+--rpc-url=http://127.0.0.1:8545 \
+--target=2000000000 \
+--type="proposal" \
+--broadcast --wait-receipt
 `,
-		RunE: func(_ *cobra.Command, args []string) error {
-			c := ctx.Config.NodeConfig
-			c.SetRoot(viper.GetString(cli.HomeFlag))
+		RunE: func(c *cobra.Command, args []string) error {
+			nodeCfg := ctx.Config.NodeConfig
+			nodeCfg.SetRoot(viper.GetString(cli.HomeFlag))
+
+			var client *ethclient.Client
+			if url := viper.GetString(flagRpcUrl); url != "" {
+				var err error
+				if client, err = ethclient.Dial(url); err != nil {
+					return fmt.Errorf("dial rpc error: %s", err.Error())
+				}
+				defer client.Close()
+			}
+
+			fType := viper.GetString(flagType)
+			if fType == getVote && client != nil {
+				return callGetVote(client)
+			}
 
 			// get private key
-			priKey, _, err := ethutils.HexToPrivKey(viper.GetString(flagValKey))
+			priKey, err := loadPrivKey()
 			if err != nil {
-				return fmt.Errorf("private key parse error: " + err.Error())
+				return err
 			}
-			nonce := viper.GetUint64(flagNonce)
-			//todo: get chain id in config.toml
-			//chainID := ctx.Config.ChainID()
-			chainID, err := parseChainID(viper.GetString(flagChainId))
+			data, value, err := packStakingCall(fType, priKey)
 			if err != nil {
-				return fmt.Errorf("parse chain id errpr: %s", err.Error())
+				return err
 			}
-
-			fType := viper.GetString(flagType)
-			if fType == retire {
-				data := staking.PackRetire()
-				return printSignedTx(big.NewInt(0), data, nonce, priKey, chainID.ToBig())
-			} else if fType == increaseMinGasPrice {
-				data := staking.PackIncreaseMinGasPrice()
-				return printSignedTx(big.NewInt(0), data, nonce, priKey, chainID.ToBig())
-			} else if fType == decreaseMinGasPrice {
-				data := staking.PackDecreaseMinGasPrice()
-				return printSignedTx(big.NewInt(0), data, nonce, priKey, chainID.ToBig())
+			txParams, err := getTxParams(c, client, ethutils.PrivKeyToAddr(priKey))
+			if err != nil {
+				return err
 			}
-
-			// get staking coin
-			sCoin, success := bigutils.ParseU256(viper.GetString(flagStakingCoin))
-			if !success {
-				return fmt.Errorf("staking coin parse failed")
+			tx, err := signStakingTx(value, data, txParams, priKey)
+			if err != nil {
+				return err
 			}
-			// generate edit validator info
-
-			var intro [32]byte
-			copy(intro[:], viper.GetString(flagIntroduction))
-
-			rewardToString := viper.GetString(flagRewardTo)
-			rewardTo := common.HexToAddress(rewardToString)
-			if rewardToString == "" {
-				rewardTo = ethutils.PrivKeyToAddr(priKey)
+			if err = printSignedTx(tx); err != nil {
+				return err
 			}
-
-			if fType == edit {
-				data := staking.PackEditValidator(rewardTo, intro)
-				return printSignedTx(sCoin.ToBig(), data, nonce, priKey, chainID.ToBig())
+			if !viper.GetBool(flagBroadcast) {
+				return nil
 			}
-
-			if fType == create {
-				pubKeyHex := viper.GetString(flagConsPubKey)
-				if pubKeyHex == "" {
-					return errors.New(flagConsPubKey + " is missing")
-				}
-				pk, _, err := ethutils.HexToPubKey(pubKeyHex)
-				if err != nil {
-					return err
-				}
-				var pubkey [32]byte
-				copy(pubkey[:], pk)
-				data := staking.PackCreateValidator(rewardTo, intro, pubkey)
-				return printSignedTx(sCoin.ToBig(), data, nonce, priKey, chainID.ToBig())
+			if client == nil {
+				return errors.New(flagRpcUrl + " is missing, cannot broadcast")
 			}
-
-			return errors.New("invalid staking function type")
+			return broadcastTx(client, tx, viper.GetBool(flagWaitReceipt))
 		},
 	}
 
@@ -121,37 +133,219 @@ smartbchd staking \
 	cmd.Flags().Int64(flagVotingPower, 0, "voting power")
 	cmd.Flags().String(flagStakingCoin, "0", "staking coin")
 	cmd.Flags().String(flagRewardTo, "", "validator rewardTo address")
-	cmd.Flags().String(flagType, "", "validator function type, including create, edit, retire, increase, decrease")
+	cmd.Flags().String(flagType, "", "validator function type, including create, edit, retire, increase, decrease, "+
+		"proposal, vote, executeProposal, getVote")
 	cmd.Flags().String(flagIntroduction, "", "introduction")
 	cmd.Flags().Bool(flagVerbose, false, "display verbose information")
 	cmd.Flags().Uint64(flagGasPrice, 1500000000, "specify gas price")
-	cmd.Flags().String(flagChainId, "", "specify gas price")
+	cmd.Flags().String(flagChainId, "", "specify chain id")
 	cmd.Flags().Uint64(flagNonce, 1, "specify tx nonce")
 	cmd.Flags().String(flagValKey, "", "specify from address private key")
+	cmd.Flags().Uint64(flagTarget, 0, "the target min gas price of proposal and vote")
+	cmd.Flags().String(flagKeystore, "", "the encrypted keystore file of the private key, instead of "+flagValKey)
+	cmd.Flags().String(flagPasswordFile, "", "the file containing the password of the keystore")
+	cmd.Flags().String(flagRpcUrl, "", "the rpc endpoint to fetch nonce, chain id and gas price from, and to broadcast to")
+	cmd.Flags().Bool(flagBroadcast, false, "broadcast the signed tx by eth_sendRawTransaction")
+	cmd.Flags().Bool(flagWaitReceipt, false, "wait for the receipt of the broadcast tx")
 
 	_ = cmd.MarkFlagRequired(flagType)
-	_ = cmd.MarkFlagRequired(flagValKey)
-	_ = cmd.MarkFlagRequired(flagChainId)
-	_ = cmd.MarkFlagRequired(flagNonce)
+	cmd.AddCommand(StakingSimulateCmd(ctx))
 	return cmd
 }
 
-func printSignedTx(value *big.Int, data []byte, nonce uint64, priKey *ecdsa.PrivateKey, chainID *big.Int) error {
+// load the private key from --validator-key or --keystore
+func loadPrivKey() (*ecdsa.PrivateKey, error) {
+	keystoreFile := viper.GetString(flagKeystore)
+	if keystoreFile == "" {
+		if viper.GetString(flagValKey) == "" {
+			return nil, errors.New(flagValKey + " or " + flagKeystore + " is missing")
+		}
+		priKey, _, err := ethutils.HexToPrivKey(viper.GetString(flagValKey))
+		if err != nil {
+			return nil, fmt.Errorf("private key parse error: " + err.Error())
+		}
+		return priKey, nil
+	}
+	keyJson, err := os.ReadFile(keystoreFile)
+	if err != nil {
+		return nil, err
+	}
+	password, err := readPassword(viper.GetString(flagPasswordFile))
+	if err != nil {
+		return nil, err
+	}
+	return decryptKeystore(keyJson, password)
+}
+
+// read the password from the first line of 'passwordFile', or from stdin if it is empty
+func readPassword(passwordFile string) (string, error) {
+	if passwordFile != "" {
+		bz, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(strings.SplitN(string(bz), "\n", 2)[0], "\r"), nil
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read password error: %s", err.Error())
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func decryptKeystore(keyJson []byte, password string) (*ecdsa.PrivateKey, error) {
+	key, err := keystore.DecryptKey(keyJson, password)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore error: %s", err.Error())
+	}
+	return key.PrivateKey, nil
+}
+
+// the call data and the value of the TX calling staking function 'fType'
+func packStakingCall(fType string, priKey *ecdsa.PrivateKey) (data []byte, value *big.Int, err error) {
+	value = big.NewInt(0)
+	switch fType {
+	case retire:
+		return staking.PackRetire(), value, nil
+	case increaseMinGasPrice:
+		return staking.PackIncreaseMinGasPrice(), value, nil
+	case decreaseMinGasPrice:
+		return staking.PackDecreaseMinGasPrice(), value, nil
+	case proposal, vote:
+		target := viper.GetUint64(flagTarget)
+		if target == 0 {
+			return nil, nil, errors.New(flagTarget + " is missing")
+		}
+		if fType == proposal {
+			return staking.PackProposal(new(big.Int).SetUint64(target)), value, nil
+		}
+		return staking.PackVote(new(big.Int).SetUint64(target)), value, nil
+	case executeProposal:
+		return staking.PackExecuteProposal(), value, nil
+	case getVote:
+		return staking.PackGetVote(getVoteValidator(priKey)), value, nil
+	case create, edit:
+	default:
+		return nil, nil, errors.New("invalid staking function type")
+	}
+
+	// get staking coin
+	sCoin, success := bigutils.ParseU256(viper.GetString(flagStakingCoin))
+	if !success {
+		return nil, nil, fmt.Errorf("staking coin parse failed")
+	}
+	// generate edit validator info
+
+	var intro [32]byte
+	copy(intro[:], viper.GetString(flagIntroduction))
+
+	rewardToString := viper.GetString(flagRewardTo)
+	rewardTo := common.HexToAddress(rewardToString)
+	if rewardToString == "" {
+		rewardTo = ethutils.PrivKeyToAddr(priKey)
+	}
+
+	if fType == edit {
+		return staking.PackEditValidator(rewardTo, intro), sCoin.ToBig(), nil
+	}
+
+	pubKeyHex := viper.GetString(flagConsPubKey)
+	if pubKeyHex == "" {
+		return nil, nil, errors.New(flagConsPubKey + " is missing")
+	}
+	pk, _, err := ethutils.HexToPubKey(pubKeyHex)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pubkey [32]byte
+	copy(pubkey[:], pk)
+	return staking.PackCreateValidator(rewardTo, intro, pubkey), sCoin.ToBig(), nil
+}
+
+// the validator whose vote is queried, which is the key's address if --validator-address is not given
+func getVoteValidator(priKey *ecdsa.PrivateKey) common.Address {
+	if addr := viper.GetString(flagAddress); addr != "" {
+		return common.HexToAddress(addr)
+	}
+	return ethutils.PrivKeyToAddr(priKey)
+}
+
+func callGetVote(client *ethclient.Client) error {
+	addr := viper.GetString(flagAddress)
+	if addr == "" {
+		return errors.New(flagAddress + " is missing")
+	}
+	to := common.Address(staking.StakingContractAddress)
+	out, err := client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &to,
+		Gas:  staking.GasOfMinGasPriceOp,
+		Data: staking.PackGetVote(common.HexToAddress(addr)),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("call getVote error: %s", err.Error())
+	}
+	fmt.Println(new(big.Int).SetBytes(out).String())
+	return nil
+}
+
+type txParams struct {
+	nonce    uint64
+	chainID  *big.Int
+	gasPrice *big.Int
+}
+
+// the nonce, chain id and gas price are taken from the flags, the ones not specified are fetched from 'client'
+func getTxParams(c *cobra.Command, client *ethclient.Client, from common.Address) (params txParams, err error) {
+	bg := context.Background()
+	params.nonce = viper.GetUint64(flagNonce)
+	if !c.Flags().Changed(flagNonce) && client != nil {
+		if params.nonce, err = client.PendingNonceAt(bg, from); err != nil {
+			return params, fmt.Errorf("get nonce error: %s", err.Error())
+		}
+	}
+	if chainID := viper.GetString(flagChainId); chainID != "" {
+		id, err := parseChainID(chainID)
+		if err != nil {
+			return params, fmt.Errorf("parse chain id errpr: %s", err.Error())
+		}
+		params.chainID = id.ToBig()
+	} else if client != nil {
+		if params.chainID, err = client.ChainID(bg); err != nil {
+			return params, fmt.Errorf("get chain id error: %s", err.Error())
+		}
+	} else {
+		return params, errors.New(flagChainId + " is missing")
+	}
+	params.gasPrice = new(big.Int).SetUint64(viper.GetUint64(flagGasPrice))
+	if !c.Flags().Changed(flagGasPrice) && client != nil {
+		if params.gasPrice, err = client.SuggestGasPrice(bg); err != nil {
+			return params, fmt.Errorf("get gas price error: %s", err.Error())
+		}
+	}
+	return params, nil
+}
+
+func signStakingTx(value *big.Int, data []byte, params txParams, priKey *ecdsa.PrivateKey) (*gethtypes.Transaction, error) {
 	to := common.Address(staking.StakingContractAddress)
 
 	txData := &gethtypes.LegacyTx{
-		Nonce:    nonce,
-		GasPrice: big.NewInt(viper.GetInt64(flagGasPrice)),
+		Nonce:    params.nonce,
+		GasPrice: params.gasPrice,
 		Gas:      staking.GasOfValidatorOp,
 		To:       &to,
 		Value:    value,
 		Data:     data,
 	}
 	tx := gethtypes.NewTx(txData)
-	tx, e := ethutils.SignTx(tx, chainID, priKey)
+	tx, e := ethutils.SignTx(tx, params.chainID, priKey)
 	if e != nil {
-		return fmt.Errorf("sign tx errpr: %s", e.Error())
+		return nil, fmt.Errorf("sign tx errpr: %s", e.Error())
 	}
+	return tx, nil
+}
+
+func printSignedTx(tx *gethtypes.Transaction) error {
 	txBytes, e := ethutils.EncodeTx(tx)
 	if e != nil {
 		return fmt.Errorf("encode tx errpr: %s", e.Error())
@@ -163,3 +357,32 @@ func printSignedTx(value *big.Int, data []byte, nonce uint64, priKey *ecdsa.Priv
 	}
 	return nil
 }
+
+// send the tx by eth_sendRawTransaction, and poll its receipt if 'wait' is true
+func broadcastTx(client *ethclient.Client, tx *gethtypes.Transaction, wait bool) error {
+	bg := context.Background()
+	if err := client.SendTransaction(bg, tx); err != nil {
+		return fmt.Errorf("send tx error: %s", err.Error())
+	}
+	fmt.Println("tx hash:", tx.Hash().Hex())
+	if !wait {
+		return nil
+	}
+	deadline := time.Now().Add(receiptWaitTimeout)
+	for time.Now().Before(deadline) {
+		receipt, err := client.TransactionReceipt(bg, tx.Hash())
+		if err == nil {
+			out, _ := receipt.MarshalJSON()
+			fmt.Println(string(out))
+			if receipt.Status != gethtypes.ReceiptStatusSuccessful {
+				return errors.New("tx failed")
+			}
+			return nil
+		}
+		if err != ethereum.NotFound {
+			return fmt.Errorf("get receipt error: %s", err.Error())
+		}
+		time.Sleep(receiptPollInterval)
+	}
+	return errors.New("timeout waiting for the receipt")
+}
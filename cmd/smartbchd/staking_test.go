@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/smartbch/internal/ethutils"
+	"github.com/smartbch/smartbch/staking"
+)
+
+func TestDecryptKeystore(t *testing.T) {
+	priKey, _, err := ethutils.HexToPrivKey("07427a59913df1ae8af709f60f536ddba122b0afa8908291471ca58c603a7447")
+	require.NoError(t, err)
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(priKey, "123456")
+	require.NoError(t, err)
+	keyJson, err := os.ReadFile(acc.URL.Path)
+	require.NoError(t, err)
+
+	_, err = decryptKeystore(keyJson, "654321")
+	require.Error(t, err)
+	key, err := decryptKeystore(keyJson, "123456")
+	require.NoError(t, err)
+	require.Equal(t, ethutils.PrivKeyToAddr(priKey), ethutils.PrivKeyToAddr(key))
+}
+
+func TestPackStakingCall(t *testing.T) {
+	priKey, _, err := ethutils.HexToPrivKey("07427a59913df1ae8af709f60f536ddba122b0afa8908291471ca58c603a7447")
+	require.NoError(t, err)
+	defer viper.Reset()
+
+	_, _, err = packStakingCall(proposal, priKey)
+	require.Error(t, err) // the target is missing
+	viper.Set(flagTarget, 2000000000)
+	data, value, err := packStakingCall(proposal, priKey)
+	require.NoError(t, err)
+	require.Equal(t, staking.SelectorProposal[:], data[:4])
+	require.Zero(t, value.Sign())
+	data, _, err = packStakingCall(vote, priKey)
+	require.NoError(t, err)
+	require.Equal(t, staking.SelectorVote[:], data[:4])
+
+	data, _, err = packStakingCall(executeProposal, priKey)
+	require.NoError(t, err)
+	require.Equal(t, staking.SelectorExecuteProposal[:], data[:4])
+
+	data, _, err = packStakingCall(getVote, priKey)
+	require.NoError(t, err)
+	require.Equal(t, staking.PackGetVote(ethutils.PrivKeyToAddr(priKey)), data)
+
+	_, _, err = packStakingCall("unknown", priKey)
+	require.Error(t, err)
+}
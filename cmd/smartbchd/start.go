@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/holiman/uint256"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -24,6 +26,7 @@ import (
 
 	"github.com/smartbch/smartbch/api"
 	"github.com/smartbch/smartbch/app"
+	"github.com/smartbch/smartbch/param"
 	"github.com/smartbch/smartbch/rpc"
 )
 
@@ -44,9 +47,12 @@ const (
 	flagUnlock                 = "unlock"
 	flagGenesisMainnetHeight   = "mainnet-genesis-height"
 	flagCCGenesisMainnetHeight = "crosschain-genesis-height"
+	flagMainnetDataSource      = "mainnet-data-source"
 	flagMainnetUrl             = "mainnet-rpc-url"
 	flagMainnetRpcUser         = "mainnet-rpc-username"
 	flagMainnetRpcPassword     = "mainnet-rpc-password"
+	flagMainnetElectrumUrl     = "mainnet-electrum-url"
+	flagMainnetBlocksDir       = "mainnet-blocks-dir"
 	flagSmartBchUrl            = "smartbch-url"
 	flagWatcherSpeedup         = "watcher-speedup"
 	flagRpcOnly                = "rpc-only"
@@ -90,9 +96,12 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Uint(flagMaxHeaderBytes, uint(defaultRpcCfg.MaxHeaderBytes), "max header bytes of RPC server")
 	cmd.Flags().Uint(flagMaxBodyBytes, uint(defaultRpcCfg.MaxBodyBytes), "max body bytes of RPC server")
 	cmd.Flags().String(flagUnlock, "", "Comma separated list of private keys to unlock (only for testing)")
+	cmd.Flags().String(flagMainnetDataSource, param.MainnetDataSourceBCHN, "BCH Mainnet data source: bchn, electrum or blkfiles")
 	cmd.Flags().String(flagMainnetUrl, "tcp://:8432", "BCH Mainnet RPC URL")
 	cmd.Flags().String(flagMainnetRpcUser, "user", "BCH Mainnet RPC user name")
 	cmd.Flags().String(flagMainnetRpcPassword, "88888888", "BCH Mainnet RPC user password")
+	cmd.Flags().String(flagMainnetElectrumUrl, "tcp://:50001", "BCH Mainnet Electrum server URL, such as tcp://host:50001 or ssl://host:50002")
+	cmd.Flags().String(flagMainnetBlocksDir, "", "BCH Mainnet blocks directory which contains blkNNNNN.dat files")
 	cmd.Flags().String(flagSmartBchUrl, "tcp://:8545", "SmartBch RPC URL")
 	cmd.Flags().Bool(flagWatcherSpeedup, false, "Watcher Speedup")
 	cmd.Flags().Bool(flagRpcOnly, false, "Start RPC server even tmnode is not started correctly, only useful for debug purpose")
@@ -166,8 +175,15 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	keyfileDir := filepath.Join(nodeCfg.RootDir, "nodeCfg/key.pem")
 	httpAPI := viper.GetString(flagRpcAPI)
 	wsAPI := viper.GetString(flagWsAPI)
+	rpcMetrics := rpc.NopMetrics()
+	if registry := appImpl.MetricsRegistry(); registry != nil {
+		rpcMetrics = rpc.PrometheusMetrics(registry, app.MetricsNamespace)
+		if err := startPrometheusServer(ctx.Config.AppConfig.PrometheusListenAddr, registry, ctx.Logger); err != nil {
+			return nil, err
+		}
+	}
 	rpcServer := rpc.NewServer(rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, certfileDir, keyfileDir,
-		serverCfg, rpcBackend, ctx.Logger, strings.Split(unlockedKeys, ","), httpAPI, wsAPI)
+		serverCfg, rpcBackend, ctx.Logger, strings.Split(unlockedKeys, ","), httpAPI, wsAPI, rpcMetrics)
 
 	if err := rpcServer.Start(); err != nil {
 		return nil, err
@@ -211,6 +227,23 @@ func startTmNode(nodeCfg *tmcfg.Config,
 	return tmNode, nil
 }
 
+// startPrometheusServer serves the metrics in registry at addr, such as "tcp://127.0.0.1:26661"
+func startPrometheusServer(addr string, registry *stdprometheus.Registry, logger tmlog.Logger) error {
+	serverCfg := tmrpcserver.DefaultConfig()
+	listener, err := tmrpcserver.Listen(addr, serverCfg)
+	if err != nil {
+		return err
+	}
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	go func() {
+		err := tmrpcserver.Serve(listener, handler, logger, serverCfg)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+	return nil
+}
+
 func getChainID(ctx *Context) (*uint256.Int, error) {
 	gDoc, err := tmtypes.GenesisDocFromFile(ctx.Config.NodeConfig.GenesisFile())
 	if err != nil {
@@ -465,7 +465,7 @@ func sendRawTxSubList(c *websocket.Conn, txList [][]byte, idxList []int, logsMsg
 		}
 	}
 	remainList := make([]int, 0, len(idxList)/3)
-	signer := gethtypes.NewEIP155Signer(chainId.ToBig())
+	signer := gethtypes.NewLondonSigner(chainId.ToBig())
 	// Now we make sure the on-chain nonce has already been updated
 	for _, idx := range checkList {
 		tx, err := ethutils.DecodeTx(txList[idx])
@@ -33,9 +33,10 @@ import (
 type RocksDB = indextree.RocksDB
 
 const (
-	adsDir   = "./testdbdata"
-	modbDir  = "./modbdata"
-	blockDir = "./blkdata"
+	adsDir    = "./testdbdata"
+	modbDir   = "./modbdata"
+	ledgerDir = "./ledgerdata"
+	blockDir  = "./blkdata"
 )
 
 var num1e18 = uint256.NewInt(1_000_000_000_000_000_000)
@@ -351,6 +352,7 @@ func CreateTestApp(testInitAmt *uint256.Int, keys []string) *testutils.TestApp {
 	params := param.DefaultConfig()
 	params.AppConfig.AppDataPath = adsDir
 	params.AppConfig.ModbDataPath = modbDir
+	params.AppConfig.LedgerDataPath = ledgerDir
 	params.AppConfig.UseLiteDB = true
 	params.AppConfig.NumKeptBlocks = 5
 	testValidatorPubKey := ed25519.GenPrivKeyFromSecret([]byte("stress")).PubKey()
@@ -512,6 +514,7 @@ func ShowSlots(_app *testutils.TestApp, caller common.Address, contractAddrs []c
 func RunRecordBlocks(randBlocks, fromSize, toSize, txPerBlock int, fname string) {
 	_ = os.RemoveAll(adsDir)
 	_ = os.RemoveAll(modbDir)
+	_ = os.RemoveAll(ledgerDir)
 	_ = os.RemoveAll(blockDir)
 	_ = os.Mkdir(modbDir, 0700)
 	_ = os.Mkdir(blockDir, 0700)
@@ -533,6 +536,7 @@ func RunRecordBlocks(randBlocks, fromSize, toSize, txPerBlock int, fname string)
 
 func RunReplayBlocks(fromSize int, fname string) {
 	_ = os.RemoveAll(modbDir)
+	_ = os.RemoveAll(ledgerDir)
 	_ = os.Mkdir(modbDir, 0700)
 
 	blkDB := NewBlockDB(blockDir)
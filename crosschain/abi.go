@@ -31,6 +31,99 @@ var ABI = ethutils.MustParseABI(`
 		"name": "Burn",
 		"type": "event"
 	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "bytes32",
+				"name": "mainnetTxId",
+				"type": "bytes32"
+			},
+			{
+				"indexed": true,
+				"internalType": "bytes4",
+				"name": "vOutIndex",
+				"type": "bytes4"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "receiver",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			}
+		],
+		"name": "Deposit",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "bytes32",
+				"name": "mainnetTxId",
+				"type": "bytes32"
+			},
+			{
+				"indexed": true,
+				"internalType": "bytes4",
+				"name": "vOutIndex",
+				"type": "bytes4"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "redeemer",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			}
+		],
+		"name": "RedemptionRefunded",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "bytes32",
+				"name": "mainnetTxId",
+				"type": "bytes32"
+			},
+			{
+				"indexed": true,
+				"internalType": "bytes4",
+				"name": "vOutIndex",
+				"type": "bytes4"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "from",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "string",
+				"name": "cashAddr",
+				"type": "string"
+			}
+		],
+		"name": "RedemptionRequested",
+		"type": "event"
+	},
 	{
 		"anonymous": false,
 		"inputs": [
@@ -75,6 +168,55 @@ var ABI = ethutils.MustParseABI(`
 		"stateMutability": "nonpayable",
 		"type": "function"
 	},
+	{
+		"inputs": [
+			{
+				"internalType": "bytes",
+				"name": "utxo",
+				"type": "bytes"
+			}
+		],
+		"name": "refundRedemption",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "bytes",
+				"name": "header",
+				"type": "bytes"
+			},
+			{
+				"internalType": "bytes",
+				"name": "merkleProof",
+				"type": "bytes"
+			},
+			{
+				"internalType": "bytes",
+				"name": "rawTx",
+				"type": "bytes"
+			}
+		],
+		"name": "submitDeposit",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "bytes",
+				"name": "headers",
+				"type": "bytes"
+			}
+		],
+		"name": "submitHeaders",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
 	{
 		"inputs": [
 			{
@@ -87,6 +229,24 @@ var ABI = ethutils.MustParseABI(`
 		"outputs": [],
 		"stateMutability": "nonpayable",
 		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "bytes",
+				"name": "utxo",
+				"type": "bytes"
+			},
+			{
+				"internalType": "string",
+				"name": "cashAddr",
+				"type": "string"
+			}
+		],
+		"name": "transferBCHToMainnetAddress",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
 	}
 ]
 `)
@@ -97,3 +257,15 @@ func PackTransferBCHToMainnet(utxo [36]byte) []byte {
 func PackBurnBCH(utxo [36]byte) []byte {
 	return ABI.MustPack("burnBCH", utxo[:])
 }
+func PackSubmitHeaders(headers []byte) []byte {
+	return ABI.MustPack("submitHeaders", headers)
+}
+func PackSubmitDeposit(header, merkleProof, rawTx []byte) []byte {
+	return ABI.MustPack("submitDeposit", header, merkleProof, rawTx)
+}
+func PackTransferBCHToMainnetAddress(utxo [36]byte, cashAddr string) []byte {
+	return ABI.MustPack("transferBCHToMainnetAddress", utxo[:], cashAddr)
+}
+func PackRefundRedemption(utxo [36]byte) []byte {
+	return ABI.MustPack("refundRedemption", utxo[:])
+}
@@ -0,0 +1,126 @@
+package crosschain
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+// The targets of redemptions are given in cashaddr, and kept as a type byte (0 for P2PKH and 1 for P2SH)
+// followed by the 160-bit hash.
+
+const cashAddrCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var InvalidCashAddr = errors.New("invalid cashaddr")
+
+func cashAddrPolymod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := byte(c >> 35)
+		c = ((c & 0x07ffffffff) << 5) ^ uint64(d)
+		if c0&0x01 != 0 {
+			c ^= 0x98f2bc8e61
+		}
+		if c0&0x02 != 0 {
+			c ^= 0x79b76d99e2
+		}
+		if c0&0x04 != 0 {
+			c ^= 0xf33e5fb3c4
+		}
+		if c0&0x08 != 0 {
+			c ^= 0xae2eabe2a8
+		}
+		if c0&0x10 != 0 {
+			c ^= 0x1e4f43e470
+		}
+	}
+	return c ^ 1
+}
+
+func cashAddrPrefixValues(prefix string) []byte {
+	values := make([]byte, 0, len(prefix)+1)
+	for i := 0; i < len(prefix); i++ {
+		values = append(values, prefix[i]&0x1f)
+	}
+	return append(values, 0)
+}
+
+// regroup the bits of 'data' from 'fromBits' to 'toBits', padding zeros if 'pad'
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, bool) {
+	acc, bits := uint(0), uint(0)
+	maxv := uint(1)<<toBits - 1
+	out := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, d := range data {
+		acc = acc<<fromBits | uint(d)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// DecodeCashAddr decodes a P2PKH or P2SH cashaddr, whose prefix can be omitted
+func DecodeCashAddr(addr string) (target [21]byte, err error) {
+	if strings.ToLower(addr) != addr && strings.ToUpper(addr) != addr {
+		return target, InvalidCashAddr
+	}
+	addr = strings.ToLower(addr)
+	prefix := param.CCCashAddrPrefix
+	if i := strings.LastIndexByte(addr, ':'); i >= 0 {
+		if addr[:i] != prefix {
+			return target, InvalidCashAddr
+		}
+		addr = addr[i+1:]
+	}
+	// 34 characters of the version byte and the hash, followed by 8 characters of the checksum
+	if len(addr) != 42 {
+		return target, InvalidCashAddr
+	}
+	values := make([]byte, len(addr))
+	for i := 0; i < len(addr); i++ {
+		v := strings.IndexByte(cashAddrCharset, addr[i])
+		if v < 0 {
+			return target, InvalidCashAddr
+		}
+		values[i] = byte(v)
+	}
+	if cashAddrPolymod(append(cashAddrPrefixValues(prefix), values...)) != 0 {
+		return target, InvalidCashAddr
+	}
+	payload, ok := convertBits(values[:len(values)-8], 5, 8, false)
+	if !ok || len(payload) != 21 || (payload[0] != 0 && payload[0] != 8) {
+		return target, InvalidCashAddr
+	}
+	copy(target[:], payload)
+	target[0] >>= 3
+	return target, nil
+}
+
+// EncodeCashAddr encodes a target to cashaddr with the prefix
+func EncodeCashAddr(target [21]byte) string {
+	payload := target
+	payload[0] <<= 3
+	values, _ := convertBits(payload[:], 8, 5, true)
+	prefixValues := cashAddrPrefixValues(param.CCCashAddrPrefix)
+	checksum := cashAddrPolymod(append(append(prefixValues, values...), make([]byte, 8)...))
+	for i := 0; i < 8; i++ {
+		values = append(values, byte(checksum>>(5*(7-i))&0x1f))
+	}
+	var sb strings.Builder
+	sb.WriteString(param.CCCashAddrPrefix)
+	sb.WriteByte(':')
+	for _, v := range values {
+		sb.WriteByte(cashAddrCharset[v])
+	}
+	return sb.String()
+}
@@ -10,8 +10,8 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
-	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/smartbch/moeingevm/ebp"
@@ -33,18 +33,33 @@ var (
 	/*------selector------*/
 	/*interface CC {
 	    function transferBCHToMainnet(bytes utxo) external;
+	    function transferBCHToMainnetAddress(bytes utxo, string cashAddr) external;
+	    function refundRedemption(bytes utxo) external;
 	    function burnBCH(bytes utxo) external;
+	    function submitHeaders(bytes headers) external;
+	    function submitDeposit(bytes header, bytes merkleProof, bytes rawTx) external;
 
 	    event TransferToMainnet(bytes32 indexed mainnetTxId, bytes4 indexed vOutIndex, address indexed from, uint256 value);
 	    event Burn(bytes32 indexed mainnetTxId, bytes4 indexed vOutIndex, uint256 value);
+	    event Deposit(bytes32 indexed mainnetTxId, bytes4 indexed vOutIndex, address indexed receiver, uint256 value);
+	    event RedemptionRequested(bytes32 indexed mainnetTxId, bytes4 indexed vOutIndex, address indexed from, string cashAddr);
+	    event RedemptionRefunded(bytes32 indexed mainnetTxId, bytes4 indexed vOutIndex, address indexed redeemer, uint256 value);
 	}*/
-	SelectorTransferBchToMainnet [4]byte = [4]byte{0xa0, 0x4f, 0x3b, 0x01}
-	SelectorBurnBCH              [4]byte = [4]byte{0x18, 0x92, 0xa8, 0xb3}
-
-	HashOfEventTransferToBch [32]byte = common.HexToHash("0x4a9f09be1e2df144675144ec10cb5fe6c05504a84262275b62028189c1d410c1")
-	HashOfEventBurn          [32]byte = common.HexToHash("0xeae299b236fc8161793d044c8260b3dc7f8c20b5b3b577eb7f075e4a9c3bf48d")
-
-	GasOfCCOp uint64 = 400_000
+	SelectorTransferBchToMainnet        [4]byte = [4]byte{0xa0, 0x4f, 0x3b, 0x01}
+	SelectorTransferBchToMainnetAddress [4]byte = [4]byte{0x16, 0x71, 0xc8, 0xae}
+	SelectorRefundRedemption            [4]byte = [4]byte{0x2a, 0x67, 0x3a, 0x3d}
+	SelectorBurnBCH                     [4]byte = [4]byte{0x18, 0x92, 0xa8, 0xb3}
+	SelectorSubmitHeaders               [4]byte = [4]byte{0x25, 0x9b, 0xf3, 0xf1}
+	SelectorSubmitDeposit               [4]byte = [4]byte{0x81, 0xea, 0x4e, 0x35}
+
+	HashOfEventTransferToBch       [32]byte = common.HexToHash("0x4a9f09be1e2df144675144ec10cb5fe6c05504a84262275b62028189c1d410c1")
+	HashOfEventBurn                [32]byte = common.HexToHash("0xeae299b236fc8161793d044c8260b3dc7f8c20b5b3b577eb7f075e4a9c3bf48d")
+	HashOfEventDeposit             [32]byte = common.HexToHash("0x1726e678ebe8de718631c9e135741f97e428e5971749a57b5177230c7065c939")
+	HashOfEventRedemptionRequested [32]byte = common.HexToHash("0xbfa936b307ecf283c7d20c7a6d7ceee1a6f654568ee165484bb02c638d4e4fbd")
+	HashOfEventRedemptionRefunded  [32]byte = common.HexToHash("0x99d180ed3fdf1d290bb1842aca5753037201afd18bd1128d75ce6b0db590c7f7")
+
+	GasOfCCOp      uint64 = 400_000
+	GasOfSpvHeader uint64 = 50_000
 
 	InvalidCallData   = errors.New("invalid call data")
 	InvalidSelector   = errors.New("invalid selector")
@@ -52,6 +67,26 @@ var (
 	BalanceNotEnough  = errors.New("balance is not enough")
 	BurnToMuch        = errors.New("burn more bch than reality")
 
+	SpvNotEnabled          = errors.New("spv is not enabled")
+	InvalidHeader          = errors.New("invalid header")
+	UnknownPrevHeader      = errors.New("previous header is not found")
+	BadDifficulty          = errors.New("header has bad difficulty bits")
+	InsufficientPow        = errors.New("header has insufficient proof of work")
+	InvalidTimestamp       = errors.New("header has invalid timestamp")
+	SpvTipTooOld           = errors.New("tip of the header chain is too old")
+	NotOnBestChain         = errors.New("header is not on the best chain")
+	NotEnoughConfirmations = errors.New("deposit does not have enough confirmations")
+	InvalidRawTx           = errors.New("invalid raw tx")
+	InvalidMerkleProof     = errors.New("invalid merkle proof")
+	NoShaGateOutput        = errors.New("no output to the ShaGate address")
+	DepositAlreadySeen     = errors.New("deposit is already seen")
+
+	UTXOOffsetOutOfRange = errors.New("offset is out of the range of utxo index")
+
+	RedemptionNotFound   = errors.New("redemption is not found")
+	RedemptionNotPending = errors.New("redemption is not pending")
+	RedemptionNotTimeout = errors.New("redemption is not timeout yet")
+
 	SlotCCInfo          string = strings.Repeat(string([]byte{0}), 32)
 	SlotBCHAlreadyBurnt string = strings.Repeat(string([]byte{0}), 31) + string([]byte{1})
 )
@@ -92,8 +127,16 @@ func (s *CcContractExecutor) Execute(ctx *mevmtypes.Context, currBlock *mevmtype
 	switch selector {
 	case SelectorTransferBchToMainnet:
 		return transferBchToMainnet(ctx, tx)
+	case SelectorTransferBchToMainnetAddress:
+		return transferBchToMainnetAddress(ctx, tx)
+	case SelectorRefundRedemption:
+		return refundRedemption(ctx, tx)
 	case SelectorBurnBCH:
 		return burnBch(ctx, tx)
+	case SelectorSubmitHeaders:
+		return submitHeaders(ctx, currBlock, tx)
+	case SelectorSubmitDeposit:
+		return submitDeposit(ctx, currBlock, tx)
 	default:
 		status = StatusFailed
 		outData = []byte(InvalidSelector.Error())
@@ -122,7 +165,14 @@ func transferBchToMainnet(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status
 	// First argument: utxo
 	var utxo [36]byte
 	copy(utxo[:], callData[64:64+36])
+	// the redemption has no target, it is confirmed by whatever tx spending the utxo
+	status, logs, outData = redeemUTXO(ctx, tx, utxo, [21]byte{})
+	return
+}
 
+// consume the utxo and record a pending redemption paying to 'target'
+func redeemUTXO(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun, utxo [36]byte, target [21]byte) (status int, logs []mevmtypes.EvmLog, outData []byte) {
+	status = StatusFailed
 	value := uint256.NewInt(0).SetBytes32(tx.Value[:])
 	err := consumeUTXO(ctx, utxo, value)
 	if err != nil {
@@ -133,6 +183,7 @@ func transferBchToMainnet(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status
 	if status == StatusSuccess {
 		// emit event, convert BCH from 18bit to 8bit
 		logs = append(logs, buildTransferToMainnetEvmLog(utxo, tx.From, value))
+		addRedemption(ctx, tx, utxo, value, target)
 	}
 	return
 }
@@ -219,13 +270,17 @@ func LoadUTXO(ctx *mevmtypes.Context, utxo [36]byte) *uint256.Int {
 }
 
 func SaveUTXO(ctx *mevmtypes.Context, utxo [36]byte, amount *uint256.Int) {
+	oldAmount := LoadUTXO(ctx, utxo)
 	key := sha256.Sum256(utxo[:])
 	ctx.SetStorageAt(ccContractSequence, string(key[:]), amount.Bytes())
+	addToUTXOIndex(ctx, utxo, oldAmount, amount)
 }
 
 func deleteUTXO(ctx *mevmtypes.Context, utxo [36]byte) {
+	amount := LoadUTXO(ctx, utxo)
 	key := sha256.Sum256(utxo[:])
 	ctx.DeleteStorageAt(ccContractSequence, string(key[:]))
+	removeFromUTXOIndex(ctx, utxo, amount)
 }
 
 func LoadBchMainnetBurnt(ctx *mevmtypes.Context) *uint256.Int {
@@ -283,6 +338,10 @@ func transferBch(ctx *mevmtypes.Context, sender, receiver common.Address, value
 
 func SwitchCCEpoch(ctx *mevmtypes.Context, epoch *types.CCEpoch) {
 	ccInfo := LoadCCInfo(ctx)
+	if ccInfo.CurrEpochNum == 0 && ccInfo.GenesisMainnetBlockHeight == 0 && epoch.StartHeight > 0 {
+		// the first cc epoch starts right after the genesis height
+		ccInfo.GenesisMainnetBlockHeight = epoch.StartHeight - 1
+	}
 	//when open epoch speedup, staking epoch is in strict accordance with the mainnet height,
 	//but cc epoch which already switched in recent staking epoch can be fetch repeat,
 	//so filter these cc epochs here
@@ -291,26 +350,86 @@ func SwitchCCEpoch(ctx *mevmtypes.Context, epoch *types.CCEpoch) {
 		return
 	}
 	for _, info := range epoch.TransferInfos {
-		value := uint256.NewInt(0).SetUint64(info.Amount)
-		//convert BCH decimals from 8 to 18
-		value.Mul(value, uint256.NewInt(10e10))
-		fmt.Printf("switch epoch: info.pubkey:%v, info.amount:%d, info.utxo:%v\n",
-			info.SenderPubkey, info.Amount, info.UTXO)
-		SaveUTXO(ctx, info.UTXO, value)
-		var sender common.Address
-		sender.SetBytes(secp256k1.PubKey(info.SenderPubkey[:]).Address().Bytes())
-		err := transferBch(ctx, CCContractAddress, sender, value)
+		_, _, err := acceptDeposit(ctx, info)
 		if err != nil {
-			//log it, never in here if mainnet is honest
+			//log it, never in here if mainnet is honest, unless the deposit is submitted with spv
 			fmt.Println(err.Error())
 		}
 	}
+	for _, spend := range epoch.SpendInfos {
+		confirmRedemption(ctx, spend)
+	}
 	ccInfo.CurrEpochNum++
 	SaveCCInfo(ctx, ccInfo)
 	epoch.Number = ccInfo.CurrEpochNum
 	SaveCCEpoch(ctx, epoch.Number, epoch)
 }
 
+// credit the receiver of a deposit, or park it with a zero receiver if the sender cannot be determined.
+// A parked deposit is never credited on smartBCH: its coins stay in the cc contract until the operators refund
+// the UTXO to the depositor on mainnet, and then confirmRedemption releases it when the spend is seen
+func acceptDeposit(ctx *mevmtypes.Context, info *types.CCTransferInfo) (receiver common.Address, value *uint256.Int, err error) {
+	if isDepositSeen(ctx, info.UTXO) {
+		err = DepositAlreadySeen
+		return
+	}
+	markDepositSeen(ctx, info.UTXO)
+	value = uint256.NewInt(0).SetUint64(info.Amount)
+	//convert BCH decimals from 8 to 18
+	value.Mul(value, uint256.NewInt(1e10))
+	receiver, ok := GetCCReceiver(info)
+	if !ok {
+		//the coins stay in the cc contract until the sender is determined in other ways
+		SaveParkedUTXO(ctx, info.UTXO, value)
+		return
+	}
+	SaveUTXO(ctx, info.UTXO, value)
+	err = transferBch(ctx, CCContractAddress, receiver, value)
+	return
+}
+
+// GetCCReceiver returns the account credited by a deposit: the receiver specified by the depositor, or else the
+// smartBCH account of the sender pubkey, which is controlled by the same private key as the mainnet address
+func GetCCReceiver(info *types.CCTransferInfo) (receiver common.Address, ok bool) {
+	if info.Receiver != ([20]byte{}) {
+		return common.Address(info.Receiver), true
+	}
+	pubkey, err := crypto.DecompressPubkey(info.SenderPubkey[:])
+	if err != nil {
+		return
+	}
+	return crypto.PubkeyToAddress(*pubkey), true
+}
+
+// get a slot number to store the amount of a parked deposit
+func getSlotForParkedUTXO(utxo [36]byte) string {
+	var buf [37]byte
+	buf[0] = 'p'
+	copy(buf[1:], utxo[:])
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+// LoadParkedUTXO returns the amount of a deposit whose sender cannot be determined
+func LoadParkedUTXO(ctx *mevmtypes.Context, utxo [36]byte) *uint256.Int {
+	bz := ctx.GetStorageAt(ccContractSequence, getSlotForParkedUTXO(utxo))
+	return uint256.NewInt(0).SetBytes(bz)
+}
+
+func SaveParkedUTXO(ctx *mevmtypes.Context, utxo [36]byte, amount *uint256.Int) {
+	parkedAmount := loadAmountAt(ctx, SlotParkedAmount)
+	parkedAmount.Sub(parkedAmount, LoadParkedUTXO(ctx, utxo))
+	ctx.SetStorageAt(ccContractSequence, getSlotForParkedUTXO(utxo), amount.Bytes())
+	ctx.SetStorageAt(ccContractSequence, SlotParkedAmount, parkedAmount.Add(parkedAmount, amount).Bytes())
+}
+
+func deleteParkedUTXO(ctx *mevmtypes.Context, utxo [36]byte) {
+	parkedAmount := loadAmountAt(ctx, SlotParkedAmount)
+	parkedAmount.Sub(parkedAmount, LoadParkedUTXO(ctx, utxo))
+	ctx.DeleteStorageAt(ccContractSequence, getSlotForParkedUTXO(utxo))
+	ctx.SetStorageAt(ccContractSequence, SlotParkedAmount, parkedAmount.Bytes())
+}
+
 func LoadCCInfo(ctx *mevmtypes.Context) (info types.CCInfo) {
 	bz := ctx.GetStorageAt(ccContractSequence, SlotCCInfo)
 	if bz == nil {
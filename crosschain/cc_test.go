@@ -3,16 +3,27 @@ package crosschain_test
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartbch/moeingevm/ebp"
 	"github.com/smartbch/moeingevm/types"
 	"github.com/smartbch/smartbch/crosschain"
+	cctypes "github.com/smartbch/smartbch/crosschain/types"
 	"github.com/smartbch/smartbch/internal/testutils"
+	"github.com/smartbch/smartbch/param"
+	watchertypes "github.com/smartbch/smartbch/watcher/types"
 )
 
 func TestCC(t *testing.T) {
@@ -90,3 +101,431 @@ func TestCC(t *testing.T) {
 	require.Equal(t, txId2, logs[0].Topics[1])
 	require.Equal(t, binary.BigEndian.Uint32(vOutIndex[:]), binary.BigEndian.Uint32(logs[0].Topics[2].Bytes()[32-4:]))
 }
+
+func TestSwitchCCEpoch(t *testing.T) {
+	key, _ := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+	defer _app.Destroy()
+	ctx := _app.GetRunTxContext()
+	e := &crosschain.CcContractExecutor{}
+	e.Init(ctx)
+
+	acc := ctx.GetAccount(crosschain.CCContractAddress)
+	acc.UpdateBalance(uint256.NewInt(0).Mul(uint256.NewInt(1e18), uint256.NewInt(100)))
+	ctx.SetAccount(crosschain.CCContractAddress, acc)
+
+	privKey, _ := crypto.GenerateKey()
+	depositor := crypto.PubkeyToAddress(privKey.PublicKey)
+	receiver := common.Address{0xab, 0xcd}
+	info1 := &cctypes.CCTransferInfo{UTXO: [36]byte{0x01}, Amount: 100000000}
+	copy(info1.SenderPubkey[:], crypto.CompressPubkey(&privKey.PublicKey))
+	info2 := &cctypes.CCTransferInfo{UTXO: [36]byte{0x02}, Amount: 200000000, SenderPubkey: info1.SenderPubkey, Receiver: receiver}
+	info3 := &cctypes.CCTransferInfo{UTXO: [36]byte{0x03}, Amount: 300000000}
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{TransferInfos: []*cctypes.CCTransferInfo{info1, info2, info3}})
+
+	oneBCH := uint256.NewInt(1e18)
+	balance, _ := ctx.GetBalance(depositor)
+	require.Equal(t, oneBCH, balance)
+	require.Equal(t, oneBCH, crosschain.LoadUTXO(ctx, info1.UTXO))
+	balance, _ = ctx.GetBalance(receiver)
+	require.Equal(t, uint256.NewInt(0).Mul(oneBCH, uint256.NewInt(2)), balance)
+	require.Equal(t, uint256.NewInt(0).Mul(oneBCH, uint256.NewInt(2)), crosschain.LoadUTXO(ctx, info2.UTXO))
+
+	// the deposit without a sender is parked
+	require.True(t, crosschain.LoadUTXO(ctx, info3.UTXO).IsZero())
+	require.Equal(t, uint256.NewInt(0).Mul(oneBCH, uint256.NewInt(3)), crosschain.LoadParkedUTXO(ctx, info3.UTXO))
+	balance, _ = ctx.GetBalance(crosschain.CCContractAddress)
+	require.Equal(t, uint256.NewInt(0).Mul(oneBCH, uint256.NewInt(97)), balance)
+	require.Equal(t, int64(1), crosschain.LoadCCInfo(ctx).CurrEpochNum)
+}
+
+func mineBchHeader(prev chainhash.Hash, height int64, merkleRoot chainhash.Hash) *wire.BlockHeader {
+	return mineBchHeaderAt(prev, merkleRoot, 1600000000+600*(height+1))
+}
+
+func mineBchHeaderAt(prev chainhash.Hash, merkleRoot chainhash.Hash, timestamp int64) *wire.BlockHeader {
+	header := wire.NewBlockHeader(1, &prev, &merkleRoot, 0x207fffff, 0)
+	header.Timestamp = time.Unix(timestamp, 0)
+	target := blockchain.CompactToBig(header.Bits)
+	for {
+		hash := header.BlockHash()
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			return header
+		}
+		header.Nonce++
+	}
+}
+
+func serializeBchHeader(header *wire.BlockHeader) []byte {
+	var buf bytes.Buffer
+	_ = header.Serialize(&buf)
+	return buf.Bytes()
+}
+
+func TestSubmitDeposit(t *testing.T) {
+	key, sender := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+	defer _app.Destroy()
+	ctx := _app.GetRunTxContext()
+	e := &crosschain.CcContractExecutor{}
+	e.Init(ctx)
+
+	acc := ctx.GetAccount(crosschain.CCContractAddress)
+	acc.UpdateBalance(uint256.NewInt(0).Mul(uint256.NewInt(1e18), uint256.NewInt(100)))
+	ctx.SetAccount(crosschain.CCContractAddress, acc)
+
+	checkpoint := mineBchHeader(chainhash.Hash{}, 0, chainhash.Hash{})
+	oldSpvParams := crosschain.SpvParams
+	crosschain.SpvParams = crosschain.SpvParamSet{
+		CheckpointHeight:      0,
+		CheckpointHeader:      hex.EncodeToString(serializeBchHeader(checkpoint)),
+		DepositConfirmations:  2,
+		PowLimitBits:          0x207fffff,
+		AsertAnchorHeight:     0,
+		AsertAnchorBits:       0x207fffff,
+		AsertAnchorParentTime: 1600000000,
+		MaxTipAge:             3 * 3600,
+	}
+	defer func() { crosschain.SpvParams = oldSpvParams }()
+
+	// a deposit of 1 BCH signed by a P2PKH key
+	privKey, _ := crypto.GenerateKey()
+	depositor := crypto.PubkeyToAddress(privKey.PublicKey)
+	scriptSig, _ := txscript.NewScriptBuilder().AddData(bytes.Repeat([]byte{0x30}, 71)).
+		AddData(crypto.CompressPubkey(&privKey.PublicKey)).Script()
+	depositTx := wire.NewMsgTx(1)
+	depositTx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}}, SignatureScript: scriptSig})
+	shaGate, _ := hex.DecodeString(watchertypes.ShaGateAddress)
+	pkScript, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).AddData(shaGate).AddOp(txscript.OP_EQUAL).Script()
+	depositTx.AddTxOut(wire.NewTxOut(100000000, pkScript))
+	var rawTx bytes.Buffer
+	_ = depositTx.Serialize(&rawTx)
+	coinbaseTx := wire.NewMsgTx(1)
+	coinbaseTx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex}, SignatureScript: []byte{0x01, 0x01}})
+	coinbaseTx.AddTxOut(wire.NewTxOut(625000000, []byte{txscript.OP_TRUE}))
+	txid0, txid1 := coinbaseTx.TxHash(), depositTx.TxHash()
+	merkleRoot := chainhash.DoubleHashH(append(txid0[:], txid1[:]...))
+	proof := append([]byte{0, 0, 0, 1}, txid0[:]...)
+
+	header1 := mineBchHeader(checkpoint.BlockHash(), 1, merkleRoot)
+	blockTime := int64(1600000000 + 600*4)
+	run := func(data []byte) (int, []types.EvmLog, []byte) {
+		currBlock := &types.BlockInfo{Timestamp: blockTime}
+		status, logs, _, outData := e.Execute(ctx, currBlock, &types.TxToRun{BasicTx: types.BasicTx{From: sender, Data: data}})
+		return status, logs, outData
+	}
+	status, _, outData := run(crosschain.PackSubmitDeposit(serializeBchHeader(header1), proof, rawTx.Bytes()))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.NotEnoughConfirmations.Error(), string(outData))
+
+	// the difficulty must follow ASERT
+	badHeader := mineBchHeader(header1.BlockHash(), 2, chainhash.Hash{})
+	badHeader.Bits = 0x207ffffe
+	status, _, outData = run(crosschain.PackSubmitHeaders(serializeBchHeader(badHeader)))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.BadDifficulty.Error(), string(outData))
+	status, _, outData = run(crosschain.PackSubmitHeaders(serializeBchHeader(mineBchHeader(chainhash.Hash{0x01}, 2, chainhash.Hash{}))))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.UnknownPrevHeader.Error(), string(outData))
+
+	header2 := mineBchHeader(header1.BlockHash(), 2, chainhash.Hash{})
+	status, _, _ = run(crosschain.PackSubmitHeaders(serializeBchHeader(header2)))
+	require.Equal(t, crosschain.StatusSuccess, status)
+	tip, ok := crosschain.LoadSpvTip(ctx)
+	require.True(t, ok)
+	require.Equal(t, int64(2), tip.Height)
+
+	// the timestamp must be later than the median time past, and not too far in the future
+	status, _, outData = run(crosschain.PackSubmitHeaders(serializeBchHeader(
+		mineBchHeaderAt(header2.BlockHash(), chainhash.Hash{}, header1.Timestamp.Unix()))))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.InvalidTimestamp.Error(), string(outData))
+	status, _, outData = run(crosschain.PackSubmitHeaders(serializeBchHeader(
+		mineBchHeaderAt(header2.BlockHash(), chainhash.Hash{}, blockTime+2*3600+1))))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.InvalidTimestamp.Error(), string(outData))
+
+	// when the tip is stale, deposits are refused, and a chain forked from it can not jump to the current
+	// time, where ASERT would give it a much lower difficulty
+	staleTime := blockTime
+	blockTime += 30 * 24 * 3600
+	status, _, outData = run(crosschain.PackSubmitDeposit(serializeBchHeader(header1), proof, rawTx.Bytes()))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.SpvTipTooOld.Error(), string(outData))
+	futureHeader := mineBchHeaderAt(header2.BlockHash(), chainhash.Hash{}, blockTime)
+	status, _, outData = run(crosschain.PackSubmitHeaders(serializeBchHeader(futureHeader)))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.InvalidTimestamp.Error(), string(outData))
+	_, ok = crosschain.LoadBchHeader(ctx, futureHeader.BlockHash())
+	require.False(t, ok)
+	blockTime = staleTime
+
+	status, _, outData = run(crosschain.PackSubmitDeposit(serializeBchHeader(header1), append([]byte{0, 0, 0, 0}, txid0[:]...), rawTx.Bytes()))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.InvalidMerkleProof.Error(), string(outData))
+
+	status, logs, _ := run(crosschain.PackSubmitDeposit(serializeBchHeader(header1), proof, rawTx.Bytes()))
+	require.Equal(t, crosschain.StatusSuccess, status)
+	require.Len(t, logs, 1)
+	require.Equal(t, common.Hash(crosschain.HashOfEventDeposit), logs[0].Topics[0])
+	require.Equal(t, txid1.String(), hex.EncodeToString(logs[0].Topics[1][:]))
+	require.Equal(t, depositor.Bytes(), logs[0].Topics[3].Bytes()[12:])
+	oneBCH := uint256.NewInt(1e18)
+	balance, _ := ctx.GetBalance(depositor)
+	require.Equal(t, oneBCH, balance)
+
+	// the deposit is credited only once, even if the watcher reports it
+	status, _, outData = run(crosschain.PackSubmitDeposit(serializeBchHeader(header1), proof, rawTx.Bytes()))
+	require.Equal(t, crosschain.StatusFailed, status)
+	require.Equal(t, crosschain.DepositAlreadySeen.Error(), string(outData))
+	var utxo [36]byte
+	copy(utxo[:], logs[0].Topics[1][:])
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{TransferInfos: []*cctypes.CCTransferInfo{
+		{UTXO: utxo, Amount: 100000000, Receiver: depositor},
+	}})
+	balance, _ = ctx.GetBalance(depositor)
+	require.Equal(t, oneBCH, balance)
+}
+
+func TestUTXOIndex(t *testing.T) {
+	key, sender := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+	defer _app.Destroy()
+	ctx := _app.GetRunTxContext()
+	e := &crosschain.CcContractExecutor{}
+	e.Init(ctx)
+
+	utxos := make([][36]byte, 3)
+	for i := range utxos {
+		utxos[i][0] = byte(i + 1)
+		crosschain.SaveUTXO(ctx, utxos[i], uint256.NewInt(uint64(100*(i+1))))
+	}
+	// overwriting does not add an entry
+	crosschain.SaveUTXO(ctx, utxos[2], uint256.NewInt(300))
+	crosschain.SaveParkedUTXO(ctx, [36]byte{0x09}, uint256.NewInt(50))
+	status := crosschain.GetPegStatus(ctx)
+	require.Equal(t, int64(3), status.UTXOCount)
+	require.Equal(t, uint64(600), status.LockedAmount.Uint64())
+	require.Equal(t, uint64(50), status.ParkedAmount.Uint64())
+
+	// consume the first one, and the last one takes its position
+	tx := &types.TxToRun{
+		BasicTx: types.BasicTx{
+			From:  sender,
+			Value: uint256.NewInt(100).Bytes32(),
+			Data:  crosschain.PackTransferBCHToMainnet(utxos[0]),
+		},
+	}
+	s, _, _, _ := e.Execute(ctx, nil, tx)
+	require.Equal(t, crosschain.StatusSuccess, s)
+	infos, err := crosschain.GetUTXOInfos(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, utxos[2], infos[0].UTXO)
+	require.Equal(t, uint64(300), infos[0].Amount.Uint64())
+	require.Equal(t, utxos[1], infos[1].UTXO)
+	infos, _ = crosschain.GetUTXOInfos(ctx, 1, 10)
+	require.Len(t, infos, 1)
+	_, err = crosschain.GetUTXOInfos(ctx, 2, 10)
+	require.Equal(t, crosschain.UTXOOffsetOutOfRange, err)
+	_, err = crosschain.GetUTXOInfos(ctx, 1<<63, 10)
+	require.Equal(t, crosschain.UTXOOffsetOutOfRange, err)
+	require.Nil(t, crosschain.GetUTXOInfo(ctx, utxos[0]))
+	require.True(t, crosschain.GetUTXOInfo(ctx, [36]byte{0x09}).Parked)
+
+	// burn the last one
+	_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, sender, uint256.NewInt(1000))
+	tx = &types.TxToRun{BasicTx: types.BasicTx{From: sender, Data: crosschain.PackBurnBCH(utxos[1])}}
+	s, _, _, _ = e.Execute(ctx, nil, tx)
+	require.Equal(t, crosschain.StatusSuccess, s)
+	status = crosschain.GetPegStatus(ctx)
+	require.Equal(t, int64(1), status.UTXOCount)
+	require.Equal(t, uint64(300), status.LockedAmount.Uint64())
+	require.Equal(t, uint64(200), status.BurntAmount.Uint64())
+	require.Equal(t, uint64(100), status.CCContractBalance.Uint64())
+	require.Equal(t, utxos[2], crosschain.GetUTXOAt(ctx, 0))
+
+	// the last one is spent on mainnet without a redemption, which is recorded as a deficit
+	// and the parked one is refunded on mainnet, which releases it
+	spends := []*cctypes.CCSpendInfo{
+		{UTXO: utxos[2], MainnetTxId: [32]byte{0xa1}},
+		{UTXO: [36]byte{0x09}, MainnetTxId: [32]byte{0xa2}},
+	}
+	epochNum := crosschain.LoadCCInfo(ctx).CurrEpochNum
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{StartHeight: epochNum * param.BlocksInCCEpoch, SpendInfos: spends})
+	_, ok := crosschain.LoadRedemption(ctx, utxos[2])
+	require.False(t, ok)
+	require.Nil(t, crosschain.GetUTXOInfo(ctx, utxos[2]))
+	status = crosschain.GetPegStatus(ctx)
+	require.Equal(t, int64(0), status.UTXOCount)
+	require.Equal(t, uint64(0), status.LockedAmount.Uint64())
+	require.Equal(t, uint64(300), status.DeficitAmount.Uint64())
+	require.Nil(t, crosschain.GetUTXOInfo(ctx, [36]byte{0x09}))
+	require.Equal(t, uint64(0), status.ParkedAmount.Uint64())
+	require.Equal(t, uint64(100), status.CCContractBalance.Uint64())
+}
+
+func TestCCEpochGenesis(t *testing.T) {
+	key, _ := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+	defer _app.Destroy()
+	ctx := _app.GetRunTxContext()
+	e := &crosschain.CcContractExecutor{}
+	e.Init(ctx)
+
+	// the genesis height is recorded by the first cc epoch
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{StartHeight: 101})
+	ccInfo := crosschain.LoadCCInfo(ctx)
+	require.Equal(t, int64(100), ccInfo.GenesisMainnetBlockHeight)
+	require.Equal(t, int64(1), ccInfo.CurrEpochNum)
+
+	// a switched epoch is skipped
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{StartHeight: 101})
+	require.Equal(t, int64(1), crosschain.LoadCCInfo(ctx).CurrEpochNum)
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{StartHeight: 101 + param.BlocksInCCEpoch})
+	ccInfo = crosschain.LoadCCInfo(ctx)
+	require.Equal(t, int64(100), ccInfo.GenesisMainnetBlockHeight)
+	require.Equal(t, int64(2), ccInfo.CurrEpochNum)
+}
+
+func TestCashAddr(t *testing.T) {
+	var target [21]byte
+	hash, _ := hex.DecodeString("76a04053bda0a88bda5177b86a15c3b29f559873")
+	copy(target[1:], hash)
+	addr := crosschain.EncodeCashAddr(target)
+	if param.CCCashAddrPrefix == "bitcoincash" {
+		require.Equal(t, "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", addr)
+	}
+	decoded, err := crosschain.DecodeCashAddr(addr)
+	require.NoError(t, err)
+	require.Equal(t, target, decoded)
+	decoded, err = crosschain.DecodeCashAddr(strings.ToUpper(addr[len(param.CCCashAddrPrefix)+1:]))
+	require.NoError(t, err)
+	require.Equal(t, target, decoded)
+
+	target[0] = 1
+	addr = crosschain.EncodeCashAddr(target)
+	if param.CCCashAddrPrefix == "bitcoincash" {
+		require.Equal(t, "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq", addr)
+	}
+	decoded, err = crosschain.DecodeCashAddr(addr)
+	require.NoError(t, err)
+	require.Equal(t, target, decoded)
+
+	// mixed case, bad checksum and wrong prefix
+	_, err = crosschain.DecodeCashAddr(addr[:len(addr)-1] + strings.ToUpper(addr[len(addr)-1:]))
+	require.Equal(t, crosschain.InvalidCashAddr, err)
+	_, err = crosschain.DecodeCashAddr(strings.Replace(addr, ":p", ":q", 1))
+	require.Equal(t, crosschain.InvalidCashAddr, err)
+	_, err = crosschain.DecodeCashAddr("bchreg" + addr[len(param.CCCashAddrPrefix):])
+	require.Equal(t, crosschain.InvalidCashAddr, err)
+}
+
+func TestRedemption(t *testing.T) {
+	key, sender := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+	defer _app.Destroy()
+	ctx := _app.GetRunTxContext()
+	e := &crosschain.CcContractExecutor{}
+	e.Init(ctx)
+
+	utxos := make([][36]byte, 4)
+	for i := range utxos {
+		utxos[i][0] = byte(i + 1)
+		crosschain.SaveUTXO(ctx, utxos[i], uint256.NewInt(uint64(100*(i+1))))
+	}
+	target := [21]byte{0, 0x11}
+	cashAddr := crosschain.EncodeCashAddr(target)
+	redeem := func(hashID common.Hash, utxo [36]byte, data []byte) (int, []byte) {
+		tx := &types.TxToRun{
+			BasicTx: types.BasicTx{
+				From:  sender,
+				Value: crosschain.LoadUTXO(ctx, utxo).Bytes32(),
+				Data:  data,
+			},
+			HashID: hashID,
+		}
+		s, _, _, out := e.Execute(ctx, nil, tx)
+		return s, out
+	}
+	s, out := redeem(common.Hash{0x01}, utxos[0], crosschain.PackTransferBCHToMainnetAddress(utxos[0], "bchreg:qq"))
+	require.Equal(t, crosschain.StatusFailed, s)
+	require.Equal(t, crosschain.InvalidCashAddr.Error(), string(out))
+	s, _ = redeem(common.Hash{0x01}, utxos[0], crosschain.PackTransferBCHToMainnetAddress(utxos[0], cashAddr))
+	require.Equal(t, crosschain.StatusSuccess, s)
+	s, _ = redeem(common.Hash{0x02}, utxos[1], crosschain.PackTransferBCHToMainnet(utxos[1]))
+	require.Equal(t, crosschain.StatusSuccess, s)
+	s, _ = redeem(common.Hash{0x03}, utxos[2], crosschain.PackTransferBCHToMainnetAddress(utxos[2], cashAddr))
+	require.Equal(t, crosschain.StatusSuccess, s)
+	s, _ = redeem(common.Hash{0x03}, utxos[3], crosschain.PackTransferBCHToMainnetAddress(utxos[3], cashAddr))
+	require.Equal(t, crosschain.StatusSuccess, s)
+
+	rs := crosschain.GetRedemptionsByTx(ctx, common.Hash{0x01})
+	require.Len(t, rs, 1)
+	require.Equal(t, utxos[0], rs[0].UTXO)
+	require.Equal(t, [20]byte(sender), rs[0].Redeemer)
+	require.Equal(t, target, rs[0].Target)
+	require.Equal(t, cctypes.RedemptionPending, rs[0].Status)
+	require.Len(t, crosschain.GetRedemptionsByTx(ctx, common.Hash{0x03}), 2)
+
+	// the first one is paid to the target, the second one has no target, the third one is paid elsewhere
+	payouts := []*cctypes.CCPayout{{Target: target, Amount: 90}}
+	spends := []*cctypes.CCSpendInfo{
+		{UTXO: utxos[0], MainnetTxId: [32]byte{0xa1}, Payouts: payouts},
+		{UTXO: utxos[1], MainnetTxId: [32]byte{0xa1}, Payouts: payouts},
+		{UTXO: utxos[2], MainnetTxId: [32]byte{0xa2}, Payouts: []*cctypes.CCPayout{{Target: [21]byte{1}, Amount: 290}}},
+	}
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{SpendInfos: spends})
+	r, _ := crosschain.LoadRedemption(ctx, utxos[0])
+	require.Equal(t, cctypes.RedemptionConfirmed, r.Status)
+	require.Equal(t, [32]byte{0xa1}, r.MainnetTxId)
+	require.Equal(t, uint64(90), r.PaidAmount)
+	r, _ = crosschain.LoadRedemption(ctx, utxos[1])
+	require.Equal(t, cctypes.RedemptionConfirmed, r.Status)
+	r, _ = crosschain.LoadRedemption(ctx, utxos[2])
+	require.Equal(t, cctypes.RedemptionMispaid, r.Status)
+
+	// the fourth one is refunded after the cc epochs cover the timeout, no matter how many smartBCH blocks passed
+	refund := func() (int, []byte) {
+		tx := &types.TxToRun{BasicTx: types.BasicTx{From: sender, Data: crosschain.PackRefundRedemption(utxos[3])}}
+		s, _, _, out := e.Execute(ctx, nil, tx)
+		return s, out
+	}
+	switchEmptyCCEpoch := func() {
+		epochNum := crosschain.LoadCCInfo(ctx).CurrEpochNum
+		crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{StartHeight: epochNum * param.BlocksInCCEpoch})
+	}
+	balance0, _ := ctx.GetBalance(sender)
+	ctx.SetCurrentHeight(ctx.Height + 100000)
+	s, out = refund()
+	require.Equal(t, crosschain.StatusFailed, s)
+	require.Equal(t, crosschain.RedemptionNotTimeout.Error(), string(out))
+	for (crosschain.LoadCCInfo(ctx).CurrEpochNum+1)*param.BlocksInCCEpoch < param.CCRedemptionTimeoutMainnetBlocks {
+		switchEmptyCCEpoch()
+	}
+	s, out = refund()
+	require.Equal(t, crosschain.StatusFailed, s)
+	require.Equal(t, crosschain.RedemptionNotTimeout.Error(), string(out))
+	switchEmptyCCEpoch()
+	s, _ = refund()
+	require.Equal(t, crosschain.StatusSuccess, s)
+	balance1, _ := ctx.GetBalance(sender)
+	require.Equal(t, uint64(400), balance1.Uint64()-balance0.Uint64())
+	require.Equal(t, uint64(400), crosschain.LoadUTXO(ctx, utxos[3]).Uint64())
+	s, out = refund()
+	require.Equal(t, crosschain.StatusFailed, s)
+	require.Equal(t, crosschain.RedemptionNotPending.Error(), string(out))
+	lockedAmount := crosschain.GetPegStatus(ctx).LockedAmount.Uint64()
+
+	// the refunded utxo is spent on mainnet after all, which is recorded as a deficit
+	spends = []*cctypes.CCSpendInfo{{UTXO: utxos[3], MainnetTxId: [32]byte{0xa3}}}
+	epochNum := crosschain.LoadCCInfo(ctx).CurrEpochNum
+	crosschain.SwitchCCEpoch(ctx, &cctypes.CCEpoch{StartHeight: epochNum * param.BlocksInCCEpoch, SpendInfos: spends})
+	r, _ = crosschain.LoadRedemption(ctx, utxos[3])
+	require.Equal(t, cctypes.RedemptionLateSpent, r.Status)
+	require.Equal(t, [32]byte{0xa3}, r.MainnetTxId)
+	require.True(t, crosschain.LoadUTXO(ctx, utxos[3]).IsZero())
+	status := crosschain.GetPegStatus(ctx)
+	require.Equal(t, uint64(400), status.DeficitAmount.Uint64())
+	require.Equal(t, lockedAmount-400, status.LockedAmount.Uint64())
+}
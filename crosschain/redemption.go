@@ -0,0 +1,265 @@
+package crosschain
+
+import (
+	"crypto/sha256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/crosschain/types"
+	"github.com/smartbch/smartbch/param"
+)
+
+// A redemption is recorded when a UTXO is transferred back to mainnet. It is confirmed by the cc epochs when
+// the watchers see the UTXO spent on mainnet, and if it is still pending after the cc epochs switched later
+// cover CCRedemptionTimeoutMainnetBlocks, anyone can refund its coins to the redeemer on smartBCH, which
+// restores the UTXO. The timeout is measured with mainnet blocks instead of smartBCH blocks, such that a
+// stalled watcher does not make the redemptions refundable while they are being spent on mainnet. If the UTXO
+// is spent on mainnet after the refund anyway, its amount is recorded as a deficit of the peg.
+
+func getSlotForRedemption(utxo [36]byte) string {
+	var buf [37]byte
+	buf[0] = 'r'
+	copy(buf[1:], utxo[:])
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func getSlotForTxRedemptions(txHash [32]byte) string {
+	var buf [33]byte
+	buf[0] = 't'
+	copy(buf[1:], txHash[:])
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func LoadRedemption(ctx *mevmtypes.Context, utxo [36]byte) (r types.Redemption, ok bool) {
+	bz := ctx.GetStorageAt(ccContractSequence, getSlotForRedemption(utxo))
+	if bz == nil {
+		return
+	}
+	_, err := r.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	ok = true
+	return
+}
+
+func SaveRedemption(ctx *mevmtypes.Context, r *types.Redemption) {
+	bz, err := r.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(ccContractSequence, getSlotForRedemption(r.UTXO), bz)
+}
+
+func loadTxRedemptions(ctx *mevmtypes.Context, txHash [32]byte) (list types.TxRedemptions) {
+	bz := ctx.GetStorageAt(ccContractSequence, getSlotForTxRedemptions(txHash))
+	if bz == nil {
+		return
+	}
+	_, err := list.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveTxRedemptions(ctx *mevmtypes.Context, txHash [32]byte, list *types.TxRedemptions) {
+	bz, err := list.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(ccContractSequence, getSlotForTxRedemptions(txHash), bz)
+}
+
+// GetRedemptionsByTx returns the redemptions requested by the smartBCH tx 'txHash'
+func GetRedemptionsByTx(ctx *mevmtypes.Context, txHash [32]byte) []*types.Redemption {
+	list := loadTxRedemptions(ctx, txHash)
+	result := make([]*types.Redemption, 0, len(list.UTXOs))
+	for _, utxo := range list.UTXOs {
+		r, ok := LoadRedemption(ctx, utxo)
+		if ok && r.TxHash == txHash {
+			result = append(result, &r)
+		}
+	}
+	return result
+}
+
+func addRedemption(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun, utxo [36]byte, amount *uint256.Int, target [21]byte) {
+	SaveRedemption(ctx, &types.Redemption{
+		UTXO:          utxo,
+		TxHash:        tx.HashID,
+		Redeemer:      tx.From,
+		Amount:        amount.Bytes32(),
+		Target:        target,
+		Status:        types.RedemptionPending,
+		CreatedHeight: ctx.Height,
+
+		CreatedCCEpochNum: LoadCCInfo(ctx).CurrEpochNum,
+	})
+	list := loadTxRedemptions(ctx, tx.HashID)
+	list.UTXOs = append(list.UTXOs, utxo)
+	saveTxRedemptions(ctx, tx.HashID, &list)
+}
+
+// function transferBCHToMainnetAddress(bytes utxo, string cashAddr) external;
+func transferBchToMainnetAddress(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfCCOp
+	values, err := ABI.GetABI().Methods["transferBCHToMainnetAddress"].Inputs.Unpack(tx.Data[4:])
+	if err != nil || len(values) != 2 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	utxoBz, ok1 := values[0].([]byte)
+	cashAddr, ok2 := values[1].(string)
+	if !ok1 || !ok2 || len(utxoBz) != 36 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	target, err := DecodeCashAddr(cashAddr)
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	var utxo [36]byte
+	copy(utxo[:], utxoBz)
+	status, logs, outData = redeemUTXO(ctx, tx, utxo, target)
+	if status == StatusSuccess {
+		logs = append(logs, buildRedemptionRequestedEvmLog(utxo, tx.From, cashAddr))
+	}
+	return
+}
+
+// function refundRedemption(bytes utxo) external;
+func refundRedemption(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfCCOp
+	callData := tx.Data[4:]
+	if len(callData) < 32+32+64 /*[36]byte*/ {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var utxo [36]byte
+	copy(utxo[:], callData[64:64+36])
+
+	r, ok := LoadRedemption(ctx, utxo)
+	if !ok {
+		outData = []byte(RedemptionNotFound.Error())
+		return
+	}
+	if r.Status != types.RedemptionPending {
+		outData = []byte(RedemptionNotPending.Error())
+		return
+	}
+	if (LoadCCInfo(ctx).CurrEpochNum-r.CreatedCCEpochNum)*param.BlocksInCCEpoch < param.CCRedemptionTimeoutMainnetBlocks {
+		outData = []byte(RedemptionNotTimeout.Error())
+		return
+	}
+	amount := uint256.NewInt(0).SetBytes32(r.Amount[:])
+	err := transferBch(ctx, CCContractAddress, r.Redeemer, amount)
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	// the UTXO is not spent on mainnet, so it can be redeemed again
+	SaveUTXO(ctx, utxo, amount)
+	r.Status = types.RedemptionRefunded
+	SaveRedemption(ctx, &r)
+	logs = append(logs, buildRedemptionRefundedEvmLog(utxo, r.Redeemer, amount))
+	status = StatusSuccess
+	return
+}
+
+func buildRedemptionRequestedEvmLog(utxo [36]byte, from common.Address, cashAddr string) mevmtypes.EvmLog {
+	evmLog := mevmtypes.EvmLog{
+		Address: CCContractAddress,
+		Topics:  make([]common.Hash, 0, 4),
+	}
+
+	evmLog.Topics = append(evmLog.Topics, HashOfEventRedemptionRequested)
+	txId := common.Hash{}
+	txId.SetBytes(utxo[:32])
+	evmLog.Topics = append(evmLog.Topics, txId)
+	vOutIndex := common.Hash{}
+	vOutIndex.SetBytes(utxo[32:])
+	evmLog.Topics = append(evmLog.Topics, vOutIndex)
+	evmLog.Topics = append(evmLog.Topics, common.BytesToHash(from[:]))
+
+	evmLog.Data, _ = ABI.GetABI().Events["RedemptionRequested"].Inputs.NonIndexed().Pack(cashAddr)
+	return evmLog
+}
+
+func buildRedemptionRefundedEvmLog(utxo [36]byte, redeemer common.Address, value *uint256.Int) mevmtypes.EvmLog {
+	evmLog := mevmtypes.EvmLog{
+		Address: CCContractAddress,
+		Topics:  make([]common.Hash, 0, 4),
+	}
+
+	evmLog.Topics = append(evmLog.Topics, HashOfEventRedemptionRefunded)
+	txId := common.Hash{}
+	txId.SetBytes(utxo[:32])
+	evmLog.Topics = append(evmLog.Topics, txId)
+	vOutIndex := common.Hash{}
+	vOutIndex.SetBytes(utxo[32:])
+	evmLog.Topics = append(evmLog.Topics, vOutIndex)
+	evmLog.Topics = append(evmLog.Topics, common.BytesToHash(redeemer[:]))
+
+	data := value.Bytes32()
+	evmLog.Data = append(evmLog.Data, data[:]...)
+	return evmLog
+}
+
+// =========================================================================================
+// Following functions are called by the engine
+
+// confirm the redemption of the UTXO spent by a mainnet tx, the redemptions without targets are confirmed
+// by any spending tx. An indexed UTXO spent without a redemption is not backed any more, so it is recorded as
+// a deficit, while a parked one is refunded to its depositor on mainnet, so it is released
+func confirmRedemption(ctx *mevmtypes.Context, spend *types.CCSpendInfo) {
+	r, ok := LoadRedemption(ctx, spend.UTXO)
+	if !ok {
+		if getUTXOPosition(ctx, spend.UTXO) != 0 {
+			addDeficitOfUTXO(ctx, spend.UTXO)
+		} else if !LoadParkedUTXO(ctx, spend.UTXO).IsZero() {
+			deleteParkedUTXO(ctx, spend.UTXO)
+		}
+		return
+	}
+	if r.MainnetTxId != ([32]byte{}) {
+		return
+	}
+	r.MainnetTxId = spend.MainnetTxId
+	switch r.Status {
+	case types.RedemptionPending:
+		r.Status = types.RedemptionMispaid
+		if r.Target == ([21]byte{}) {
+			r.Status = types.RedemptionConfirmed
+			break
+		}
+		for _, payout := range spend.Payouts {
+			if payout.Target == r.Target {
+				r.Status = types.RedemptionConfirmed
+				r.PaidAmount = payout.Amount
+				break
+			}
+		}
+	case types.RedemptionRefunded:
+		// the UTXO restored by the refund is spent on mainnet after all, so the refunded coins are not backed
+		r.Status = types.RedemptionLateSpent
+		addDeficitOfUTXO(ctx, spend.UTXO)
+	}
+	SaveRedemption(ctx, &r)
+}
+
+// delete a UTXO which is spent on mainnet while its coins are still on smartBCH, and add its amount to the deficit
+func addDeficitOfUTXO(ctx *mevmtypes.Context, utxo [36]byte) {
+	deficit := loadAmountAt(ctx, SlotDeficitAmount)
+	deficit.Add(deficit, LoadUTXO(ctx, utxo))
+	ctx.SetStorageAt(ccContractSequence, SlotDeficitAmount, deficit.Bytes())
+	deleteUTXO(ctx, utxo)
+}
@@ -0,0 +1,472 @@
+package crosschain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/crosschain/types"
+	"github.com/smartbch/smartbch/param"
+	watchertypes "github.com/smartbch/smartbch/watcher/types"
+)
+
+// Besides the deposits in the cc epochs provided by the watchers, the relayers can bring deposits in with
+// submitDeposit, which are verified with SPV. The BCH header chain is tracked since a checkpoint, the
+// difficulty of each header is checked against ASERT, and the chain with the most cumulative work is the best
+// one. A deposit is accepted if its block is on the best chain with enough confirmations, and the tip of the
+// best chain is recent. Either way, a UTXO is only credited once.
+//
+// ASERT lowers the difficulty as the timestamps go ahead of the schedule, so the timestamps are checked like
+// BCHN: a header must be later than the median time of its previous 11 headers, and must not be more than
+// 2 hours later than the smartBCH block. Besides, a header must not be later than its parent by more than
+// MaxTipAge, otherwise a chain forked from a stale tip could jump to the current time at a much lower
+// difficulty. The deposits are not accepted until the tip is recent again.
+
+const (
+	bchHeaderLen        = 80
+	asertIdealBlockTime = 600
+	asertHalfLife       = 2 * 24 * 3600
+	medianTimeBlocks    = 11
+	maxFutureBlockTime  = 2 * 3600
+)
+
+// SpvParamSet contains the spv params, the checkpoint header is serialized in hex, and spv is disabled if it's empty
+type SpvParamSet struct {
+	CheckpointHeight            int64
+	CheckpointHeader            string
+	DepositConfirmations        int64
+	PowLimitBits                uint32
+	PowAllowMinDifficultyBlocks bool
+	AsertAnchorHeight           int64
+	AsertAnchorBits             uint32
+	AsertAnchorParentTime       int64
+	MaxTipAge                   int64
+}
+
+var (
+	SpvParams = SpvParamSet{
+		CheckpointHeight:            param.CCSpvCheckpointHeight,
+		CheckpointHeader:            param.CCSpvCheckpointHeader,
+		DepositConfirmations:        param.CCDepositConfirmations,
+		PowLimitBits:                param.CCPowLimitBits,
+		PowAllowMinDifficultyBlocks: param.CCPowAllowMinDifficultyBlocks,
+		AsertAnchorHeight:           param.CCAsertAnchorHeight,
+		AsertAnchorBits:             param.CCAsertAnchorBits,
+		AsertAnchorParentTime:       param.CCAsertAnchorParentTime,
+		MaxTipAge:                   param.CCSpvMaxTipAge,
+	}
+
+	SlotSpvTip string = strings.Repeat(string([]byte{0}), 31) + string([]byte{2})
+
+	shaGatePkScript = buildShaGatePkScript()
+)
+
+func buildShaGatePkScript() []byte {
+	scriptHash, _ := hex.DecodeString(watchertypes.ShaGateAddress)
+	script, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).AddData(scriptHash).AddOp(txscript.OP_EQUAL).Script()
+	return script
+}
+
+func getSlotForBchHeader(hash [32]byte) string {
+	var buf [33]byte
+	buf[0] = 'h'
+	copy(buf[1:], hash[:])
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func getSlotForBestChain(height int64) string {
+	var buf [9]byte
+	buf[0] = 'm'
+	binary.BigEndian.PutUint64(buf[1:], uint64(height))
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func getSlotForSeenDeposit(utxo [36]byte) string {
+	var buf [37]byte
+	buf[0] = 'd'
+	copy(buf[1:], utxo[:])
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func LoadBchHeader(ctx *mevmtypes.Context, hash [32]byte) (header types.BchHeader, ok bool) {
+	bz := ctx.GetStorageAt(ccContractSequence, getSlotForBchHeader(hash))
+	if bz == nil {
+		return
+	}
+	_, err := header.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	ok = true
+	return
+}
+
+func SaveBchHeader(ctx *mevmtypes.Context, header *types.BchHeader) {
+	bz, err := header.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(ccContractSequence, getSlotForBchHeader(header.Hash), bz)
+}
+
+// GetBestChainHash returns the hash of the header at 'height' on the best chain
+func GetBestChainHash(ctx *mevmtypes.Context, height int64) (hash [32]byte, ok bool) {
+	bz := ctx.GetStorageAt(ccContractSequence, getSlotForBestChain(height))
+	if len(bz) != 32 {
+		return
+	}
+	copy(hash[:], bz)
+	ok = true
+	return
+}
+
+// LoadSpvTip returns the tip of the best chain, and ok is false if no header is tracked yet
+func LoadSpvTip(ctx *mevmtypes.Context) (tip types.BchHeader, ok bool) {
+	bz := ctx.GetStorageAt(ccContractSequence, SlotSpvTip)
+	if len(bz) != 32 {
+		return
+	}
+	var hash [32]byte
+	copy(hash[:], bz)
+	return LoadBchHeader(ctx, hash)
+}
+
+func isDepositSeen(ctx *mevmtypes.Context, utxo [36]byte) bool {
+	return len(ctx.GetStorageAt(ccContractSequence, getSlotForSeenDeposit(utxo))) != 0
+}
+
+func markDepositSeen(ctx *mevmtypes.Context, utxo [36]byte) {
+	ctx.SetStorageAt(ccContractSequence, getSlotForSeenDeposit(utxo), []byte{1})
+}
+
+// load the tip of the best chain, the checkpoint is tracked as the first header if there is no tip
+func loadOrInitSpvTip(ctx *mevmtypes.Context) (types.BchHeader, error) {
+	tip, ok := LoadSpvTip(ctx)
+	if ok {
+		return tip, nil
+	}
+	if SpvParams.CheckpointHeader == "" {
+		return tip, SpvNotEnabled
+	}
+	bz, err := hex.DecodeString(SpvParams.CheckpointHeader)
+	if err != nil || len(bz) != bchHeaderLen {
+		return tip, InvalidHeader
+	}
+	var h wire.BlockHeader
+	if err = h.Deserialize(bytes.NewReader(bz)); err != nil {
+		return tip, InvalidHeader
+	}
+	tip = types.BchHeader{
+		Hash:      h.BlockHash(),
+		PrevHash:  h.PrevBlock,
+		Height:    SpvParams.CheckpointHeight,
+		Timestamp: h.Timestamp.Unix(),
+		Bits:      h.Bits,
+	}
+	SaveBchHeader(ctx, &tip)
+	switchBestChain(ctx, &tip)
+	return tip, nil
+}
+
+// the median timestamp of 'header' and its ancestors, at most medianTimeBlocks ones back to the checkpoint
+func medianTimePast(ctx *mevmtypes.Context, header types.BchHeader) int64 {
+	timestamps := make([]int64, 1, medianTimeBlocks)
+	timestamps[0] = header.Timestamp
+	for len(timestamps) < medianTimeBlocks {
+		var ok bool
+		if header, ok = LoadBchHeader(ctx, header.PrevHash); !ok {
+			break
+		}
+		timestamps = append(timestamps, header.Timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	return timestamps[len(timestamps)/2]
+}
+
+// add a serialized header to the tracked chain, whose parent must have been tracked, 'blockTime' is the
+// timestamp of current smartBCH block
+func addBchHeader(ctx *mevmtypes.Context, bz []byte, blockTime int64) (*types.BchHeader, error) {
+	tip, err := loadOrInitSpvTip(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var h wire.BlockHeader
+	if len(bz) != bchHeaderLen || h.Deserialize(bytes.NewReader(bz)) != nil {
+		return nil, InvalidHeader
+	}
+	hash := h.BlockHash()
+	if header, ok := LoadBchHeader(ctx, hash); ok {
+		return &header, nil
+	}
+	parent, ok := LoadBchHeader(ctx, h.PrevBlock)
+	if !ok {
+		return nil, UnknownPrevHeader
+	}
+	timestamp := h.Timestamp.Unix()
+	if timestamp <= medianTimePast(ctx, parent) || timestamp > blockTime+maxFutureBlockTime ||
+		timestamp > parent.Timestamp+SpvParams.MaxTipAge {
+		return nil, InvalidTimestamp
+	}
+	if parent.Height < SpvParams.AsertAnchorHeight || h.Bits != nextBchBits(&parent, timestamp) {
+		return nil, BadDifficulty
+	}
+	target := blockchain.CompactToBig(h.Bits)
+	if target.Sign() <= 0 || blockchain.HashToBig(&hash).Cmp(target) > 0 {
+		return nil, InsufficientPow
+	}
+	chainWork := uint256.NewInt(0).SetBytes32(parent.ChainWork[:])
+	work, _ := uint256.FromBig(blockchain.CalcWork(h.Bits))
+	chainWork.Add(chainWork, work)
+	header := &types.BchHeader{
+		Hash:      hash,
+		PrevHash:  h.PrevBlock,
+		Height:    parent.Height + 1,
+		Timestamp: timestamp,
+		Bits:      h.Bits,
+		ChainWork: chainWork.Bytes32(),
+	}
+	SaveBchHeader(ctx, header)
+	if chainWork.Gt(uint256.NewInt(0).SetBytes32(tip.ChainWork[:])) {
+		switchBestChain(ctx, header)
+	}
+	return header, nil
+}
+
+// make 'tip' the tip of the best chain, and update the best chain index back to the fork point
+func switchBestChain(ctx *mevmtypes.Context, tip *types.BchHeader) {
+	ctx.SetStorageAt(ccContractSequence, SlotSpvTip, append([]byte{}, tip.Hash[:]...))
+	header := *tip
+	for {
+		hash, ok := GetBestChainHash(ctx, header.Height)
+		if ok && hash == header.Hash {
+			break
+		}
+		ctx.SetStorageAt(ccContractSequence, getSlotForBestChain(header.Height), append([]byte{}, header.Hash[:]...))
+		header, ok = LoadBchHeader(ctx, header.PrevHash)
+		if !ok { // the checkpoint
+			break
+		}
+	}
+}
+
+// the bits of the header following 'parent', calculated by the aserti3-2d algorithm of BCHN
+func nextBchBits(parent *types.BchHeader, timestamp int64) uint32 {
+	if SpvParams.PowAllowMinDifficultyBlocks && timestamp > parent.Timestamp+2*asertIdealBlockTime {
+		return SpvParams.PowLimitBits
+	}
+	timeDiff := parent.Timestamp - SpvParams.AsertAnchorParentTime
+	heightDiff := parent.Height - SpvParams.AsertAnchorHeight
+	// the division is truncating, like C++
+	exponent := ((timeDiff - asertIdealBlockTime*(heightDiff+1)) * 65536) / asertHalfLife
+	shifts := exponent >> 16
+	frac := uint64(exponent - shifts*65536)
+	factor := 65536 + ((195766423245049*frac + 971821376*frac*frac + 5127*frac*frac*frac + (1 << 47)) >> 48)
+	target := big.NewInt(0).Mul(blockchain.CompactToBig(SpvParams.AsertAnchorBits), big.NewInt(0).SetUint64(factor))
+	shifts -= 16
+	if shifts < 0 {
+		target.Rsh(target, uint(-shifts))
+	} else {
+		target.Lsh(target, uint(shifts))
+	}
+	if target.Sign() == 0 {
+		target.SetInt64(1)
+	}
+	powLimit := blockchain.CompactToBig(SpvParams.PowLimitBits)
+	if target.Cmp(powLimit) > 0 {
+		target = powLimit
+	}
+	return blockchain.BigToCompact(target)
+}
+
+// the proof is a 4-byte big-endian index of the tx in the block, followed by the sibling hashes from the leaf
+func verifyMerkleProof(txid chainhash.Hash, proof []byte, merkleRoot chainhash.Hash) bool {
+	if len(proof) < 4 || (len(proof)-4)%32 != 0 {
+		return false
+	}
+	index := binary.BigEndian.Uint32(proof[:4])
+	hash := txid
+	var buf [64]byte
+	for sibling := proof[4:]; len(sibling) != 0; sibling = sibling[32:] {
+		if index&1 == 0 {
+			copy(buf[:32], hash[:])
+			copy(buf[32:], sibling[:32])
+		} else {
+			copy(buf[:32], sibling[:32])
+			copy(buf[32:], hash[:])
+		}
+		hash = chainhash.DoubleHashH(buf[:])
+		index >>= 1
+	}
+	return index == 0 && hash == merkleRoot
+}
+
+// the transfer infos of the outputs paying to the ShaGate address, whose txid is in the displayed byte order
+func getCCTransferInfosFromTx(tx *wire.MsgTx) (infos []*types.CCTransferInfo) {
+	txid := tx.TxHash()
+	var pubkey [33]byte
+	var receiver [20]byte
+	hasPubkey, hasReceiver := false, false
+	if len(tx.TxIn) != 0 {
+		pubkey, hasPubkey = watchertypes.GetPubkeyFromScriptSig(tx.TxIn[0].SignatureScript)
+	}
+	for _, out := range tx.TxOut {
+		if receiver, hasReceiver = watchertypes.GetCCReceiverFromPkScript(out.PkScript); hasReceiver {
+			break
+		}
+	}
+	for n, out := range tx.TxOut {
+		if !bytes.Equal(out.PkScript, shaGatePkScript) || out.Value <= 0 {
+			continue
+		}
+		info := &types.CCTransferInfo{Amount: uint64(out.Value)}
+		for i := 0; i < 32; i++ {
+			info.UTXO[i] = txid[31-i]
+		}
+		binary.BigEndian.PutUint32(info.UTXO[32:], uint32(n))
+		if hasPubkey {
+			info.SenderPubkey = pubkey
+		}
+		if hasReceiver {
+			info.Receiver = receiver
+		}
+		infos = append(infos, info)
+	}
+	return
+}
+
+func unpackBytesArgs(method string, callData []byte) ([][]byte, error) {
+	values, err := ABI.GetABI().Methods[method].Inputs.Unpack(callData)
+	if err != nil {
+		return nil, InvalidCallData
+	}
+	args := make([][]byte, len(values))
+	for i, value := range values {
+		bz, ok := value.([]byte)
+		if !ok {
+			return nil, InvalidCallData
+		}
+		args[i] = bz
+	}
+	return args, nil
+}
+
+// function submitHeaders(bytes headers) external;
+func submitHeaders(ctx *mevmtypes.Context, currBlock *mevmtypes.BlockInfo, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfCCOp
+	args, err := unpackBytesArgs("submitHeaders", tx.Data[4:])
+	if err != nil || len(args[0]) == 0 || len(args[0])%bchHeaderLen != 0 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	headers := args[0]
+	gasUsed += GasOfSpvHeader * uint64(len(headers)/bchHeaderLen)
+	for ; len(headers) != 0; headers = headers[bchHeaderLen:] {
+		if _, err = addBchHeader(ctx, headers[:bchHeaderLen], currBlock.Timestamp); err != nil {
+			outData = []byte(err.Error())
+			return
+		}
+	}
+	status = StatusSuccess
+	return
+}
+
+// function submitDeposit(bytes header, bytes merkleProof, bytes rawTx) external;
+func submitDeposit(ctx *mevmtypes.Context, currBlock *mevmtypes.BlockInfo, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfCCOp + GasOfSpvHeader
+	args, err := unpackBytesArgs("submitDeposit", tx.Data[4:])
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	header, err := addBchHeader(ctx, args[0], currBlock.Timestamp)
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	tip, _ := LoadSpvTip(ctx)
+	if tip.Timestamp+SpvParams.MaxTipAge < currBlock.Timestamp {
+		outData = []byte(SpvTipTooOld.Error())
+		return
+	}
+	if hash, ok := GetBestChainHash(ctx, header.Height); !ok || hash != header.Hash || header.Height > tip.Height {
+		outData = []byte(NotOnBestChain.Error())
+		return
+	}
+	if tip.Height-header.Height+1 < SpvParams.DepositConfirmations {
+		outData = []byte(NotEnoughConfirmations.Error())
+		return
+	}
+	// a 64-byte tx could be taken as an inner node of the merkle tree
+	rawTx := args[2]
+	var msgTx wire.MsgTx
+	reader := bytes.NewReader(rawTx)
+	if len(rawTx) == 64 || msgTx.DeserializeNoWitness(reader) != nil || reader.Len() != 0 {
+		outData = []byte(InvalidRawTx.Error())
+		return
+	}
+	var merkleRoot chainhash.Hash
+	copy(merkleRoot[:], args[0][36:68]) // the header is valid as it is tracked
+	if !verifyMerkleProof(chainhash.DoubleHashH(rawTx), args[1], merkleRoot) {
+		outData = []byte(InvalidMerkleProof.Error())
+		return
+	}
+	infos := getCCTransferInfosFromTx(&msgTx)
+	if len(infos) == 0 {
+		outData = []byte(NoShaGateOutput.Error())
+		return
+	}
+	for _, info := range infos {
+		receiver, value, err := acceptDeposit(ctx, info)
+		if err == DepositAlreadySeen {
+			continue
+		} else if err != nil {
+			outData = []byte(err.Error())
+			return
+		}
+		logs = append(logs, buildDepositEvmLog(info.UTXO, receiver, value))
+	}
+	if len(logs) == 0 {
+		outData = []byte(DepositAlreadySeen.Error())
+		return
+	}
+	status = StatusSuccess
+	return
+}
+
+func buildDepositEvmLog(utxo [36]byte, receiver common.Address, value *uint256.Int) mevmtypes.EvmLog {
+	evmLog := mevmtypes.EvmLog{
+		Address: CCContractAddress,
+		Topics:  make([]common.Hash, 0, 4),
+	}
+
+	evmLog.Topics = append(evmLog.Topics, HashOfEventDeposit)
+	txId := common.Hash{}
+	txId.SetBytes(utxo[:32])
+	evmLog.Topics = append(evmLog.Topics, txId)
+	vOutIndex := common.Hash{}
+	vOutIndex.SetBytes(utxo[32:])
+	evmLog.Topics = append(evmLog.Topics, vOutIndex)
+	evmLog.Topics = append(evmLog.Topics, common.BytesToHash(receiver[:]))
+
+	data := value.Bytes32()
+	evmLog.Data = append(evmLog.Data, data[:]...)
+	return evmLog
+}
@@ -5,7 +5,22 @@ package types
 type CCTransferInfo struct {
 	UTXO         [36]byte
 	Amount       uint64
-	SenderPubkey [33]byte
+	SenderPubkey [33]byte // the pubkey signing the first input of the deposit tx, zero if not found
+	Receiver     [20]byte // the receiver specified by an OP_RETURN output, zero if not specified
+}
+
+// CCPayout is an output of a mainnet tx, the target is a cashaddr type byte (0 for P2PKH and 1 for P2SH)
+// followed by the hash
+type CCPayout struct {
+	Target [21]byte
+	Amount uint64
+}
+
+// CCSpendInfo is a mainnet tx spending a UTXO of the ShaGate address
+type CCSpendInfo struct {
+	UTXO        [36]byte
+	MainnetTxId [32]byte // in the displayed byte order, like UTXO
+	Payouts     []*CCPayout
 }
 
 type CCEpoch struct {
@@ -13,9 +28,48 @@ type CCEpoch struct {
 	StartHeight   int64
 	EndTime       int64
 	TransferInfos []*CCTransferInfo
+	SpendInfos    []*CCSpendInfo
 }
 
 type CCInfo struct {
 	GenesisMainnetBlockHeight int64 `msgp:"genesis_mainnet_block_height"`
 	CurrEpochNum              int64 `msgp:"curr_epoch_num"`
 }
+
+// BchHeader is a header in the BCH header chain tracked by the cross chain contract, whose hashes are in the
+// internal byte order
+type BchHeader struct {
+	Hash      [32]byte
+	PrevHash  [32]byte
+	Height    int64
+	Timestamp int64
+	Bits      uint32
+	ChainWork [32]byte // the cumulative work since the checkpoint
+}
+
+const (
+	RedemptionPending   int64 = 0
+	RedemptionConfirmed int64 = 1 // the UTXO is spent to the target on mainnet
+	RedemptionMispaid   int64 = 2 // the UTXO is spent on mainnet, but not to the target
+	RedemptionRefunded  int64 = 3 // the coins are refunded on smartBCH after timeout
+	RedemptionLateSpent int64 = 4 // the coins are refunded on smartBCH, but the UTXO is spent on mainnet later
+)
+
+// Redemption is the request to transfer a UTXO back to mainnet, made by transferBCHToMainnet
+type Redemption struct {
+	UTXO              [36]byte
+	TxHash            [32]byte // the smartBCH tx making the request
+	Redeemer          [20]byte
+	Amount            [32]byte
+	Target            [21]byte // the same format as CCPayout.Target, zero if not specified
+	Status            int64
+	CreatedHeight     int64
+	CreatedCCEpochNum int64    // the number of cc epochs switched when the request is made
+	MainnetTxId       [32]byte // the mainnet tx spending the UTXO
+	PaidAmount        uint64   // the amount paid to the target by the mainnet tx, in satoshi
+}
+
+// TxRedemptions lists the UTXOs redeemed by a smartBCH tx
+type TxRedemptions struct {
+	UTXOs [][36]byte
+}
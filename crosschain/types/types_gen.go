@@ -6,6 +6,234 @@ import (
 	"github.com/tinylib/msgp/msgp"
 )
 
+// DecodeMsg implements msgp.Decodable
+func (z *BchHeader) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Hash":
+			err = dc.ReadExactBytes((z.Hash)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Hash")
+				return
+			}
+		case "PrevHash":
+			err = dc.ReadExactBytes((z.PrevHash)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "PrevHash")
+				return
+			}
+		case "Height":
+			z.Height, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			z.Timestamp, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		case "Bits":
+			z.Bits, err = dc.ReadUint32()
+			if err != nil {
+				err = msgp.WrapError(err, "Bits")
+				return
+			}
+		case "ChainWork":
+			err = dc.ReadExactBytes((z.ChainWork)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ChainWork")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *BchHeader) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "Hash"
+	err = en.Append(0x86, 0xa4, 0x48, 0x61, 0x73, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Hash)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Hash")
+		return
+	}
+	// write "PrevHash"
+	err = en.Append(0xa8, 0x50, 0x72, 0x65, 0x76, 0x48, 0x61, 0x73, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.PrevHash)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "PrevHash")
+		return
+	}
+	// write "Height"
+	err = en.Append(0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "Timestamp"
+	err = en.Append(0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Timestamp)
+	if err != nil {
+		err = msgp.WrapError(err, "Timestamp")
+		return
+	}
+	// write "Bits"
+	err = en.Append(0xa4, 0x42, 0x69, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint32(z.Bits)
+	if err != nil {
+		err = msgp.WrapError(err, "Bits")
+		return
+	}
+	// write "ChainWork"
+	err = en.Append(0xa9, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x6f, 0x72, 0x6b)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.ChainWork)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "ChainWork")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *BchHeader) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "Hash"
+	o = append(o, 0x86, 0xa4, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendBytes(o, (z.Hash)[:])
+	// string "PrevHash"
+	o = append(o, 0xa8, 0x50, 0x72, 0x65, 0x76, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendBytes(o, (z.PrevHash)[:])
+	// string "Height"
+	o = append(o, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.Height)
+	// string "Timestamp"
+	o = append(o, 0xa9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70)
+	o = msgp.AppendInt64(o, z.Timestamp)
+	// string "Bits"
+	o = append(o, 0xa4, 0x42, 0x69, 0x74, 0x73)
+	o = msgp.AppendUint32(o, z.Bits)
+	// string "ChainWork"
+	o = append(o, 0xa9, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x6f, 0x72, 0x6b)
+	o = msgp.AppendBytes(o, (z.ChainWork)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *BchHeader) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Hash":
+			bts, err = msgp.ReadExactBytes(bts, (z.Hash)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Hash")
+				return
+			}
+		case "PrevHash":
+			bts, err = msgp.ReadExactBytes(bts, (z.PrevHash)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "PrevHash")
+				return
+			}
+		case "Height":
+			z.Height, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Timestamp":
+			z.Timestamp, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Timestamp")
+				return
+			}
+		case "Bits":
+			z.Bits, bts, err = msgp.ReadUint32Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bits")
+				return
+			}
+		case "ChainWork":
+			bts, err = msgp.ReadExactBytes(bts, (z.ChainWork)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ChainWork")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *BchHeader) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 7 + msgp.Int64Size + 10 + msgp.Int64Size + 5 + msgp.Uint32Size + 10 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *CCEpoch) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
@@ -73,6 +301,37 @@ func (z *CCEpoch) DecodeMsg(dc *msgp.Reader) (err error) {
 					}
 				}
 			}
+		case "SpendInfos":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "SpendInfos")
+				return
+			}
+			if cap(z.SpendInfos) >= int(zb0003) {
+				z.SpendInfos = (z.SpendInfos)[:zb0003]
+			} else {
+				z.SpendInfos = make([]*CCSpendInfo, zb0003)
+			}
+			for za0002 := range z.SpendInfos {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "SpendInfos", za0002)
+						return
+					}
+					z.SpendInfos[za0002] = nil
+				} else {
+					if z.SpendInfos[za0002] == nil {
+						z.SpendInfos[za0002] = new(CCSpendInfo)
+					}
+					err = z.SpendInfos[za0002].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "SpendInfos", za0002)
+						return
+					}
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -86,9 +345,9 @@ func (z *CCEpoch) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *CCEpoch) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
+	// map header, size 5
 	// write "Number"
-	err = en.Append(0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
+	err = en.Append(0x85, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
 	if err != nil {
 		return
 	}
@@ -141,15 +400,39 @@ func (z *CCEpoch) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	// write "SpendInfos"
+	err = en.Append(0xaa, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.SpendInfos)))
+	if err != nil {
+		err = msgp.WrapError(err, "SpendInfos")
+		return
+	}
+	for za0002 := range z.SpendInfos {
+		if z.SpendInfos[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.SpendInfos[za0002].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "SpendInfos", za0002)
+				return
+			}
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *CCEpoch) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
+	// map header, size 5
 	// string "Number"
-	o = append(o, 0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
+	o = append(o, 0x85, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
 	o = msgp.AppendInt64(o, z.Number)
 	// string "StartHeight"
 	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
@@ -171,6 +454,20 @@ func (z *CCEpoch) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 	}
+	// string "SpendInfos"
+	o = append(o, 0xaa, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SpendInfos)))
+	for za0002 := range z.SpendInfos {
+		if z.SpendInfos[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.SpendInfos[za0002].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "SpendInfos", za0002)
+				return
+			}
+		}
+	}
 	return
 }
 
@@ -240,6 +537,36 @@ func (z *CCEpoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "SpendInfos":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SpendInfos")
+				return
+			}
+			if cap(z.SpendInfos) >= int(zb0003) {
+				z.SpendInfos = (z.SpendInfos)[:zb0003]
+			} else {
+				z.SpendInfos = make([]*CCSpendInfo, zb0003)
+			}
+			for za0002 := range z.SpendInfos {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.SpendInfos[za0002] = nil
+				} else {
+					if z.SpendInfos[za0002] == nil {
+						z.SpendInfos[za0002] = new(CCSpendInfo)
+					}
+					bts, err = z.SpendInfos[za0002].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "SpendInfos", za0002)
+						return
+					}
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -262,6 +589,14 @@ func (z *CCEpoch) Msgsize() (s int) {
 			s += z.TransferInfos[za0001].Msgsize()
 		}
 	}
+	s += 11 + msgp.ArrayHeaderSize
+	for za0002 := range z.SpendInfos {
+		if z.SpendInfos[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.SpendInfos[za0002].Msgsize()
+		}
+	}
 	return
 }
 
@@ -394,7 +729,7 @@ func (z CCInfo) Msgsize() (s int) {
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *CCTransferInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *CCPayout) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -411,10 +746,10 @@ func (z *CCTransferInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "UTXO":
-			err = dc.ReadExactBytes((z.UTXO)[:])
+		case "Target":
+			err = dc.ReadExactBytes((z.Target)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "UTXO")
+				err = msgp.WrapError(err, "Target")
 				return
 			}
 		case "Amount":
@@ -423,12 +758,6 @@ func (z *CCTransferInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Amount")
 				return
 			}
-		case "SenderPubkey":
-			err = dc.ReadExactBytes((z.SenderPubkey)[:])
-			if err != nil {
-				err = msgp.WrapError(err, "SenderPubkey")
-				return
-			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -441,16 +770,16 @@ func (z *CCTransferInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *CCTransferInfo) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "UTXO"
-	err = en.Append(0x83, 0xa4, 0x55, 0x54, 0x58, 0x4f)
+func (z *CCPayout) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Target"
+	err = en.Append(0x82, 0xa6, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.UTXO)[:])
+	err = en.WriteBytes((z.Target)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "UTXO")
+		err = msgp.WrapError(err, "Target")
 		return
 	}
 	// write "Amount"
@@ -463,37 +792,24 @@ func (z *CCTransferInfo) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Amount")
 		return
 	}
-	// write "SenderPubkey"
-	err = en.Append(0xac, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-	if err != nil {
-		return
-	}
-	err = en.WriteBytes((z.SenderPubkey)[:])
-	if err != nil {
-		err = msgp.WrapError(err, "SenderPubkey")
-		return
-	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *CCTransferInfo) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *CCPayout) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "UTXO"
-	o = append(o, 0x83, 0xa4, 0x55, 0x54, 0x58, 0x4f)
-	o = msgp.AppendBytes(o, (z.UTXO)[:])
+	// map header, size 2
+	// string "Target"
+	o = append(o, 0x82, 0xa6, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74)
+	o = msgp.AppendBytes(o, (z.Target)[:])
 	// string "Amount"
 	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	o = msgp.AppendUint64(o, z.Amount)
-	// string "SenderPubkey"
-	o = append(o, 0xac, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-	o = msgp.AppendBytes(o, (z.SenderPubkey)[:])
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *CCTransferInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *CCPayout) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -510,10 +826,10 @@ func (z *CCTransferInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "UTXO":
-			bts, err = msgp.ReadExactBytes(bts, (z.UTXO)[:])
+		case "Target":
+			bts, err = msgp.ReadExactBytes(bts, (z.Target)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "UTXO")
+				err = msgp.WrapError(err, "Target")
 				return
 			}
 		case "Amount":
@@ -522,12 +838,6 @@ func (z *CCTransferInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Amount")
 				return
 			}
-		case "SenderPubkey":
-			bts, err = msgp.ReadExactBytes(bts, (z.SenderPubkey)[:])
-			if err != nil {
-				err = msgp.WrapError(err, "SenderPubkey")
-				return
-			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -541,7 +851,962 @@ func (z *CCTransferInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *CCTransferInfo) Msgsize() (s int) {
-	s = 1 + 5 + msgp.ArrayHeaderSize + (36 * (msgp.ByteSize)) + 7 + msgp.Uint64Size + 13 + msgp.ArrayHeaderSize + (33 * (msgp.ByteSize))
+func (z *CCPayout) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize + (21 * (msgp.ByteSize)) + 7 + msgp.Uint64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *CCSpendInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXO":
+			err = dc.ReadExactBytes((z.UTXO)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "UTXO")
+				return
+			}
+		case "MainnetTxId":
+			err = dc.ReadExactBytes((z.MainnetTxId)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "MainnetTxId")
+				return
+			}
+		case "Payouts":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Payouts")
+				return
+			}
+			if cap(z.Payouts) >= int(zb0002) {
+				z.Payouts = (z.Payouts)[:zb0002]
+			} else {
+				z.Payouts = make([]*CCPayout, zb0002)
+			}
+			for za0003 := range z.Payouts {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Payouts", za0003)
+						return
+					}
+					z.Payouts[za0003] = nil
+				} else {
+					if z.Payouts[za0003] == nil {
+						z.Payouts[za0003] = new(CCPayout)
+					}
+					var zb0003 uint32
+					zb0003, err = dc.ReadMapHeader()
+					if err != nil {
+						err = msgp.WrapError(err, "Payouts", za0003)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, err = dc.ReadMapKeyPtr()
+						if err != nil {
+							err = msgp.WrapError(err, "Payouts", za0003)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Target":
+							err = dc.ReadExactBytes((z.Payouts[za0003].Target)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Payouts", za0003, "Target")
+								return
+							}
+						case "Amount":
+							z.Payouts[za0003].Amount, err = dc.ReadUint64()
+							if err != nil {
+								err = msgp.WrapError(err, "Payouts", za0003, "Amount")
+								return
+							}
+						default:
+							err = dc.Skip()
+							if err != nil {
+								err = msgp.WrapError(err, "Payouts", za0003)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *CCSpendInfo) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "UTXO"
+	err = en.Append(0x83, 0xa4, 0x55, 0x54, 0x58, 0x4f)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.UTXO)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "UTXO")
+		return
+	}
+	// write "MainnetTxId"
+	err = en.Append(0xab, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x54, 0x78, 0x49, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.MainnetTxId)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "MainnetTxId")
+		return
+	}
+	// write "Payouts"
+	err = en.Append(0xa7, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Payouts)))
+	if err != nil {
+		err = msgp.WrapError(err, "Payouts")
+		return
+	}
+	for za0003 := range z.Payouts {
+		if z.Payouts[za0003] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Target"
+			err = en.Append(0x82, 0xa6, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z.Payouts[za0003].Target)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Payouts", za0003, "Target")
+				return
+			}
+			// write "Amount"
+			err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			if err != nil {
+				return
+			}
+			err = en.WriteUint64(z.Payouts[za0003].Amount)
+			if err != nil {
+				err = msgp.WrapError(err, "Payouts", za0003, "Amount")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *CCSpendInfo) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "UTXO"
+	o = append(o, 0x83, 0xa4, 0x55, 0x54, 0x58, 0x4f)
+	o = msgp.AppendBytes(o, (z.UTXO)[:])
+	// string "MainnetTxId"
+	o = append(o, 0xab, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x54, 0x78, 0x49, 0x64)
+	o = msgp.AppendBytes(o, (z.MainnetTxId)[:])
+	// string "Payouts"
+	o = append(o, 0xa7, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Payouts)))
+	for za0003 := range z.Payouts {
+		if z.Payouts[za0003] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Target"
+			o = append(o, 0x82, 0xa6, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74)
+			o = msgp.AppendBytes(o, (z.Payouts[za0003].Target)[:])
+			// string "Amount"
+			o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			o = msgp.AppendUint64(o, z.Payouts[za0003].Amount)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CCSpendInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXO":
+			bts, err = msgp.ReadExactBytes(bts, (z.UTXO)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "UTXO")
+				return
+			}
+		case "MainnetTxId":
+			bts, err = msgp.ReadExactBytes(bts, (z.MainnetTxId)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "MainnetTxId")
+				return
+			}
+		case "Payouts":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Payouts")
+				return
+			}
+			if cap(z.Payouts) >= int(zb0002) {
+				z.Payouts = (z.Payouts)[:zb0002]
+			} else {
+				z.Payouts = make([]*CCPayout, zb0002)
+			}
+			for za0003 := range z.Payouts {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Payouts[za0003] = nil
+				} else {
+					if z.Payouts[za0003] == nil {
+						z.Payouts[za0003] = new(CCPayout)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Payouts", za0003)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Payouts", za0003)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Target":
+							bts, err = msgp.ReadExactBytes(bts, (z.Payouts[za0003].Target)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Payouts", za0003, "Target")
+								return
+							}
+						case "Amount":
+							z.Payouts[za0003].Amount, bts, err = msgp.ReadUint64Bytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Payouts", za0003, "Amount")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Payouts", za0003)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *CCSpendInfo) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize + (36 * (msgp.ByteSize)) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 8 + msgp.ArrayHeaderSize
+	for za0003 := range z.Payouts {
+		if z.Payouts[za0003] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 7 + msgp.ArrayHeaderSize + (21 * (msgp.ByteSize)) + 7 + msgp.Uint64Size
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *CCTransferInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXO":
+			err = dc.ReadExactBytes((z.UTXO)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "UTXO")
+				return
+			}
+		case "Amount":
+			z.Amount, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "SenderPubkey":
+			err = dc.ReadExactBytes((z.SenderPubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "SenderPubkey")
+				return
+			}
+		case "Receiver":
+			err = dc.ReadExactBytes((z.Receiver)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Receiver")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *CCTransferInfo) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "UTXO"
+	err = en.Append(0x84, 0xa4, 0x55, 0x54, 0x58, 0x4f)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.UTXO)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "UTXO")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Amount)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// write "SenderPubkey"
+	err = en.Append(0xac, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.SenderPubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "SenderPubkey")
+		return
+	}
+	// write "Receiver"
+	err = en.Append(0xa8, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Receiver)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Receiver")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *CCTransferInfo) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "UTXO"
+	o = append(o, 0x84, 0xa4, 0x55, 0x54, 0x58, 0x4f)
+	o = msgp.AppendBytes(o, (z.UTXO)[:])
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendUint64(o, z.Amount)
+	// string "SenderPubkey"
+	o = append(o, 0xac, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.SenderPubkey)[:])
+	// string "Receiver"
+	o = append(o, 0xa8, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72)
+	o = msgp.AppendBytes(o, (z.Receiver)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CCTransferInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXO":
+			bts, err = msgp.ReadExactBytes(bts, (z.UTXO)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "UTXO")
+				return
+			}
+		case "Amount":
+			z.Amount, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "SenderPubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.SenderPubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "SenderPubkey")
+				return
+			}
+		case "Receiver":
+			bts, err = msgp.ReadExactBytes(bts, (z.Receiver)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Receiver")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *CCTransferInfo) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize + (36 * (msgp.ByteSize)) + 7 + msgp.Uint64Size + 13 + msgp.ArrayHeaderSize + (33 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *Redemption) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXO":
+			err = dc.ReadExactBytes((z.UTXO)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "UTXO")
+				return
+			}
+		case "TxHash":
+			err = dc.ReadExactBytes((z.TxHash)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TxHash")
+				return
+			}
+		case "Redeemer":
+			err = dc.ReadExactBytes((z.Redeemer)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Redeemer")
+				return
+			}
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "Target":
+			err = dc.ReadExactBytes((z.Target)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Target")
+				return
+			}
+		case "Status":
+			z.Status, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Status")
+				return
+			}
+		case "CreatedHeight":
+			z.CreatedHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "CreatedHeight")
+				return
+			}
+		case "CreatedCCEpochNum":
+			z.CreatedCCEpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "CreatedCCEpochNum")
+				return
+			}
+		case "MainnetTxId":
+			err = dc.ReadExactBytes((z.MainnetTxId)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "MainnetTxId")
+				return
+			}
+		case "PaidAmount":
+			z.PaidAmount, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "PaidAmount")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *Redemption) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 10
+	// write "UTXO"
+	err = en.Append(0x8a, 0xa4, 0x55, 0x54, 0x58, 0x4f)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.UTXO)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "UTXO")
+		return
+	}
+	// write "TxHash"
+	err = en.Append(0xa6, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.TxHash)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "TxHash")
+		return
+	}
+	// write "Redeemer"
+	err = en.Append(0xa8, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Redeemer)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Redeemer")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Amount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// write "Target"
+	err = en.Append(0xa6, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Target)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Target")
+		return
+	}
+	// write "Status"
+	err = en.Append(0xa6, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Status)
+	if err != nil {
+		err = msgp.WrapError(err, "Status")
+		return
+	}
+	// write "CreatedHeight"
+	err = en.Append(0xad, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.CreatedHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "CreatedHeight")
+		return
+	}
+	// write "CreatedCCEpochNum"
+	err = en.Append(0xb1, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x43, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.CreatedCCEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "CreatedCCEpochNum")
+		return
+	}
+	// write "MainnetTxId"
+	err = en.Append(0xab, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x54, 0x78, 0x49, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.MainnetTxId)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "MainnetTxId")
+		return
+	}
+	// write "PaidAmount"
+	err = en.Append(0xaa, 0x50, 0x61, 0x69, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.PaidAmount)
+	if err != nil {
+		err = msgp.WrapError(err, "PaidAmount")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Redemption) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 10
+	// string "UTXO"
+	o = append(o, 0x8a, 0xa4, 0x55, 0x54, 0x58, 0x4f)
+	o = msgp.AppendBytes(o, (z.UTXO)[:])
+	// string "TxHash"
+	o = append(o, 0xa6, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendBytes(o, (z.TxHash)[:])
+	// string "Redeemer"
+	o = append(o, 0xa8, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x72)
+	o = msgp.AppendBytes(o, (z.Redeemer)[:])
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	// string "Target"
+	o = append(o, 0xa6, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74)
+	o = msgp.AppendBytes(o, (z.Target)[:])
+	// string "Status"
+	o = append(o, 0xa6, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73)
+	o = msgp.AppendInt64(o, z.Status)
+	// string "CreatedHeight"
+	o = append(o, 0xad, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.CreatedHeight)
+	// string "CreatedCCEpochNum"
+	o = append(o, 0xb1, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x43, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.CreatedCCEpochNum)
+	// string "MainnetTxId"
+	o = append(o, 0xab, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x54, 0x78, 0x49, 0x64)
+	o = msgp.AppendBytes(o, (z.MainnetTxId)[:])
+	// string "PaidAmount"
+	o = append(o, 0xaa, 0x50, 0x61, 0x69, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendUint64(o, z.PaidAmount)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Redemption) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXO":
+			bts, err = msgp.ReadExactBytes(bts, (z.UTXO)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "UTXO")
+				return
+			}
+		case "TxHash":
+			bts, err = msgp.ReadExactBytes(bts, (z.TxHash)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TxHash")
+				return
+			}
+		case "Redeemer":
+			bts, err = msgp.ReadExactBytes(bts, (z.Redeemer)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Redeemer")
+				return
+			}
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "Target":
+			bts, err = msgp.ReadExactBytes(bts, (z.Target)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Target")
+				return
+			}
+		case "Status":
+			z.Status, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Status")
+				return
+			}
+		case "CreatedHeight":
+			z.CreatedHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CreatedHeight")
+				return
+			}
+		case "CreatedCCEpochNum":
+			z.CreatedCCEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CreatedCCEpochNum")
+				return
+			}
+		case "MainnetTxId":
+			bts, err = msgp.ReadExactBytes(bts, (z.MainnetTxId)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "MainnetTxId")
+				return
+			}
+		case "PaidAmount":
+			z.PaidAmount, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PaidAmount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Redemption) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize + (36 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (21 * (msgp.ByteSize)) + 7 + msgp.Int64Size + 14 + msgp.Int64Size + 18 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 11 + msgp.Uint64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *TxRedemptions) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXOs":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "UTXOs")
+				return
+			}
+			if cap(z.UTXOs) >= int(zb0002) {
+				z.UTXOs = (z.UTXOs)[:zb0002]
+			} else {
+				z.UTXOs = make([][36]byte, zb0002)
+			}
+			for za0001 := range z.UTXOs {
+				err = dc.ReadExactBytes((z.UTXOs[za0001])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "UTXOs", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *TxRedemptions) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "UTXOs"
+	err = en.Append(0x81, 0xa5, 0x55, 0x54, 0x58, 0x4f, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.UTXOs)))
+	if err != nil {
+		err = msgp.WrapError(err, "UTXOs")
+		return
+	}
+	for za0001 := range z.UTXOs {
+		err = en.WriteBytes((z.UTXOs[za0001])[:])
+		if err != nil {
+			err = msgp.WrapError(err, "UTXOs", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *TxRedemptions) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "UTXOs"
+	o = append(o, 0x81, 0xa5, 0x55, 0x54, 0x58, 0x4f, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.UTXOs)))
+	for za0001 := range z.UTXOs {
+		o = msgp.AppendBytes(o, (z.UTXOs[za0001])[:])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *TxRedemptions) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "UTXOs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UTXOs")
+				return
+			}
+			if cap(z.UTXOs) >= int(zb0002) {
+				z.UTXOs = (z.UTXOs)[:zb0002]
+			} else {
+				z.UTXOs = make([][36]byte, zb0002)
+			}
+			for za0001 := range z.UTXOs {
+				bts, err = msgp.ReadExactBytes(bts, (z.UTXOs[za0001])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "UTXOs", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *TxRedemptions) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize + (len(z.UTXOs) * (36 * (msgp.ByteSize)))
 	return
 }
@@ -0,0 +1,162 @@
+package crosschain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+
+	"github.com/holiman/uint256"
+
+	"github.com/smartbch/moeingevm/ebp"
+	mevmtypes "github.com/smartbch/moeingevm/types"
+)
+
+// The UTXOs saved by SaveUTXO are indexed in a dense array, such that they can be listed page by page, and the
+// total amount of them is kept, such that the operators can audit the peg. When a UTXO is deleted, the last one
+// in the array takes its position.
+//
+// The index has no backfill: it is maintained by SaveUTXO and deleteUTXO, so it only covers the UTXOs saved
+// since the index exists. That is all of them as long as the cc contract is enabled (ShaGateSwitch) in the same
+// release as the index; a chain which saved UTXOs before must not rely on UTXOCount and LockedAmount.
+
+var (
+	SlotUTXOCount     string = strings.Repeat(string([]byte{0}), 31) + string([]byte{3})
+	SlotLockedAmount  string = strings.Repeat(string([]byte{0}), 31) + string([]byte{4})
+	SlotParkedAmount  string = strings.Repeat(string([]byte{0}), 31) + string([]byte{5})
+	SlotDeficitAmount string = strings.Repeat(string([]byte{0}), 31) + string([]byte{6})
+)
+
+// UTXOInfo is a UTXO of the ShaGate address known by the cc contract
+type UTXOInfo struct {
+	UTXO   [36]byte
+	Amount *uint256.Int
+	Parked bool // the sender of the deposit cannot be determined
+}
+
+// PegStatus shows the coins locked on mainnet against the coins on smartBCH
+type PegStatus struct {
+	UTXOCount         int64
+	LockedAmount      *uint256.Int // the total amount of the indexed UTXOs
+	ParkedAmount      *uint256.Int // the total amount of the parked deposits
+	CCContractBalance *uint256.Int
+	BurntAmount       *uint256.Int // the amount of the UTXOs matched against the burnt coins by burnBCH
+	BlackHoleBalance  *uint256.Int
+	DeficitAmount     *uint256.Int // the amount of the UTXOs spent on mainnet while their coins are still on smartBCH
+}
+
+func getSlotForUTXOAt(index int64) string {
+	var buf [9]byte
+	buf[0] = 'u'
+	binary.BigEndian.PutUint64(buf[1:], uint64(index))
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func getSlotForUTXOPosition(utxo [36]byte) string {
+	var buf [37]byte
+	buf[0] = 'i'
+	copy(buf[1:], utxo[:])
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func loadAmountAt(ctx *mevmtypes.Context, slot string) *uint256.Int {
+	return uint256.NewInt(0).SetBytes(ctx.GetStorageAt(ccContractSequence, slot))
+}
+
+func GetUTXOCount(ctx *mevmtypes.Context) int64 {
+	return int64(loadAmountAt(ctx, SlotUTXOCount).Uint64())
+}
+
+func setUTXOCount(ctx *mevmtypes.Context, count int64) {
+	ctx.SetStorageAt(ccContractSequence, SlotUTXOCount, uint256.NewInt(uint64(count)).Bytes())
+}
+
+// the position of 'utxo' in the index plus one, zero means it is not indexed
+func getUTXOPosition(ctx *mevmtypes.Context, utxo [36]byte) int64 {
+	return int64(loadAmountAt(ctx, getSlotForUTXOPosition(utxo)).Uint64())
+}
+
+// GetUTXOAt returns the UTXO at 'index' of the index, which must be less than GetUTXOCount
+func GetUTXOAt(ctx *mevmtypes.Context, index int64) (utxo [36]byte) {
+	copy(utxo[:], ctx.GetStorageAt(ccContractSequence, getSlotForUTXOAt(index)))
+	return
+}
+
+func setUTXOAt(ctx *mevmtypes.Context, index int64, utxo [36]byte) {
+	ctx.SetStorageAt(ccContractSequence, getSlotForUTXOAt(index), append([]byte{}, utxo[:]...))
+	ctx.SetStorageAt(ccContractSequence, getSlotForUTXOPosition(utxo), uint256.NewInt(uint64(index+1)).Bytes())
+}
+
+func addToUTXOIndex(ctx *mevmtypes.Context, utxo [36]byte, oldAmount, amount *uint256.Int) {
+	lockedAmount := loadAmountAt(ctx, SlotLockedAmount)
+	lockedAmount.Sub(lockedAmount, oldAmount)
+	ctx.SetStorageAt(ccContractSequence, SlotLockedAmount, lockedAmount.Add(lockedAmount, amount).Bytes())
+	if getUTXOPosition(ctx, utxo) != 0 {
+		return
+	}
+	count := GetUTXOCount(ctx)
+	setUTXOAt(ctx, count, utxo)
+	setUTXOCount(ctx, count+1)
+}
+
+func removeFromUTXOIndex(ctx *mevmtypes.Context, utxo [36]byte, amount *uint256.Int) {
+	pos := getUTXOPosition(ctx, utxo)
+	if pos == 0 {
+		return
+	}
+	lockedAmount := loadAmountAt(ctx, SlotLockedAmount)
+	ctx.SetStorageAt(ccContractSequence, SlotLockedAmount, lockedAmount.Sub(lockedAmount, amount).Bytes())
+	count := GetUTXOCount(ctx)
+	if pos != count {
+		setUTXOAt(ctx, pos-1, GetUTXOAt(ctx, count-1))
+	}
+	ctx.DeleteStorageAt(ccContractSequence, getSlotForUTXOAt(count-1))
+	ctx.DeleteStorageAt(ccContractSequence, getSlotForUTXOPosition(utxo))
+	setUTXOCount(ctx, count-1)
+}
+
+// GetUTXOInfos returns at most 'limit' UTXOs in the index from 'offset', which must be less than the count of
+// UTXOs unless the index is empty
+func GetUTXOInfos(ctx *mevmtypes.Context, offset, limit uint64) ([]*UTXOInfo, error) {
+	count := uint64(GetUTXOCount(ctx))
+	if offset != 0 && offset >= count {
+		return nil, UTXOOffsetOutOfRange
+	}
+	if limit > count-offset {
+		limit = count - offset
+	}
+	infos := make([]*UTXOInfo, 0, limit)
+	for i := offset; i < offset+limit; i++ {
+		utxo := GetUTXOAt(ctx, int64(i))
+		infos = append(infos, &UTXOInfo{UTXO: utxo, Amount: LoadUTXO(ctx, utxo)})
+	}
+	return infos, nil
+}
+
+// GetUTXOInfo returns the UTXO either indexed or parked, and nil if it is not found
+func GetUTXOInfo(ctx *mevmtypes.Context, utxo [36]byte) *UTXOInfo {
+	if getUTXOPosition(ctx, utxo) != 0 {
+		return &UTXOInfo{UTXO: utxo, Amount: LoadUTXO(ctx, utxo)}
+	}
+	if amount := LoadParkedUTXO(ctx, utxo); !amount.IsZero() {
+		return &UTXOInfo{UTXO: utxo, Amount: amount, Parked: true}
+	}
+	return nil
+}
+
+func GetPegStatus(ctx *mevmtypes.Context) *PegStatus {
+	status := &PegStatus{
+		UTXOCount:         GetUTXOCount(ctx),
+		LockedAmount:      loadAmountAt(ctx, SlotLockedAmount),
+		ParkedAmount:      loadAmountAt(ctx, SlotParkedAmount),
+		CCContractBalance: uint256.NewInt(0),
+		BurntAmount:       LoadBchMainnetBurnt(ctx),
+		BlackHoleBalance:  ebp.GetBlackHoleBalance(ctx),
+		DeficitAmount:     loadAmountAt(ctx, SlotDeficitAmount),
+	}
+	if acc := ctx.GetAccount(CCContractAddress); acc != nil {
+		status.CCContractBalance = acc.Balance()
+	}
+	return status
+}
@@ -23,15 +23,36 @@ func NewTx(nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, g
 	})
 }
 
+func NewDynamicFeeTx(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64,
+	gasTipCap, gasFeeCap *big.Int, data []byte, accessList types.AccessList) *types.Transaction {
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:    chainID,
+		Nonce:      nonce,
+		To:         to,
+		Value:      amount,
+		Gas:        gasLimit,
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  gasFeeCap,
+		Data:       data,
+		AccessList: accessList,
+	})
+}
+
+// EncodeTx encodes tx in the canonical format, which is RLP for legacy TXs and the EIP-2718 envelope for typed TXs
 func EncodeTx(tx *types.Transaction) ([]byte, error) {
-	buf := &bytes.Buffer{}
-	if err := tx.EncodeRLP(buf); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	return tx.MarshalBinary()
 }
 
+// DecodeTx decodes both legacy TXs and EIP-2718 typed TXs
 func DecodeTx(data []byte) (*types.Transaction, error) {
+	tx := &types.Transaction{}
+	err := tx.UnmarshalBinary(data)
+	return tx, err
+}
+
+// DecodeTxRLP is how TXs were decoded before EIP1559ForkHeight, typed TXs in raw envelope can not be decoded
+func DecodeTxRLP(data []byte) (*types.Transaction, error) {
 	tx := &types.Transaction{}
 	err := tx.DecodeRLP(rlp.NewStream(bytes.NewReader(data), 0))
 	return tx, err
@@ -40,7 +61,7 @@ func DecodeTx(data []byte) (*types.Transaction, error) {
 func SignTx(tx *types.Transaction,
 	chainID *big.Int, key *ecdsa.PrivateKey) (*types.Transaction, error) {
 
-	signer := types.NewEIP155Signer(chainID)
+	signer := types.NewLondonSigner(chainID)
 	txHash := signer.Hash(tx)
 	sig, err := crypto.Sign(txHash[:], key)
 	if err != nil {
@@ -61,9 +82,51 @@ func EncodeVRS(tx *types.Transaction) [65]byte {
 	return bs
 }
 
+// DecodeVRS is the reverse of EncodeVRS. The V of typed TXs is 0 or 1, otherwise it is recovered assuming chainId is 10000 or 10001
 func DecodeVRS(bs [65]byte) (v, r, s *big.Int) {
-	v = big.NewInt(0x4e00 + int64(bs[0]))
+	if IsTypedTxSig(bs) {
+		v = big.NewInt(int64(bs[0]))
+	} else {
+		v = big.NewInt(0x4e00 + int64(bs[0]))
+	}
 	r = big.NewInt(0).SetBytes(bs[1:33])
 	s = big.NewInt(0).SetBytes(bs[33:65])
 	return
 }
+
+// IsTypedTxSig tells whether the sig encoded by EncodeVRS belongs to a typed TX, whose V is its y-parity.
+// Legacy TXs always have V >= 27.
+func IsTypedTxSig(bs [65]byte) bool {
+	return bs[0] < 27
+}
+
+// TypedTxFields contains the fields of a typed TX which are not recorded in moeingevm's Transaction
+type TypedTxFields struct {
+	Type       uint8
+	ChainID    *big.Int
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	AccessList types.AccessList
+}
+
+func EncodeTypedTxFields(tx *types.Transaction) []byte {
+	bz, err := rlp.EncodeToBytes(&TypedTxFields{
+		Type:       tx.Type(),
+		ChainID:    tx.ChainId(),
+		GasTipCap:  tx.GasTipCap(),
+		GasFeeCap:  tx.GasFeeCap(),
+		AccessList: tx.AccessList(),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func DecodeTypedTxFields(bz []byte) (*TypedTxFields, error) {
+	fields := &TypedTxFields{}
+	if err := rlp.DecodeBytes(bz, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 
@@ -56,3 +57,45 @@ func TestTxSig2(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "0xFaD1182406c4456c84148F6A679EF97E1d321958", sender.Hex())
 }
+
+func TestDynamicFeeTx(t *testing.T) {
+	key1, addr1 := testutils.GenKeyAndAddr()
+	_, addr2 := testutils.GenKeyAndAddr()
+
+	chainID := big.NewInt(10000)
+	accessList := types.AccessList{{Address: addr2, StorageKeys: []common.Hash{{0x12}}}}
+	tx := ethutils.NewDynamicFeeTx(chainID, 123, &addr2, big.NewInt(100), 100000,
+		big.NewInt(1), big.NewInt(2), nil, accessList)
+	tx = testutils.MustSignTx(tx, chainID, key1)
+
+	txBytes, err := ethutils.EncodeTx(tx)
+	require.NoError(t, err)
+	require.Equal(t, byte(types.DynamicFeeTxType), txBytes[0])
+	_, err = ethutils.DecodeTxRLP(txBytes)
+	require.Error(t, err)
+
+	tx2, err := ethutils.DecodeTx(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), tx2.Hash())
+	sender, err := types.NewLondonSigner(chainID).Sender(tx2)
+	require.NoError(t, err)
+	require.Equal(t, addr1, sender)
+	_, err = types.NewEIP155Signer(chainID).Sender(tx2)
+	require.Error(t, err)
+
+	sig := ethutils.EncodeVRS(tx2)
+	require.True(t, ethutils.IsTypedTxSig(sig))
+	v, r, s := ethutils.DecodeVRS(sig)
+	v2, r2, s2 := tx2.RawSignatureValues()
+	require.Equal(t, v2, v)
+	require.Equal(t, r2, r)
+	require.Equal(t, s2, s)
+
+	fields, err := ethutils.DecodeTypedTxFields(ethutils.EncodeTypedTxFields(tx2))
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.DynamicFeeTxType), fields.Type)
+	require.Equal(t, chainID, fields.ChainID)
+	require.Equal(t, big.NewInt(1), fields.GasTipCap)
+	require.Equal(t, big.NewInt(2), fields.GasFeeCap)
+	require.Equal(t, accessList, fields.AccessList)
+}
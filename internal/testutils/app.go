@@ -31,9 +31,10 @@ import (
 )
 
 const (
-	testAdsDir  = "./testdbdata"
-	testMoDbDir = "./modbdata"
-	testSyncDir = "./syscdb"
+	testAdsDir    = "./testdbdata"
+	testMoDbDir   = "./modbdata"
+	testSyncDir   = "./syscdb"
+	testLedgerDir = "./ledgerdata"
 )
 
 const (
@@ -120,9 +121,14 @@ func createTestApp0(startHeight int64, startTime time.Time, valPubKey crypto.Pub
 	if err != nil {
 		panic("remove test modb failed " + err.Error())
 	}
+	err = os.RemoveAll(testLedgerDir)
+	if err != nil {
+		panic("remove test ledger failed " + err.Error())
+	}
 	params := param.DefaultConfig()
 	params.AppConfig.AppDataPath = testAdsDir
 	params.AppConfig.ModbDataPath = testMoDbDir
+	params.AppConfig.LedgerDataPath = testLedgerDir
 	params.AppConfig.SyncdbDataPath = testSyncDir
 	params.AppConfig.ArchiveMode = archiveMode
 	params.AppConfig.WithSyncDB = withSyncDB
@@ -184,6 +190,7 @@ func (_app *TestApp) ReloadApp() *TestApp {
 	params := param.DefaultConfig()
 	params.AppConfig.AppDataPath = testAdsDir
 	params.AppConfig.ModbDataPath = testMoDbDir
+	params.AppConfig.LedgerDataPath = testLedgerDir
 	newApp := app.NewApp(params, bigutils.NewU256(1), 0, 0, nopLogger, true)
 	allBalance := uint256.NewInt(0)
 	if checkAllBalance {
@@ -228,6 +235,7 @@ func cleanUpTestData() {
 	_ = os.RemoveAll(testAdsDir)
 	_ = os.RemoveAll(testMoDbDir)
 	_ = os.RemoveAll(testSyncDir)
+	_ = os.RemoveAll(testLedgerDir)
 }
 
 func (_app *TestApp) WaitMS(n int64) {
@@ -433,6 +441,27 @@ func (_app *TestApp) MakeAndSignTxWithAllArgs(hexPrivKey string,
 	return tx, addr
 }
 
+func (_app *TestApp) MakeAndSignDynamicFeeTx(hexPrivKey string,
+	toAddr *gethcmn.Address, val int64, data []byte, gasTipCap, gasFeeCap int64,
+	accessList gethtypes.AccessList) (*gethtypes.Transaction, gethcmn.Address) {
+
+	privKey, _, err := ethutils.HexToPrivKey(hexPrivKey)
+	if err != nil {
+		panic(err)
+	}
+
+	addr := ethutils.PrivKeyToAddr(privKey)
+	chainID := _app.ChainID().ToBig()
+	tx := ethutils.NewDynamicFeeTx(chainID, _app.GetNonce(addr), toAddr, big.NewInt(val), DefaultGasLimit,
+		big.NewInt(gasTipCap), big.NewInt(gasFeeCap), data, accessList)
+	tx, err = ethutils.SignTx(tx, chainID, privKey)
+	if err != nil {
+		panic(err)
+	}
+
+	return tx, addr
+}
+
 func (_app *TestApp) CallWithABI(sender, contractAddr gethcmn.Address,
 	abi ethutils.ABIWrapper, methodName string, args ...interface{}) []interface{} {
 
@@ -17,10 +17,19 @@ const (
 	DefaultTrunkCacheSize          = 200
 	DefaultChangeRetainEveryN      = 100
 	DefaultPruneEveryN             = 10
+	DefaultSnapshotInterval        = 0
+	DefaultSnapshotKeepRecent      = 2
 
 	AppDataPath    = "app"
 	ModbDataPath   = "modb"
 	SyncdbDataPath = "syncdb"
+	LedgerDataPath = "ledger"
+	SnapshotsPath  = "snapshots"
+
+	// the data sources of BCH mainnet used by the watcher
+	MainnetDataSourceBCHN     = "bchn"     // BCHN's JSON-RPC, with txindex enabled
+	MainnetDataSourceElectrum = "electrum" // an Electrum protocol server, such as Fulcrum
+	MainnetDataSourceBlkFiles = "blkfiles" // the raw blkNNNNN.dat files in a directory
 )
 
 type AppConfig struct {
@@ -28,6 +37,8 @@ type AppConfig struct {
 	AppDataPath    string `mapstructure:"app_data_path"`
 	ModbDataPath   string `mapstructure:"modb_data_path"`
 	SyncdbDataPath string `mapstructure:"syncdb_data_path"`
+	// the side index of the reward ledgers and the slash events of validators
+	LedgerDataPath string `mapstructure:"ledger_data_path"`
 	// rpc config
 	RpcEthGetLogsMaxResults int `mapstructure:"get_logs_max_results"`
 	// tm db config
@@ -47,11 +58,16 @@ type AppConfig struct {
 	// If more than this threshold, no further transactions can go in mempool
 	RecheckThreshold int `mapstructure:"recheck_threshold"`
 	//watcher config
+	MainnetDataSource  string `mapstructure:"mainnet-data-source"`
 	MainnetRPCUrl      string `mapstructure:"mainnet-rpc-url"`
 	MainnetRPCUsername string `mapstructure:"mainnet-rpc-username"`
 	MainnetRPCPassword string `mapstructure:"mainnet-rpc-password"`
-	SmartBchRPCUrl     string `mapstructure:"smartbch-rpc-url"`
-	Speedup            bool   `mapstructure:"watcher-speedup"`
+	// used when MainnetDataSource is "electrum", such as "tcp://127.0.0.1:50001" or "ssl://127.0.0.1:50002"
+	MainnetElectrumUrl string `mapstructure:"mainnet-electrum-url"`
+	// used when MainnetDataSource is "blkfiles", it is the "blocks" directory of a BCH fullnode
+	MainnetBlocksDir string `mapstructure:"mainnet-blocks-dir"`
+	SmartBchRPCUrl   string `mapstructure:"smartbch-rpc-url"`
+	Speedup          bool   `mapstructure:"watcher-speedup"`
 
 	FrontierGasLimit uint64 `mapstructure:"frontier-gaslimit"`
 
@@ -60,6 +76,17 @@ type AppConfig struct {
 	WithSyncDB bool `mapstructure:"with-syncdb"`
 
 	DisableBchClient bool `mapstructure:"disable-bch-client"`
+
+	// state-sync snapshot config
+	// take a snapshot of moeingads every n blocks, zero means no snapshot is taken
+	SnapshotInterval int64 `mapstructure:"snapshot-interval"`
+	// the number of recent snapshots kept on disk
+	SnapshotKeepRecent int    `mapstructure:"snapshot-keep-recent"`
+	SnapshotDir        string `mapstructure:"snapshot_dir"`
+
+	// the listen address of the prometheus metrics server, such as "tcp://127.0.0.1:26661",
+	// empty means the metrics are disabled
+	PrometheusListenAddr string `mapstructure:"prometheus-listen-addr"`
 }
 
 type ChainConfig struct {
@@ -82,6 +109,8 @@ func DefaultAppConfigWithHome(home string) *AppConfig {
 		AppDataPath:             filepath.Join(home, "data", AppDataPath),
 		ModbDataPath:            filepath.Join(home, "data", ModbDataPath),
 		SyncdbDataPath:          filepath.Join(home, "data", SyncdbDataPath),
+		LedgerDataPath:          filepath.Join(home, "data", LedgerDataPath),
+		SnapshotDir:             filepath.Join(home, "data", SnapshotsPath),
 		RpcEthGetLogsMaxResults: DefaultRpcEthGetLogsMaxResults,
 		RetainBlocks:            DefaultRetainBlocks,
 		NumKeptBlocks:           DefaultNumKeptBlocks,
@@ -91,8 +120,11 @@ func DefaultAppConfigWithHome(home string) *AppConfig {
 		TrunkCacheSize:          DefaultTrunkCacheSize,
 		ChangeRetainEveryN:      DefaultChangeRetainEveryN,
 		PruneEveryN:             DefaultPruneEveryN,
+		MainnetDataSource:       MainnetDataSourceBCHN,
 		MainnetRPCPassword:      "123456",
 		FrontierGasLimit:        uint64(BlockMaxGas / 200), //5Million gas
+		SnapshotInterval:        DefaultSnapshotInterval,
+		SnapshotKeepRecent:      DefaultSnapshotKeepRecent,
 	}
 }
 
@@ -35,17 +35,37 @@ const (
 	BlocksInEpochAfterStakingFork          int64 = 2016 * 10 * 60 / 6
 
 	// ccEpoch params
-	BlocksInCCEpoch    int64 = 7
-	CCEpochSwitchDelay int64 = 3 * 20 / 20
+	BlocksInCCEpoch int64 = 7
+	// the watcher emits a cc epoch 9 mainnet blocks after its end (the finalize depth), the delay covers it
+	// with a margin of 11 blocks, such that all the validators have collected the epoch when it is switched
+	CCEpochSwitchDelay int64 = 600 * 20
+
+	// spv params of cross chain deposits, the header chain is tracked since the checkpoint, and spv is
+	// disabled if the checkpoint header is empty
+	CCDepositConfirmations        int64  = 6
+	CCSpvCheckpointHeight         int64  = 0
+	CCSpvCheckpointHeader         string = ""
+	CCPowLimitBits                uint32 = 0x1d00ffff
+	CCPowAllowMinDifficultyBlocks bool   = false // allow the min difficulty blocks after 20 minutes, like testnet
+	CCAsertAnchorHeight           int64  = 661647
+	CCAsertAnchorBits             uint32 = 0x1804dafe
+	CCAsertAnchorParentTime       int64  = 1605447844
+	CCSpvMaxTipAge                int64  = 3 * 3600 // the spv tip must be this recent when a deposit is submitted
+
+	// redemption params of cross chain
+	CCCashAddrPrefix                 string = "bitcoincash"
+	CCRedemptionTimeoutMainnetBlocks int64  = 288 // the pending redemptions can be refunded after the cc epochs cover 48 hours of mainnet
 
 	// staking and slash params
 	ValidatorWatchWindowSize       int64  = 100
 	ValidatorWatchMinSignatures    int32  = 5
 	VotingPowerDivider             int64  = 10
-	OnlineWindowSize               int64  = 14400 // 24 * 3600s / 6s
-	MinOnlineSignatures            int32  = 8640  // OnlineWindowSize * 0.6
-	NotOnlineSlashAmountDivisor    uint64 = 16    // 1/16 MinimumStakingAmountAfterStakingFork
-	DuplicateSigSlashAMountDivisor uint64 = 4     // 1/4 MinimumStakingAmountAfterStakingFork
+	OnlineWindowSize               int64  = 14400  // 24 * 3600s / 6s
+	MinOnlineSignatures            int32  = 8640   // OnlineWindowSize * 0.6
+	NotOnlineSlashAmountDivisor    uint64 = 16     // 1/16 MinimumStakingAmountAfterStakingFork
+	DuplicateSigSlashAMountDivisor uint64 = 4      // 1/4 MinimumStakingAmountAfterStakingFork
+	JailCooldownBlocks             int64  = 14400  // a jailed validator can unjail itself after 24 hours
+	UnbondingBlocks                int64  = 100800 // the coins of a removed validator can be withdrawn after 7 days
 	SlashReceiver                  string = "0xad114243D2D61b78F76D63C1Fef6709219b2cd22"
 
 	// network params
@@ -59,4 +79,12 @@ const (
 	ShaGateSwitch          bool   = false
 	StakingForkHeight      int64  = 11006000 // near 20230815
 	SymbolSbchForkHeight   int64  = 13627300
+	EIP1559ForkHeight      int64  = math.MaxInt64 // accept EIP-2930 and EIP-1559 typed TXs
+	DelegationForkHeight   int64  = math.MaxInt64 // delegated staking
+	JailForkHeight         int64  = math.MaxInt64 // jail the validators which are not online
+	ParamGovForkHeight     int64  = math.MaxInt64 // on-chain governance of consensus params
+	UnbondingForkHeight    int64  = math.MaxInt64 // unbonding queue for the stakes of removed validators
+	StakingViewForkHeight  int64  = math.MaxInt64 // read-only staking functions callable from contracts
+	KeyRotationForkHeight  int64  = math.MaxInt64 // validators can rotate their consensus keys
+	StakingLogsForkHeight  int64  = math.MaxInt64 // engine-side staking events kept in world state and moeingdb
 )
@@ -32,14 +32,34 @@ const (
 	MaxActiveValidatorCount                int   = 50
 
 	// ccEpoch params
-	BlocksInCCEpoch    int64 = 7
-	CCEpochSwitchDelay int64 = 3 * 20 / 20
+	BlocksInCCEpoch int64 = 7
+	// the watcher emits a cc epoch 9 mainnet blocks after its end (the finalize depth), the delay covers it
+	// with a margin of 11 blocks, such that all the validators have collected the epoch when it is switched
+	CCEpochSwitchDelay int64 = 600 * 20
+
+	// spv params of cross chain deposits, the header chain is tracked since the checkpoint, and spv is
+	// disabled if the checkpoint header is empty
+	CCDepositConfirmations        int64  = 6
+	CCSpvCheckpointHeight         int64  = 0
+	CCSpvCheckpointHeader         string = ""
+	CCPowLimitBits                uint32 = 0x1d00ffff
+	CCPowAllowMinDifficultyBlocks bool   = true // allow the min difficulty blocks after 20 minutes, like testnet
+	CCAsertAnchorHeight           int64  = 1421481
+	CCAsertAnchorBits             uint32 = 0x1d00ffff
+	CCAsertAnchorParentTime       int64  = 1605445400
+	CCSpvMaxTipAge                int64  = 3 * 3600 // the spv tip must be this recent when a deposit is submitted
+
+	// redemption params of cross chain
+	CCCashAddrPrefix                 string = "bchtest"
+	CCRedemptionTimeoutMainnetBlocks int64  = 288 // the pending redemptions can be refunded after the cc epochs cover these mainnet blocks
 
 	// staking params
 	OnlineWindowSize               int64  = 500
 	MinOnlineSignatures            int32  = 400
 	NotOnlineSlashAmountDivisor    uint64 = 10
 	DuplicateSigSlashAMountDivisor uint64 = 5
+	JailCooldownBlocks             int64  = 500
+	UnbondingBlocks                int64  = 1000
 
 	// network params
 	IsAmber                           bool  = true
@@ -51,4 +71,12 @@ const (
 	ShaGateForkBlock       int64  = 80000000
 	ShaGateSwitch          bool   = false
 	StakingForkHeight      int64  = 80000000
+	EIP1559ForkHeight      int64  = 80000000
+	DelegationForkHeight   int64  = 80000000
+	JailForkHeight         int64  = 80000000
+	ParamGovForkHeight     int64  = 80000000
+	UnbondingForkHeight    int64  = 80000000
+	StakingViewForkHeight  int64  = 80000000
+	KeyRotationForkHeight  int64  = 80000000
+	StakingLogsForkHeight  int64  = 80000000
 )
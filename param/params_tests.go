@@ -36,6 +36,22 @@ const (
 	BlocksInCCEpoch    int64 = 3
 	CCEpochSwitchDelay int64 = 3*10 + 10
 
+	// spv params of cross chain deposits, the header chain is tracked since the checkpoint, and spv is
+	// disabled if the checkpoint header is empty
+	CCDepositConfirmations        int64  = 2
+	CCSpvCheckpointHeight         int64  = 0
+	CCSpvCheckpointHeader         string = ""
+	CCPowLimitBits                uint32 = 0x207fffff
+	CCPowAllowMinDifficultyBlocks bool   = false // allow the min difficulty blocks after 20 minutes, like testnet
+	CCAsertAnchorHeight           int64  = 0
+	CCAsertAnchorBits             uint32 = 0x207fffff
+	CCAsertAnchorParentTime       int64  = 1600000000
+	CCSpvMaxTipAge                int64  = 3 * 3600 // the spv tip must be this recent when a deposit is submitted
+
+	// redemption params of cross chain
+	CCCashAddrPrefix                 string = "bchtest"
+	CCRedemptionTimeoutMainnetBlocks int64  = 6 // the pending redemptions can be refunded after the cc epochs cover these mainnet blocks
+
 	// staking params
 	ValidatorWatchWindowSize       int64  = 100
 	ValidatorWatchMinSignatures    int32  = 5
@@ -44,6 +60,8 @@ const (
 	MinOnlineSignatures            int32  = 4320
 	NotOnlineSlashAmountDivisor    uint64 = 16
 	DuplicateSigSlashAMountDivisor uint64 = 4
+	JailCooldownBlocks             int64  = 7200
+	UnbondingBlocks                int64  = 14400
 	SlashReceiver                  string = ""
 
 	// network params
@@ -57,4 +75,12 @@ const (
 	ShaGateSwitch          bool   = false
 	StakingForkHeight      int64  = 11006000
 	SymbolSbchForkHeight   int64  = 13627300
+	EIP1559ForkHeight      int64  = 0
+	DelegationForkHeight   int64  = 0
+	JailForkHeight         int64  = 0
+	ParamGovForkHeight     int64  = 0
+	UnbondingForkHeight    int64  = 0
+	StakingViewForkHeight  int64  = 0
+	KeyRotationForkHeight  int64  = 0
+	StakingLogsForkHeight  int64  = 0
 )
@@ -42,6 +42,9 @@ prune_every_n = {{ .PruneEveryN }}
 # adding new transactions into mempool
 recheck_threshold = {{ .RecheckThreshold }}
 
+# Where the watcher gets BCH mainnet blocks from: "bchn", "electrum" or "blkfiles"
+mainnet-data-source = "{{ .MainnetDataSource }}"
+
 # BCH mainnet rpc url
 mainnet-rpc-url = "{{ .MainnetRPCUrl }}"
 
@@ -51,11 +54,29 @@ mainnet-rpc-username = "{{ .MainnetRPCUsername }}"
 # BCH mainnet rpc password
 mainnet-rpc-password = "{{ .MainnetRPCPassword }}"
 
+# Electrum protocol server (such as Fulcrum) url, used when mainnet-data-source is "electrum"
+mainnet-electrum-url = "{{ .MainnetElectrumUrl }}"
+
+# The "blocks" directory containing blkNNNNN.dat files, used when mainnet-data-source is "blkfiles"
+mainnet-blocks-dir = "{{ .MainnetBlocksDir }}"
+
 # smartBCH rpc url for epoch get
 smartbch-rpc-url = "{{ .SmartBchRPCUrl }}"
 
 # open epoch get to speedup mainnet block catch, work with "smartbch_rpc_url"
 watcher-speedup = {{ .Speedup }}
+
+# Take a state-sync snapshot of moeingads every n blocks, 0 means snapshots are disabled
+snapshot-interval = {{ .SnapshotInterval }}
+
+# How many recent snapshots are kept on disk
+snapshot-keep-recent = {{ .SnapshotKeepRecent }}
+
+# The directory where the snapshots are stored
+snapshot_dir = "{{ .SnapshotDir }}"
+
+# Where the prometheus metrics are served, such as "tcp://127.0.0.1:26661", empty means the metrics are disabled
+prometheus-listen-addr = "{{ .PrometheusListenAddr }}"
 `
 
 var configTemplate *template.Template
@@ -28,7 +28,7 @@ func GetAPIs(backend sbchapi.BackendService,
 	_netAPI := newNetAPI(backend.ChainId().Uint64(), logger)
 	_filterAPI := filters.NewAPI(backend, logger)
 	_web3API := newWeb3API(logger)
-	_txPoolAPI := newTxPoolAPI(logger)
+	_txPoolAPI := newTxPoolAPI(backend, logger)
 	_sbchAPI := newSbchAPI(backend, logger)
 	_debugAPI := newDebugAPI(_ethAPI, logger)
 	//_evmAPI := newEvmAPI(backend)
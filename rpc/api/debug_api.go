@@ -8,10 +8,12 @@ import (
 
 	gethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/mackerelio/go-osstat/memory"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/smartbch/smartbch/param"
+	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
 )
 
@@ -42,6 +44,9 @@ type DebugAPI interface {
 	NodeInfo() json.RawMessage
 	ValidatorOnlineInfos() json.RawMessage
 	ValidatorWatchInfos() json.RawMessage
+	TraceTransaction(hash gethcmn.Hash, config *TraceConfig) (interface{}, error)
+	TraceCall(args rpctypes.CallArgs, blockNrOrHash gethrpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error)
+	TraceBlockByNumber(number gethrpc.BlockNumber, config *TraceConfig) ([]*TxTraceResult, error)
 }
 
 type debugAPI struct {
@@ -195,3 +200,97 @@ func (api *debugAPI) ValidatorWatchInfos() json.RawMessage {
 	bytes, _ := json.Marshal(onlineInfosToMarshal)
 	return bytes
 }
+
+/* Tracing */
+
+// TraceTransaction re-executes the tx under the context of its parent block, after the TXs before it in the
+// same block are replayed in order, and returns the trace formatted by callTracer (the default) or prestateTracer.
+//
+// https://geth.ethereum.org/docs/rpc/ns-debug#debug_tracetransaction
+func (api *debugAPI) TraceTransaction(hash gethcmn.Hash, config *TraceConfig) (interface{}, error) {
+	api.logger.Debug("debug_traceTransaction")
+	backend := api.ethAPI.backend
+	if !backend.IsArchiveMode() {
+		return nil, errTraceNeedsArchiveMode
+	}
+	moTx, _, err := backend.GetTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+	txs, _, err := backend.GetTxListByHeight(uint32(moTx.BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	idx := 0
+	for idx < len(txs) && txs[idx].Hash != moTx.Hash {
+		idx++
+	}
+	if idx == len(txs) {
+		return nil, errTraceBlockNotFound
+	}
+	details := backend.CallsForTrace(txs[:idx+1], moTx.BlockNumber)
+	if details == nil {
+		return nil, errTraceBlockNotFound
+	}
+	return formatTraceResult(backend, moTxToGethTx(moTx), moTx.From, moTx.BlockNumber-1, details[idx], moTx.GasUsed, config)
+}
+
+// TraceCall executes the call under the context of the specified block, just like eth_call,
+// and returns the trace formatted by callTracer (the default) or prestateTracer.
+//
+// https://geth.ethereum.org/docs/rpc/ns-debug#debug_tracecall
+func (api *debugAPI) TraceCall(args rpctypes.CallArgs, blockNrOrHash gethrpc.BlockNumberOrHash,
+	config *TraceConfig) (interface{}, error) {
+
+	api.logger.Debug("debug_traceCall")
+	backend := api.ethAPI.backend
+	tx, from := createGethTxFromCallArgs(args)
+	height, err := getHeightArg(backend, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	detail := backend.CallForTrace(tx, from, height)
+	if detail == nil {
+		return nil, errTraceBlockNotFound
+	}
+	return formatTraceResult(backend, tx, from, height, detail, 0, config)
+}
+
+// TraceBlockByNumber traces all the TXs in the specified block, which are re-executed in order, such that
+// each TX runs on the changes made by the ones before it.
+//
+// https://geth.ethereum.org/docs/rpc/ns-debug#debug_traceblockbynumber
+func (api *debugAPI) TraceBlockByNumber(number gethrpc.BlockNumber, config *TraceConfig) ([]*TxTraceResult, error) {
+	api.logger.Debug("debug_traceBlockByNumber")
+	backend := api.ethAPI.backend
+	if !backend.IsArchiveMode() {
+		return nil, errTraceNeedsArchiveMode
+	}
+	height, err := getHeightArg(backend, gethrpc.BlockNumberOrHashWithNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	if height < 0 {
+		height = backend.LatestHeight()
+	}
+	txs, _, err := backend.GetTxListByHeight(uint32(height))
+	if err != nil {
+		return nil, err
+	}
+
+	details := backend.CallsForTrace(txs, height)
+	if details == nil && len(txs) != 0 {
+		return nil, errTraceBlockNotFound
+	}
+	results := make([]*TxTraceResult, len(txs))
+	for i, moTx := range txs {
+		results[i] = &TxTraceResult{TxHash: moTx.Hash}
+		result, err := formatTraceResult(backend, moTxToGethTx(moTx), moTx.From, height-1, details[i], moTx.GasUsed, config)
+		if err != nil {
+			results[i].Error = err.Error()
+		} else {
+			results[i].Result = result
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartbch/smartbch/internal/testutils"
+	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
+)
+
+func TestTraceTransaction(t *testing.T) {
+	key, addr := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestAppInArchiveMode(key)
+	defer _app.Destroy()
+	_api := createDebugAPI(_app)
+
+	tx1, _, contract3Addr := _app.DeployContractInBlock(key, contract3CreationBytecode)
+	_app.EnsureTxSuccess(tx1.Hash())
+	tx2, _, contract2Addr := _app.DeployContractInBlock(key,
+		testutils.JoinBytes(contract2CreationBytecode, make([]byte, 12), contract3Addr[:]))
+	_app.EnsureTxSuccess(tx2.Hash())
+	tx3, _, contract1Addr := _app.DeployContractInBlock(key,
+		testutils.JoinBytes(contract1CreationBytecode, make([]byte, 12), contract2Addr[:], make([]byte, 12), contract3Addr[:]))
+	_app.EnsureTxSuccess(tx3.Hash())
+
+	callData := testutils.JoinBytes(testutils.HexToBytes(methodIdCall2), testutils.UintToBytes32(0x100))
+	tx4, h4 := _app.MakeAndExecTxInBlock(key, contract1Addr, 0, callData)
+	_app.EnsureTxSuccess(tx4.Hash())
+
+	ret, err := _api.TraceTransaction(tx4.Hash(), nil)
+	require.NoError(t, err)
+	top := ret.(*CallFrame)
+	require.Equal(t, "CALL", top.Type)
+	require.Equal(t, addr, top.From)
+	require.Equal(t, contract1Addr, top.To)
+	require.Equal(t, hexutil.Uint64(tx4.Gas()), top.Gas)
+	require.Equal(t, hexutil.Uint64(_app.GetTx(tx4.Hash()).GasUsed), top.GasUsed)
+	require.Len(t, top.Calls, 2)
+	require.Equal(t, contract2Addr, top.Calls[0].To)
+	require.Len(t, top.Calls[0].Calls, 2)
+	require.Equal(t, "CALL", top.Calls[0].Calls[0].Type)
+	require.Equal(t, "STATICCALL", top.Calls[0].Calls[1].Type)
+	require.Nil(t, top.Calls[0].Calls[1].Value)
+	require.Equal(t, contract3Addr, top.Calls[1].Calls[1].To)
+
+	tracer := callTracerName
+	ret, err = _api.TraceTransaction(tx4.Hash(), &TraceConfig{
+		Tracer:       &tracer,
+		TracerConfig: json.RawMessage(`{"onlyTopCall":true}`),
+	})
+	require.NoError(t, err)
+	require.Len(t, ret.(*CallFrame).Calls, 0)
+
+	tracer = prestateTracerName
+	ret, err = _api.TraceTransaction(tx4.Hash(), &TraceConfig{Tracer: &tracer})
+	require.NoError(t, err)
+	prestate := ret.(map[gethcmn.Address]*PrestateAccount)
+	require.Len(t, prestate, 4)
+	require.Equal(t, uint64(3), prestate[addr].Nonce)
+	require.NotEmpty(t, prestate[contract3Addr].Code)
+
+	tracer = "4byteTracer"
+	_, err = _api.TraceTransaction(tx4.Hash(), &TraceConfig{Tracer: &tracer})
+	require.Error(t, err)
+
+	results, err := _api.TraceBlockByNumber(gethrpc.BlockNumber(h4), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, tx4.Hash(), results[0].TxHash)
+	require.Equal(t, top, results[0].Result)
+}
+
+func TestTraceContractCreation(t *testing.T) {
+	key, addr := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestAppInArchiveMode(key)
+	defer _app.Destroy()
+	_api := createDebugAPI(_app)
+
+	tx, _, contractAddr := _app.DeployContractInBlock(key, contract3CreationBytecode)
+	_app.EnsureTxSuccess(tx.Hash())
+
+	ret, err := _api.TraceTransaction(tx.Hash(), nil)
+	require.NoError(t, err)
+	top := ret.(*CallFrame)
+	require.Equal(t, "CREATE", top.Type)
+	require.Equal(t, addr, top.From)
+	require.Equal(t, contractAddr, top.To)
+	require.Empty(t, top.Error)
+}
+
+func TestTraceDependentTxsInBlock(t *testing.T) {
+	key, addr := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestAppInArchiveMode(key)
+	defer _app.Destroy()
+	_api := createDebugAPI(_app)
+
+	// the contract is deployed, counted and read in the same block
+	tx1, _ := _app.MakeAndSignTxWithNonce(key, nil, 0, contract3CreationBytecode, 0)
+	contractAddr := gethcrypto.CreateAddress(addr, 0)
+	tx2, _ := _app.MakeAndSignTxWithNonce(key, &contractAddr, 0,
+		testutils.JoinBytes(testutils.HexToBytes("0xe73620c3"), testutils.UintToBytes32(0x10)), 1)
+	tx3, _ := _app.MakeAndSignTxWithNonce(key, &contractAddr, 0, testutils.HexToBytes("0x61bc221a"), 2)
+	h := _app.ExecTxsInBlock(tx1, tx2, tx3)
+	_app.EnsureTxSuccess(tx1.Hash())
+	_app.EnsureTxSuccess(tx2.Hash())
+	_app.EnsureTxSuccess(tx3.Hash())
+
+	ret, err := _api.TraceTransaction(tx3.Hash(), nil)
+	require.NoError(t, err)
+	top := ret.(*CallFrame)
+	require.Equal(t, contractAddr, top.To)
+	require.Empty(t, top.Error)
+	require.Equal(t, hexutil.Bytes(testutils.UintToBytes32(1)), top.Output) // counted by tx2
+
+	results, err := _api.TraceBlockByNumber(gethrpc.BlockNumber(h), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, tx1.Hash(), results[0].TxHash)
+	require.Equal(t, "CREATE", results[0].Result.(*CallFrame).Type)
+	require.Equal(t, hexutil.Bytes(testutils.JoinBytes(make([]byte, 23), []byte{0x10}, make([]byte, 8))),
+		results[1].Result.(*CallFrame).Output)
+	require.Equal(t, top, results[2].Result)
+}
+
+func TestTraceCall(t *testing.T) {
+	key, addr := testutils.GenKeyAndAddr()
+	_, addr2 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestAppInArchiveMode(key)
+	defer _app.Destroy()
+	_api := createDebugAPI(_app)
+
+	tx, h := _app.MakeAndExecTxInBlock(key, addr2, 1000, nil)
+	_app.EnsureTxSuccess(tx.Hash())
+
+	gas := hexutil.Uint64(100000)
+	ret, err := _api.TraceCall(rpctypes.CallArgs{
+		From:  &addr,
+		To:    &addr2,
+		Gas:   &gas,
+		Value: (*hexutil.Big)(hexutil.MustDecodeBig("0x64")),
+	}, wrapBlockNumber(gethrpc.BlockNumber(h)), nil)
+	require.NoError(t, err)
+	top := ret.(*CallFrame)
+	require.Equal(t, "CALL", top.Type)
+	require.Equal(t, addr, top.From)
+	require.Equal(t, addr2, top.To)
+	require.Equal(t, "0x64", top.Value.String())
+	require.Empty(t, top.Error)
+}
+
+func createDebugAPI(_app *testutils.TestApp) DebugAPI {
+	return newDebugAPI(createEthAPI(_app), _app.Logger())
+}
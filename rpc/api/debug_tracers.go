@@ -0,0 +1,256 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartbch/moeingevm/ebp"
+	motypes "github.com/smartbch/moeingevm/types"
+	sbchapi "github.com/smartbch/smartbch/api"
+	"github.com/smartbch/smartbch/internal/ethutils"
+)
+
+const (
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+)
+
+var (
+	errTraceNeedsArchiveMode = errors.New("tracing transactions requires archive mode")
+	errTraceBlockNotFound    = errors.New("block to trace not found")
+)
+
+// TraceConfig is a subset of geth's TraceConfig, only the native callTracer and prestateTracer are supported
+type TraceConfig struct {
+	Tracer       *string         `json:"tracer"`
+	TracerConfig json.RawMessage `json:"tracerConfig,omitempty"`
+}
+
+type callTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"`
+}
+
+// CallFrame is the result of callTracer, which has the same format as geth
+type CallFrame struct {
+	Type    string          `json:"type"`
+	From    gethcmn.Address `json:"from"`
+	To      gethcmn.Address `json:"to"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  hexutil.Bytes   `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*CallFrame    `json:"calls,omitempty"`
+}
+
+// PrestateAccount is an item in the result of prestateTracer, which has the same format as geth
+type PrestateAccount struct {
+	Balance *hexutil.Big                  `json:"balance"`
+	Nonce   uint64                        `json:"nonce,omitempty"`
+	Code    hexutil.Bytes                 `json:"code,omitempty"`
+	Storage map[gethcmn.Hash]gethcmn.Hash `json:"storage,omitempty"`
+}
+
+// TxTraceResult is an item in the result of debug_traceBlockByNumber
+type TxTraceResult struct {
+	TxHash gethcmn.Hash `json:"txHash"`
+	Result interface{}  `json:"result,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+func formatTraceResult(backend sbchapi.BackendService, tx *gethtypes.Transaction, from gethcmn.Address,
+	stateHeight int64, detail *sbchapi.CallDetail, gasUsed uint64, config *TraceConfig) (interface{}, error) {
+
+	tracer := callTracerName
+	if config != nil && config.Tracer != nil {
+		tracer = *config.Tracer
+	}
+	switch tracer {
+	case callTracerName:
+		var tracerConfig callTracerConfig
+		if config != nil && len(config.TracerConfig) > 0 {
+			if err := json.Unmarshal(config.TracerConfig, &tracerConfig); err != nil {
+				return nil, err
+			}
+		}
+		return buildCallFrame(tx, from, detail, gasUsed, tracerConfig.OnlyTopCall), nil
+	case prestateTracerName:
+		return buildPrestate(backend, tx, from, stateHeight, detail), nil
+	default:
+		return nil, fmt.Errorf("tracer %s is not supported", tracer)
+	}
+}
+
+// buildCallFrame builds a call frame tree from the internal calls, whose first element is the top call
+func buildCallFrame(tx *gethtypes.Transaction, from gethcmn.Address, detail *sbchapi.CallDetail,
+	gasUsed uint64, onlyTopCall bool) *CallFrame {
+
+	callList := buildInternalCallList(detail.InternalTxCalls, detail.InternalTxReturns)
+	if len(callList) == 0 { // transfers and calls to predefined contracts have no internal calls
+		if gasUsed == 0 {
+			gasUsed = detail.GasUsed
+		}
+		top := &CallFrame{
+			Type:    "CALL",
+			From:    from,
+			Value:   (*hexutil.Big)(tx.Value()),
+			Gas:     hexutil.Uint64(tx.Gas()),
+			GasUsed: hexutil.Uint64(gasUsed),
+			Input:   tx.Data(),
+			Output:  detail.OutData,
+			Error:   statusToTraceError(detail.Status),
+		}
+		if tx.To() != nil {
+			top.To = *tx.To()
+		}
+		return top
+	}
+
+	var top *CallFrame
+	var stack []*CallFrame
+	var depthStack []int32
+	for _, itx := range callList {
+		frame := newCallFrame(itx)
+		for len(depthStack) > 0 && depthStack[len(depthStack)-1] >= itx.depth {
+			stack = stack[:len(stack)-1]
+			depthStack = depthStack[:len(depthStack)-1]
+		}
+		if len(stack) == 0 {
+			top = frame
+		} else if !onlyTopCall {
+			parent := stack[len(stack)-1]
+			parent.Calls = append(parent.Calls, frame)
+		}
+		stack = append(stack, frame)
+		depthStack = append(depthStack, itx.depth)
+	}
+
+	// the internal call of top level does not count in the intrinsic gas
+	intrinsicGas := tx.Gas() - uint64(top.Gas)
+	top.Gas = hexutil.Uint64(tx.Gas())
+	if gasUsed != 0 {
+		top.GasUsed = hexutil.Uint64(gasUsed)
+	} else {
+		top.GasUsed += hexutil.Uint64(intrinsicGas)
+	}
+	return top
+}
+
+func newCallFrame(itx *InternalTx) *CallFrame {
+	frame := &CallFrame{
+		Type:    strings.ToUpper(strings.Split(itx.CallPath, "_")[0]),
+		From:    itx.From,
+		To:      itx.To,
+		Value:   itx.Value,
+		Gas:     itx.GasLimit,
+		GasUsed: itx.GasUsed,
+		Input:   itx.Input,
+		Output:  itx.Output,
+		Error:   statusToTraceError(itx.status),
+	}
+	if itx.CreatedAddress != nil {
+		frame.To = *itx.CreatedAddress
+	}
+	if frame.Type == "STATICCALL" || frame.Type == "DELEGATECALL" {
+		frame.Value = nil
+	}
+	return frame
+}
+
+func statusToTraceError(status int) string {
+	if !ebp.StatusIsFailure(status) {
+		return ""
+	}
+	if str := ebp.StatusToStr(status); str != "revert" {
+		return str
+	}
+	return "execution reverted"
+}
+
+// buildPrestate collects the accounts touched by the tx and their states before the tx is executed.
+// The storage slots can only be collected when ebp.EnableRWList is on, which are read during the replay,
+// while the balances, nonces and codes are read at block#stateHeight, without the changes made by the
+// TXs before the tx in the same block.
+func buildPrestate(backend sbchapi.BackendService, tx *gethtypes.Transaction, from gethcmn.Address,
+	stateHeight int64, detail *sbchapi.CallDetail) map[gethcmn.Address]*PrestateAccount {
+
+	addrs := []gethcmn.Address{from}
+	if tx.To() != nil && *tx.To() != (gethcmn.Address{}) {
+		addrs = append(addrs, *tx.To())
+	}
+	for _, call := range detail.InternalTxCalls {
+		addrs = append(addrs, call.Sender, call.Destination)
+	}
+	if detail.RwLists != nil {
+		for _, op := range detail.RwLists.AccountRList {
+			addrs = append(addrs, op.Addr)
+		}
+	}
+
+	result := make(map[gethcmn.Address]*PrestateAccount)
+	seqToAddr := make(map[uint64]gethcmn.Address)
+	for _, addr := range addrs {
+		if _, ok := result[addr]; ok || addr == (gethcmn.Address{}) {
+			continue
+		}
+		balance, err := backend.GetBalance(addr, stateHeight)
+		if err != nil { // the account does not exist before the tx
+			continue
+		}
+		nonce, _ := backend.GetNonce(addr, stateHeight)
+		code, _ := backend.GetCode(addr, stateHeight)
+		result[addr] = &PrestateAccount{
+			Balance: (*hexutil.Big)(balance),
+			Nonce:   nonce,
+			Code:    code,
+		}
+		seqToAddr[getSeqForPrestate(backend, addr)] = addr
+	}
+
+	if detail.RwLists == nil {
+		return result
+	}
+	for _, op := range detail.RwLists.StorageRList {
+		addr, ok := seqToAddr[op.Seq]
+		if !ok {
+			continue
+		}
+		acc := result[addr]
+		if acc.Storage == nil {
+			acc.Storage = make(map[gethcmn.Hash]gethcmn.Hash)
+		}
+		key := gethcmn.BytesToHash([]byte(op.Key))
+		if _, ok := acc.Storage[key]; !ok { // only the first read shows the state before the tx
+			acc.Storage[key] = gethcmn.BytesToHash(op.Value)
+		}
+	}
+	return result
+}
+
+func getSeqForPrestate(backend sbchapi.BackendService, addr gethcmn.Address) uint64 {
+	if addr == gethcmn.Address(sbchapi.SEP206ContractAddress) {
+		return 2000
+	}
+	return backend.GetSeq(addr)
+}
+
+// moTxToGethTx converts a history tx to a geth tx which can be run again, a contract creation tx is
+// recorded with a zero To address
+func moTxToGethTx(tx *motypes.Transaction) *gethtypes.Transaction {
+	var to *gethcmn.Address
+	if !isZeroAddress(tx.To) {
+		addr := gethcmn.Address(tx.To)
+		to = &addr
+	}
+	value := big.NewInt(0).SetBytes(tx.Value[:])
+	gasPrice := big.NewInt(0).SetBytes(tx.GasPrice[:])
+	return ethutils.NewTx(tx.Nonce, to, value, tx.Gas, gasPrice, tx.Input)
+}
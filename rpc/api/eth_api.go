@@ -3,6 +3,7 @@ package api
 import (
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"math/big"
 	"sort"
 	"sync/atomic"
@@ -20,6 +21,7 @@ import (
 	"github.com/smartbch/moeingevm/types"
 	sbchapi "github.com/smartbch/smartbch/api"
 	"github.com/smartbch/smartbch/internal/ethutils"
+	"github.com/smartbch/smartbch/param"
 	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
 	"github.com/smartbch/smartbch/staking"
 )
@@ -29,6 +31,10 @@ const (
 	DefaultGasPrice = 20000000000
 	// DefaultRPCGasLimit is default gas limit for RPC call operations
 	DefaultRPCGasLimit = 10000000
+	// maxFeeHistory is the maximum number of blocks that can be retrieved by eth_feeHistory
+	maxFeeHistory = 1024
+	// maxProofStorageKeys is the maximum number of storage keys that can be proven by one eth_getProof
+	maxProofStorageKeys = 32
 )
 
 // smartBCH genesis height is 1, so we need this to make it compatible with Ethereum
@@ -43,8 +49,13 @@ var fakeBlock0 = &types.Block{
 var _ PublicEthAPI = (*ethAPI)(nil)
 
 var (
-	errPendingBlockNum = errors.New("pending block is not supported")
-	errFutureBlockNum  = errors.New("block has not been mined")
+	errPendingBlockNum   = errors.New("pending block is not supported")
+	errFutureBlockNum    = errors.New("block has not been mined")
+	errInvalidPercentile = errors.New("invalid reward percentile")
+	errNotLatestProof    = errors.New("proofs can only be generated for the latest block")
+	errTooManyProofKeys  = fmt.Errorf("at most %d storage keys can be proven at a time", maxProofStorageKeys)
+
+	emptyCodeHash = crypto.Keccak256Hash(nil)
 )
 
 type PublicEthAPI interface {
@@ -54,6 +65,7 @@ type PublicEthAPI interface {
 	ChainId() hexutil.Uint64
 	Coinbase() (common.Address, error)
 	EstimateGas(args rpctypes.CallArgs, blockNrOrHash *gethrpc.BlockNumberOrHash) (hexutil.Uint64, error)
+	FeeHistory(blockCount gethrpc.DecimalOrHex, lastBlock gethrpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error)
 	GasPrice() *hexutil.Big
 	GetBalance(addr common.Address, blockNrOrHash gethrpc.BlockNumberOrHash) (*hexutil.Big, error)
 	GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error)
@@ -61,6 +73,7 @@ type PublicEthAPI interface {
 	GetBlockTransactionCountByHash(hash common.Hash) *hexutil.Uint
 	GetBlockTransactionCountByNumber(blockNum gethrpc.BlockNumber) *hexutil.Uint
 	GetCode(addr common.Address, blockNrOrHash gethrpc.BlockNumberOrHash) (hexutil.Bytes, error)
+	GetProof(addr common.Address, storageKeys []string, blockNrOrHash gethrpc.BlockNumberOrHash) (*AccountResult, error)
 	GetStorageAt(addr common.Address, key string, blockNrOrHash gethrpc.BlockNumberOrHash) (hexutil.Bytes, error)
 	GetTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (*rpctypes.Transaction, error)
 	GetTransactionByBlockNumberAndIndex(blockNum gethrpc.BlockNumber, idx hexutil.Uint) (*rpctypes.Transaction, error)
@@ -71,6 +84,7 @@ type PublicEthAPI interface {
 	GetUncleByBlockNumberAndIndex(number hexutil.Uint, idx hexutil.Uint) map[string]interface{}
 	GetUncleCountByBlockHash(_ common.Hash) hexutil.Uint
 	GetUncleCountByBlockNumber(_ gethrpc.BlockNumber) hexutil.Uint
+	MaxPriorityFeePerGas() *hexutil.Big
 	ProtocolVersion() hexutil.Uint
 	SendRawTransaction(data hexutil.Bytes) (common.Hash, error) // ?
 	SendTransaction(args rpctypes.SendTxArgs) (common.Hash, error)
@@ -156,6 +170,69 @@ func (api *ethAPI) GasPrice() *hexutil.Big {
 	return (*hexutil.Big)(big.NewInt(0).SetBytes(val))
 }
 
+// https://github.com/ethereum/go-ethereum/blob/v1.10.7/internal/ethapi/api.go#L75
+// There is no base fee, so the whole gas price is the priority fee.
+func (api *ethAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	api.logger.Debug("eth_maxPriorityFeePerGas")
+	return api.GasPrice()
+}
+
+// https://github.com/ethereum/go-ethereum/blob/v1.10.7/internal/ethapi/api.go#L90
+// The base fees are always zero and the rewards are the gas prices paid by TXs.
+func (api *ethAPI) FeeHistory(blockCount gethrpc.DecimalOrHex, lastBlock gethrpc.BlockNumber,
+	rewardPercentiles []float64) (*FeeHistoryResult, error) {
+
+	api.logger.Debug("eth_feeHistory")
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
+		}
+	}
+	if blockCount > maxFeeHistory {
+		blockCount = maxFeeHistory
+	}
+
+	latest := api.backend.LatestHeight()
+	last := lastBlock.Int64()
+	if last < 0 {
+		last = latest
+	} else if last > latest {
+		return nil, errFutureBlockNum
+	}
+	oldest := last - int64(blockCount) + 1
+	if oldest < 1 {
+		oldest = 1 // the genesis block's height is 1
+	}
+	result := &FeeHistoryResult{OldestBlock: (*hexutil.Big)(big.NewInt(oldest))}
+	if blockCount == 0 || last < oldest {
+		return result, nil
+	}
+
+	result.GasUsedRatio = make([]float64, 0, last-oldest+1)
+	result.BaseFee = make([]*hexutil.Big, 0, last-oldest+2)
+	if len(rewardPercentiles) != 0 {
+		result.Reward = make([][]*hexutil.Big, 0, last-oldest+1)
+	}
+	for height := oldest; height <= last; height++ {
+		block, err := api.backend.BlockByNumber(height)
+		if err != nil {
+			return nil, err
+		}
+		result.GasUsedRatio = append(result.GasUsedRatio, float64(block.GasUsed)/float64(param.BlockMaxGas))
+		result.BaseFee = append(result.BaseFee, (*hexutil.Big)(big.NewInt(0)))
+		if len(rewardPercentiles) == 0 {
+			continue
+		}
+		txs, _, err := api.backend.GetTxListByHeight(uint32(height))
+		if err != nil {
+			return nil, err
+		}
+		result.Reward = append(result.Reward, getRewardPercentiles(txs, rewardPercentiles))
+	}
+	result.BaseFee = append(result.BaseFee, (*hexutil.Big)(big.NewInt(0))) // the next block's base fee
+	return result, nil
+}
+
 // https://eth.wiki/json-rpc/API#eth_getBalance
 func (api *ethAPI) GetBalance(addr common.Address, blockNrOrHash gethrpc.BlockNumberOrHash) (*hexutil.Big, error) {
 	api.logger.Debug("eth_getBalance")
@@ -208,6 +285,42 @@ func (api *ethAPI) GetStorageAt(addr common.Address, key string, blockNrOrHash g
 	return val, nil
 }
 
+// https://eips.ethereum.org/EIPS/eip-1186
+// The proofs are against the app hash of the latest world state, which is the stateRoot of the latest block
+// and the AppHash in the header of the next tendermint block. See package stateproof for the proof format.
+func (api *ethAPI) GetProof(addr common.Address, storageKeys []string,
+	blockNrOrHash gethrpc.BlockNumberOrHash) (*AccountResult, error) {
+
+	api.logger.Debug("eth_getProof")
+	if len(storageKeys) > maxProofStorageKeys {
+		return nil, errTooManyProofKeys
+	}
+	latestHeight := api.backend.LatestHeight()
+	if blockNum, ok := blockNrOrHash.Number(); ok {
+		if blockNum != gethrpc.LatestBlockNumber && blockNum.Int64() != latestHeight {
+			return nil, errNotLatestProof
+		}
+	} else {
+		block, err := api.backend.BlockByHash(*blockNrOrHash.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if block.Number != latestHeight {
+			return nil, errNotLatestProof
+		}
+	}
+
+	slots := make([]common.Hash, len(storageKeys))
+	for i, key := range storageKeys {
+		slots[i] = common.HexToHash(key)
+	}
+	proof, err := api.backend.GetProof(addr, slots)
+	if err != nil {
+		return nil, err
+	}
+	return toAccountResult(addr, storageKeys, proof), nil
+}
+
 // https://eth.wiki/json-rpc/API#eth_getBlockByHash
 func (api *ethAPI) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error) {
 	api.logger.Debug("eth_getBlockByHash")
@@ -215,7 +328,7 @@ func (api *ethAPI) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]int
 	var txs []*types.Transaction
 	var sigs [][65]byte
 	if hash == zeroHash {
-		return blockToRpcResp(fakeBlock0, txs, sigs), nil
+		return blockToRpcResp(api.backend, fakeBlock0, txs, sigs), nil
 	}
 	block, err := api.backend.BlockByHash(hash)
 	if err != nil {
@@ -232,7 +345,7 @@ func (api *ethAPI) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]int
 		}
 	}
 
-	return blockToRpcResp(block, txs, sigs), nil
+	return blockToRpcResp(api.backend, block, txs, sigs), nil
 }
 
 // https://eth.wiki/json-rpc/API#eth_getBlockByNumber
@@ -254,7 +367,7 @@ func (api *ethAPI) GetBlockByNumber(blockNum gethrpc.BlockNumber, fullTx bool) (
 			return nil, err
 		}
 	}
-	return blockToRpcResp(block, txs, sigs), nil
+	return blockToRpcResp(api.backend, block, txs, sigs), nil
 }
 
 // https://eth.wiki/json-rpc/API#eth_getBlockTransactionCountByHash
@@ -312,7 +425,7 @@ func (api *ethAPI) GetTransactionByHash(hash common.Hash) (*rpctypes.Transaction
 	if err != nil {
 		return nil, nil
 	}
-	return txToRpcResp(tx, sig), nil
+	return txToRpcResp(api.backend, tx, sig), nil
 }
 
 // https://eth.wiki/json-rpc/API#eth_getTransactionCount
@@ -353,18 +466,18 @@ func (api *ethAPI) getTxByIdx(block *types.Block, idx hexutil.Uint) (*rpctypes.T
 		return nil, err
 	}
 
-	return txToRpcResp(tx, sig), nil
+	return txToRpcResp(api.backend, tx, sig), nil
 }
 
 // https://eth.wiki/json-rpc/API#eth_getTransactionReceipt
 func (api *ethAPI) GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error) {
 	api.logger.Debug("eth_getTransactionReceipt")
-	tx, _, err := api.backend.GetTransaction(hash)
+	tx, sig, err := api.backend.GetTransaction(hash)
 	if err != nil {
 		// the transaction is not yet available
 		return nil, nil
 	}
-	return txToReceiptRpcResp(tx), nil
+	return txToReceiptRpcResp(api.backend, tx, sig), nil
 }
 
 // https://eth.wiki/json-rpc/API#eth_getUncleByBlockHashAndIndex
@@ -431,12 +544,12 @@ func (api *ethAPI) SendTransaction(args rpctypes.SendTxArgs) (common.Hash, error
 		}
 	}
 
-	tx, err := createGethTxFromSendTxArgs(args)
+	chainID := api.backend.ChainId()
+	tx, err := createGethTxFromSendTxArgs(args, chainID)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	chainID := api.backend.ChainId()
 	tx, err = ethutils.SignTx(tx, chainID, privKey)
 	if err != nil {
 		return common.Hash{}, err
@@ -559,6 +672,15 @@ func createGethTxFromCallArgs(args rpctypes.CallArgs,
 		data = *args.Data
 	}
 
+	if args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil {
+		var accessList gethtypes.AccessList
+		if args.AccessList != nil {
+			accessList = *args.AccessList
+		}
+		gasTipCap, gasFeeCap := getFeeCaps(args.MaxPriorityFeePerGas, args.MaxFeePerGas)
+		tx := ethutils.NewDynamicFeeTx(nil, 0, &to, val, gasLimit, gasTipCap, gasFeeCap, data, accessList)
+		return tx, from
+	}
 	tx := ethutils.NewTx(0, &to, val, gasLimit, gasPrice, data)
 	return tx, from
 }
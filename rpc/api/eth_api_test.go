@@ -25,6 +25,7 @@ import (
 	"github.com/smartbch/smartbch/rpc/internal/ethapi"
 	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
 	"github.com/smartbch/smartbch/staking"
+	"github.com/smartbch/smartbch/stateproof"
 )
 
 // testdata/sol/contracts/basic/Counter.sol
@@ -326,6 +327,92 @@ func TestGetStorageAt(t *testing.T) {
 	require.Equal(t, val0, getStorageAt(_api, addr2, "0x7890", -1))
 }
 
+func TestGetProof(t *testing.T) {
+	key, addr := testutils.GenKeyAndAddr()
+	_, addr2 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+	_app.WaitLock()
+	defer _app.Destroy()
+	_api := createEthAPI(_app)
+
+	ctx := _app.GetRunTxContext()
+	seq := ctx.GetAccount(addr).Sequence()
+	sKey := bytes.Repeat([]byte{0xab, 0xcd}, 16)
+	sVal := bytes.Repeat([]byte{0x12, 0x34}, 16)
+	ctx.SetStorageAt(seq, string(sKey), sVal)
+	code := append(bytes.Repeat([]byte{0xff}, 32), 0x0 /*version byte*/, 0x12, 0x34)
+	ctx.Rbt.Set(types.GetBytecodeKey(addr), types.NewBytecodeInfo(code).Bytes())
+	ctx.Close(true)
+	_app.CloseTxEngineContext()
+	_app.CloseTrunk()
+
+	sKeyHex := "0x" + hex.EncodeToString(sKey)
+	result, err := _api.GetProof(addr, []string{sKeyHex, "0x7890"}, latestBlockNumber())
+	require.NoError(t, err)
+	require.Equal(t, "0x989680", result.Balance.String())
+	require.Equal(t, hexutil.Uint64(seq), result.Sequence)
+	require.Len(t, result.AccountProof, 1)
+	acc, err := stateproof.VerifyAccount(result.AppHash, addr, hexutil.MustDecode(result.AccountProof[0]))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x989680), acc.Balance().Uint64())
+	require.Len(t, result.CodeProof, 1)
+	codeHash, err := stateproof.VerifyCodeHash(result.AppHash, addr, hexutil.MustDecode(result.CodeProof[0]))
+	require.NoError(t, err)
+	require.Equal(t, result.CodeHash, codeHash)
+	require.Equal(t, sKeyHex, result.StorageProof[0].Key)
+	require.Len(t, result.StorageProof[0].Proof, 1)
+	val, err := stateproof.VerifyStorage(result.AppHash, seq, gethcmn.BytesToHash(sKey),
+		hexutil.MustDecode(result.StorageProof[0].Proof[0]))
+	require.NoError(t, err)
+	require.Equal(t, sVal, val)
+	require.Equal(t, new(big.Int).SetBytes(sVal), result.StorageProof[0].Value.ToInt())
+	require.Len(t, result.StorageProof[1].Proof, 0)
+	require.Equal(t, "0x0", result.StorageProof[1].Value.String())
+
+	result, err = _api.GetProof(addr2, []string{sKeyHex}, latestBlockNumber())
+	require.NoError(t, err)
+	require.Len(t, result.AccountProof, 0)
+	require.Equal(t, "0x0", result.Balance.String())
+	require.Equal(t, gethcrypto.Keccak256Hash(nil), result.CodeHash)
+	require.Len(t, result.StorageProof[0].Proof, 0)
+
+	_, err = _api.GetProof(addr, nil, wrapBlockNumber(100))
+	require.Error(t, err)
+	_, err = _api.GetProof(addr, make([]string, maxProofStorageKeys+1), latestBlockNumber())
+	require.ErrorIs(t, err, errTooManyProofKeys)
+}
+
+func TestGetProof_missingKeys(t *testing.T) {
+	key, addr := testutils.GenKeyAndAddr()
+	_, addr2 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestAppInArchiveMode(key)
+	defer _app.Destroy()
+	_api := createEthAPI(_app)
+	seq := _app.GetSeq(addr)
+
+	result, err := _api.GetProof(addr, []string{"0x7890"}, latestBlockNumber())
+	require.NoError(t, err)
+	require.Len(t, result.CodeProof, 1)
+	codeHash, err := stateproof.VerifyCodeHash(result.AppHash, addr, hexutil.MustDecode(result.CodeProof[0]))
+	require.NoError(t, err)
+	require.Equal(t, gethcmn.Hash{}, codeHash)
+	require.Equal(t, emptyCodeHash, result.CodeHash)
+	require.Len(t, result.StorageProof[0].Proof, 1)
+	val, err := stateproof.VerifyStorage(result.AppHash, seq, gethcmn.HexToHash("0x7890"),
+		hexutil.MustDecode(result.StorageProof[0].Proof[0]))
+	require.NoError(t, err)
+	require.Nil(t, val)
+	require.Equal(t, "0x0", result.StorageProof[0].Value.String())
+
+	result, err = _api.GetProof(addr2, nil, latestBlockNumber())
+	require.NoError(t, err)
+	require.Len(t, result.AccountProof, 1)
+	acc, err := stateproof.VerifyAccount(result.AppHash, addr2, hexutil.MustDecode(result.AccountProof[0]))
+	require.NoError(t, err)
+	require.Nil(t, acc)
+	require.Equal(t, "0x0", result.Balance.String())
+}
+
 func TestQueryBlockByNum(t *testing.T) {
 	_app := testutils.CreateTestApp()
 	defer _app.Destroy()
@@ -660,6 +747,90 @@ func TestTxVRS(t *testing.T) {
 	checkTxVRS(t, tx, txResult)
 }
 
+func TestDynamicFeeTx(t *testing.T) {
+	key1, _ := testutils.GenKeyAndAddr()
+	_, addr2 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key1)
+	_app.WaitLock()
+	defer _app.Destroy()
+	_api := createEthAPI(_app)
+
+	accessList := gethtypes.AccessList{{Address: addr2, StorageKeys: []gethcmn.Hash{{0x12}}}}
+	tx, _ := _app.MakeAndSignDynamicFeeTx(key1, &addr2, 123, nil, 1, 2, accessList)
+	blockNum := _app.ExecTxInBlock(tx)
+	_app.EnsureTxSuccess(tx.Hash())
+
+	txResult, err := _api.GetTransactionByHash(tx.Hash())
+	require.NoError(t, err)
+	checkTxVRS(t, tx, txResult)
+	require.Equal(t, hexutil.Uint64(gethtypes.DynamicFeeTxType), txResult.Type)
+	require.Equal(t, accessList, *txResult.Accesses)
+	require.Equal(t, "0x2711", txResult.ChainID.String())
+	require.Equal(t, "0x1", txResult.GasTipCap.String())
+	require.Equal(t, "0x2", txResult.GasFeeCap.String())
+	require.Equal(t, "0x2", txResult.GasPrice.String())
+
+	blockResult, err := _api.GetBlockByNumber(gethrpc.BlockNumber(blockNum), true)
+	require.NoError(t, err)
+	require.Equal(t, "0x0", blockResult["baseFeePerGas"].(*hexutil.Big).String())
+	require.Equal(t, hexutil.Uint64(gethtypes.DynamicFeeTxType), blockResult["transactions"].([]*rpctypes.Transaction)[0].Type)
+
+	receipt, err := _api.GetTransactionReceipt(tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint(gethtypes.DynamicFeeTxType), receipt["type"])
+	require.Equal(t, "0x2", receipt["effectiveGasPrice"].(*hexutil.Big).String())
+
+	legacyTx, _ := _app.MakeAndExecTxInBlock(key1, addr2, 123, nil)
+	_app.EnsureTxSuccess(legacyTx.Hash())
+	txResult, err = _api.GetTransactionByHash(legacyTx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(gethtypes.LegacyTxType), txResult.Type)
+	require.Nil(t, txResult.Accesses)
+	receipt, err = _api.GetTransactionReceipt(legacyTx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint(gethtypes.LegacyTxType), receipt["type"])
+}
+
+func TestFeeHistory(t *testing.T) {
+	key1, _ := testutils.GenKeyAndAddr()
+	key2, _ := testutils.GenKeyAndAddr()
+	_, addr3 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key1, key2)
+	_app.WaitLock()
+	defer _app.Destroy()
+	_api := createEthAPI(_app)
+
+	tx1, _ := _app.MakeAndSignTxWithGas(key1, &addr3, 1, nil, testutils.DefaultGasLimit, 2)
+	tx2, _ := _app.MakeAndSignDynamicFeeTx(key2, &addr3, 1, nil, 3, 3, nil)
+	h := _app.ExecTxsInBlock(tx1, tx2)
+	_app.EnsureTxSuccess(tx1.Hash())
+	_app.EnsureTxSuccess(tx2.Hash())
+
+	result, err := _api.FeeHistory(2, gethrpc.BlockNumber(h), []float64{0, 50, 100})
+	require.NoError(t, err)
+	require.Equal(t, "0x"+big.NewInt(h-1).Text(16), result.OldestBlock.String())
+	require.Len(t, result.GasUsedRatio, 2)
+	require.Len(t, result.BaseFee, 3)
+	require.Equal(t, "0x0", result.BaseFee[2].String())
+	require.Len(t, result.Reward, 2)
+	require.Equal(t, "0x0", result.Reward[0][0].String())
+	require.Equal(t, "0x2", result.Reward[1][0].String())
+	require.Equal(t, "0x2", result.Reward[1][1].String())
+	require.Equal(t, "0x3", result.Reward[1][2].String())
+
+	result, err = _api.FeeHistory(100, gethrpc.LatestBlockNumber, nil)
+	require.NoError(t, err)
+	require.Equal(t, "0x1", result.OldestBlock.String())
+	require.Nil(t, result.Reward)
+
+	_, err = _api.FeeHistory(2, gethrpc.BlockNumber(h), []float64{50, 10})
+	require.ErrorIs(t, err, errInvalidPercentile)
+	_, err = _api.FeeHistory(2, gethrpc.BlockNumber(h+100), nil)
+	require.Error(t, err)
+
+	require.Equal(t, _api.GasPrice(), _api.MaxPriorityFeePerGas())
+}
+
 func checkTxVRS(t *testing.T, tx *gethtypes.Transaction, resp interface{}) {
 	v, r, s := tx.RawSignatureValues()
 	respJSON := testutils.ToJSON(resp)
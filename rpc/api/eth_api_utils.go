@@ -6,25 +6,33 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	gethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethcore "github.com/ethereum/go-ethereum/core"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/smartbch/moeingevm/ebp"
 	"github.com/smartbch/moeingevm/types"
+	sbchapi "github.com/smartbch/smartbch/api"
 	"github.com/smartbch/smartbch/internal/bigutils"
 	"github.com/smartbch/smartbch/internal/ethutils"
 	"github.com/smartbch/smartbch/param"
 	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
 )
 
-func createGethTxFromSendTxArgs(args rpctypes.SendTxArgs) (*gethtypes.Transaction, error) {
+func createGethTxFromSendTxArgs(args rpctypes.SendTxArgs, chainID *big.Int) (*gethtypes.Transaction, error) {
 	var (
 		nonce    uint64
 		gasLimit uint64
 	)
 
+	isDynamicFee := args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil
+	if args.GasPrice != nil && isDynamicFee {
+		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	}
+
 	amount := (*big.Int)(args.Value)
 	gasPrice := (*big.Int)(args.GasPrice)
 
@@ -59,17 +67,63 @@ func createGethTxFromSendTxArgs(args rpctypes.SendTxArgs) (*gethtypes.Transactio
 		gasLimit = (uint64)(*args.Gas)
 	}
 
+	var accessList gethtypes.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+
 	var tx *gethtypes.Transaction
-	if args.To != nil {
-		tx = ethutils.NewTx(nonce, args.To, amount, gasLimit, gasPrice, input)
+	if isDynamicFee {
+		gasTipCap, gasFeeCap := getFeeCaps(args.MaxPriorityFeePerGas, args.MaxFeePerGas)
+		if gasTipCap.Cmp(gasFeeCap) > 0 {
+			return nil, gethcore.ErrTipAboveFeeCap
+		}
+		tx = ethutils.NewDynamicFeeTx(chainID, nonce, args.To, amount, gasLimit, gasTipCap, gasFeeCap, input, accessList)
+	} else if args.AccessList != nil {
+		tx = gethtypes.NewTx(&gethtypes.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			To:         args.To,
+			Value:      amount,
+			Gas:        gasLimit,
+			GasPrice:   gasPrice,
+			Data:       input,
+			AccessList: accessList,
+		})
 	} else {
-		tx = ethutils.NewTx(nonce, nil, amount, gasLimit, gasPrice, input)
+		tx = ethutils.NewTx(nonce, args.To, amount, gasLimit, gasPrice, input)
 	}
 
 	return tx, nil
 }
 
-func blockToRpcResp(block *types.Block, txs []*types.Transaction, sigs [][65]byte) map[string]interface{} {
+// getFeeCaps makes the missing one of maxPriorityFeePerGas and maxFeePerGas equal to the other one,
+// because there is no base fee and a dynamic-fee TX always pays its maxFeePerGas
+func getFeeCaps(maxPriorityFeePerGas, maxFeePerGas *hexutil.Big) (gasTipCap, gasFeeCap *big.Int) {
+	if maxPriorityFeePerGas != nil {
+		gasTipCap = maxPriorityFeePerGas.ToInt()
+	}
+	if maxFeePerGas != nil {
+		gasFeeCap = maxFeePerGas.ToInt()
+	}
+	if gasTipCap == nil {
+		gasTipCap = gasFeeCap
+	}
+	if gasFeeCap == nil {
+		gasFeeCap = gasTipCap
+	}
+	return
+}
+
+// getTypedTxFields only queries the typed fields for typed TXs, whose V in sig is their y-parity
+func getTypedTxFields(backend sbchapi.BackendService, tx *types.Transaction, sig [65]byte) *ethutils.TypedTxFields {
+	if !ethutils.IsTypedTxSig(sig) {
+		return nil
+	}
+	return backend.GetTypedTxFields(tx.Hash)
+}
+
+func blockToRpcResp(backend sbchapi.BackendService, block *types.Block, txs []*types.Transaction, sigs [][65]byte) map[string]interface{} {
 	result := map[string]interface{}{
 		"number":           hexutil.Uint64(block.Number),
 		"hash":             hexutil.Bytes(block.Hash[:]),
@@ -91,28 +145,25 @@ func blockToRpcResp(block *types.Block, txs []*types.Transaction, sigs [][65]byt
 		"transactions":     types.ToGethHashes(block.Transactions),
 		"uncles":           []string{},
 		"receiptsRoot":     gethcmn.Hash{},
+		"baseFeePerGas":    (*hexutil.Big)(big.NewInt(0)), // No base fee is burnt
 	}
 
 	if len(txs) > 0 {
-		rpcTxs := make([]*rpctypes.Transaction, len(txs))
-		for i, tx := range txs {
-			rpcTxs[i] = txToRpcResp(tx, sigs[i])
-		}
-		result["transactions"] = rpcTxs
+		result["transactions"] = txsToRpcResp(backend, txs, sigs)
 	}
 
 	return result
 }
 
-func txsToRpcResp(txs []*types.Transaction, sigs [][65]byte) []*rpctypes.Transaction {
+func txsToRpcResp(backend sbchapi.BackendService, txs []*types.Transaction, sigs [][65]byte) []*rpctypes.Transaction {
 	rpcTxs := make([]*rpctypes.Transaction, len(txs))
 	for i, tx := range txs {
-		rpcTxs[i] = txToRpcResp(tx, sigs[i])
+		rpcTxs[i] = txToRpcResp(backend, tx, sigs[i])
 	}
 	return rpcTxs
 }
 
-func txToRpcResp(tx *types.Transaction, rawSig [65]byte) *rpctypes.Transaction {
+func txToRpcResp(backend sbchapi.BackendService, tx *types.Transaction, rawSig [65]byte) *rpctypes.Transaction {
 	v, r, s := ethutils.DecodeVRS(rawSig)
 	idx := hexutil.Uint64(tx.TransactionIndex)
 	resp := &rpctypes.Transaction{
@@ -135,24 +186,27 @@ func txToRpcResp(tx *types.Transaction, rawSig [65]byte) *rpctypes.Transaction {
 		resp.To = &gethcmn.Address{}
 		copy(resp.To[:], tx.To[:])
 	}
+	if typed := getTypedTxFields(backend, tx, rawSig); typed != nil {
+		resp.SetTypedTxFields(typed.Type, typed.ChainID, typed.GasTipCap, typed.GasFeeCap, typed.AccessList)
+	}
 	return resp
 }
 
-func txsToReceiptsWithInternalTxs(txs []*types.Transaction) []map[string]interface{} {
+func txsToReceiptsWithInternalTxs(backend sbchapi.BackendService, txs []*types.Transaction, sigs [][65]byte) []map[string]interface{} {
 	rpcTxs := make([]map[string]interface{}, len(txs))
 	for i, tx := range txs {
-		rpcTxs[i] = txToReceiptWithInternalTxs(tx)
+		rpcTxs[i] = txToReceiptWithInternalTxs(backend, tx, sigs[i])
 	}
 	return rpcTxs
 }
 
-func txToReceiptWithInternalTxs(tx *types.Transaction) map[string]interface{} {
-	resp := txToReceiptRpcResp(tx)
+func txToReceiptWithInternalTxs(backend sbchapi.BackendService, tx *types.Transaction, sig [65]byte) map[string]interface{} {
+	resp := txToReceiptRpcResp(backend, tx, sig)
 	resp["internalTransactions"] = buildInternalCallList(tx.InternalTxCalls, tx.InternalTxReturns)
 	return resp
 }
 
-func txToReceiptRpcResp(tx *types.Transaction) map[string]interface{} {
+func txToReceiptRpcResp(backend sbchapi.BackendService, tx *types.Transaction, sig [65]byte) map[string]interface{} {
 	resp := map[string]interface{}{
 		"transactionHash":   gethcmn.Hash(tx.Hash),
 		"transactionIndex":  hexutil.Uint64(tx.TransactionIndex),
@@ -166,6 +220,11 @@ func txToReceiptRpcResp(tx *types.Transaction) map[string]interface{} {
 		"logs":              types.ToGethLogs(tx.Logs),
 		"logsBloom":         hexutil.Bytes(tx.LogsBloom[:]),
 		"status":            hexutil.Uint(tx.Status),
+		"type":              hexutil.Uint(gethtypes.LegacyTxType),
+		"effectiveGasPrice": (*hexutil.Big)(bigutils.U256FromSlice32(tx.GasPrice[:]).ToBig()),
+	}
+	if typed := getTypedTxFields(backend, tx, sig); typed != nil {
+		resp["type"] = hexutil.Uint(typed.Type)
 	}
 	if !isZeroAddress(tx.To) {
 		resp["to"] = gethcmn.Address(tx.To)
@@ -180,6 +239,107 @@ func txToReceiptRpcResp(tx *types.Transaction) map[string]interface{} {
 	return resp
 }
 
+// AccountResult is the result of eth_getProof, which has the fields of geth's AccountResult. But the proofs
+// are MoeingADS proofs (one hex string for each KV pair, proving its value or absence, or none if a non-archive
+// node cannot prove the absence), instead of MPT nodes. They are verified by package stateproof against
+// AppHash, and StorageHash is always zero because MoeingADS has no storage trie for each account. The
+// storage slots are indexed by Sequence, and CodeProof proves CodeHash.
+type AccountResult struct {
+	Address      gethcmn.Address `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     gethcmn.Hash    `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  gethcmn.Hash    `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+	Sequence     hexutil.Uint64  `json:"sequence"`
+	CodeProof    []string        `json:"codeProof"`
+	AppHash      hexutil.Bytes   `json:"appHash"`
+}
+
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+func proofToStrings(proof []byte) []string {
+	if proof == nil {
+		return []string{}
+	}
+	return []string{hexutil.Encode(proof)}
+}
+
+func toAccountResult(addr gethcmn.Address, storageKeys []string, proof *sbchapi.StateProof) *AccountResult {
+	result := &AccountResult{
+		Address:      addr,
+		AccountProof: proofToStrings(proof.AccountProof),
+		Balance:      (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:     proof.CodeHash,
+		StorageProof: make([]StorageResult, len(storageKeys)),
+		Sequence:     hexutil.Uint64(proof.StorageSeq),
+		CodeProof:    proofToStrings(proof.CodeProof),
+		AppHash:      proof.AppHash,
+	}
+	if proof.Account != nil {
+		result.Balance = (*hexutil.Big)(proof.Account.Balance().ToBig())
+		result.Nonce = hexutil.Uint64(proof.Account.Nonce())
+	}
+	if proof.CodeHash == (gethcmn.Hash{}) {
+		result.CodeHash = emptyCodeHash
+	}
+	for i, key := range storageKeys {
+		result.StorageProof[i] = StorageResult{
+			Key:   key,
+			Value: (*hexutil.Big)(new(big.Int).SetBytes(proof.StorageValues[i])),
+			Proof: proofToStrings(proof.StorageProofs[i]),
+		}
+	}
+	return result
+}
+
+// FeeHistoryResult is the result of eth_feeHistory, which has the same format as geth
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// getRewardPercentiles sorts the TXs by their gas prices and picks the gas prices at the given
+// percentiles of the total gas used, like geth does for the effective priority fees
+func getRewardPercentiles(txs []*types.Transaction, percentiles []float64) []*hexutil.Big {
+	rewards := make([]*hexutil.Big, len(percentiles))
+	if len(txs) == 0 {
+		for i := range rewards {
+			rewards[i] = (*hexutil.Big)(big.NewInt(0))
+		}
+		return rewards
+	}
+
+	sorted := make([]*types.Transaction, len(txs))
+	copy(sorted, txs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].GasPrice[:], sorted[j].GasPrice[:]) < 0
+	})
+	totalGasUsed := uint64(0)
+	for _, tx := range sorted {
+		totalGasUsed += tx.GasUsed
+	}
+
+	txIndex := 0
+	sumGasUsed := sorted[0].GasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(totalGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].GasUsed
+		}
+		rewards[i] = (*hexutil.Big)(bigutils.U256FromSlice32(sorted[txIndex].GasPrice[:]).ToBig())
+	}
+	return rewards
+}
+
 func isZeroAddress(addr [20]byte) bool {
 	for _, b := range addr {
 		if b != 0 {
@@ -16,6 +16,7 @@ import (
 
 	motypes "github.com/smartbch/moeingevm/types"
 	mapi "github.com/smartbch/smartbch/api"
+	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
 )
 
 var _ PublicFilterAPI = (*filterAPI)(nil)
@@ -29,9 +30,11 @@ type PublicFilterAPI interface {
 	GetFilterLogs(id rpc.ID) ([]*gethtypes.Log, error)
 	GetLogs(crit gethfilters.FilterCriteria) ([]*gethtypes.Log, error)
 	NewBlockFilter() rpc.ID
+	NewPendingTransactionFilter() rpc.ID
 	NewFilter(crit gethfilters.FilterCriteria) (rpc.ID, error)
 	UninstallFilter(id rpc.ID) bool
 	NewHeads(ctx context.Context) (*rpc.Subscription, error)
+	NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error)
 	Logs(ctx context.Context, crit gethfilters.FilterCriteria) (*rpc.Subscription, error)
 }
 
@@ -180,6 +183,51 @@ func (api *filterAPI) NewBlockFilter() rpc.ID {
 	return headerSub.ID
 }
 
+// NewPendingTransactionFilter creates a filter that fetches pending transaction hashes
+// as transactions enter the pending state, i.e. accepted by CheckTx.
+// It is part of the filter package because this filter can be used through the
+// `eth_getFilterChanges` polling method that is also used for log filters.
+//
+// https://eth.wiki/json-rpc/API#eth_newpendingtransactionfilter
+func (api *filterAPI) NewPendingTransactionFilter() rpc.ID {
+	api.logger.Debug("eth_newPendingTransactionFilter")
+	var (
+		pendingTxs   = make(chan []*gethtypes.Transaction)
+		pendingTxSub = api.events.SubscribePendingTxs(pendingTxs)
+	)
+
+	api.filtersMu.Lock()
+	api.filters[pendingTxSub.ID] = &filter{
+		typ:      PendingTransactionsSubscription,
+		deadline: time.NewTimer(deadline),
+		hashes:   make([]gethcmn.Hash, 0),
+		s:        pendingTxSub,
+	}
+	api.filtersMu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case txs := <-pendingTxs:
+				api.filtersMu.Lock()
+				if f, found := api.filters[pendingTxSub.ID]; found {
+					for _, tx := range txs {
+						f.hashes = append(f.hashes, tx.Hash())
+					}
+				}
+				api.filtersMu.Unlock()
+			case <-pendingTxSub.Err():
+				api.filtersMu.Lock()
+				delete(api.filters, pendingTxSub.ID)
+				api.filtersMu.Unlock()
+				return
+			}
+		}
+	}()
+
+	return pendingTxSub.ID
+}
+
 // UninstallFilter removes the filter with the given filter id.
 //
 // https://eth.wiki/json-rpc/API#eth_uninstallfilter
@@ -223,7 +271,7 @@ func (api *filterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
 	f.deadline.Reset(deadline)
 
 	switch f.typ {
-	case /*PendingTransactionsSubscription, */ BlocksSubscription:
+	case PendingTransactionsSubscription, BlocksSubscription:
 		hashes := f.hashes
 		f.hashes = nil
 		return returnHashes(hashes), nil
@@ -302,6 +350,10 @@ func (api *filterAPI) GetLogs(crit gethfilters.FilterCriteria) ([]*gethtypes.Log
 func (api *filterAPI) getLogsByBlockNumberRange(begin, end int64) ([]*gethtypes.Log, error) {
 	maxLogResults := api.backend.GetRpcMaxLogResults()
 	allLogs := make([]*gethtypes.Log, 0, 10)
+	stakingLogs, err := api.backend.GetStakingLogs(uint32(begin), uint32(end))
+	if err != nil {
+		return nil, err
+	}
 
 	for i := begin; i < end; i++ {
 		txList, _, err := api.backend.GetTxListByHeight(uint32(i))
@@ -312,6 +364,11 @@ func (api *filterAPI) getLogsByBlockNumberRange(begin, end int64) ([]*gethtypes.
 		for _, tx := range txList {
 			allLogs = append(allLogs, motypes.ToGethLogs(tx.Logs)...)
 		}
+		// the staking logs of a block follow its TXs' logs
+		for len(stakingLogs) != 0 && int64(stakingLogs[0].BlockNumber) == i {
+			allLogs = append(allLogs, motypes.ToGethLog(stakingLogs[0]))
+			stakingLogs = stakingLogs[1:]
+		}
 
 		if len(allLogs) > maxLogResults {
 			return nil, errors.New("too many potential results")
@@ -351,6 +408,49 @@ func (api *filterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 	return rpcSub, nil
 }
 
+// NewPendingTransactions creates a subscription that is triggered each time a
+// transaction enters the pending state, i.e. accepted by CheckTx. If fullTx is true
+// the full tx is sent to the client, otherwise only the hash is sent.
+func (api *filterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		txs := make(chan []*gethtypes.Transaction, 128)
+		pendingTxSub := api.events.SubscribePendingTxs(txs)
+		signer := gethtypes.NewLondonSigner(api.backend.ChainId())
+
+		for {
+			select {
+			case txs := <-txs:
+				for _, tx := range txs {
+					if fullTx != nil && *fullTx {
+						sender, err := gethtypes.Sender(signer, tx)
+						if err != nil {
+							continue
+						}
+						_ = notifier.Notify(rpcSub.ID, rpctypes.NewRPCPendingTransaction(tx, sender))
+					} else {
+						_ = notifier.Notify(rpcSub.ID, tx.Hash())
+					}
+				}
+			case <-rpcSub.Err():
+				pendingTxSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				pendingTxSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
 func (api *filterAPI) Logs(ctx context.Context, crit gethfilters.FilterCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
@@ -56,6 +56,31 @@ func TestGetFilterChanges_blockFilter(t *testing.T) {
 	require.Equal(t, gethcmn.Hash{0xB1, 0x23}, hashes[0])
 }
 
+func TestGetFilterChanges_pendingTxFilter(t *testing.T) {
+	key, addr := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key)
+	defer _app.Destroy()
+	_api := createFiltersAPI(_app)
+	id := _api.NewPendingTransactionFilter()
+	require.NotEmpty(t, id)
+
+	tx0, _ := _app.MakeAndSignTxWithNonce(key, &addr, 100, nil, 0)
+	tx1, _ := _app.MakeAndSignTxWithNonce(key, &addr, 100, nil, 1)
+	tx3, _ := _app.MakeAndSignTxWithNonce(key, &addr, 100, nil, 3)
+	require.Equal(t, uint32(0), _app.CheckNewTxABCI(tx0))
+	require.Equal(t, uint32(0), _app.RecheckTxABCI(tx1)) // rechecked TXs are not published
+	require.NotEqual(t, uint32(0), _app.CheckNewTxABCI(tx3))
+
+	_app.WaitMS(10)
+	ret, err := _api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Equal(t, []gethcmn.Hash{tx0.Hash()}, ret)
+
+	ret, err = _api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Len(t, ret, 0)
+}
+
 func TestGetFilterChanges_addrFilter(t *testing.T) {
 	_app := testutils.CreateTestApp()
 	defer _app.Destroy()
@@ -24,7 +24,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
@@ -47,7 +46,7 @@ const (
 	PendingLogsSubscription
 	// MinedAndPendingLogsSubscription queries for logs in mined and pending blocks.
 	MinedAndPendingLogsSubscription
-	// PendingTransactionsSubscription queries tx hashes for pending
+	// PendingTransactionsSubscription queries transactions for pending
 	// transactions entering the pending state
 	PendingTransactionsSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
@@ -74,7 +73,7 @@ type subscription struct {
 	created   time.Time
 	logsCrit  ethereum.FilterQuery
 	logs      chan []*types.Log
-	hashes    chan []common.Hash
+	txs       chan []*types.Transaction
 	headers   chan *motypes.Header
 	installed chan struct{} // closed when the filter is installed
 	err       chan error    // closed when the filter is uninstalled
@@ -165,7 +164,7 @@ func (sub *Subscription) Unsubscribe() {
 			case sub.es.uninstall <- sub.f:
 				break uninstallLoop
 			case <-sub.f.logs:
-			case <-sub.f.hashes:
+			case <-sub.f.txs:
 			case <-sub.f.headers:
 			}
 		}
@@ -232,7 +231,7 @@ func (es *EventSystem) SubscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 //		logsCrit:  crit,
 //		created:   time.Now(),
 //		logs:      logs,
-//		hashes:    make(chan []common.Hash),
+//		txs:       make(chan []*types.Transaction),
 //		headers:   make(chan *motypes.Header),
 //		installed: make(chan struct{}),
 //		err:       make(chan error),
@@ -249,7 +248,7 @@ func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 		logsCrit:  crit,
 		created:   time.Now(),
 		logs:      logs,
-		hashes:    make(chan []common.Hash),
+		txs:       make(chan []*types.Transaction),
 		headers:   make(chan *motypes.Header),
 		installed: make(chan struct{}),
 		err:       make(chan error),
@@ -266,7 +265,7 @@ func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 //		logsCrit:  crit,
 //		created:   time.Now(),
 //		logs:      logs,
-//		hashes:    make(chan []common.Hash),
+//		txs:       make(chan []*types.Transaction),
 //		headers:   make(chan *motypes.Header),
 //		installed: make(chan struct{}),
 //		err:       make(chan error),
@@ -282,7 +281,7 @@ func (es *EventSystem) SubscribeNewHeads(headers chan *motypes.Header) *Subscrip
 		typ:       BlocksSubscription,
 		created:   time.Now(),
 		logs:      make(chan []*types.Log),
-		hashes:    make(chan []common.Hash),
+		txs:       make(chan []*types.Transaction),
 		headers:   headers,
 		installed: make(chan struct{}),
 		err:       make(chan error),
@@ -290,21 +289,21 @@ func (es *EventSystem) SubscribeNewHeads(headers chan *motypes.Header) *Subscrip
 	return es.subscribe(sub)
 }
 
-// SubscribePendingTxs creates a subscription that writes transaction hashes for
+// SubscribePendingTxs creates a subscription that writes transactions for
 // transactions that enter the transaction pool.
-//func (es *EventSystem) SubscribePendingTxs(hashes chan []common.Hash) *Subscription {
-//	sub := &subscription{
-//		id:        rpc.NewID(),
-//		typ:       PendingTransactionsSubscription,
-//		created:   time.Now(),
-//		logs:      make(chan []*types.Log),
-//		hashes:    hashes,
-//		headers:   make(chan *motypes.Header),
-//		installed: make(chan struct{}),
-//		err:       make(chan error),
-//	}
-//	return es.subscribe(sub)
-//}
+func (es *EventSystem) SubscribePendingTxs(txs chan []*types.Transaction) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       PendingTransactionsSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		txs:       txs,
+		headers:   make(chan *motypes.Header),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
 
 type filterIndex map[Type]map[rpc.ID]*subscription
 
@@ -342,13 +341,9 @@ func (es *EventSystem) handleRemovedLogs(filters filterIndex, ev core.RemovedLog
 }
 
 func (es *EventSystem) handleTxsEvent(filters filterIndex, ev core.NewTxsEvent) {
-	//hashes := make([]common.Hash, 0, len(ev.Txs))
-	//for _, tx := range ev.Txs {
-	//	hashes = append(hashes, tx.Hash())
-	//}
-	//for _, f := range filters[PendingTransactionsSubscription] {
-	//	f.hashes <- hashes
-	//}
+	for _, f := range filters[PendingTransactionsSubscription] {
+		f.txs <- ev.Txs
+	}
 }
 
 func (es *EventSystem) handleChainEvent(filters filterIndex, ev motypes.ChainEvent) {
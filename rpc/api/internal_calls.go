@@ -26,6 +26,7 @@ const (
 type InternalTx struct {
 	depth          int32
 	count          int
+	status         int              // the original status code returned by EVM
 	CallPath       string           `json:"callPath"`
 	From           gethcmn.Address  `json:"from"`
 	To             gethcmn.Address  `json:"to"`
@@ -101,6 +102,7 @@ func newCallSite(call motypes.InternalTxCall) *InternalTx {
 	}
 }
 func addRetInfo(callSite *InternalTx, ret motypes.InternalTxReturn) {
+	callSite.status = ret.StatusCode
 	callSite.StatusCode = hexutil.Uint64(gethtypes.ReceiptStatusSuccessful)
 	if ebp.StatusIsFailure(ret.StatusCode) {
 		callSite.StatusCode = hexutil.Uint64(gethtypes.ReceiptStatusFailed)
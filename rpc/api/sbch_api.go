@@ -2,6 +2,7 @@ package api
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -25,6 +26,8 @@ import (
 	"github.com/smartbch/smartbch/staking/types"
 )
 
+const maxCCUTXOsPerQuery = 1000
+
 var _ SbchAPI = (*sbchAPI)(nil)
 
 type SbchAPI interface {
@@ -42,11 +45,18 @@ type SbchAPI interface {
 	GetCurrEpoch(includesPosVotes *bool) (*StakingEpoch, error)
 	GetCCEpochs(start, end hexutil.Uint64) ([]*cctypes.CCEpoch, error)
 	GetCCEpochs2(start, end hexutil.Uint64) ([]*CCEpoch, error) // result is more human-readable
+	GetCCUTXOs(fromOffset, limit hexutil.Uint64) ([]*CCUTXO, error)
+	GetCCUTXO(txid gethcmn.Hash, vout hexutil.Uint64) *CCUTXO
+	GetCCPegStatus() *CCPegStatus
+	GetCCRedemptions(txHash gethcmn.Hash) []*CCRedemption
 	HealthCheck(latestBlockTooOldAge hexutil.Uint64) map[string]interface{}
 	GetTransactionReceipt(hash gethcmn.Hash) (map[string]interface{}, error)
 	GetTransactionReceiptWithSig(hash gethcmn.Hash) (map[string]interface{}, error)
 	Call(args rpctypes.CallArgs, blockNr gethrpc.BlockNumberOrHash) (*CallDetail, error)
 	ValidatorsInfo(blockNr gethrpc.BlockNumberOrHash) json.RawMessage
+	GetUnbonding(addr gethcmn.Address) []*UnbondingEntry
+	GetValidatorRewards(addr gethcmn.Address, fromEpoch, toEpoch hexutil.Uint64) ([]*ValidatorReward, error)
+	GetSlashEvents(addr gethcmn.Address) ([]*SlashEvent, error)
 	GetSyncBlock(height hexutil.Uint64) (hexutil.Bytes, error)
 	SetRpcKey(key string) error
 	GetRpcPubkey() (string, error)
@@ -85,11 +95,11 @@ func (sbch sbchAPI) GetTxListByHeightWithRange(height gethrpc.BlockNumber, start
 	if iEnd == 0 {
 		iEnd = -1
 	}
-	txs, _, err := sbch.backend.GetTxListByHeightWithRange(uint32(height), iStart, iEnd)
+	txs, sigs, err := sbch.backend.GetTxListByHeightWithRange(uint32(height), iStart, iEnd)
 	if err != nil {
 		return nil, err
 	}
-	return txsToReceiptsWithInternalTxs(txs), nil
+	return txsToReceiptsWithInternalTxs(sbch.backend, txs, sigs), nil
 }
 
 func (sbch sbchAPI) QueryTxBySrc(addr gethcmn.Address,
@@ -102,7 +112,7 @@ func (sbch sbchAPI) QueryTxBySrc(addr gethcmn.Address,
 		return nil, err
 	}
 
-	return txsToRpcResp(txs, sigs), nil
+	return txsToRpcResp(sbch.backend, txs, sigs), nil
 }
 
 func (sbch sbchAPI) QueryTxByDst(addr gethcmn.Address,
@@ -115,7 +125,7 @@ func (sbch sbchAPI) QueryTxByDst(addr gethcmn.Address,
 		return nil, err
 	}
 
-	return txsToRpcResp(txs, sigs), nil
+	return txsToRpcResp(sbch.backend, txs, sigs), nil
 }
 
 func (sbch sbchAPI) QueryTxByAddr(addr gethcmn.Address,
@@ -128,7 +138,7 @@ func (sbch sbchAPI) QueryTxByAddr(addr gethcmn.Address,
 		return nil, err
 	}
 
-	return txsToRpcResp(txs, sigs), nil
+	return txsToRpcResp(sbch.backend, txs, sigs), nil
 }
 
 func (sbch sbchAPI) prepareHeightRange(startHeight, endHeight gethrpc.BlockNumber) (uint32, uint32) {
@@ -258,6 +268,44 @@ func (sbch sbchAPI) GetCCEpochs2(start, end hexutil.Uint64) ([]*CCEpoch, error)
 	return castCCEpochs(ccEpochs), nil
 }
 
+// GetCCUTXOs returns the UTXOs of the ShaGate address from 'fromOffset' in the index, whose order changes when
+// the UTXOs are consumed or burnt
+func (sbch sbchAPI) GetCCUTXOs(fromOffset, limit hexutil.Uint64) ([]*CCUTXO, error) {
+	sbch.logger.Debug("sbch_getCCUTXOs")
+	if limit == 0 || limit > maxCCUTXOsPerQuery {
+		limit = maxCCUTXOsPerQuery
+	}
+	infos, err := sbch.backend.GetCCUTXOs(uint64(fromOffset), uint64(limit))
+	if err != nil {
+		return nil, err
+	}
+	return castCCUTXOs(infos), nil
+}
+
+// GetCCUTXO returns the UTXO of the ShaGate address, either indexed or parked, and nil if it is not found
+func (sbch sbchAPI) GetCCUTXO(txid gethcmn.Hash, vout hexutil.Uint64) *CCUTXO {
+	sbch.logger.Debug("sbch_getCCUTXO")
+	var utxo [36]byte
+	copy(utxo[:32], txid[:])
+	binary.BigEndian.PutUint32(utxo[32:], uint32(vout))
+	info := sbch.backend.GetCCUTXO(utxo)
+	if info == nil {
+		return nil
+	}
+	return castCCUTXO(info)
+}
+
+func (sbch sbchAPI) GetCCPegStatus() *CCPegStatus {
+	sbch.logger.Debug("sbch_getCCPegStatus")
+	return castCCPegStatus(sbch.backend.GetCCPegStatus())
+}
+
+// GetCCRedemptions returns the redemptions requested by a smartBCH tx, with their latest status
+func (sbch sbchAPI) GetCCRedemptions(txHash gethcmn.Hash) []*CCRedemption {
+	sbch.logger.Debug("sbch_getCCRedemptions")
+	return castCCRedemptions(sbch.backend.GetCCRedemptions(txHash))
+}
+
 func (sbch sbchAPI) HealthCheck(latestBlockTooOldAge hexutil.Uint64) map[string]interface{} {
 	sbch.logger.Debug("sbch_healthCheck")
 	if latestBlockTooOldAge == 0 {
@@ -293,23 +341,23 @@ func (sbch sbchAPI) HealthCheck(latestBlockTooOldAge hexutil.Uint64) map[string]
 
 func (sbch sbchAPI) GetTransactionReceipt(hash gethcmn.Hash) (map[string]interface{}, error) {
 	sbch.logger.Debug("sbch_getTransactionReceipt")
-	tx, _, err := sbch.backend.GetTransaction(hash)
+	tx, sig, err := sbch.backend.GetTransaction(hash)
 	if err != nil {
 		// the transaction is not yet available
 		return nil, nil
 	}
-	ret := txToReceiptWithInternalTxs(tx)
+	ret := txToReceiptWithInternalTxs(sbch.backend, tx, sig)
 	return ret, nil
 }
 
 func (sbch sbchAPI) GetTransactionReceiptWithSig(hash gethcmn.Hash) (map[string]interface{}, error) {
 	sbch.logger.Debug("sbch_getTransactionReceiptWithSig")
-	tx, _, err := sbch.backend.GetTransaction(hash)
+	tx, sig, err := sbch.backend.GetTransaction(hash)
 	if err != nil {
 		// the transaction is not yet available
 		return nil, nil
 	}
-	ret := txToReceiptWithInternalTxs(tx)
+	ret := txToReceiptWithInternalTxs(sbch.backend, tx, sig)
 	bytes, _ := json.Marshal(ret)
 	resp := map[string]interface{}{
 		"resp": string(bytes),
@@ -343,6 +391,32 @@ func (sbch sbchAPI) ValidatorsInfo(blockNr gethrpc.BlockNumberOrHash) json.RawMe
 	return bytes
 }
 
+// GetUnbonding returns the pending unbonding coins whose validator or receiver is 'addr'
+func (sbch sbchAPI) GetUnbonding(addr gethcmn.Address) []*UnbondingEntry {
+	sbch.logger.Debug("sbch_getUnbonding")
+	return castUnbondingEntries(sbch.backend.UnbondingEntries(addr))
+}
+
+// GetValidatorRewards returns the rewards and slashed coins booked for validator 'addr' in the epochs
+// of [fromEpoch, toEpoch), the epochs in which nothing is booked for it are skipped
+func (sbch sbchAPI) GetValidatorRewards(addr gethcmn.Address, fromEpoch, toEpoch hexutil.Uint64) ([]*ValidatorReward, error) {
+	sbch.logger.Debug("sbch_getValidatorRewards")
+	if toEpoch == 0 {
+		toEpoch = fromEpoch + 10
+	}
+	ledgers, err := sbch.backend.RewardLedgers(uint64(fromEpoch), uint64(toEpoch))
+	if err != nil {
+		return nil, err
+	}
+	return castValidatorRewards(ledgers, addr), nil
+}
+
+// GetSlashEvents returns the Slash events of validator 'addr', which are recorded in the side index of the node
+func (sbch sbchAPI) GetSlashEvents(addr gethcmn.Address) ([]*SlashEvent, error) {
+	sbch.logger.Debug("sbch_getSlashEvents")
+	return castSlashEvents(sbch.backend.SlashEvents(addr)), nil
+}
+
 func (sbch sbchAPI) GetSyncBlock(height hexutil.Uint64) (hexutil.Bytes, error) {
 	sbch.logger.Debug("sbch_getSyncBlock")
 	return sbch.backend.GetSyncBlock(int64(height))
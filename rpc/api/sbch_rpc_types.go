@@ -1,6 +1,8 @@
 package api
 
 import (
+	"encoding/binary"
+
 	gethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/holiman/uint256"
@@ -8,6 +10,7 @@ import (
 
 	motypes "github.com/smartbch/moeingevm/types"
 	sbchapi "github.com/smartbch/smartbch/api"
+	"github.com/smartbch/smartbch/crosschain"
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
 )
@@ -57,6 +60,86 @@ func castNominations(nominations []*stakingtypes.Nomination) []*Nomination {
 	return rpcNominations
 }
 
+// UnbondingEntry
+
+type UnbondingEntry struct {
+	Validator    gethcmn.Address `json:"validator"`
+	Pubkey       gethcmn.Hash    `json:"pubkey"`
+	Receiver     gethcmn.Address `json:"receiver"`
+	Amount       *hexutil.Big    `json:"amount"`
+	MatureHeight hexutil.Uint64  `json:"matureHeight"`
+}
+
+func castUnbondingEntries(entries []*stakingtypes.UnbondingEntry) []*UnbondingEntry {
+	rpcEntries := make([]*UnbondingEntry, len(entries))
+	for i, entry := range entries {
+		rpcEntries[i] = &UnbondingEntry{
+			Validator:    entry.Validator,
+			Pubkey:       entry.Pubkey,
+			Receiver:     entry.Receiver,
+			Amount:       (*hexutil.Big)(uint256.NewInt(0).SetBytes32(entry.Amount[:]).ToBig()),
+			MatureHeight: hexutil.Uint64(entry.MatureHeight),
+		}
+	}
+	return rpcEntries
+}
+
+// ValidatorReward
+
+type ValidatorReward struct {
+	EpochNum        hexutil.Uint64 `json:"epochNum"`
+	ProposerReward  *hexutil.Big   `json:"proposerReward"`
+	CollectorReward *hexutil.Big   `json:"collectorReward"`
+	VoterReward     *hexutil.Big   `json:"voterReward"`
+	MintedReward    *hexutil.Big   `json:"mintedReward"`
+	SlashedAmount   *hexutil.Big   `json:"slashedAmount"`
+}
+
+func castValidatorRewards(ledgers []*stakingtypes.RewardLedger, validator gethcmn.Address) []*ValidatorReward {
+	rpcRewards := make([]*ValidatorReward, 0, len(ledgers))
+	for _, ledger := range ledgers {
+		entry := ledger.GetEntry(validator)
+		if entry == nil {
+			continue
+		}
+		rpcRewards = append(rpcRewards, &ValidatorReward{
+			EpochNum:        hexutil.Uint64(ledger.EpochNum),
+			ProposerReward:  bytes32ToBig(entry.ProposerReward),
+			CollectorReward: bytes32ToBig(entry.CollectorReward),
+			VoterReward:     bytes32ToBig(entry.VoterReward),
+			MintedReward:    bytes32ToBig(entry.MintedReward),
+			SlashedAmount:   bytes32ToBig(entry.SlashedAmount),
+		})
+	}
+	return rpcRewards
+}
+
+func bytes32ToBig(bz [32]byte) *hexutil.Big {
+	return (*hexutil.Big)(uint256.NewInt(0).SetBytes32(bz[:]).ToBig())
+}
+
+// SlashEvent
+
+type SlashEvent struct {
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	Validator   gethcmn.Address `json:"validator"`
+	Reason      hexutil.Uint64  `json:"reason"`
+	Amount      *hexutil.Big    `json:"amount"`
+}
+
+func castSlashEvents(events []*stakingtypes.SlashEvent) []*SlashEvent {
+	rpcEvents := make([]*SlashEvent, len(events))
+	for i, event := range events {
+		rpcEvents[i] = &SlashEvent{
+			BlockNumber: hexutil.Uint64(event.Height),
+			Validator:   event.Validator,
+			Reason:      hexutil.Uint64(event.Reason),
+			Amount:      bytes32ToBig(event.Amount),
+		}
+	}
+	return rpcEvents
+}
+
 // CCEpoch
 
 type CCEpoch struct {
@@ -69,6 +152,7 @@ type CCTransferInfo struct {
 	UTXO         hexutil.Bytes  `json:"utxo"`
 	Amount       hexutil.Uint64 `json:"amount"`
 	SenderPubkey hexutil.Bytes  `json:"senderPubkey"`
+	Receiver     hexutil.Bytes  `json:"receiver"`
 }
 
 func castCCEpochs(ccEpochs []*cctypes.CCEpoch) []*CCEpoch {
@@ -90,11 +174,101 @@ func castTransferInfos(ccTransferInfos []*cctypes.CCTransferInfo) []*CCTransferI
 			UTXO:         ccTransferInfo.UTXO[:],
 			Amount:       hexutil.Uint64(ccTransferInfo.Amount),
 			SenderPubkey: ccTransferInfo.SenderPubkey[:],
+			Receiver:     ccTransferInfo.Receiver[:],
 		}
 	}
 	return rpcTransferInfos
 }
 
+type CCUTXO struct {
+	Txid   gethcmn.Hash   `json:"txid"`
+	Vout   hexutil.Uint64 `json:"vout"`
+	Amount *hexutil.Big   `json:"amount"`
+	Parked bool           `json:"parked"`
+}
+
+type CCPegStatus struct {
+	UTXOCount         hexutil.Uint64 `json:"utxoCount"`
+	LockedAmount      *hexutil.Big   `json:"lockedAmount"`
+	ParkedAmount      *hexutil.Big   `json:"parkedAmount"`
+	CCContractBalance *hexutil.Big   `json:"ccContractBalance"`
+	BurntAmount       *hexutil.Big   `json:"burntAmount"`
+	BlackHoleBalance  *hexutil.Big   `json:"blackHoleBalance"`
+	DeficitAmount     *hexutil.Big   `json:"deficitAmount"`
+}
+
+func castCCUTXOs(infos []*crosschain.UTXOInfo) []*CCUTXO {
+	utxos := make([]*CCUTXO, len(infos))
+	for i, info := range infos {
+		utxos[i] = castCCUTXO(info)
+	}
+	return utxos
+}
+func castCCUTXO(info *crosschain.UTXOInfo) *CCUTXO {
+	return &CCUTXO{
+		Txid:   gethcmn.BytesToHash(info.UTXO[:32]),
+		Vout:   hexutil.Uint64(binary.BigEndian.Uint32(info.UTXO[32:])),
+		Amount: (*hexutil.Big)(info.Amount.ToBig()),
+		Parked: info.Parked,
+	}
+}
+func castCCPegStatus(status *crosschain.PegStatus) *CCPegStatus {
+	return &CCPegStatus{
+		UTXOCount:         hexutil.Uint64(status.UTXOCount),
+		LockedAmount:      (*hexutil.Big)(status.LockedAmount.ToBig()),
+		ParkedAmount:      (*hexutil.Big)(status.ParkedAmount.ToBig()),
+		CCContractBalance: (*hexutil.Big)(status.CCContractBalance.ToBig()),
+		BurntAmount:       (*hexutil.Big)(status.BurntAmount.ToBig()),
+		BlackHoleBalance:  (*hexutil.Big)(status.BlackHoleBalance.ToBig()),
+		DeficitAmount:     (*hexutil.Big)(status.DeficitAmount.ToBig()),
+	}
+}
+
+type CCRedemption struct {
+	Txid          gethcmn.Hash    `json:"txid"`
+	Vout          hexutil.Uint64  `json:"vout"`
+	TxHash        gethcmn.Hash    `json:"txHash"`
+	Redeemer      gethcmn.Address `json:"redeemer"`
+	Amount        *hexutil.Big    `json:"amount"`
+	Target        string          `json:"target"`
+	Status        string          `json:"status"`
+	CreatedHeight hexutil.Uint64  `json:"createdHeight"`
+	MainnetTxId   *gethcmn.Hash   `json:"mainnetTxId"`
+	PaidAmount    hexutil.Uint64  `json:"paidAmount"`
+}
+
+var redemptionStatusNames = map[int64]string{
+	cctypes.RedemptionPending:   "pending",
+	cctypes.RedemptionConfirmed: "confirmed",
+	cctypes.RedemptionMispaid:   "mispaid",
+	cctypes.RedemptionRefunded:  "refunded",
+	cctypes.RedemptionLateSpent: "lateSpent",
+}
+
+func castCCRedemptions(redemptions []*cctypes.Redemption) []*CCRedemption {
+	result := make([]*CCRedemption, len(redemptions))
+	for i, r := range redemptions {
+		result[i] = &CCRedemption{
+			Txid:          gethcmn.BytesToHash(r.UTXO[:32]),
+			Vout:          hexutil.Uint64(binary.BigEndian.Uint32(r.UTXO[32:])),
+			TxHash:        r.TxHash,
+			Redeemer:      r.Redeemer,
+			Amount:        (*hexutil.Big)(uint256.NewInt(0).SetBytes32(r.Amount[:]).ToBig()),
+			Status:        redemptionStatusNames[r.Status],
+			CreatedHeight: hexutil.Uint64(r.CreatedHeight),
+			PaidAmount:    hexutil.Uint64(r.PaidAmount),
+		}
+		if r.Target != ([21]byte{}) {
+			result[i].Target = crosschain.EncodeCashAddr(r.Target)
+		}
+		if r.MainnetTxId != ([32]byte{}) {
+			txId := gethcmn.Hash(r.MainnetTxId)
+			result[i].MainnetTxId = &txId
+		}
+	}
+	return result
+}
+
 // CallDetail
 
 type CallDetail struct {
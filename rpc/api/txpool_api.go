@@ -1,9 +1,15 @@
 package api
 
 import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/tendermint/tendermint/libs/log"
 
+	sbchapi "github.com/smartbch/smartbch/api"
 	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
 )
 
@@ -11,41 +17,99 @@ var _ PublicTxPoolAPI = (*txPoolAPI)(nil)
 
 type PublicTxPoolAPI interface {
 	Content() map[string]map[string]map[string]*rpctypes.Transaction
+	ContentFrom(addr common.Address) map[string]map[string]*rpctypes.Transaction
 	Status() map[string]hexutil.Uint
 	Inspect() map[string]map[string]map[string]string
 }
 
 type txPoolAPI struct {
-	logger log.Logger
+	backend sbchapi.BackendService
+	logger  log.Logger
 }
 
-func newTxPoolAPI(logger log.Logger) PublicTxPoolAPI {
-	return txPoolAPI{logger: logger}
+func newTxPoolAPI(backend sbchapi.BackendService, logger log.Logger) PublicTxPoolAPI {
+	return txPoolAPI{
+		backend: backend,
+		logger:  logger,
+	}
 }
 
+// https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content
 func (api txPoolAPI) Content() map[string]map[string]map[string]*rpctypes.Transaction {
 	api.logger.Debug("txpool_content")
 	content := map[string]map[string]map[string]*rpctypes.Transaction{
 		"pending": make(map[string]map[string]*rpctypes.Transaction),
 		"queued":  make(map[string]map[string]*rpctypes.Transaction),
 	}
+	pending, queued := api.backend.TxPoolContent()
+	for addr, txs := range pending {
+		content["pending"][addr.Hex()] = poolTxsToRpcResp(addr, txs)
+	}
+	for addr, txs := range queued {
+		content["queued"][addr.Hex()] = poolTxsToRpcResp(addr, txs)
+	}
 	return content
 }
 
+// https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom
+func (api txPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*rpctypes.Transaction {
+	api.logger.Debug("txpool_contentFrom")
+	pending, queued := api.backend.TxPoolContentFrom(addr)
+	return map[string]map[string]*rpctypes.Transaction{
+		"pending": poolTxsToRpcResp(addr, pending),
+		"queued":  poolTxsToRpcResp(addr, queued),
+	}
+}
+
+// https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_status
 func (api txPoolAPI) Status() map[string]hexutil.Uint {
 	api.logger.Debug("txpool_status")
-	pending, queue := 0, 0
+	pending, queue := api.backend.TxPoolStats()
 	return map[string]hexutil.Uint{
 		"pending": hexutil.Uint(pending),
 		"queued":  hexutil.Uint(queue),
 	}
 }
 
+// https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect
 func (api txPoolAPI) Inspect() map[string]map[string]map[string]string {
 	api.logger.Debug("txpool_inspect")
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
+	pending, queued := api.backend.TxPoolContent()
+	for addr, txs := range pending {
+		content["pending"][addr.Hex()] = inspectPoolTxs(txs)
+	}
+	for addr, txs := range queued {
+		content["queued"][addr.Hex()] = inspectPoolTxs(txs)
+	}
 	return content
 }
+
+// poolTxsToRpcResp returns a nonce-to-tx map, the TXs are not in any block yet
+func poolTxsToRpcResp(from common.Address, txs gethtypes.Transactions) map[string]*rpctypes.Transaction {
+	result := make(map[string]*rpctypes.Transaction, len(txs))
+	for _, tx := range txs {
+		result[strconv.FormatUint(tx.Nonce(), 10)] = rpctypes.NewRPCPendingTransaction(tx, from)
+	}
+	return result
+}
+
+func inspectPoolTxs(txs gethtypes.Transactions) map[string]string {
+	result := make(map[string]string, len(txs))
+	for _, tx := range txs {
+		result[strconv.FormatUint(tx.Nonce(), 10)] = inspectPoolTx(tx)
+	}
+	return result
+}
+
+// inspectPoolTx returns a summary in the same format as geth
+func inspectPoolTx(tx *gethtypes.Transaction) string {
+	to := "contract creation"
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+	return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to, tx.Value(), tx.Gas(), tx.GasPrice())
+}
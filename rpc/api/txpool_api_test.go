@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/smartbch/smartbch/api"
+	"github.com/smartbch/smartbch/internal/testutils"
+)
+
+type mockTmNode struct {
+	mempool tmtypes.Txs
+}
+
+var _ api.ITmNode = (*mockTmNode)(nil)
+
+func (node *mockTmNode) BroadcastTxSync(tx tmtypes.Tx) (gethcmn.Hash, error) {
+	node.mempool = append(node.mempool, tx)
+	return gethcmn.BytesToHash(tx.Hash()), nil
+}
+
+func (node *mockTmNode) GetNodeInfo() api.Info {
+	return api.Info{}
+}
+
+func (node *mockTmNode) GetUnconfirmedTxs(max int) tmtypes.Txs {
+	if max >= 0 && max < len(node.mempool) {
+		return node.mempool[:max]
+	}
+	return node.mempool
+}
+
+func (node *mockTmNode) addTxs(txs ...*gethtypes.Transaction) {
+	for _, tx := range txs {
+		node.mempool = append(node.mempool, testutils.MustEncodeTx(tx))
+	}
+}
+
+func TestTxPool(t *testing.T) {
+	key1, addr1 := testutils.GenKeyAndAddr()
+	key2, addr2 := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key1, key2)
+	_app.WaitLock()
+	defer _app.Destroy()
+
+	tx0, _ := _app.MakeAndSignTxWithNonce(key1, &addr2, 100, nil, 0)
+	tx1, _ := _app.MakeAndSignTxWithNonce(key1, &addr2, 200, nil, 1)
+	tx3, _ := _app.MakeAndSignTxWithNonce(key1, &addr2, 300, nil, 3)
+	txB, _ := _app.MakeAndSignTxWithNonce(key2, &addr1, 400, nil, 0)
+	require.Equal(t, uint32(0), _app.CheckNewTxABCI(tx0))
+	require.Equal(t, uint32(0), _app.CheckNewTxABCI(tx1))
+
+	node := &mockTmNode{}
+	node.addTxs(tx3, tx1, txB, tx0)
+	_api := newTxPoolAPI(api.NewBackend(node, _app.App), _app.Logger())
+
+	status := _api.Status()
+	require.Equal(t, hexutil.Uint(3), status["pending"])
+	require.Equal(t, hexutil.Uint(1), status["queued"])
+
+	content := _api.Content()
+	require.Len(t, content["pending"], 2)
+	require.Len(t, content["queued"], 1)
+	require.Len(t, content["pending"][addr1.Hex()], 2)
+	require.Equal(t, tx0.Hash(), content["pending"][addr1.Hex()]["0"].Hash)
+	require.Equal(t, tx1.Hash(), content["pending"][addr1.Hex()]["1"].Hash)
+	require.Equal(t, addr1, content["pending"][addr1.Hex()]["1"].From)
+	require.Nil(t, content["pending"][addr1.Hex()]["1"].BlockNumber)
+	require.Equal(t, tx3.Hash(), content["queued"][addr1.Hex()]["3"].Hash)
+	require.Equal(t, txB.Hash(), content["pending"][addr2.Hex()]["0"].Hash)
+
+	contentFrom := _api.ContentFrom(addr2)
+	require.Len(t, contentFrom["pending"], 1)
+	require.Len(t, contentFrom["queued"], 0)
+
+	inspect := _api.Inspect()
+	require.Equal(t, addr2.Hex()+": 300 wei + 1000000 gas × 0 wei", inspect["queued"][addr1.Hex()]["3"])
+}
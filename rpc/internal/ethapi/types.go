@@ -1,8 +1,11 @@
 package ethapi
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // Copied the Transaction, SendTxArgs and CallArgs types since they are registered under an
@@ -10,44 +13,93 @@ import (
 
 // Transaction represents a transaction returned to RPC clients.
 type Transaction struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
+	BlockHash        *common.Hash          `json:"blockHash"`
+	BlockNumber      *hexutil.Big          `json:"blockNumber"`
+	From             common.Address        `json:"from"`
+	Gas              hexutil.Uint64        `json:"gas"`
+	GasPrice         *hexutil.Big          `json:"gasPrice"`
+	GasFeeCap        *hexutil.Big          `json:"maxFeePerGas,omitempty"`
+	GasTipCap        *hexutil.Big          `json:"maxPriorityFeePerGas,omitempty"`
+	Hash             common.Hash           `json:"hash"`
+	Input            hexutil.Bytes         `json:"input"`
+	Nonce            hexutil.Uint64        `json:"nonce"`
+	To               *common.Address       `json:"to"`
+	TransactionIndex *hexutil.Uint64       `json:"transactionIndex"`
+	Value            *hexutil.Big          `json:"value"`
+	Type             hexutil.Uint64        `json:"type"`
+	Accesses         *gethtypes.AccessList `json:"accessList,omitempty"`
+	ChainID          *hexutil.Big          `json:"chainId,omitempty"`
+	V                *hexutil.Big          `json:"v"`
+	R                *hexutil.Big          `json:"r"`
+	S                *hexutil.Big          `json:"s"`
+}
+
+// SetTypedTxFields fills the fields which are only used by EIP-2930 and EIP-1559 typed TXs
+func (tx *Transaction) SetTypedTxFields(txType uint8, chainID, gasTipCap, gasFeeCap *big.Int, accessList gethtypes.AccessList) {
+	tx.Type = hexutil.Uint64(txType)
+	switch txType {
+	case gethtypes.AccessListTxType:
+		tx.Accesses = &accessList
+		tx.ChainID = (*hexutil.Big)(chainID)
+	case gethtypes.DynamicFeeTxType:
+		tx.Accesses = &accessList
+		tx.ChainID = (*hexutil.Big)(chainID)
+		tx.GasTipCap = (*hexutil.Big)(gasTipCap)
+		tx.GasFeeCap = (*hexutil.Big)(gasFeeCap)
+	}
+}
+
+// NewRPCPendingTransaction returns a transaction that will serialize to the RPC
+// representation, with the block fields left empty since it is not in any block yet.
+func NewRPCPendingTransaction(tx *gethtypes.Transaction, from common.Address) *Transaction {
+	v, r, s := tx.RawSignatureValues()
+	result := &Transaction{
+		From:     from,
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+		Hash:     tx.Hash(),
+		Input:    tx.Data(),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		To:       tx.To(),
+		Value:    (*hexutil.Big)(tx.Value()),
+		V:        (*hexutil.Big)(v),
+		R:        (*hexutil.Big)(r),
+		S:        (*hexutil.Big)(s),
+	}
+	result.SetTypedTxFields(tx.Type(), tx.ChainId(), tx.GasTipCap(), tx.GasFeeCap(), tx.AccessList())
+	return result
 }
 
 // SendTxArgs represents the arguments to submit a new transaction into the transaction pool.
 // Duplicate struct definition since geth struct is in internal package
 // Ref: https://github.com/ethereum/go-ethereum/blob/release/1.9/internal/ethapi/api.go#L1346
 type SendTxArgs struct {
-	From     common.Address  `json:"from"`
-	To       *common.Address `json:"to"`
-	Gas      *hexutil.Uint64 `json:"gas"`
-	GasPrice *hexutil.Big    `json:"gasPrice"`
-	Value    *hexutil.Big    `json:"value"`
-	Nonce    *hexutil.Uint64 `json:"nonce"`
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  *hexutil.Uint64 `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                *hexutil.Uint64 `json:"nonce"`
 	// We accept "data" and "input" for backwards-compatibility reasons. "input" is the
 	// newer name and should be preferred by clients.
 	Data  *hexutil.Bytes `json:"data"`
 	Input *hexutil.Bytes `json:"input"`
+
+	// Introduced by EIP-2930
+	AccessList *gethtypes.AccessList `json:"accessList,omitempty"`
 }
 
 // CallArgs represents the arguments for a call.
 type CallArgs struct {
-	From     *common.Address `json:"from"`
-	To       *common.Address `json:"to"`
-	Gas      *hexutil.Uint64 `json:"gas"`
-	GasPrice *hexutil.Big    `json:"gasPrice"`
-	Value    *hexutil.Big    `json:"value"`
-	Data     *hexutil.Bytes  `json:"data"`
+	From                 *common.Address       `json:"from"`
+	To                   *common.Address       `json:"to"`
+	Gas                  *hexutil.Uint64       `json:"gas"`
+	GasPrice             *hexutil.Big          `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big          `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big          `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big          `json:"value"`
+	Data                 *hexutil.Bytes        `json:"data"`
+	AccessList           *gethtypes.AccessList `json:"accessList,omitempty"`
 }
@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+	"unicode"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this package.
+	MetricsSubsystem = "rpc"
+
+	// the label value used for the methods which are not registered, to keep the cardinality bounded
+	unknownMethod = "unknown"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of calls of each JSON-RPC method.
+	Calls metrics.Counter
+	// Latency of each JSON-RPC method, in seconds.
+	Latency metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library, which are
+// registered into the given registerer.
+func PrometheusMetrics(registerer stdprometheus.Registerer, namespace string) *Metrics {
+	calls := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "calls",
+		Help:      "Number of calls of each JSON-RPC method.",
+	}, []string{"method"})
+	latency := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "latency_seconds",
+		Help:      "Latency of each JSON-RPC method.",
+		Buckets:   stdprometheus.ExponentialBuckets(0.0005, 2, 16),
+	}, []string{"method"})
+	registerer.MustRegister(calls, latency)
+	return &Metrics{
+		Calls:   prometheus.NewCounter(calls),
+		Latency: prometheus.NewHistogram(latency),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Calls:   discard.NewCounter(),
+		Latency: discard.NewHistogram(),
+	}
+}
+
+// rpcMethodNames returns the names of the methods provided by apis, following the naming rule of geth's rpc
+// server: the namespace, an underscore and the method name whose first letter is in lower case.
+func rpcMethodNames(apis []gethrpc.API) map[string]bool {
+	names := make(map[string]bool)
+	for _, _api := range apis {
+		typ := reflect.TypeOf(_api.Service)
+		for i := 0; i < typ.NumMethod(); i++ {
+			name := []rune(typ.Method(i).Name)
+			name[0] = unicode.ToLower(name[0])
+			names[_api.Namespace+"_"+string(name)] = true
+		}
+	}
+	return names
+}
+
+// parseRpcMethods returns the methods called by a single or a batch JSON-RPC request
+func parseRpcMethods(body []byte) []string {
+	var call struct {
+		Method string `json:"method"`
+	}
+	body = bytes.TrimLeft(body, " \t\r\n")
+	if len(body) != 0 && body[0] == '[' {
+		var calls []json.RawMessage
+		if json.Unmarshal(body, &calls) != nil {
+			return nil
+		}
+		methods := make([]string, 0, len(calls))
+		for _, c := range calls {
+			call.Method = ""
+			if json.Unmarshal(c, &call) == nil {
+				methods = append(methods, call.Method)
+			}
+		}
+		return methods
+	}
+	if json.Unmarshal(body, &call) != nil {
+		return nil
+	}
+	return []string{call.Method}
+}
+
+// newMetricsHandler counts the calls and measures the latency of the JSON-RPC methods served over HTTP.
+// Each method in a batch request observes the latency of the whole batch.
+func newMetricsHandler(srv http.Handler, m *Metrics, knownMethods map[string]bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			srv.ServeHTTP(w, r)
+			return
+		}
+		// the body size is already limited by tendermint's rpc server
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		methods := parseRpcMethods(body)
+		startTime := time.Now()
+		srv.ServeHTTP(w, r)
+		seconds := time.Since(startTime).Seconds()
+		for _, method := range methods {
+			if !knownMethods[method] {
+				method = unknownMethod
+			}
+			m.Calls.With("method", method).Add(1)
+			m.Latency.With("method", method).Observe(seconds)
+		}
+	})
+}
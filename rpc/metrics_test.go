@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/require"
+)
+
+type testService struct{}
+
+func (s *testService) BlockNumber() uint64 { return 1 }
+func (s *testService) ChainId() uint64     { return 2 }
+
+// records the calls of each method
+type testCounter struct {
+	method string
+	calls  map[string]float64
+}
+
+func (c *testCounter) With(labelValues ...string) metrics.Counter {
+	return &testCounter{method: labelValues[1], calls: c.calls}
+}
+
+func (c *testCounter) Add(delta float64) { c.calls[c.method] += delta }
+
+func TestParseRpcMethods(t *testing.T) {
+	require.Equal(t, []string{"eth_blockNumber"},
+		parseRpcMethods([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)))
+	require.Equal(t, []string{"eth_blockNumber", "eth_chainId"},
+		parseRpcMethods([]byte(` [{"id":1,"method":"eth_blockNumber"},{"id":2,"method":"eth_chainId"}]`)))
+	require.Nil(t, parseRpcMethods([]byte(`not json`)))
+}
+
+func TestMetricsHandler(t *testing.T) {
+	apis := []gethrpc.API{{Namespace: "eth", Service: &testService{}}}
+	srv := gethrpc.NewServer()
+	require.NoError(t, registerApis(srv, []string{"eth"}, apis))
+	calls := &testCounter{calls: make(map[string]float64)}
+	m := &Metrics{Calls: calls, Latency: discard.NewHistogram()}
+	handler := newMetricsHandler(srv, m, rpcMethodNames(apis))
+
+	body := `[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},{"jsonrpc":"2.0","id":2,"method":"eth_foo"}]`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"result":1`)
+	require.Equal(t, map[string]float64{"eth_blockNumber": 1, "unknown": 1}, calls.calls)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":3,"method":"eth_chainId"}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, map[string]float64{"eth_blockNumber": 1, "eth_chainId": 1, "unknown": 1}, calls.calls)
+}
@@ -45,12 +45,14 @@ type Server struct {
 	wssListener   net.Listener
 
 	unlockedKeys []string
+
+	metrics *Metrics
 }
 
 func NewServer(rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, certFile, keyFile string,
 	serverCfg *tmrpcserver.Config, backend api.BackendService,
 	logger tmlog.Logger, unlockedKeys []string,
-	httpAPI string, wsAPI string) tmservice.Service {
+	httpAPI string, wsAPI string, metrics *Metrics) tmservice.Service {
 
 	impl := &Server{
 		rpcAddr:      rpcAddr,
@@ -66,6 +68,7 @@ func NewServer(rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, certFil
 		wssAddr:      wsAddrSecure,  //"tcp://:9546",
 		httpAPIs:     splitAndTrim(httpAPI),
 		wsAPIs:       splitAndTrim(wsAPI),
+		metrics:      metrics,
 	}
 	return tmservice.NewBaseService(logger, "", impl)
 }
@@ -95,7 +98,7 @@ func (server *Server) startHTTPAndHTTPS(apis []gethrpc.API) (err error) {
 	}
 
 	allowedOrigins := strings.Split(server.corsDomain, ",")
-	handler := newCorsHandler(server.httpServer, allowedOrigins)
+	handler := newCorsHandler(newMetricsHandler(server.httpServer, server.metrics, rpcMethodNames(apis)), allowedOrigins)
 
 	server.httpListener, err = tmrpcserver.Listen(
 		server.rpcAddr, server.serverConfig)
@@ -10,6 +10,256 @@ import (
 
 var ABI = ethutils.MustParseABI(`
 [
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "operator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "oldMinGasPrice",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "newMinGasPrice",
+				"type": "uint256"
+			}
+		],
+		"name": "ChangeMinGasPrice",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "stakedCoins",
+				"type": "uint256"
+			}
+		],
+		"name": "CreateValidator",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "epochNum",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "DeliverReward",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "addedCoins",
+				"type": "uint256"
+			}
+		],
+		"name": "EditValidator",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "executor",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "oldMinGasPrice",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "newMinGasPrice",
+				"type": "uint256"
+			}
+		],
+		"name": "ExecuteProposal",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "proposer",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "target",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "deadline",
+				"type": "uint256"
+			}
+		],
+		"name": "Proposal",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "Retire",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "reason",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "Slash",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "epochNum",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "startHeight",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "endTime",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "bool",
+				"name": "isValid",
+				"type": "bool"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "activeValidatorCount",
+				"type": "uint256"
+			}
+		],
+		"name": "SwitchEpoch",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "voter",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "target",
+				"type": "uint256"
+			}
+		],
+		"name": "Vote",
+		"type": "event"
+	},
 	{
 		"inputs": [
 			{
@@ -147,36 +397,649 @@ var ABI = ethutils.MustParseABI(`
 		"outputs": [],
 		"stateMutability": "nonpayable",
 		"type": "function"
-	}
-]
-`)
-
-func PackCreateValidator(rewardTo gethcmn.Address, intro [32]byte, pubKey [32]byte) []byte {
-	return ABI.MustPack("createValidator", rewardTo, intro, pubKey)
-}
-func PackEditValidator(rewardTo gethcmn.Address, intro [32]byte) []byte {
-	return ABI.MustPack("editValidator", rewardTo, intro)
-}
-func PackRetire() []byte {
-	return ABI.MustPack("retire")
-}
-func PackIncreaseMinGasPrice() []byte {
-	return ABI.MustPack("increaseMinGasPrice")
-}
-func PackDecreaseMinGasPrice() []byte {
-	return ABI.MustPack("decreaseMinGasPrice")
-}
-func PackProposal(target *big.Int) []byte {
-	return ABI.MustPack("proposal", target)
-}
-func PackVote(target *big.Int) []byte {
-	return ABI.MustPack("vote", target)
-}
-func PackExecuteProposal() []byte {
-	return ABI.MustPack("executeProposal")
-}
-func PackGetVote(validator gethcmn.Address) []byte {
-	return ABI.MustPack("getVote", validator)
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "delegator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "Delegate",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "delegator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "Undelegate",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "delegator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "WithdrawRewards",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "rate",
+				"type": "uint256"
+			}
+		],
+		"name": "SetCommissionRate",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "delegate",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "undelegate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "withdrawRewards",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "rate",
+				"type": "uint256"
+			}
+		],
+		"name": "setCommissionRate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "Unjail",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "unjailHeight",
+				"type": "uint256"
+			}
+		],
+		"name": "Jail",
+		"type": "event"
+	},
+	{
+		"inputs": [],
+		"name": "unjail",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "proposer",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "activationHeight",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "deadline",
+				"type": "uint256"
+			}
+		],
+		"name": "ParamProposal",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "voter",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "bool",
+				"name": "approve",
+				"type": "bool"
+			}
+		],
+		"name": "ParamVote",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "executor",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "bool",
+				"name": "passed",
+				"type": "bool"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "activationHeight",
+				"type": "uint256"
+			}
+		],
+		"name": "ExecuteParamProposal",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "activationHeight",
+				"type": "uint256"
+			}
+		],
+		"name": "proposeParam",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"internalType": "bool",
+				"name": "approve",
+				"type": "bool"
+			}
+		],
+		"name": "voteParam",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			}
+		],
+		"name": "executeParamProposal",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			}
+		],
+		"name": "getParam",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "receiver",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "matureHeight",
+				"type": "uint256"
+			}
+		],
+		"name": "Unbond",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "receiver",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "WithdrawUnbonded",
+		"type": "event"
+	},
+	{
+		"inputs": [],
+		"name": "withdrawUnbonded",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "getValidator",
+		"outputs": [
+			{
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "uint256",
+				"name": "stakedCoins",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "votingPower",
+				"type": "uint256"
+			},
+			{
+				"internalType": "bool",
+				"name": "isRetiring",
+				"type": "bool"
+			},
+			{
+				"internalType": "bool",
+				"name": "isJailed",
+				"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getActiveValidators",
+		"outputs": [
+			{
+				"internalType": "address[]",
+				"name": "validators",
+				"type": "address[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "getPendingRewards",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getCurrEpochNum",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getMinGasPrice",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "oldPubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "newPubkey",
+				"type": "bytes32"
+			}
+		],
+		"name": "RotateConsensusKey",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "bytes32",
+				"name": "newPubkey",
+				"type": "bytes32"
+			}
+		],
+		"name": "rotateConsensusKey",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]
+`)
+
+func PackCreateValidator(rewardTo gethcmn.Address, intro [32]byte, pubKey [32]byte) []byte {
+	return ABI.MustPack("createValidator", rewardTo, intro, pubKey)
+}
+func PackEditValidator(rewardTo gethcmn.Address, intro [32]byte) []byte {
+	return ABI.MustPack("editValidator", rewardTo, intro)
+}
+func PackRetire() []byte {
+	return ABI.MustPack("retire")
+}
+func PackIncreaseMinGasPrice() []byte {
+	return ABI.MustPack("increaseMinGasPrice")
+}
+func PackDecreaseMinGasPrice() []byte {
+	return ABI.MustPack("decreaseMinGasPrice")
+}
+func PackProposal(target *big.Int) []byte {
+	return ABI.MustPack("proposal", target)
+}
+func PackVote(target *big.Int) []byte {
+	return ABI.MustPack("vote", target)
+}
+func PackExecuteProposal() []byte {
+	return ABI.MustPack("executeProposal")
+}
+func PackGetVote(validator gethcmn.Address) []byte {
+	return ABI.MustPack("getVote", validator)
+}
+func PackDelegate(validator gethcmn.Address) []byte {
+	return ABI.MustPack("delegate", validator)
+}
+func PackUndelegate(validator gethcmn.Address, amount *big.Int) []byte {
+	return ABI.MustPack("undelegate", validator, amount)
+}
+func PackWithdrawRewards(validator gethcmn.Address) []byte {
+	return ABI.MustPack("withdrawRewards", validator)
+}
+func PackSetCommissionRate(rate *big.Int) []byte {
+	return ABI.MustPack("setCommissionRate", rate)
+}
+func PackUnjail() []byte {
+	return ABI.MustPack("unjail")
+}
+func PackProposeParam(paramId, value, activationHeight *big.Int) []byte {
+	return ABI.MustPack("proposeParam", paramId, value, activationHeight)
+}
+func PackVoteParam(paramId *big.Int, approve bool) []byte {
+	return ABI.MustPack("voteParam", paramId, approve)
+}
+func PackExecuteParamProposal(paramId *big.Int) []byte {
+	return ABI.MustPack("executeParamProposal", paramId)
+}
+func PackGetParam(paramId *big.Int) []byte {
+	return ABI.MustPack("getParam", paramId)
+}
+func PackWithdrawUnbonded() []byte {
+	return ABI.MustPack("withdrawUnbonded")
+}
+func PackGetValidator(validator gethcmn.Address) []byte {
+	return ABI.MustPack("getValidator", validator)
+}
+func PackGetActiveValidators() []byte {
+	return ABI.MustPack("getActiveValidators")
+}
+func PackGetPendingRewards(validator gethcmn.Address) []byte {
+	return ABI.MustPack("getPendingRewards", validator)
+}
+func PackGetCurrEpochNum() []byte {
+	return ABI.MustPack("getCurrEpochNum")
+}
+func PackGetMinGasPrice() []byte {
+	return ABI.MustPack("getMinGasPrice")
+}
+func PackRotateConsensusKey(newPubkey [32]byte) []byte {
+	return ABI.MustPack("rotateConsensusKey", newPubkey)
 }
 
 func PackSumVotingPower(addrList []gethcmn.Address) []byte {
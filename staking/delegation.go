@@ -0,0 +1,356 @@
+package staking
+
+import (
+	"crypto/sha256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	"github.com/smartbch/moeingevm/ebp"
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// Since DelegationForkHeight, the holders who do not run validators can delegate their coins to validators.
+// The delegated coins are counted in the validators' voting power, and the delegators share the
+// validators' rewards pro rata, after the validators take commissions. The delegated amounts active in an
+// epoch are snapshotted at its start, and the rewards got in this epoch are split by the snapshot, such that
+// the coins delegated later do not share them. Since UnbondingForkHeight, the undelegated coins which have
+// been counted in voting power are put into the unbonding queue, where they can still be slashed.
+
+var (
+	GasOfDelegationOp uint64 = 200_000
+
+	// voting power is proportional to the staked and delegated coins since DelegationForkHeight
+	VotingPowerPerBCH     int64  = 100
+	DefaultCommissionRate uint64 = 1000 // 10%
+
+	// bound the size of a validator's DelegationInfo, which is loaded at each epoch switch
+	MinimumDelegationAmount   = uint256.NewInt(Uint64_1e18 / 10) // 0.1 BCH
+	MaxDelegatorsPerValidator = 1000
+
+	delegationSlotHashPrefix = [4]byte{'d', 'l', 'g', 't'}
+)
+
+func isDelegationFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.DelegationForkHeight
+}
+
+// delegate coins to a validator, which are counted after next epoch switch
+func delegate(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfDelegationOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var validator [20]byte
+	copy(validator[:], callData[12:])
+	coins := uint256.NewInt(0).SetBytes32(tx.Value[:])
+	if coins.IsZero() {
+		outData = []byte(ZeroDelegation.Error())
+		return
+	}
+	if coins.Lt(MinimumDelegationAmount) {
+		outData = []byte(DelegationLessThanMinimum.Error())
+		return
+	}
+	stakingAcc, info := LoadStakingAccAndInfo(ctx)
+	val := info.GetValidatorByAddr(validator)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.IsRetiring {
+		outData = []byte(ValidatorInRetiring.Error())
+		return
+	}
+	dInfo := LoadDelegationInfo(ctx, validator)
+	if dInfo.GetDelegation(tx.From) == nil && len(dInfo.Delegations) >= MaxDelegatorsPerValidator {
+		outData = []byte(TooManyDelegators.Error())
+		return
+	}
+	status, outData = transferStakedCoins(ctx, tx, stakingAcc)
+	if status != StatusSuccess {
+		return
+	}
+	d := dInfo.GetOrAddDelegation(tx.From)
+	pending := uint256.NewInt(0).SetBytes32(d.PendingAmount[:])
+	d.PendingAmount = pending.Add(pending, coins).Bytes32()
+	SaveDelegationInfo(ctx, &dInfo)
+	logs = append(logs, buildDelegateEvmLog(tx.From, validator, coins))
+	return
+}
+
+// take back some delegated coins from a validator, the ones delegated in current epoch are taken first and
+// returned at once, the others are put into the unbonding queue since UnbondingForkHeight
+func undelegate(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfDelegationOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 64 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var validator [20]byte
+	copy(validator[:], callData[12:32])
+	amount := uint256.NewInt(0).SetBytes(callData[32:64])
+	if amount.IsZero() {
+		outData = []byte(InvalidArgument.Error())
+		return
+	}
+	dInfo, found := loadDelegationInfo(ctx, validator)
+	if !found || dInfo.GetDelegation(tx.From) == nil {
+		outData = []byte(types.NoSuchDelegation.Error())
+		return
+	}
+	// the undelegated and the remained coins cannot be less than the minimum, unless all are undelegated
+	total := dInfo.GetDelegation(tx.From).TotalAmount()
+	if total.Gt(amount) && (amount.Lt(MinimumDelegationAmount) ||
+		uint256.NewInt(0).Sub(total, amount).Lt(MinimumDelegationAmount)) {
+		outData = []byte(DelegationLessThanMinimum.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(validator)
+	if val == nil { // never happens, the delegations are refunded when the validator is removed
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	fromActive, err := dInfo.Undelegate(tx.From, amount)
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	dInfo.RemoveEmptyDelegations()
+	SaveDelegationInfo(ctx, &dInfo)
+	logs = append(logs, buildUndelegateEvmLog(tx.From, validator, amount))
+	returned := amount.Clone()
+	if isUnbondingFork(ctx) && !fromActive.IsZero() {
+		returned.Sub(returned, fromActive)
+		entry := info.AddUnbondingEntryFor(val, tx.From, fromActive.Bytes32(), ctx.Height+getUnbondingBlocks(ctx))
+		entry.Delegated = true
+		SaveStakingInfo(ctx, info)
+		logs = append(logs, buildUnbondEvmLog(entry.Validator, entry.Receiver, fromActive, entry.MatureHeight))
+	}
+	if !returned.IsZero() {
+		transferFromStakingAcc(ctx, tx.From, returned)
+	}
+	status = StatusSuccess
+	return
+}
+
+// withdraw the rewards got from the coins delegated to a validator
+func withdrawRewards(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfDelegationOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var validator [20]byte
+	copy(validator[:], callData[12:])
+	dInfo, _ := loadDelegationInfo(ctx, validator)
+	d := dInfo.GetDelegation(tx.From)
+	if d == nil {
+		outData = []byte(types.NoSuchDelegation.Error())
+		return
+	}
+	rewards := uint256.NewInt(0).SetBytes32(d.Rewards[:])
+	if rewards.IsZero() {
+		outData = []byte(NoRewardsToWithdraw.Error())
+		return
+	}
+	d.Rewards = [32]byte{}
+	dInfo.RemoveEmptyDelegations()
+	SaveDelegationInfo(ctx, &dInfo)
+	transferFromStakingAcc(ctx, tx.From, rewards)
+	logs = append(logs, buildWithdrawRewardsEvmLog(tx.From, validator, rewards))
+	status = StatusSuccess
+	return
+}
+
+// a validator sets the commission rate (in basis points) taken from its delegators' rewards, which
+// takes effect at next epoch switch
+func setCommissionRate(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfDelegationOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	rate := uint256.NewInt(0).SetBytes(callData)
+	if !rate.IsUint64() || rate.Uint64() > types.MaxCommissionRate {
+		outData = []byte(InvalidCommissionRate.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	if info.GetValidatorByAddr(tx.From) == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	dInfo := LoadDelegationInfo(ctx, tx.From)
+	dInfo.NextCommissionRate = rate.Uint64()
+	SaveDelegationInfo(ctx, &dInfo)
+	logs = append(logs, buildSetCommissionRateEvmLog(tx.From, rate.Uint64()))
+	status = StatusSuccess
+	return
+}
+
+// transfer coins from stakingAcc to 'receiver'
+func transferFromStakingAcc(ctx *mevmtypes.Context, receiver common.Address, amount *uint256.Int) {
+	err := ebp.SubSenderAccBalance(ctx, StakingContractAddress, amount)
+	if err != nil {
+		panic(err) // the delegated coins and rewards are always kept in stakingAcc
+	}
+	acc := ctx.GetAccount(receiver)
+	if acc == nil {
+		acc = mevmtypes.ZeroAccountInfo()
+	}
+	balance := acc.Balance()
+	balance.Add(balance, amount)
+	acc.UpdateBalance(balance)
+	ctx.SetAccount(receiver, acc)
+}
+
+func getSlotForDelegationInfo(validator [20]byte) string {
+	key := sha256.Sum256(append(delegationSlotHashPrefix[:], validator[:]...))
+	return string(key[:])
+}
+
+func loadDelegationInfo(ctx *mevmtypes.Context, validator [20]byte) (dInfo types.DelegationInfo, found bool) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForDelegationInfo(validator))
+	if len(bz) == 0 {
+		dInfo = types.DelegationInfo{
+			Validator:          validator,
+			CommissionRate:     DefaultCommissionRate,
+			NextCommissionRate: DefaultCommissionRate,
+		}
+		return
+	}
+	_, err := dInfo.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	found = true
+	return
+}
+
+// LoadDelegationInfo returns the delegations to a validator, or an empty DelegationInfo with the default
+// commission rate if no one has delegated to it.
+func LoadDelegationInfo(ctx *mevmtypes.Context, validator [20]byte) types.DelegationInfo {
+	dInfo, _ := loadDelegationInfo(ctx, validator)
+	return dInfo
+}
+
+func SaveDelegationInfo(ctx *mevmtypes.Context, dInfo *types.DelegationInfo) {
+	bz, err := dInfo.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForDelegationInfo(dInfo.Validator), bz)
+}
+
+// =========================================================================================
+// Following functions are called by the engine
+
+// the delegations and the commission rates set in the last epoch take effect, and they are snapshotted for
+// the new epoch. The snapshots of the delivered rewards are removed. Return the delegators' shares of the
+// validators' rewards in the new epoch.
+func activateDelegations(ctx *mevmtypes.Context, info *types.StakingInfo) (shares map[[20]byte]uint64) {
+	shares = make(map[[20]byte]uint64)
+	for _, val := range info.Validators {
+		if dInfo, found := loadDelegationInfo(ctx, val.Address); found {
+			dInfo.Activate()
+			selfStake := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+			share := dInfo.TakeSnapshot(info.CurrEpochNum, info.CurrEpochNum-param.EpochCountBeforeRewardMature, selfStake)
+			if share != 0 {
+				shares[val.Address] = share
+			}
+			SaveDelegationInfo(ctx, &dInfo)
+		}
+	}
+	return
+}
+
+// the pending rewards of current epoch split out the delegators' shares
+func setDelegatorsShares(info *types.StakingInfo, shares map[[20]byte]uint64) {
+	for _, pr := range info.PendingRewards {
+		if share, ok := shares[pr.Address]; ok && pr.EpochNum == info.CurrEpochNum {
+			pr.Delegators = &types.DelegatorsReward{Share: share}
+		}
+	}
+}
+
+// the voting power of a validator is decided by the coins staked by itself and delegated to it
+func votingPowerOfStake(ctx *mevmtypes.Context, val *types.Validator) int64 {
+	dInfo := LoadDelegationInfo(ctx, val.Address)
+	stake := dInfo.TotalAmount()
+	stake.Add(stake, uint256.NewInt(0).SetBytes32(val.StakedCoins[:]))
+	stake.Div(stake, uint256.NewInt(Uint64_1e18))
+	power := int64(stake.Uint64()) * getVotingPowerPerBCH(ctx)
+	if power <= 0 {
+		power = 1
+	}
+	return power
+}
+
+// Return all the delegated coins and withdrawable rewards of a deleted validator to the delegators.
+// Since UnbondingForkHeight, the active delegated coins are put into the unbonding queue instead, just like
+// the undelegated ones, and the Unbond events are returned. It returns the total amount refunded at once,
+// which must be deducted from stakingAcc by the caller.
+func refundDelegations(ctx *mevmtypes.Context, info *types.StakingInfo, val *types.Validator) (total *uint256.Int, logs []mevmtypes.EvmLog) {
+	total = uint256.NewInt(0)
+	dInfo, found := loadDelegationInfo(ctx, val.Address)
+	if !found {
+		return
+	}
+	for _, d := range dInfo.Delegations {
+		coins := uint256.NewInt(0).SetBytes32(d.PendingAmount[:])
+		coins.Add(coins, uint256.NewInt(0).SetBytes32(d.Rewards[:]))
+		active := uint256.NewInt(0).SetBytes32(d.Amount[:])
+		if isUnbondingFork(ctx) && !active.IsZero() {
+			entry := info.AddUnbondingEntryFor(val, d.Delegator, d.Amount, ctx.Height+getUnbondingBlocks(ctx))
+			entry.Delegated = true
+			logs = append(logs, buildUnbondEvmLog(entry.Validator, entry.Receiver, active, entry.MatureHeight))
+		} else {
+			coins.Add(coins, active)
+		}
+		acc := ctx.GetAccount(d.Delegator)
+		if acc == nil {
+			acc = mevmtypes.ZeroAccountInfo()
+		}
+		balance := acc.Balance()
+		balance.Add(balance, coins)
+		acc.UpdateBalance(balance)
+		ctx.SetAccount(d.Delegator, acc)
+		total.Add(total, coins)
+	}
+	ctx.DeleteStorageAt(StakingContractSequence, getSlotForDelegationInfo(val.Address))
+	return
+}
@@ -0,0 +1,204 @@
+package staking
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+/*------events------*/
+/*interface Staking {
+	event CreateValidator(address indexed validator, address indexed rewardTo, bytes32 pubkey, uint stakedCoins);
+	event EditValidator(address indexed validator, address indexed rewardTo, uint addedCoins);
+	event Retire(address indexed validator);
+	event Proposal(address indexed proposer, uint target, uint deadline);
+	event Vote(address indexed voter, uint target);
+	event ExecuteProposal(address indexed executor, uint oldMinGasPrice, uint newMinGasPrice);
+	event ChangeMinGasPrice(address indexed operator, uint oldMinGasPrice, uint newMinGasPrice);
+	event Delegate(address indexed delegator, address indexed validator, uint amount);
+	event Undelegate(address indexed delegator, address indexed validator, uint amount);
+	event WithdrawRewards(address indexed delegator, address indexed validator, uint amount);
+	event SetCommissionRate(address indexed validator, uint rate);
+	event Unjail(address indexed validator);
+	event ParamProposal(address indexed proposer, uint indexed paramId, uint value, uint activationHeight, uint deadline);
+	event ParamVote(address indexed voter, uint indexed paramId, bool approve);
+	event ExecuteParamProposal(address indexed executor, uint indexed paramId, bool passed, uint value, uint activationHeight);
+	event WithdrawUnbonded(address indexed validator, address indexed receiver, uint amount);
+	event RotateConsensusKey(address indexed validator, bytes32 oldPubkey, bytes32 newPubkey);
+
+	// following events are emitted by the engine at the end of blocks, not by transactions
+	event Slash(address indexed validator, uint indexed reason, uint amount);
+	event SwitchEpoch(uint indexed epochNum, uint startHeight, uint endTime, bool isValid, uint activeValidatorCount);
+	event DeliverReward(address indexed rewardTo, uint indexed epochNum, uint amount);
+	event Jail(address indexed validator, uint unjailHeight);
+	event Unbond(address indexed validator, address indexed receiver, uint amount, uint matureHeight);
+}*/
+var (
+	HashOfEventCreateValidator      [32]byte = common.HexToHash("0xca341e4f8668e6ecfee56c5b3503960b65ea800138ae8a8530c8d03ece2431e2")
+	HashOfEventEditValidator        [32]byte = common.HexToHash("0xae642e962707c3d3dd46c76a7743623c8d2239ff148842e9490dbd70563bdb77")
+	HashOfEventRetire               [32]byte = common.HexToHash("0x2d037bb93b6eace86523988d5183719dbf62a2b729a9a913e3dd1ee0a92aa8b9")
+	HashOfEventProposal             [32]byte = common.HexToHash("0xce3f9123968c27e3ae7f4f935977986cfabb2cd896d4029dd04bad5683f60782")
+	HashOfEventVote                 [32]byte = common.HexToHash("0xf668ead05c744b9178e571d2edb452e72baf6529c8d72160e64e59b50d865bd0")
+	HashOfEventExecuteProposal      [32]byte = common.HexToHash("0xe34644e78bdac6d4c7c4ac7f4873b236f258e7017cffcb2946d29d55f02201d7")
+	HashOfEventChangeMinGasPrice    [32]byte = common.HexToHash("0xe16b4c63eadb0ff7cfa2a697a2920742c27b8baa67cc4b9babb5620d421e3f84")
+	HashOfEventDelegate             [32]byte = common.HexToHash("0x510b11bb3f3c799b11307c01ab7db0d335683ef5b2da98f7697de744f465eacc")
+	HashOfEventUndelegate           [32]byte = common.HexToHash("0xbda8c0e95802a0e6788c3e9027292382d5a41b86556015f846b03a9874b2b827")
+	HashOfEventWithdrawRewards      [32]byte = common.HexToHash("0xa6572d5d818a6a4960ef939cd39abc0f131db8b0aaba702189dfba3cc596fb61")
+	HashOfEventSetCommissionRate    [32]byte = common.HexToHash("0xfb621a017bb038be49d13b22e821cbca1b2f153f0a4933795e7a363aa47fdf88")
+	HashOfEventUnjail               [32]byte = common.HexToHash("0xc3ef55ddda4bc9300706e15ab3aed03c762d8afd43a7d358a7b9503cb39f281b")
+	HashOfEventParamProposal        [32]byte = common.HexToHash("0xf459942355eedfd347e51345a6bf47d92ffda5f1839067bf4e75e2d67e9745f3")
+	HashOfEventParamVote            [32]byte = common.HexToHash("0x13907f60faa4f7c7ba2447fe25317ea1ae0e42c6e2ec91944f2622dab74a6485")
+	HashOfEventExecuteParamProposal [32]byte = common.HexToHash("0xc7574c8dfa058e959436206644f9d7faa08cfa00c25e57a27e631d1d45407305")
+	HashOfEventWithdrawUnbonded     [32]byte = common.HexToHash("0x774ce95490bc5fda68c7712a24862be309542f858fd8cf7c7cc68f0970526f18")
+	HashOfEventRotateConsensusKey   [32]byte = common.HexToHash("0x4d218baf8c75c30aed6584209a3dd4ff3fcf41204211d80f3f4d3f053152b2b7")
+	HashOfEventSlash                [32]byte = common.HexToHash("0xe05ad941535eea602efe44ddd7d96e5db6ad9a4865c360257aad8cf4c0a94469")
+	HashOfEventSwitchEpoch          [32]byte = common.HexToHash("0xd2fe340ab4372a4f3ebd2fc5f1e7b3389704660529d548593b617841c24e01fb")
+	HashOfEventDeliverReward        [32]byte = common.HexToHash("0x3879b514645a628822cd682809a61d6863078230eba187de74e369e01a9d3e7c")
+	HashOfEventJail                 [32]byte = common.HexToHash("0xbe3aa33bd245135e4e26b223d79d14ea479a47bff09f2b03c53838af1edbb14b")
+	HashOfEventUnbond               [32]byte = common.HexToHash("0x4bf8087be3b8a59c2662514df2ed4a3dcaf9ca22f442340cfc05a4e52343d18e")
+)
+
+// the reasons of Slash events
+const (
+	SlashReasonDuplicateSig uint64 = 1
+	SlashReasonNotOnline    uint64 = 2
+)
+
+// The TXs calling the functions which exist before the delegation fork emit no logs before
+// StakingLogsForkHeight, such that the old blocks are replayed with the same receipts.
+func isStakingLogsFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.StakingLogsForkHeight
+}
+
+// newEvmLog builds a log of the staking contract, whose topics are the event hash and the indexed
+// arguments, and whose data are the other arguments, each of which takes 32 bytes
+func newEvmLog(eventHash [32]byte, indexed []common.Hash, data ...*uint256.Int) mevmtypes.EvmLog {
+	evmLog := mevmtypes.EvmLog{
+		Address: StakingContractAddress,
+		Topics:  make([]common.Hash, 0, 4),
+		Data:    make([]byte, 0, 32*len(data)),
+	}
+	evmLog.Topics = append(evmLog.Topics, eventHash)
+	evmLog.Topics = append(evmLog.Topics, indexed...)
+	for _, d := range data {
+		bz := d.Bytes32()
+		evmLog.Data = append(evmLog.Data, bz[:]...)
+	}
+	return evmLog
+}
+
+func addrToHash(addr [20]byte) common.Hash {
+	return common.BytesToHash(addr[:])
+}
+
+func uint64ToHash(n uint64) common.Hash {
+	return uint256.NewInt(n).Bytes32()
+}
+
+func buildCreateValidatorEvmLog(validator, rewardTo [20]byte, pubkey [32]byte, stakedCoins *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventCreateValidator, []common.Hash{addrToHash(validator), addrToHash(rewardTo)},
+		uint256.NewInt(0).SetBytes32(pubkey[:]), stakedCoins)
+}
+
+func buildEditValidatorEvmLog(validator, rewardTo [20]byte, addedCoins *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventEditValidator, []common.Hash{addrToHash(validator), addrToHash(rewardTo)}, addedCoins)
+}
+
+func buildRetireEvmLog(validator [20]byte) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventRetire, []common.Hash{addrToHash(validator)})
+}
+
+func buildProposalEvmLog(proposer [20]byte, target, deadline uint64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventProposal, []common.Hash{addrToHash(proposer)},
+		uint256.NewInt(target), uint256.NewInt(deadline))
+}
+
+func buildVoteEvmLog(voter [20]byte, target uint64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventVote, []common.Hash{addrToHash(voter)}, uint256.NewInt(target))
+}
+
+func buildExecuteProposalEvmLog(executor [20]byte, oldMinGasPrice, newMinGasPrice uint64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventExecuteProposal, []common.Hash{addrToHash(executor)},
+		uint256.NewInt(oldMinGasPrice), uint256.NewInt(newMinGasPrice))
+}
+
+func buildChangeMinGasPriceEvmLog(operator [20]byte, oldMinGasPrice, newMinGasPrice uint64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventChangeMinGasPrice, []common.Hash{addrToHash(operator)},
+		uint256.NewInt(oldMinGasPrice), uint256.NewInt(newMinGasPrice))
+}
+
+func buildDelegateEvmLog(delegator, validator [20]byte, amount *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventDelegate, []common.Hash{addrToHash(delegator), addrToHash(validator)}, amount)
+}
+
+func buildUndelegateEvmLog(delegator, validator [20]byte, amount *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventUndelegate, []common.Hash{addrToHash(delegator), addrToHash(validator)}, amount)
+}
+
+func buildWithdrawRewardsEvmLog(delegator, validator [20]byte, amount *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventWithdrawRewards, []common.Hash{addrToHash(delegator), addrToHash(validator)}, amount)
+}
+
+func buildSetCommissionRateEvmLog(validator [20]byte, rate uint64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventSetCommissionRate, []common.Hash{addrToHash(validator)}, uint256.NewInt(rate))
+}
+
+func buildUnjailEvmLog(validator [20]byte) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventUnjail, []common.Hash{addrToHash(validator)})
+}
+
+func boolToUint256(b bool) *uint256.Int {
+	if b {
+		return uint256.NewInt(1)
+	}
+	return uint256.NewInt(0)
+}
+
+func buildParamProposalEvmLog(proposer [20]byte, paramId, value uint64, activationHeight int64, deadline uint64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventParamProposal, []common.Hash{addrToHash(proposer), uint64ToHash(paramId)},
+		uint256.NewInt(value), uint256.NewInt(uint64(activationHeight)), uint256.NewInt(deadline))
+}
+
+func buildParamVoteEvmLog(voter [20]byte, paramId uint64, approve bool) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventParamVote, []common.Hash{addrToHash(voter), uint64ToHash(paramId)}, boolToUint256(approve))
+}
+
+func buildExecuteParamProposalEvmLog(executor [20]byte, paramId uint64, passed bool, value uint64, activationHeight int64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventExecuteParamProposal, []common.Hash{addrToHash(executor), uint64ToHash(paramId)},
+		boolToUint256(passed), uint256.NewInt(value), uint256.NewInt(uint64(activationHeight)))
+}
+
+func buildWithdrawUnbondedEvmLog(validator, receiver [20]byte, amount *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventWithdrawUnbonded, []common.Hash{addrToHash(validator), addrToHash(receiver)}, amount)
+}
+
+func buildRotateConsensusKeyEvmLog(validator [20]byte, oldPubkey, newPubkey [32]byte) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventRotateConsensusKey, []common.Hash{addrToHash(validator)},
+		uint256.NewInt(0).SetBytes32(oldPubkey[:]), uint256.NewInt(0).SetBytes32(newPubkey[:]))
+}
+
+func buildSlashEvmLog(validator [20]byte, reason uint64, amount *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventSlash, []common.Hash{addrToHash(validator), uint64ToHash(reason)}, amount)
+}
+
+func buildSwitchEpochEvmLog(epochNum, startHeight, endTime int64, isValid bool, activeValidatorCount int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventSwitchEpoch, []common.Hash{uint64ToHash(uint64(epochNum))},
+		uint256.NewInt(uint64(startHeight)), uint256.NewInt(uint64(endTime)), boolToUint256(isValid),
+		uint256.NewInt(uint64(activeValidatorCount)))
+}
+
+func buildDeliverRewardEvmLog(rewardTo [20]byte, epochNum int64, amount *uint256.Int) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventDeliverReward, []common.Hash{addrToHash(rewardTo), uint64ToHash(uint64(epochNum))}, amount)
+}
+
+func buildJailEvmLog(validator [20]byte, unjailHeight int64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventJail, []common.Hash{addrToHash(validator)}, uint256.NewInt(uint64(unjailHeight)))
+}
+
+func buildUnbondEvmLog(validator, receiver [20]byte, amount *uint256.Int, matureHeight int64) mevmtypes.EvmLog {
+	return newEvmLog(HashOfEventUnbond, []common.Hash{addrToHash(validator), addrToHash(receiver)},
+		amount, uint256.NewInt(uint64(matureHeight)))
+}
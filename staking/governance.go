@@ -0,0 +1,371 @@
+package staking
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// Since ParamGovForkHeight, some consensus params, which used to be compile-time constants, are governed on
+// chain. An active validator proposes a new value and the height since which it takes effect, the active
+// validators vote on it by their voting power, and anyone can execute it after the voting deadline.
+
+// the ids of the governed params
+const (
+	ParamMaxActiveValidatorCount     uint64 = 1
+	ParamNotOnlineSlashAmountDivisor uint64 = 2
+	ParamOnlineWindowSize            uint64 = 3
+	ParamBlockMaxGas                 uint64 = 4
+	ParamUnbondingBlocks             uint64 = 5
+)
+
+var (
+	GasOfParamGovOp uint64 = 100_000
+
+	// the activation height must be at least so many blocks later than the proposal's height,
+	// which leaves enough time for voting and upgrading the nodes' configs
+	MinParamActivationDelay int64 = 2 * 24 * 3600 / 6
+
+	// the whitelist of the governed params, and the ranges of their values
+	paramRanges = map[uint64][2]uint64{
+		ParamMaxActiveValidatorCount:     {4, 200},
+		ParamNotOnlineSlashAmountDivisor: {1, 1000},
+		ParamOnlineWindowSize:            {100, 1_000_000},
+		ParamBlockMaxGas:                 {100_000_000, 10_000_000_000},
+		ParamUnbondingBlocks:             {1_000, 1_000_000},
+	}
+
+	paramSlotHashPrefix         = [4]byte{'p', 'a', 'r', 'm'}
+	paramProposalSlotHashPrefix = [4]byte{'p', 'p', 'r', 'p'}
+)
+
+func isParamGovFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.ParamGovForkHeight
+}
+
+// the compile-time values, which are used before they are changed by proposals
+func defaultParamValue(paramId uint64) uint64 {
+	switch paramId {
+	case ParamMaxActiveValidatorCount:
+		return uint64(param.MaxActiveValidatorCount)
+	case ParamNotOnlineSlashAmountDivisor:
+		return param.NotOnlineSlashAmountDivisor
+	case ParamOnlineWindowSize:
+		return uint64(param.OnlineWindowSize)
+	case ParamBlockMaxGas:
+		return uint64(param.BlockMaxGas)
+	case ParamUnbondingBlocks:
+		return uint64(param.UnbondingBlocks)
+	}
+	panic("unknown param id")
+}
+
+// read a uint256 argument from call data, which must be a valid param id
+func paramIdFromCallData(callData []byte) (paramId uint64, ok bool) {
+	id := uint256.NewInt(0).SetBytes(callData[:32])
+	if !id.IsUint64() {
+		return 0, false
+	}
+	_, ok = paramRanges[id.Uint64()]
+	return id.Uint64(), ok
+}
+
+// propose to change a governed param to 'value' since 'activationHeight'
+func proposeParam(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfParamGovOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 96 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	paramId, ok := paramIdFromCallData(callData)
+	if !ok {
+		outData = []byte(InvalidParamId.Error())
+		return
+	}
+	value := uint256.NewInt(0).SetBytes(callData[32:64])
+	valueRange := paramRanges[paramId]
+	if !value.IsUint64() || value.Uint64() < valueRange[0] || value.Uint64() > valueRange[1] {
+		outData = []byte(ParamValueOutOfRange.Error())
+		return
+	}
+	activationHeight := uint256.NewInt(0).SetBytes(callData[64:96])
+	if !activationHeight.IsUint64() || activationHeight.Uint64() > math.MaxInt64 ||
+		int64(activationHeight.Uint64()) < ctx.Height+MinParamActivationDelay {
+		outData = []byte(ActivationHeightTooEarly.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.VotingPower == 0 {
+		outData = []byte(ValidatorNotActive.Error())
+		return
+	}
+	if _, found := LoadParamProposal(ctx, paramId); found {
+		outData = []byte(StillInProposal.Error())
+		return
+	}
+	proposal := types.ParamProposal{
+		ParamId:          paramId,
+		Value:            value.Uint64(),
+		ActivationHeight: int64(activationHeight.Uint64()),
+		Deadline:         now + DefaultProposalDuration,
+		Proposer:         tx.From,
+	}
+	proposal.AddVote(tx.From, true)
+	SaveParamProposal(ctx, &proposal)
+	logs = append(logs, buildParamProposalEvmLog(tx.From, paramId, proposal.Value, proposal.ActivationHeight, proposal.Deadline))
+	status = StatusSuccess
+	return
+}
+
+// an active validator approves or rejects the proposal on a governed param
+func voteParam(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfParamGovOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 64 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	paramId, ok := paramIdFromCallData(callData)
+	if !ok {
+		outData = []byte(InvalidParamId.Error())
+		return
+	}
+	approve := uint256.NewInt(0).SetBytes(callData[32:64])
+	if approve.GtUint64(1) {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.VotingPower == 0 {
+		outData = []byte(ValidatorNotActive.Error())
+		return
+	}
+	proposal, found := LoadParamProposal(ctx, paramId)
+	if !found {
+		outData = []byte(NotInProposal.Error())
+		return
+	}
+	if now >= proposal.Deadline {
+		outData = []byte(ProposalHasFinished.Error())
+		return
+	}
+	proposal.AddVote(tx.From, !approve.IsZero())
+	SaveParamProposal(ctx, &proposal)
+	logs = append(logs, buildParamVoteEvmLog(tx.From, paramId, !approve.IsZero()))
+	status = StatusSuccess
+	return
+}
+
+// count the votes after the deadline, and schedule the new value if the proposal passes. If the activation
+// height has been reached, the new value takes effect since next block.
+func executeParamProposal(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfParamGovOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	paramId, ok := paramIdFromCallData(callData)
+	if !ok {
+		outData = []byte(InvalidParamId.Error())
+		return
+	}
+	proposal, found := LoadParamProposal(ctx, paramId)
+	if !found {
+		outData = []byte(NotInProposal.Error())
+		return
+	}
+	if now < proposal.Deadline {
+		outData = []byte(ProposalNotFinished.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	votingPowers := make(map[[20]byte]int64, len(info.Validators))
+	for _, val := range GetActiveValidators(ctx, info.Validators) {
+		votingPowers[val.Address] = val.VotingPower
+	}
+	passed := proposal.IsPassed(votingPowers)
+	activationHeight := proposal.ActivationHeight
+	if activationHeight <= ctx.Height {
+		activationHeight = ctx.Height + 1
+	}
+	if passed {
+		gp := loadGovernedParam(ctx, paramId)
+		gp.Schedule(ctx.Height, proposal.Value, activationHeight)
+		saveGovernedParam(ctx, paramId, &gp)
+	}
+	DeleteParamProposal(ctx, paramId)
+	logs = append(logs, buildExecuteParamProposalEvmLog(tx.From, paramId, passed, proposal.Value, activationHeight))
+	status = StatusSuccess
+	return
+}
+
+// get the current value of a governed param
+func getParam(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfMinGasPriceOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	paramId, ok := paramIdFromCallData(callData)
+	if !ok {
+		outData = []byte(InvalidParamId.Error())
+		return
+	}
+	outData = make([]byte, 32)
+	uint256.NewInt(LoadParam(ctx, paramId, ctx.Height)).WriteToSlice(outData)
+	status = StatusSuccess
+	return
+}
+
+func getSlotForParam(prefix [4]byte, paramId uint64) string {
+	var buf [12]byte
+	copy(buf[:4], prefix[:])
+	binary.BigEndian.PutUint64(buf[4:], paramId)
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func LoadParamProposal(ctx *mevmtypes.Context, paramId uint64) (proposal types.ParamProposal, found bool) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForParam(paramProposalSlotHashPrefix, paramId))
+	if len(bz) == 0 {
+		return
+	}
+	_, err := proposal.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	found = true
+	return
+}
+
+func SaveParamProposal(ctx *mevmtypes.Context, proposal *types.ParamProposal) {
+	bz, err := proposal.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForParam(paramProposalSlotHashPrefix, proposal.ParamId), bz)
+}
+
+func DeleteParamProposal(ctx *mevmtypes.Context, paramId uint64) {
+	ctx.DeleteStorageAt(StakingContractSequence, getSlotForParam(paramProposalSlotHashPrefix, paramId))
+}
+
+// the stored param, or the compile-time value if it has never been changed
+func loadGovernedParam(ctx *mevmtypes.Context, paramId uint64) (gp types.GovernedParam) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForParam(paramSlotHashPrefix, paramId))
+	if len(bz) == 0 {
+		gp.Value = defaultParamValue(paramId)
+		return
+	}
+	_, err := gp.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveGovernedParam(ctx *mevmtypes.Context, paramId uint64, gp *types.GovernedParam) {
+	bz, err := gp.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForParam(paramSlotHashPrefix, paramId), bz)
+}
+
+// =========================================================================================
+// Following functions are called by the engine to read the governed params
+
+// LoadParam returns the value of a governed param which takes effect at 'height'
+func LoadParam(ctx *mevmtypes.Context, paramId uint64, height int64) uint64 {
+	if p, found := loadSimParams(ctx); found && paramId == ParamMaxActiveValidatorCount && p.MaxActiveValidatorCount != 0 {
+		return p.MaxActiveValidatorCount
+	}
+	if !isParamGovFork(ctx) {
+		return defaultParamValue(paramId)
+	}
+	gp := loadGovernedParam(ctx, paramId)
+	return gp.ValueAt(height)
+}
+
+func GetMaxActiveValidatorCount(ctx *mevmtypes.Context) int {
+	return int(LoadParam(ctx, ParamMaxActiveValidatorCount, ctx.Height))
+}
+
+func getNotOnlineSlashAmountDivisor(ctx *mevmtypes.Context) uint64 {
+	return LoadParam(ctx, ParamNotOnlineSlashAmountDivisor, ctx.Height)
+}
+
+func getOnlineWindowSize(ctx *mevmtypes.Context) int64 {
+	return int64(LoadParam(ctx, ParamOnlineWindowSize, ctx.Height))
+}
+
+// whether the online window starting at 'startHeight' ends at current height
+func isOnlineWindowEnd(ctx *mevmtypes.Context, startHeight int64) bool {
+	if isParamGovFork(ctx) {
+		return ctx.Height >= startHeight+getOnlineWindowSize(ctx) // the window size may be decreased
+	}
+	return ctx.Height == startHeight+param.OnlineWindowSize
+}
+
+// the required signatures in an online window keep the ratio of MinOnlineSignatures to OnlineWindowSize
+func getMinOnlineSignatures(ctx *mevmtypes.Context) int32 {
+	windowSize := getOnlineWindowSize(ctx)
+	if windowSize == param.OnlineWindowSize {
+		return param.MinOnlineSignatures
+	}
+	return int32(windowSize * int64(param.MinOnlineSignatures) / param.OnlineWindowSize)
+}
+
+// GetBlockMaxGas returns the max gas of the block at 'height', which is enforced by tendermint
+func GetBlockMaxGas(ctx *mevmtypes.Context, height int64) int64 {
+	return int64(LoadParam(ctx, ParamBlockMaxGas, height))
+}
+
+// the unbonding period of the stakes removed at current height, changing it does not affect the queued stakes
+func getUnbondingBlocks(ctx *mevmtypes.Context) int64 {
+	return int64(LoadParam(ctx, ParamUnbondingBlocks, ctx.Height))
+}
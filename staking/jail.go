@@ -0,0 +1,75 @@
+package staking
+
+import (
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// Since JailForkHeight, a validator which misses too many signatures in an online window is jailed instead
+// of being retired. It loses its voting power at once, such that it is removed from tendermint's validator
+// set at the end of the block. After JailCooldownBlocks it can call unjail() to be elected again.
+
+func isJailFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.JailForkHeight
+}
+
+// a jailed validator leaves the jail, then it can be elected at the next epoch switch
+func unjail(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if !val.IsJailed {
+		outData = []byte(ValidatorNotJailed.Error())
+		return
+	}
+	if ctx.Height < val.UnjailHeight {
+		outData = []byte(StillInJailCooldown.Error())
+		return
+	}
+	val.IsJailed = false
+	val.UnjailHeight = 0
+	//Now let's update the states, readonlyStakingInfo is unchanged because voting powers are unchanged.
+	SaveStakingInfo(ctx, info)
+	logs = append(logs, buildUnjailEvmLog(tx.From))
+	status = StatusSuccess
+	return
+}
+
+// Jail slashes 'amount' of coins from the validator with 'pubkey' and jails it until JailCooldownBlocks later.
+func Jail(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amount *uint256.Int) (totalSlashed *uint256.Int) {
+	val := info.GetValidatorByPubkey(pubkey)
+	if val == nil {
+		return // If tendermint works fine, we'll never reach here
+	}
+	val.IsJailed = true
+	val.UnjailHeight = ctx.Height + param.JailCooldownBlocks
+	val.VotingPower = 0
+	return slashCoins(ctx, info, val, amount)
+}
+
+// jailAndLog jails the validator with 'pubkey' for being not online, and appends a Slash event and
+// a Jail event to logs
+func jailAndLog(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amount *uint256.Int,
+	logs []mevmtypes.EvmLog) []mevmtypes.EvmLog {
+	totalSlashed := Jail(ctx, info, pubkey, amount)
+	if totalSlashed == nil {
+		return logs
+	}
+	val := info.GetValidatorByPubkey(pubkey)
+	return append(logs, buildSlashEvmLog(val.Address, SlashReasonNotOnline, totalSlashed),
+		buildJailEvmLog(val.Address, val.UnjailHeight))
+}
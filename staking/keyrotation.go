@@ -0,0 +1,137 @@
+package staking
+
+import (
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// Since KeyRotationForkHeight, a validator can change its consensus key without retiring. The new key
+// replaces the current one at next valid epoch switch, when tendermint is told to remove the old key and
+// add the new one with the same voting power. The old key is still recognized in the signatures, evidences
+// and nominations, because they may refer to it for a while after the switch.
+
+func isKeyRotationFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.KeyRotationForkHeight
+}
+
+// schedule a change of the sender's consensus key, which takes effect at next epoch switch
+func rotateConsensusKey(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var newPubkey [32]byte
+	copy(newPubkey[:], callData)
+	if newPubkey == [32]byte{} {
+		outData = []byte(InvalidArgument.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.IsRetiring {
+		outData = []byte(ValidatorInRetiring.Error())
+		return
+	}
+	if info.IsPubkeyUsed(newPubkey) {
+		outData = []byte(types.ValidatorPubkeyAlreadyExists.Error())
+		return
+	}
+	if val.KeyRotation == nil {
+		val.KeyRotation = &types.KeyRotation{}
+	}
+	val.KeyRotation.NextPubkey = newPubkey
+	SaveStakingInfo(ctx, info)
+	logs = append(logs, buildRotateConsensusKeyEvmLog(tx.From, val.Pubkey, newPubkey))
+	status = StatusSuccess
+	return
+}
+
+func consensusAddress(pubkey [32]byte) (consAddr [20]byte) {
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	return
+}
+
+// =========================================================================================
+// Following functions are called by the engine
+
+// the old consensus keys of the rotated validators, mapped to their current keys
+func getOldPubkeyMap(info *types.StakingInfo) map[[32]byte][32]byte {
+	res := make(map[[32]byte][32]byte)
+	for _, val := range info.Validators {
+		if val.KeyRotation != nil && val.KeyRotation.OldPubkey != [32]byte{} {
+			res[val.KeyRotation.OldPubkey] = val.Pubkey
+		}
+	}
+	return res
+}
+
+// the signatures and evidences of an old consensus key are counted for the current key of the validator
+func addOldConsAddrs(info *types.StakingInfo, pubkeyMapByConsAddr map[[20]byte][32]byte) {
+	for oldPubkey, pubkey := range getOldPubkeyMap(info) {
+		pubkeyMapByConsAddr[consensusAddress(oldPubkey)] = pubkey
+	}
+}
+
+// replace the consensus addresses of the old keys in 'voters' with the ones of the current keys, such that
+// the online infos and watch infos, which are built with the current keys, count them
+func replaceOldConsAddrs(info *types.StakingInfo, voters [][]byte) [][]byte {
+	oldPubkeyMap := getOldPubkeyMap(info)
+	if len(oldPubkeyMap) == 0 {
+		return voters
+	}
+	consAddrMap := make(map[[20]byte][20]byte, len(oldPubkeyMap))
+	for oldPubkey, pubkey := range oldPubkeyMap {
+		consAddrMap[consensusAddress(oldPubkey)] = consensusAddress(pubkey)
+	}
+	res := make([][]byte, len(voters))
+	var voter [20]byte
+	for i, v := range voters {
+		copy(voter[:], v)
+		if consAddr, ok := consAddrMap[voter]; ok {
+			res[i] = append([]byte{}, consAddr[:]...)
+		} else {
+			res[i] = v
+		}
+	}
+	return res
+}
+
+// the nominations and votes to the old consensus keys are counted for the current keys of the validators
+func replaceOldPubkeysInVotes(info *types.StakingInfo, nominations []*types.Nomination,
+	posVotes map[[32]byte]int64) ([]*types.Nomination, map[[32]byte]int64) {
+	oldPubkeyMap := getOldPubkeyMap(info)
+	if len(oldPubkeyMap) == 0 {
+		return nominations, posVotes
+	}
+	newNominations := make([]*types.Nomination, len(nominations))
+	for i, n := range nominations {
+		newNominations[i] = &types.Nomination{Pubkey: n.Pubkey, NominatedCount: n.NominatedCount}
+		if pubkey, ok := oldPubkeyMap[n.Pubkey]; ok {
+			newNominations[i].Pubkey = pubkey
+		}
+	}
+	newPosVotes := make(map[[32]byte]int64, len(posVotes))
+	for pubkey, coindays := range posVotes {
+		if currPubkey, ok := oldPubkeyMap[pubkey]; ok {
+			pubkey = currPubkey
+		}
+		newPosVotes[pubkey] += coindays
+	}
+	return newNominations, newPosVotes
+}
@@ -0,0 +1,66 @@
+package staking
+
+import (
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// The rewards distributed by DistributeFee, the mature rewards delivered by deliverMintRewardInEpoch and the
+// coins slashed from validators are booked into per-epoch RewardLedgers, such that the operators can reconcile
+// their incomes epoch by epoch. The bookings are not kept in world state: the engine collects the ones of a
+// block in RewardBookings, and the app merges them into the ledgers and the slash events in its side index.
+
+// RewardBookings collects the amounts booked in a block. A block switching epoch books into the ledgers of
+// both the old and the new epoch. Booking into a nil RewardBookings does nothing.
+type RewardBookings struct {
+	Ledgers     []*types.RewardLedger
+	SlashEvents []*types.SlashEvent
+}
+
+// get the ledger of epoch 'epochNum' for booking, nil is returned if b is nil
+func (b *RewardBookings) ledger(epochNum int64) *types.RewardLedger {
+	if b == nil {
+		return nil
+	}
+	for _, ledger := range b.Ledgers {
+		if ledger.EpochNum == epochNum {
+			return ledger
+		}
+	}
+	ledger := &types.RewardLedger{EpochNum: epochNum}
+	b.Ledgers = append(b.Ledgers, ledger)
+	return ledger
+}
+
+// book the Slash events in 'logs' into the ledger of current epoch, and keep them as SlashEvents
+func (b *RewardBookings) bookSlashEvents(ctx *mevmtypes.Context, info *types.StakingInfo, logs []mevmtypes.EvmLog) {
+	if b == nil {
+		return
+	}
+	for _, l := range logs {
+		if len(l.Topics) < 3 || l.Topics[0] != HashOfEventSlash {
+			continue
+		}
+		event := &types.SlashEvent{
+			Height: ctx.Height,
+			Reason: uint256.NewInt(0).SetBytes32(l.Topics[2][:]).Uint64(),
+			Amount: uint256.NewInt(0).SetBytes(l.Data).Bytes32(),
+		}
+		copy(event.Validator[:], l.Topics[1][12:])
+		b.SlashEvents = append(b.SlashEvents, event)
+		amount := uint256.NewInt(0).SetBytes32(event.Amount[:])
+		if !amount.IsZero() {
+			addToAmount(&b.ledger(info.CurrEpochNum).GetOrAddEntry(event.Validator).SlashedAmount, amount)
+		}
+	}
+}
+
+// add 'amount' to the amount field pointed by 'field'
+func addToAmount(field *[32]byte, amount *uint256.Int) {
+	sum := uint256.NewInt(0).SetBytes32(field[:])
+	sum.Add(sum, amount)
+	*field = sum.Bytes32()
+}
@@ -0,0 +1,141 @@
+package staking
+
+import (
+	"encoding/binary"
+	"sort"
+	"strings"
+
+	"github.com/holiman/uint256"
+	"github.com/tendermint/tendermint/libs/log"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// The simulations replay the epoch switches with the engine's own code over a sandbox context, whose changes
+// are never written back, to show how the elections would come out under alternative params.
+
+// SimParams are the alternative params of a simulation, the zero fields keep the values in use
+type SimParams struct {
+	MaxActiveValidatorCount uint64
+	MinStakingAmount        *uint256.Int // the minimum staked coins of an active validator since the staking fork
+	VotingPowerPerBCH       int64
+}
+
+// SimulationContextType marks the sandbox contexts of simulations, only which read the SimParams kept in them
+const SimulationContextType uint8 = 16
+
+// the SimParams of a sandbox context, which is never written in a running node
+var SlotSimParams = strings.Repeat(string([]byte{0}), 31) + string([]byte{8})
+
+// ApplySimParams makes the sandbox 'ctx' run under the non-zero fields of 'p'. The params are kept in the
+// sandbox's own storage, such that the other contexts are not affected.
+func ApplySimParams(ctx *mevmtypes.Context, p SimParams) {
+	ctx.SetType(SimulationContextType)
+	var bz [48]byte
+	binary.BigEndian.PutUint64(bz[:8], p.MaxActiveValidatorCount)
+	if p.MinStakingAmount != nil {
+		p.MinStakingAmount.WriteToSlice(bz[8:40])
+	}
+	binary.BigEndian.PutUint64(bz[40:], uint64(p.VotingPowerPerBCH))
+	ctx.SetStorageAt(StakingContractSequence, SlotSimParams, bz[:])
+}
+
+// the SimParams applied to 'ctx', 'found' is false if it is not a sandbox of simulations
+func loadSimParams(ctx *mevmtypes.Context) (p SimParams, found bool) {
+	if ctx.Type != SimulationContextType {
+		return
+	}
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotSimParams)
+	if len(bz) != 48 {
+		return
+	}
+	p.MaxActiveValidatorCount = binary.BigEndian.Uint64(bz[:8])
+	if amount := uint256.NewInt(0).SetBytes(bz[8:40]); !amount.IsZero() {
+		p.MinStakingAmount = amount
+	}
+	p.VotingPowerPerBCH = int64(binary.BigEndian.Uint64(bz[40:]))
+	found = true
+	return
+}
+
+// the minimum staked coins of an active validator since the staking fork, which may be simulated
+func getMinStakingAmountAfterStakingFork(ctx *mevmtypes.Context) *uint256.Int {
+	if p, found := loadSimParams(ctx); found && p.MinStakingAmount != nil {
+		return p.MinStakingAmount
+	}
+	return MinimumStakingAmountAfterStakingFork
+}
+
+// the voting power per staked BCH after the delegation fork, which may be simulated
+func getVotingPowerPerBCH(ctx *mevmtypes.Context) int64 {
+	if p, found := loadSimParams(ctx); found && p.VotingPowerPerBCH != 0 {
+		return p.VotingPowerPerBCH
+	}
+	return VotingPowerPerBCH
+}
+
+// A SimValidator is an active validator in a simulated epoch
+type SimValidator struct {
+	Address     [20]byte
+	Pubkey      [32]byte
+	VotingPower int64
+	Reward      *uint256.Int // the fee rewards of the epoch, shared by the active validators by voting power
+}
+
+// A SimEpochResult is the outcome of a replayed epoch switch
+type SimEpochResult struct {
+	EpochNum   int64
+	Valid      bool            // whether the epoch is valid to elect new validators
+	Validators []*SimValidator // the active validators after the switch, sorted by voting power
+}
+
+// SimulateSwitchEpoch replays the switch to 'epoch' in the sandbox 'ctx', and the fee rewards 'totalReward'
+// collected in the new epoch are shared by the active validators by their voting power. It assumes all of
+// them vote for every block and propose blocks in proportion to their voting power, in which case the shares
+// of proposers, collectors and voters in DistributeFee add up to the same proportion.
+func SimulateSwitchEpoch(ctx *mevmtypes.Context, epoch *types.Epoch, posVotes map[[32]byte]int64,
+	totalReward *uint256.Int, logger log.Logger) *SimEpochResult {
+	// SwitchEpoch changes the epoch and its nominations
+	epoch = types.CopyEpochs([]*types.Epoch{epoch})[0]
+	votes := make(map[[32]byte]int64, len(posVotes))
+	for pubkey, coindays := range posVotes {
+		votes[pubkey] = coindays
+	}
+	newValidators, _ := SwitchEpoch(ctx, epoch, votes, nil, logger)
+	info := LoadStakingInfo(ctx)
+	res := &SimEpochResult{EpochNum: info.CurrEpochNum, Valid: newValidators != nil}
+	totalPower := int64(0)
+	for _, val := range GetActiveValidators(ctx, info.Validators) {
+		res.Validators = append(res.Validators, &SimValidator{
+			Address:     val.Address,
+			Pubkey:      val.Pubkey,
+			VotingPower: val.VotingPower,
+			Reward:      uint256.NewInt(0),
+		})
+		totalPower += val.VotingPower
+	}
+	if totalReward != nil && totalPower > 0 {
+		for _, val := range res.Validators {
+			val.Reward.Mul(totalReward, uint256.NewInt(uint64(val.VotingPower)))
+			val.Reward.Div(val.Reward, uint256.NewInt(uint64(totalPower)))
+		}
+	}
+	sort.SliceStable(res.Validators, func(i, j int) bool {
+		return res.Validators[i].VotingPower > res.Validators[j].VotingPower
+	})
+	return res
+}
+
+// TotalFeeReward returns the fee rewards booked in 'ledger', and nil if the ledger is nil
+func TotalFeeReward(ledger *types.RewardLedger) *uint256.Int {
+	if ledger == nil {
+		return nil
+	}
+	total := uint256.NewInt(0)
+	for _, entry := range ledger.Entries {
+		total.Add(total, entry.FeeReward())
+	}
+	return total
+}
@@ -60,21 +60,71 @@ var (
 		function executeProposal() external;
 		//8d337b81
 		function getVote(address validator) external view returns (uint)
+		//5c19a95c
+		function delegate(address validator) external payable;
+		//4d99dd16
+		function undelegate(address validator, uint amount) external;
+		//42d86693
+		function withdrawRewards(address validator) external;
+		//19fac8fd
+		function setCommissionRate(uint rate) external;
+		//f679d305
+		function unjail() external;
+		//ad015b59
+		function proposeParam(uint paramId, uint value, uint activationHeight) external;
+		//dc554ff0
+		function voteParam(uint paramId, bool approve) external;
+		//12c61ae6
+		function executeParamProposal(uint paramId) external;
+		//99f65122
+		function getParam(uint paramId) external view returns (uint);
+		//6e373bef
+		function withdrawUnbonded() external;
+		//3dfad0da
+		function rotateConsensusKey(bytes32 newPubkey) external;
+
+		// following methods can be called by EOA and other smart contracts
+		//1904bb2e
+		function getValidator(address validator) external view returns (address rewardTo, bytes32 pubkey, uint stakedCoins, uint votingPower, bool isRetiring, bool isJailed);
+		//9de70258
+		function getActiveValidators() external view returns (address[] memory validators);
+		//f6ed2017
+		function getPendingRewards(address validator) external view returns (uint);
+		//8453c794
+		function getCurrEpochNum() external view returns (uint);
+		//3fb58819
+		function getMinGasPrice() external view returns (uint);
 
 		// sumVotingPower can only be called by other smart contracts
 		//9ce06909
 		function sumVotingPower(address[] calldata addrList) external override returns (uint summedPower, uint totalPower)
 	}*/
-	SelectorCreateValidator     = [4]byte{0x24, 0xd1, 0xed, 0x5d}
-	SelectorEditValidator       = [4]byte{0x9d, 0xc1, 0x59, 0xb6}
-	SelectorRetire              = [4]byte{0xa4, 0x87, 0x4d, 0x77}
-	SelectorIncreaseMinGasPrice = [4]byte{0xf2, 0x01, 0x6e, 0x8e}
-	SelectorDecreaseMinGasPrice = [4]byte{0x69, 0x6e, 0x6a, 0xd2}
-	SelectorProposal            = [4]byte{0x30, 0x32, 0x6c, 0x17}
-	SelectorVote                = [4]byte{0x01, 0x21, 0xb9, 0x3f}
-	SelectorExecuteProposal     = [4]byte{0x37, 0x30, 0x58, 0xb8}
-	SelectorGetVote             = [4]byte{0x8d, 0x33, 0x7b, 0x81}
-	SelectorSumVotingPower      = [4]byte{0x9c, 0xe0, 0x69, 0x09}
+	SelectorCreateValidator      = [4]byte{0x24, 0xd1, 0xed, 0x5d}
+	SelectorEditValidator        = [4]byte{0x9d, 0xc1, 0x59, 0xb6}
+	SelectorRetire               = [4]byte{0xa4, 0x87, 0x4d, 0x77}
+	SelectorIncreaseMinGasPrice  = [4]byte{0xf2, 0x01, 0x6e, 0x8e}
+	SelectorDecreaseMinGasPrice  = [4]byte{0x69, 0x6e, 0x6a, 0xd2}
+	SelectorProposal             = [4]byte{0x30, 0x32, 0x6c, 0x17}
+	SelectorVote                 = [4]byte{0x01, 0x21, 0xb9, 0x3f}
+	SelectorExecuteProposal      = [4]byte{0x37, 0x30, 0x58, 0xb8}
+	SelectorGetVote              = [4]byte{0x8d, 0x33, 0x7b, 0x81}
+	SelectorDelegate             = [4]byte{0x5c, 0x19, 0xa9, 0x5c}
+	SelectorUndelegate           = [4]byte{0x4d, 0x99, 0xdd, 0x16}
+	SelectorWithdrawRewards      = [4]byte{0x42, 0xd8, 0x66, 0x93}
+	SelectorSetCommissionRate    = [4]byte{0x19, 0xfa, 0xc8, 0xfd}
+	SelectorUnjail               = [4]byte{0xf6, 0x79, 0xd3, 0x05}
+	SelectorProposeParam         = [4]byte{0xad, 0x01, 0x5b, 0x59}
+	SelectorVoteParam            = [4]byte{0xdc, 0x55, 0x4f, 0xf0}
+	SelectorExecuteParamProposal = [4]byte{0x12, 0xc6, 0x1a, 0xe6}
+	SelectorGetParam             = [4]byte{0x99, 0xf6, 0x51, 0x22}
+	SelectorWithdrawUnbonded     = [4]byte{0x6e, 0x37, 0x3b, 0xef}
+	SelectorRotateConsensusKey   = [4]byte{0x3d, 0xfa, 0xd0, 0xda}
+	SelectorGetValidator         = [4]byte{0x19, 0x04, 0xbb, 0x2e}
+	SelectorGetActiveValidators  = [4]byte{0x9d, 0xe7, 0x02, 0x58}
+	SelectorGetPendingRewards    = [4]byte{0xf6, 0xed, 0x20, 0x17}
+	SelectorGetCurrEpochNum      = [4]byte{0x84, 0x53, 0xc7, 0x94}
+	SelectorGetMinGasPrice       = [4]byte{0x3f, 0xb5, 0x88, 0x19}
+	SelectorSumVotingPower       = [4]byte{0x9c, 0xe0, 0x69, 0x09}
 
 	//slot
 	SlotStakingInfo               = strings.Repeat(string([]byte{0}), 32)
@@ -132,6 +182,17 @@ var (
 	ProposalHasFinished               = errors.New("proposal has finished")
 	ProposalNotFinished               = errors.New("proposal not finished")
 	ErrOutOfGas                       = errors.New("out of gas")
+	ZeroDelegation                    = errors.New("delegated coins cannot be zero")
+	NoRewardsToWithdraw               = errors.New("no rewards to withdraw")
+	InvalidCommissionRate             = errors.New("commission rate bigger than 10000")
+	ValidatorNotJailed                = errors.New("validator not jailed")
+	StillInJailCooldown               = errors.New("still in jail cooldown")
+	InvalidParamId                    = errors.New("invalid param id")
+	ParamValueOutOfRange              = errors.New("param value out of range")
+	ActivationHeightTooEarly          = errors.New("activation height too early")
+	NoMatureUnbonding                 = errors.New("no mature unbonding coins")
+	DelegationLessThanMinimum         = errors.New("delegated coins less than the minimum")
+	TooManyDelegators                 = errors.New("too many delegators of the validator")
 )
 
 var readonlyStakingInfo *types.StakingInfo // for sumVotingPower
@@ -157,6 +218,7 @@ func (_ *StakingContractExecutor) Init(ctx *mevmtypes.Context) {
 	}
 	info := LoadStakingInfo(ctx)
 	readonlyStakingInfo = &info
+	UpdateReadonlyStakingView(ctx)
 }
 
 func (_ *StakingContractExecutor) IsSystemContract(addr common.Address) bool {
@@ -214,18 +276,100 @@ func (s *StakingContractExecutor) Execute(ctx *mevmtypes.Context, currBlock *mev
 		} else {
 			return handleInvalidSelector(tx)
 		}
+	case SelectorDelegate:
+		if isDelegationFork(ctx) {
+			return delegate(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorUndelegate:
+		if isDelegationFork(ctx) {
+			return undelegate(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorWithdrawRewards:
+		if isDelegationFork(ctx) {
+			return withdrawRewards(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorSetCommissionRate:
+		if isDelegationFork(ctx) {
+			return setCommissionRate(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorUnjail:
+		if isJailFork(ctx) {
+			return unjail(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorProposeParam:
+		if isParamGovFork(ctx) {
+			return proposeParam(ctx, uint64(currBlock.Timestamp), tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorVoteParam:
+		if isParamGovFork(ctx) {
+			return voteParam(ctx, uint64(currBlock.Timestamp), tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorExecuteParamProposal:
+		if isParamGovFork(ctx) {
+			return executeParamProposal(ctx, uint64(currBlock.Timestamp), tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorGetParam:
+		if isParamGovFork(ctx) {
+			return getParam(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorWithdrawUnbonded:
+		if isUnbondingFork(ctx) {
+			return withdrawUnbonded(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorRotateConsensusKey:
+		if isKeyRotationFork(ctx) {
+			return rotateConsensusKey(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
+	case SelectorGetValidator, SelectorGetActiveValidators, SelectorGetPendingRewards,
+		SelectorGetCurrEpochNum, SelectorGetMinGasPrice:
+		if isStakingViewFork(ctx) {
+			return callView(ctx, tx)
+		} else {
+			return handleInvalidSelector(tx)
+		}
 	default:
 		return handleInvalidSelector(tx)
 	}
 }
 
-// this functions is called when other contract calls sumVotingPower
+// this functions is called when other contract calls sumVotingPower or the view functions
 func (_ *StakingContractExecutor) RequiredGas(input []byte) uint64 {
+	if isReadonlyViewCall(input) {
+		gas, _, _ := runView(input)
+		return gas
+	}
 	return uint64(len(input))*SumVotingPowerGasPerByte + SumVotingPowerBaseGas
 }
 
 // function sumVotingPower(address[] calldata addrList) external override returns (uint summedPower, uint totalPower)
+// Since StakingViewForkHeight, the view functions can also be called.
 func (_ *StakingContractExecutor) Run(input []byte) ([]byte, error) {
+	if isReadonlyViewCall(input) {
+		_, outData, err := runView(input)
+		return outData, err
+	}
 	if len(input) < 4+32*2 || !bytes.Equal(input[:4], SelectorSumVotingPower[:]) {
 		return nil, InvalidArgument
 	}
@@ -285,7 +429,7 @@ func createValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int,
 
 	initialAmount := InitialStakingAmount
 	if ctx.IsStakingFork() {
-		initialAmount = MinimumStakingAmountAfterStakingFork
+		initialAmount = getMinStakingAmountAfterStakingFork(ctx)
 	}
 	if ctx.IsStakingFork() {
 		if uint256.NewInt(0).SetBytes(tx.Value[:]).Cmp(initialAmount) < 0 {
@@ -310,6 +454,9 @@ func createValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int,
 	SaveStakingInfo(ctx, info)
 
 	status, outData = transferStakedCoins(ctx, tx, stakingAcc)
+	if status == StatusSuccess && isStakingLogsFork(ctx) {
+		logs = append(logs, buildCreateValidatorEvmLog(tx.From, rewardTo, pubkey, uint256.NewInt(0).SetBytes32(tx.Value[:])))
+	}
 	return
 }
 
@@ -365,6 +512,9 @@ func editValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, l
 	SaveStakingInfo(ctx, info)
 
 	status, outData = transferStakedCoins(ctx, tx, stakingAcc)
+	if status == StatusSuccess && isStakingLogsFork(ctx) {
+		logs = append(logs, buildEditValidatorEvmLog(tx.From, val.RewardTo, coins4staking))
+	}
 	return
 }
 
@@ -417,8 +567,17 @@ func retire(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []m
 		return
 	}
 	val.IsRetiring = true
+	if val.IsJailed && ctx.Height >= val.UnjailHeight {
+		// a jailed validator is never removed, so it leaves the jail to be removed once its cooldown has passed,
+		// a validator still in cooldown can retire again after that
+		val.IsJailed = false
+		val.UnjailHeight = 0
+	}
 	//Now let's update the states, readonlyStakingInfo is unchanged because voting powers are unchanged.
 	SaveStakingInfo(ctx, info)
+	if isStakingLogsFork(ctx) {
+		logs = append(logs, buildRetireEvmLog(tx.From))
+	}
 	status = StatusSuccess
 	return
 }
@@ -461,6 +620,9 @@ func createProposal(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (
 	SaveProposal(ctx, target, now+DefaultProposalDuration)
 	SaveVote(ctx, tx.From, target, uint64(val.VotingPower))
 	AddVoters(ctx, tx.From)
+	if isStakingLogsFork(ctx) {
+		logs = append(logs, buildProposalEvmLog(tx.From, target, now+DefaultProposalDuration))
+	}
 	status = StatusSuccess
 	return
 }
@@ -508,6 +670,9 @@ func vote(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int
 	}
 	SaveVote(ctx, tx.From, target, uint64(val.VotingPower))
 	AddVoters(ctx, tx.From)
+	if isStakingLogsFork(ctx) {
+		logs = append(logs, buildVoteEvmLog(tx.From, target))
+	}
 	status = StatusSuccess
 	return
 }
@@ -545,8 +710,12 @@ func executeProposal(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun)
 	}
 	voters := GetVoters(ctx)
 	target = CalculateTarget(ctx, voters)
+	oldMinGasPrice := LoadMinGasPrice(ctx, false)
 	SaveMinGasPrice(ctx, target, false)
 	DeleteProposalInfos(ctx, voters)
+	if isStakingLogsFork(ctx) {
+		logs = append(logs, buildExecuteProposalEvmLog(tx.From, oldMinGasPrice, target))
+	}
 	status = StatusSuccess
 	return
 }
@@ -622,6 +791,7 @@ func handleMinGasPrice(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun, isIncrease
 		}
 		sender := tx.From
 		mGP := LoadMinGasPrice(ctx, false)
+		oldMGP := mGP
 		lastMGP := LoadMinGasPrice(ctx, true) // this variable only updates at endblock
 		info := LoadStakingInfo(ctx)
 		isValidatorOrRewardTo := false
@@ -658,6 +828,9 @@ func handleMinGasPrice(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun, isIncrease
 			return
 		}
 		SaveMinGasPrice(ctx, mGP, false)
+		if isStakingLogsFork(ctx) {
+			logs = append(logs, buildChangeMinGasPriceEvmLog(sender, oldMGP, mGP))
+		}
 		status = StatusSuccess
 	}
 	return
@@ -919,7 +1092,7 @@ func UpdateOnlineInfos(ctx *mevmtypes.Context, infos types.ValidatorOnlineInfos,
 		copy(v[:], voter)
 		voterMap[v] = true
 	}
-	if ctx.Height == infos.StartHeight+param.OnlineWindowSize {
+	if isOnlineWindowEnd(ctx, infos.StartHeight) {
 		for _, info := range infos.OnlineInfos {
 			info.SignatureCount = 0
 			// todo: reset info.HeightOfLastSignature ?
@@ -1004,13 +1177,14 @@ func HandleOnlineInfos(ctx *mevmtypes.Context, stakingInfo *types.StakingInfo, v
 		UpdateOnlineInfos(ctx, infos, voters)
 		return
 	}
-	if ctx.Height != infos.StartHeight+param.OnlineWindowSize {
+	if !isOnlineWindowEnd(ctx, infos.StartHeight) {
 		UpdateOnlineInfos(ctx, infos, voters)
 		return
 	}
 	var newInfos []*types.OnlineInfo
+	minOnlineSignatures := getMinOnlineSignatures(ctx)
 	for _, info := range infos.OnlineInfos {
-		if info.SignatureCount < param.MinOnlineSignatures {
+		if info.SignatureCount < minOnlineSignatures {
 			lazyValidators[info.ValidatorConsensusAddress] = true
 		} else {
 			newInfos = append(newInfos, info)
@@ -1024,7 +1198,7 @@ func HandleOnlineInfos(ctx *mevmtypes.Context, stakingInfo *types.StakingInfo, v
 	for _, val := range stakingInfo.Validators {
 		var address [20]byte
 		copy(address[:], ed25519.PubKey(val.Pubkey[:]).Address().Bytes())
-		if lazyValidators[address] && !val.IsRetiring {
+		if lazyValidators[address] && !val.IsRetiring && !val.IsJailed {
 			slashValidators = append(slashValidators, address)
 		}
 	}
@@ -1033,9 +1207,11 @@ func HandleOnlineInfos(ctx *mevmtypes.Context, stakingInfo *types.StakingInfo, v
 }
 
 // slashValidators and lastVoters are consensus addresses generated from validator consensus pubkey
+// the returned logs are the Slash events emitted in this block, the slashed coins and the distributed fee
+// are booked into 'bookings'
 func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]byte,
 	currProposer, lastProposer [20]byte, lastVoters [][]byte, /*include proposer*/
-	blockReward *uint256.Int) (currValidators, newValidators []*types.Validator, currEpochNum int64) {
+	blockReward *uint256.Int, bookings *RewardBookings) (currValidators, newValidators []*types.Validator, currEpochNum int64, logs []mevmtypes.EvmLog) {
 
 	stakingAcc, info := LoadStakingAccAndInfo(ctx)
 	currEpochNum = info.CurrEpochNum
@@ -1047,14 +1223,22 @@ func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]by
 		copy(consAddr[:], ed25519.PubKey(v.Pubkey[:]).Address().Bytes())
 		pubkeyMapByConsAddr[consAddr] = v.Pubkey
 	}
+	if isKeyRotationFork(ctx) {
+		addOldConsAddrs(&info, pubkeyMapByConsAddr)
+		lastVoters = replaceOldConsAddrs(&info, lastVoters)
+	}
 	//slash first
 	for _, v := range duplicateSigSlashValidators {
 		if pubkey, ok := pubkeyMapByConsAddr[v]; ok {
 			slashAmount := uint256.NewInt(0)
 			if ctx.IsStakingFork() {
-				slashAmount = uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork, uint256.NewInt(param.DuplicateSigSlashAMountDivisor))
+				slashAmount = uint256.NewInt(0).Div(getMinStakingAmountAfterStakingFork(ctx), uint256.NewInt(param.DuplicateSigSlashAMountDivisor))
 			}
-			Slash(ctx, &info, pubkey, slashAmount)
+			logs = slashAndLog(ctx, &info, pubkey, slashAmount, SlashReasonDuplicateSig, logs)
+		} else if isUnbondingFork(ctx) {
+			// the validator has been removed, but its coins in the unbonding queue can still be slashed
+			slashAmount := uint256.NewInt(0).Div(getMinStakingAmountAfterStakingFork(ctx), uint256.NewInt(param.DuplicateSigSlashAMountDivisor))
+			logs = slashUnbondingAndLog(ctx, &info, v, slashAmount, SlashReasonDuplicateSig, logs)
 		}
 	}
 	if ctx.Height > param.StakingForkHeight+param.BlocksInEpochAfterStakingFork { // not handle online info and watch info before first pos epoch switch height
@@ -1062,8 +1246,12 @@ func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]by
 		notOnlineSlashValidators := HandleOnlineInfos(ctx, &info, lastVoters)
 		for _, v := range notOnlineSlashValidators {
 			if pubkey, ok := pubkeyMapByConsAddr[v]; ok {
-				slashAmount := uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork, uint256.NewInt(param.NotOnlineSlashAmountDivisor))
-				Slash(ctx, &info, pubkey, slashAmount)
+				slashAmount := uint256.NewInt(0).Div(getMinStakingAmountAfterStakingFork(ctx), uint256.NewInt(getNotOnlineSlashAmountDivisor(ctx)))
+				if isJailFork(ctx) {
+					logs = jailAndLog(ctx, &info, pubkey, slashAmount, logs)
+				} else {
+					logs = slashAndLog(ctx, &info, pubkey, slashAmount, SlashReasonNotOnline, logs)
+				}
 			}
 		}
 	} else if ctx.Height == 8000000 {
@@ -1078,37 +1266,65 @@ func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]by
 			voters = append(voters, voter)
 		}
 	}
+	bookings.bookSlashEvents(ctx, &info, logs)
 	DistributeFee(ctx, stakingAcc, &info, blockReward, pubkeyMapByConsAddr[currProposer],
-		pubkeyMapByConsAddr[lastProposer], voters)
+		pubkeyMapByConsAddr[lastProposer], voters, bookings)
 	newValidators = GetActiveValidators(ctx, info.Validators)
 	SaveStakingInfo(ctx, info)
 	return
 }
 
+// slashAndLog slashes the validator with 'pubkey' and appends a Slash event to logs
+func slashAndLog(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amount *uint256.Int,
+	reason uint64, logs []mevmtypes.EvmLog) []mevmtypes.EvmLog {
+	totalSlashed := Slash(ctx, info, pubkey, amount)
+	if totalSlashed == nil {
+		return logs
+	}
+	val := info.GetValidatorByPubkey(pubkey)
+	return append(logs, buildSlashEvmLog(val.Address, reason, totalSlashed))
+}
+
 // Slash 'amount' of coins from the validator with 'pubkey'. These coins are burnt and booked on BlackHole acc.
 func Slash(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amount *uint256.Int) (totalSlashed *uint256.Int) {
 	val := info.GetValidatorByPubkey(pubkey)
 	if val == nil {
 		return // If tendermint works fine, we'll never reach here
 	}
+	if ctx.IsStakingFork() {
+		// clear the voting power and set IsRetiring flag when validator slashed after staking fork
+		val.IsRetiring = true
+		val.VotingPower = 0
+	}
+	return slashCoins(ctx, info, val, amount)
+}
+
+// slash 'amount' of staked coins and all the pending rewards from 'val'
+// Since UnbondingForkHeight, the shortfall of staked coins is slashed from its unbonding coins. The coins delegated
+// to it are never slashed, neither active nor unbonding.
+func slashCoins(ctx *mevmtypes.Context, info *types.StakingInfo, val *types.Validator, amount *uint256.Int) (totalSlashed *uint256.Int) {
 	coins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
 	if coins.Lt(amount) { // not enough coins to be slashed
 		totalSlashed = coins.Clone()
 		coins.SetUint64(0)
+		if isUnbondingFork(ctx) {
+			shortfall := uint256.NewInt(0).Sub(amount, totalSlashed)
+			totalSlashed.Add(totalSlashed, info.SlashUnbondingEntries(val.Pubkey, shortfall))
+		}
 	} else {
 		totalSlashed = amount.Clone()
 		coins.Sub(coins, amount)
 	}
 	val.StakedCoins = coins.Bytes32()
-	if ctx.IsStakingFork() {
-		// clear the voting power and set IsRetiring flag when validator slashed after staking fork
-		val.IsRetiring = true
-		val.VotingPower = 0
-	}
 
 	totalCleared := info.ClearRewardsOf(val.Address)
 	totalSlashed.Add(totalSlashed, totalCleared)
+	transferSlashedCoins(ctx, totalSlashed)
+	return
+}
 
+// deduct the slashed coins from stakingAcc, and send them to SlashReceiver or burn them
+func transferSlashedCoins(ctx *mevmtypes.Context, totalSlashed *uint256.Int) {
 	if ctx.IsStakingFork() {
 		slashReceiver := common.HexToAddress(param.SlashReceiver)
 		err := ebp.SubSenderAccBalance(ctx, StakingContractAddress, totalSlashed)
@@ -1129,7 +1345,6 @@ func Slash(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amo
 		_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, StakingContractAddress, totalSlashed)
 		incrAllBurnt(ctx, totalSlashed)
 	}
-	return
 }
 
 // Increase the slot of 'all burnt' inside stakingAcc
@@ -1145,8 +1360,9 @@ func incrAllBurnt(ctx *mevmtypes.Context, amount *uint256.Int) {
 }
 
 // distribute the collected gas fee to validators who voted for current block, half fee burnt to blackHole Acc.
+// The distributed rewards are booked into the ledger of current epoch in 'bookings'.
 func DistributeFee(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo,
-	collectedFee *uint256.Int, collector, proposer [32]byte /*operator pubKey*/, voters [][32]byte) {
+	collectedFee *uint256.Int, collector, proposer [32]byte /*operator pubKey*/, voters [][32]byte, bookings *RewardBookings) {
 	if collectedFee == nil {
 		return
 	}
@@ -1187,6 +1403,10 @@ func DistributeFee(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, in
 		collectedFee.Sub(collectedFee, collectorFee)
 	}
 	rwdMapByAddr := info.GetCurrRewardMapByAddr()
+	var ledger *types.RewardLedger // nil if there is nothing to book
+	if !collectedFee.IsZero() {
+		ledger = bookings.ledger(info.CurrEpochNum)
+	}
 	remainedFee := collectedFee.Clone()
 	//distribute to the non-proposer voters
 	for _, voter := range voters {
@@ -1198,6 +1418,9 @@ func DistributeFee(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, in
 		rwdCoins.Div(rwdCoins, uint256.NewInt(uint64(votedPower)))
 		remainedFee.Sub(remainedFee, rwdCoins)
 		distributeToValidator(info, rwdMapByAddr, rwdCoins, val)
+		if ledger != nil {
+			addToAmount(&ledger.GetOrAddEntry(val.Address).VoterReward, rwdCoins)
+		}
 	}
 
 	if proposer != [32]byte{} {
@@ -1205,12 +1428,18 @@ func DistributeFee(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, in
 		proposerVal := valMapByPubkey[proposer]
 		coins := uint256.NewInt(0).Add(proposerBaseFee, remainedFee)
 		distributeToValidator(info, rwdMapByAddr, coins, proposerVal)
+		if ledger != nil {
+			addToAmount(&ledger.GetOrAddEntry(proposerVal.Address).ProposerReward, coins)
+		}
 	} else if !remainedFee.IsZero() {
 		_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, StakingContractAddress, remainedFee)
 	}
 
 	if collector != [32]byte{} {
 		distributeToValidator(info, rwdMapByAddr, collectorFee, valMapByPubkey[collector])
+		if ledger != nil {
+			addToAmount(&ledger.GetOrAddEntry(valMapByPubkey[collector].Address).CollectorReward, collectorFee)
+		}
 	} else if !collectorFee.IsZero() {
 		_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, StakingContractAddress, collectorFee)
 	}
@@ -1228,13 +1457,13 @@ func distributeToValidator(info *types.StakingInfo, rwdMapByAddr map[[20]byte]*t
 		}
 		info.PendingRewards = append(info.PendingRewards, rwd)
 	}
-	coins := uint256.NewInt(0).SetBytes32(rwd.Amount[:])
-	coins.Add(coins, rwdCoins)
-	rwd.Amount = coins.Bytes32()
+	rwd.AddRewards(rwdCoins) // the delegators' share is split out since DelegationForkHeight
 }
 
-// switch to a new epoch
-func SwitchEpoch(ctx *mevmtypes.Context, epoch *types.Epoch, posVotes map[[32]byte]int64, logger log.Logger) []*types.Validator {
+// switch to a new epoch, the returned logs are a SwitchEpoch event followed by the DeliverReward events
+// and the Unbond events
+func SwitchEpoch(ctx *mevmtypes.Context, epoch *types.Epoch, posVotes map[[32]byte]int64, bookings *RewardBookings,
+	logger log.Logger) ([]*types.Validator, []mevmtypes.EvmLog) {
 	stakingAcc, info := LoadStakingAccAndInfo(ctx)
 	//increase currEpochNum no matter if epoch is valid
 	info.CurrEpochNum++
@@ -1243,36 +1472,55 @@ func SwitchEpoch(ctx *mevmtypes.Context, epoch *types.Epoch, posVotes map[[32]by
 	logger.Debug(fmt.Sprintf("Epoch info in switchEpoch [newPpochNumber:%d,startHeight:%d,EndTime:%d]", epoch.Number, epoch.StartHeight, epoch.EndTime))
 
 	// distribute mature pending reward to rewardTo
-	deliverMintRewardInEpoch(ctx, stakingAcc, &info)
+	rewardLogs := deliverMintRewardInEpoch(ctx, stakingAcc, &info, bookings)
+	var delegatorsShares map[[20]byte]uint64
+	if isDelegationFork(ctx) {
+		delegatorsShares = activateDelegations(ctx, &info)
+	}
 
 	isValid, pubkey2power, oldActiveValidators := checkEpoch(ctx, info, epoch, posVotes, logger)
 	if !isValid {
 		updatePendingRewardsInNewEpoch(oldActiveValidators, &info, logger)
+		setDelegatorsShares(&info, delegatorsShares)
 		SaveStakingInfo(ctx, info)
-		return nil
+		switchLog := buildSwitchEpochEvmLog(epoch.Number, epoch.StartHeight, epoch.EndTime, false, len(oldActiveValidators))
+		return nil, append([]mevmtypes.EvmLog{switchLog}, rewardLogs...)
 	}
 	// someone who call createValidator before switchEpoch can enjoy the voting power update
 	// someone who call retire() before switchEpoch cannot get elected in this update
 	updateVotingPower(ctx, &info, pubkey2power)
-	// payback staking coins to rewardTo of useless validators and delete these validators
-	clearUselessValidators(ctx, stakingAcc, &info)
+	// payback staking coins to rewardTo of useless validators (or unbond them) and delete these validators
+	unbondLogs := clearUselessValidators(ctx, stakingAcc, &info)
+	if isKeyRotationFork(ctx) {
+		// the scheduled consensus keys take effect, tendermint gets them from GetUpdateValidatorSet
+		info.ApplyKeyRotations()
+	}
 	// allocate new entries in info.PendingRewards
 	activeValidators := GetActiveValidators(ctx, info.Validators)
 	updatePendingRewardsInNewEpoch(activeValidators, &info, logger)
+	setDelegatorsShares(&info, delegatorsShares)
 	SaveStakingInfo(ctx, info)
 	if ctx.IsStakingFork() {
 		SaveValidatorWatchInfo(ctx, *NewWatchInfos(activeValidators, ctx.Height))
 		SaveOnlineInfo(ctx, *NewOnlineInfos(activeValidators, ctx.Height))
 	}
-	return activeValidators
+	switchLog := buildSwitchEpochEvmLog(epoch.Number, epoch.StartHeight, epoch.EndTime, true, len(activeValidators))
+	logs := append([]mevmtypes.EvmLog{switchLog}, rewardLogs...)
+	return activeValidators, append(logs, unbondLogs...)
 }
 
-// deliver pending rewards which are mature now to rewardTo
-func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo) {
+// deliver pending rewards which are mature now to rewardTo, and return the DeliverReward events sorted by rewardTo.
+// Since DelegationForkHeight, the delegators' parts are split among them by the snapshots of the epochs in which
+// the rewards were got, and kept in stakingAcc until withdrawn.
+// The validators' parts are booked into the ledger of the new epoch in 'bookings'.
+func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo,
+	bookings *RewardBookings) (logs []mevmtypes.EvmLog) {
 	stakingAccBalance := stakingAcc.Balance()
 	newPRList := make([]*types.PendingReward, 0, len(info.PendingRewards))
 	valMapByAddr := info.GetValMapByAddr()
 	rewardMap := make(map[[20]byte]*uint256.Int, len(info.PendingRewards))
+	dInfoMap := make(map[[20]byte]*types.DelegationInfo)
+	var dInfoList []*types.DelegationInfo
 	// summarize all the mature rewards
 	for _, pr := range info.PendingRewards {
 		if pr.EpochNum >= info.CurrEpochNum-param.EpochCountBeforeRewardMature {
@@ -1283,9 +1531,30 @@ func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.Acco
 		if _, ok := rewardMap[val.RewardTo]; !ok {
 			rewardMap[val.RewardTo] = uint256.NewInt(0)
 		}
-		rewardMap[val.RewardTo].Add(rewardMap[val.RewardTo], uint256.NewInt(0).SetBytes32(pr.Amount[:]))
+		rwd := uint256.NewInt(0).SetBytes32(pr.Amount[:])
+		if toDelegators := pr.DelegatorsAmount(); !toDelegators.IsZero() {
+			dInfo, ok := dInfoMap[val.Address]
+			if !ok {
+				if loaded, found := loadDelegationInfo(ctx, val.Address); found {
+					dInfo = &loaded
+					dInfoList = append(dInfoList, dInfo)
+				}
+				dInfoMap[val.Address] = dInfo
+			}
+			if dInfo != nil {
+				toDelegators = dInfo.SplitReward(pr.EpochNum, toDelegators) // the remained dust
+			}
+			rwd.Add(rwd, toDelegators)
+		}
+		rewardMap[val.RewardTo].Add(rewardMap[val.RewardTo], rwd)
+		if bookings != nil && !rwd.IsZero() {
+			addToAmount(&bookings.ledger(info.CurrEpochNum).GetOrAddEntry(val.Address).MintedReward, rwd)
+		}
 	}
 	info.PendingRewards = newPRList
+	for _, dInfo := range dInfoList {
+		SaveDelegationInfo(ctx, dInfo)
+	}
 
 	// increase rewardTo's balance and decrease stakingAcc's balance
 	for addr, rwd := range rewardMap {
@@ -1298,8 +1567,13 @@ func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.Acco
 		balance.Add(balance, rwd)
 		acc.UpdateBalance(balance)
 		ctx.SetAccount(addr, acc)
+		logs = append(logs, buildDeliverRewardEvmLog(addr, info.CurrEpochNum, rwd))
 	}
 	stakingAcc.UpdateBalance(stakingAccBalance)
+	sort.Slice(logs, func(i, j int) bool {
+		return bytes.Compare(logs[i].Topics[1][:], logs[j].Topics[1][:]) < 0
+	})
+	return
 }
 
 func checkEpoch(ctx *mevmtypes.Context, info types.StakingInfo, epoch *types.Epoch, posVotes map[[32]byte]int64, logger log.Logger) (bool, map[[32]byte]int64, []*types.Validator) {
@@ -1321,16 +1595,20 @@ func checkEpoch(ctx *mevmtypes.Context, info types.StakingInfo, epoch *types.Epo
 func getPubkey2Power(ctx *mevmtypes.Context, info types.StakingInfo, epoch *types.Epoch, posVotes map[[32]byte]int64, logger log.Logger) (powTotalNomination int64, pubkey2power map[[32]byte]int64) {
 	validatorSet := make(map[[32]byte]bool, len(info.Validators))
 	for _, val := range info.Validators {
-		if !val.IsRetiring {
+		if !val.IsRetiring && !val.IsJailed {
 			validatorSet[val.Pubkey] = true
 		}
 	}
 	for _, n := range epoch.Nominations {
 		logger.Debug(fmt.Sprintf("Nomination: pubkey(%s), NominatedCount(%d)", ed25519.PubKey(n.Pubkey[:]).String(), n.NominatedCount))
 	}
-	validNominations := make([]*types.Nomination, 0, len(epoch.Nominations)+len(posVotes))
+	nominations := epoch.Nominations
+	if isKeyRotationFork(ctx) {
+		nominations, posVotes = replaceOldPubkeysInVotes(&info, nominations, posVotes)
+	}
+	validNominations := make([]*types.Nomination, 0, len(nominations)+len(posVotes))
 	totalVotedBlocks := int64(0) // how many blocks were used for pow voting. at most 2016
-	for _, n := range epoch.Nominations {
+	for _, n := range nominations {
 		if validatorSet[n.Pubkey] { // votes to non-validators are ingored
 			validNominations = append(validNominations, n)
 			totalVotedBlocks += n.NominatedCount
@@ -1370,9 +1648,13 @@ func getPubkey2Power(ctx *mevmtypes.Context, info types.StakingInfo, epoch *type
 	nominationHeap := types.NominationHeap(validNominations)
 	heap.Init(&nominationHeap)
 	pubkey2power = make(map[[32]byte]int64, len(validNominations))
-	for i := 0; i < param.MaxActiveValidatorCount && len(nominationHeap) > 0; i++ {
+	valMapByPubkey := info.GetValMapByPubkey()
+	maxActiveValidatorCount := GetMaxActiveValidatorCount(ctx)
+	for i := 0; i < maxActiveValidatorCount && len(nominationHeap) > 0; i++ {
 		n := heap.Pop(&nominationHeap).(*types.Nomination)
-		if ctx.IsStakingFork() {
+		if isDelegationFork(ctx) {
+			pubkey2power[n.Pubkey] = votingPowerOfStake(ctx, valMapByPubkey[n.Pubkey])
+		} else if ctx.IsStakingFork() {
 			pubkey2power[n.Pubkey] = 10000
 		} else {
 			pubkey2power[n.Pubkey] = 1
@@ -1400,11 +1682,11 @@ func updateVotingPower(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey2p
 	valMapByPubkey := info.GetValMapByPubkey()
 	minimumStakingAmount := MinimumStakingAmount
 	if ctx.IsStakingFork() {
-		minimumStakingAmount = MinimumStakingAmountAfterStakingFork
+		minimumStakingAmount = getMinStakingAmountAfterStakingFork(ctx)
 	}
 	for pubkey, power := range pubkey2power {
 		val, ok := valMapByPubkey[pubkey]
-		if !ok || val.IsRetiring {
+		if !ok || val.IsRetiring || val.IsJailed {
 			continue
 		}
 		if uint256.NewInt(0).SetBytes32(val.StakedCoins[:]).Cmp(minimumStakingAmount) >= 0 {
@@ -1413,13 +1695,22 @@ func updateVotingPower(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey2p
 	}
 }
 
-// Remove the useless validators from info and return StakedCoins to them
-func clearUselessValidators(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo) {
+// Remove the useless validators from info and return StakedCoins to them, and the delegated coins to the delegators.
+// Since UnbondingForkHeight, these coins are put into the unbonding queue instead, and the Unbond events are returned.
+func clearUselessValidators(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo) (logs []mevmtypes.EvmLog) {
 	uselessValMap := info.GetUselessValidators()
-	valMapByAddr := info.GetValMapByAddr()
 	stakingAccBalance := stakingAcc.Balance()
-	for addr := range uselessValMap {
-		val := valMapByAddr[addr]
+	for _, val := range info.Validators { // follow the order of info.Validators to keep the unbonding queue deterministic
+		if _, ok := uselessValMap[val.Address]; !ok {
+			continue
+		}
+		refunded, refundLogs := refundDelegations(ctx, info, val)
+		stakingAccBalance.Sub(stakingAccBalance, refunded)
+		logs = append(logs, refundLogs...)
+		if isUnbondingFork(ctx) {
+			logs = append(logs, unbondStakedCoins(ctx, info, val)...)
+			continue
+		}
 		acc := ctx.GetAccount(val.RewardTo)
 		if acc == nil {
 			acc = mevmtypes.ZeroAccountInfo()
@@ -1446,19 +1737,20 @@ func clearUselessValidators(ctx *mevmtypes.Context, stakingAcc *mevmtypes.Accoun
 	}
 	stakingAcc.UpdateBalance(stakingAccBalance)
 	ctx.SetAccount(StakingContractAddress, stakingAcc)
+	return
 }
 
-// Returns current validators on duty, who must have enough coins staked and be not in a retiring process
+// Returns current validators on duty, who must have enough coins staked and be neither retiring nor jailed
 // only update validator voting power on switchEpoch
 func GetActiveValidators(ctx *mevmtypes.Context, vals []*types.Validator) []*types.Validator {
 	minStakedCoins := MinimumStakingAmount
 	if ctx.IsStakingFork() {
-		minStakedCoins = MinimumStakingAmountAfterStakingFork
+		minStakedCoins = getMinStakingAmountAfterStakingFork(ctx)
 	}
 	res := make([]*types.Validator, 0, len(vals))
 	for _, val := range vals {
 		coins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
-		if coins.Cmp(minStakedCoins) >= 0 && !val.IsRetiring && val.VotingPower > 0 {
+		if coins.Cmp(minStakedCoins) >= 0 && !val.IsRetiring && !val.IsJailed && val.VotingPower > 0 {
 			res = append(res, val)
 		}
 	}
@@ -1466,8 +1758,8 @@ func GetActiveValidators(ctx *mevmtypes.Context, vals []*types.Validator) []*typ
 	sort.SliceStable(res, func(i, j int) bool {
 		return res[i].VotingPower > res[j].VotingPower
 	})
-	if len(res) > param.MaxActiveValidatorCount {
-		res = res[:param.MaxActiveValidatorCount]
+	if maxActiveValidatorCount := GetMaxActiveValidatorCount(ctx); len(res) > maxActiveValidatorCount {
+		res = res[:maxActiveValidatorCount]
 	}
 	return res
 }
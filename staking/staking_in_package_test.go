@@ -2,6 +2,7 @@ package staking
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -10,10 +11,13 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/smartbch/moeingads/store"
 	"github.com/smartbch/moeingads/store/rabbit"
 	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
 )
 
@@ -86,7 +90,8 @@ func TestGetActiveValidators(t *testing.T) {
 	}
 
 	min := [32]byte{0x11}
-	ctx := types.NewContext(nil, nil)
+	r := rabbit.NewRabbitStore(store.NewMockRootStore()) // the governed params are read from it
+	ctx := types.NewContext(&r, nil)
 	ctx.SetCurrentHeight(1)
 	ctx.SetStakingForkBlock(100)
 	MinimumStakingAmount = uint256.NewInt(0).SetBytes32(min[:])
@@ -239,3 +244,567 @@ func TestHandleOnlineInfos(t *testing.T) {
 	require.Equal(t, int64(1), stakingInfo.Validators[1].VotingPower)
 	require.Equal(t, false, stakingInfo.Validators[1].IsRetiring)
 }
+
+func TestEventHashes(t *testing.T) {
+	events := ABI.GetABI().Events
+	require.Equal(t, common.Hash(HashOfEventCreateValidator), events["CreateValidator"].ID)
+	require.Equal(t, common.Hash(HashOfEventEditValidator), events["EditValidator"].ID)
+	require.Equal(t, common.Hash(HashOfEventRetire), events["Retire"].ID)
+	require.Equal(t, common.Hash(HashOfEventProposal), events["Proposal"].ID)
+	require.Equal(t, common.Hash(HashOfEventVote), events["Vote"].ID)
+	require.Equal(t, common.Hash(HashOfEventExecuteProposal), events["ExecuteProposal"].ID)
+	require.Equal(t, common.Hash(HashOfEventChangeMinGasPrice), events["ChangeMinGasPrice"].ID)
+	require.Equal(t, common.Hash(HashOfEventSlash), events["Slash"].ID)
+	require.Equal(t, common.Hash(HashOfEventSwitchEpoch), events["SwitchEpoch"].ID)
+	require.Equal(t, common.Hash(HashOfEventDeliverReward), events["DeliverReward"].ID)
+	require.Equal(t, common.Hash(HashOfEventDelegate), events["Delegate"].ID)
+	require.Equal(t, common.Hash(HashOfEventUndelegate), events["Undelegate"].ID)
+	require.Equal(t, common.Hash(HashOfEventWithdrawRewards), events["WithdrawRewards"].ID)
+	require.Equal(t, common.Hash(HashOfEventSetCommissionRate), events["SetCommissionRate"].ID)
+	require.Equal(t, common.Hash(HashOfEventUnjail), events["Unjail"].ID)
+	require.Equal(t, common.Hash(HashOfEventJail), events["Jail"].ID)
+	require.Equal(t, common.Hash(HashOfEventParamProposal), events["ParamProposal"].ID)
+	require.Equal(t, common.Hash(HashOfEventParamVote), events["ParamVote"].ID)
+	require.Equal(t, common.Hash(HashOfEventExecuteParamProposal), events["ExecuteParamProposal"].ID)
+	require.Equal(t, common.Hash(HashOfEventUnbond), events["Unbond"].ID)
+	require.Equal(t, common.Hash(HashOfEventWithdrawUnbonded), events["WithdrawUnbonded"].ID)
+	require.Equal(t, common.Hash(HashOfEventRotateConsensusKey), events["RotateConsensusKey"].ID)
+
+	evmLog := buildSwitchEpochEvmLog(3, 100, 2000, true, 7)
+	values, err := ABI.GetABI().Unpack("SwitchEpoch", evmLog.Data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), values[0].(*big.Int).Uint64())
+	require.Equal(t, uint64(2000), values[1].(*big.Int).Uint64())
+	require.Equal(t, true, values[2].(bool))
+	require.Equal(t, uint64(7), values[3].(*big.Int).Uint64())
+	require.Equal(t, common.BigToHash(big.NewInt(3)), evmLog.Topics[1])
+}
+
+func TestDelegationSelectors(t *testing.T) {
+	validator := common.Address{0x01}
+	require.Equal(t, SelectorDelegate[:], PackDelegate(validator)[:4])
+	require.Equal(t, SelectorUndelegate[:], PackUndelegate(validator, big.NewInt(1))[:4])
+	require.Equal(t, SelectorWithdrawRewards[:], PackWithdrawRewards(validator)[:4])
+	require.Equal(t, SelectorSetCommissionRate[:], PackSetCommissionRate(big.NewInt(1))[:4])
+}
+
+func TestJailAndUnjail(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(100)
+	ctx.SetStakingForkBlock(90)
+	stakingAcc := types.ZeroAccountInfo()
+	stakingAcc.UpdateBalance(uint256.NewInt(0).Mul(uint256.NewInt(100), uint256.NewInt(Uint64_1e18)))
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+
+	validator1 := [32]byte{0x01}
+	validator2 := [32]byte{0x02}
+	BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
+	info := LoadStakingInfo(ctx)
+	coins := uint256.NewInt(0).Mul(uint256.NewInt(40), uint256.NewInt(Uint64_1e18))
+	for i, val := range info.Validators {
+		val.Address = [20]byte{0xad, byte(i)}
+		val.StakedCoins = coins.Bytes32()
+	}
+	totalSlashed := Jail(ctx, &info, validator2, uint256.NewInt(Uint64_1e18))
+	require.Equal(t, Uint64_1e18, totalSlashed.Uint64())
+	val := info.GetValidatorByPubkey(validator2)
+	require.True(t, val.IsJailed)
+	require.False(t, val.IsRetiring)
+	require.Equal(t, int64(0), val.VotingPower)
+	require.Equal(t, 100+param.JailCooldownBlocks, val.UnjailHeight)
+	activeValidators := GetActiveValidators(ctx, info.Validators)
+	require.Len(t, activeValidators, 1)
+	require.Equal(t, validator1, activeValidators[0].Pubkey)
+	require.Len(t, info.GetUselessValidators(), 0)
+	SaveStakingInfo(ctx, info)
+
+	tx := &types.TxToRun{
+		BasicTx: types.BasicTx{
+			From: val.Address,
+			Gas:  GasOfValidatorOp,
+		},
+	}
+	status, _, _, outData := unjail(ctx, tx)
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, StillInJailCooldown.Error(), string(outData))
+
+	ctx.SetCurrentHeight(100 + param.JailCooldownBlocks)
+	status, logs, _, _ := unjail(ctx, tx)
+	require.Equal(t, StatusSuccess, status)
+	require.Len(t, logs, 1)
+	require.Equal(t, common.Hash(HashOfEventUnjail), logs[0].Topics[0])
+	info = LoadStakingInfo(ctx)
+	require.False(t, info.GetValidatorByPubkey(validator2).IsJailed)
+
+	status, _, _, outData = unjail(ctx, tx)
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, ValidatorNotJailed.Error(), string(outData))
+	require.Equal(t, SelectorUnjail[:], PackUnjail()[:4])
+}
+
+func TestRetireJailedValidator(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(100)
+	ctx.SetStakingForkBlock(90)
+	ctx.SetAccount(StakingContractAddress, types.ZeroAccountInfo())
+	validator1 := [32]byte{0x01}
+	validator2 := [32]byte{0x02}
+	BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
+	info := LoadStakingInfo(ctx)
+	for i, val := range info.Validators {
+		val.Address = [20]byte{0xad, byte(i)}
+	}
+	Jail(ctx, &info, validator2, uint256.NewInt(0))
+	SaveStakingInfo(ctx, info)
+	jailed := info.GetValidatorByPubkey(validator2)
+	tx := &types.TxToRun{
+		BasicTx: types.BasicTx{
+			From: jailed.Address,
+			Gas:  GasOfValidatorOp,
+		},
+	}
+
+	// a validator in the jail cooldown is not removed even if it retires
+	status, _, _, _ := retire(ctx, tx)
+	require.Equal(t, StatusSuccess, status)
+	info = LoadStakingInfo(ctx)
+	require.True(t, info.GetValidatorByPubkey(validator2).IsRetiring)
+	require.True(t, info.GetValidatorByPubkey(validator2).IsJailed)
+	require.NotContains(t, info.GetUselessValidators(), jailed.Address)
+
+	// after the cooldown, retiring again clears the jail, then it is removed
+	ctx.SetCurrentHeight(100 + param.JailCooldownBlocks)
+	status, _, _, _ = retire(ctx, tx)
+	require.Equal(t, StatusSuccess, status)
+	info = LoadStakingInfo(ctx)
+	require.False(t, info.GetValidatorByPubkey(validator2).IsJailed)
+	require.Contains(t, info.GetUselessValidators(), jailed.Address)
+}
+
+func TestParamGovernance(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(100)
+	ctx.SetStakingForkBlock(90)
+
+	validator1 := [32]byte{0x01}
+	validator2 := [32]byte{0x02}
+	BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
+	info := LoadStakingInfo(ctx)
+	for i, val := range info.Validators {
+		val.Address = [20]byte{0xad, byte(i)}
+		val.StakedCoins = MinimumStakingAmountAfterStakingFork.Bytes32()
+	}
+	SaveStakingInfo(ctx, info)
+	newTx := func(from [20]byte, data []byte) *types.TxToRun {
+		return &types.TxToRun{BasicTx: types.BasicTx{From: from, Gas: GasOfParamGovOp, Data: data}}
+	}
+	paramId := big.NewInt(int64(ParamMaxActiveValidatorCount))
+	require.Equal(t, param.MaxActiveValidatorCount, GetMaxActiveValidatorCount(ctx))
+
+	// invalid proposals
+	data := PackProposeParam(big.NewInt(100), big.NewInt(30), big.NewInt(100+MinParamActivationDelay))
+	_, _, _, outData := proposeParam(ctx, 1000, newTx(info.Validators[0].Address, data))
+	require.Equal(t, InvalidParamId.Error(), string(outData))
+	data = PackProposeParam(paramId, big.NewInt(1), big.NewInt(100+MinParamActivationDelay))
+	_, _, _, outData = proposeParam(ctx, 1000, newTx(info.Validators[0].Address, data))
+	require.Equal(t, ParamValueOutOfRange.Error(), string(outData))
+	data = PackProposeParam(paramId, big.NewInt(30), big.NewInt(99+MinParamActivationDelay))
+	_, _, _, outData = proposeParam(ctx, 1000, newTx(info.Validators[0].Address, data))
+	require.Equal(t, ActivationHeightTooEarly.Error(), string(outData))
+
+	activationHeight := 100 + MinParamActivationDelay
+	data = PackProposeParam(paramId, big.NewInt(30), big.NewInt(activationHeight))
+	status, logs, _, _ := proposeParam(ctx, 1000, newTx(info.Validators[0].Address, data))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventParamProposal), logs[0].Topics[0])
+	_, _, _, outData = proposeParam(ctx, 1000, newTx(info.Validators[1].Address, data))
+	require.Equal(t, StillInProposal.Error(), string(outData))
+
+	// half of the voting power is not enough
+	data = PackExecuteParamProposal(paramId)
+	_, _, _, outData = executeParamProposal(ctx, 1000, newTx([20]byte{0x01}, data))
+	require.Equal(t, ProposalNotFinished.Error(), string(outData))
+	status, logs, _, _ = executeParamProposal(ctx, 1000+DefaultProposalDuration, newTx([20]byte{0x01}, data))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventExecuteParamProposal), logs[0].Topics[0])
+	require.Equal(t, byte(0), logs[0].Data[31]) // not passed
+	_, found := LoadParamProposal(ctx, ParamMaxActiveValidatorCount)
+	require.False(t, found)
+
+	data = PackProposeParam(paramId, big.NewInt(30), big.NewInt(activationHeight))
+	status, _, _, _ = proposeParam(ctx, 2000, newTx(info.Validators[0].Address, data))
+	require.Equal(t, StatusSuccess, status)
+	status, logs, _, _ = voteParam(ctx, 2000, newTx(info.Validators[1].Address, PackVoteParam(paramId, true)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventParamVote), logs[0].Topics[0])
+	_, _, _, outData = voteParam(ctx, 2000, newTx([20]byte{0x01}, PackVoteParam(paramId, true)))
+	require.Equal(t, NoSuchValidator.Error(), string(outData))
+	status, _, _, _ = executeParamProposal(ctx, 2000+DefaultProposalDuration, newTx([20]byte{0x01}, PackExecuteParamProposal(paramId)))
+	require.Equal(t, StatusSuccess, status)
+
+	// the new value takes effect at the activation height
+	require.Equal(t, param.MaxActiveValidatorCount, GetMaxActiveValidatorCount(ctx))
+	ctx.SetCurrentHeight(activationHeight)
+	require.Equal(t, 30, GetMaxActiveValidatorCount(ctx))
+	status, _, _, outData = getParam(ctx, newTx([20]byte{0x01}, PackGetParam(paramId)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint64(30), uint256.NewInt(0).SetBytes(outData).Uint64())
+	require.Equal(t, param.BlockMaxGas, GetBlockMaxGas(ctx, activationHeight))
+}
+
+func TestUnbonding(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(100)
+	ctx.SetStakingForkBlock(90)
+	stakingAcc := types.ZeroAccountInfo()
+	stakingAcc.UpdateBalance(uint256.NewInt(0).Mul(uint256.NewInt(100), uint256.NewInt(Uint64_1e18)))
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+
+	validator1 := [32]byte{0x01}
+	validator2 := [32]byte{0x02}
+	consAddrs := BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
+	info := LoadStakingInfo(ctx)
+	coins := uint256.NewInt(0).Mul(uint256.NewInt(40), uint256.NewInt(Uint64_1e18))
+	for i, val := range info.Validators {
+		val.Address = [20]byte{0xad, byte(i)}
+		val.RewardTo = [20]byte{0xbb, byte(i)}
+		val.StakedCoins = coins.Bytes32()
+	}
+	retired := info.Validators[1]
+	retired.IsRetiring = true
+	retired.VotingPower = 0
+	delegator := [20]byte{0xde, 0x01}
+	SaveDelegationInfo(ctx, &stakingtypes.DelegationInfo{
+		Validator: retired.Address,
+		Delegations: []*stakingtypes.Delegation{{
+			Delegator:     delegator,
+			Amount:        uint256.NewInt(Uint64_1e18).Bytes32(),
+			PendingAmount: uint256.NewInt(100).Bytes32(),
+		}},
+	})
+
+	// the retired validator is removed, but its staked coins and the active delegated coins are kept in stakingAcc
+	logs := clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Len(t, logs, 2)
+	require.Equal(t, common.Hash(HashOfEventUnbond), logs[0].Topics[0])
+	require.Equal(t, common.Hash(HashOfEventUnbond), logs[1].Topics[0])
+	require.Len(t, info.Validators, 1)
+	require.Len(t, info.UnbondingEntries, 2)
+	require.True(t, info.UnbondingEntries[0].Delegated)
+	require.Equal(t, delegator, info.UnbondingEntries[0].Receiver)
+	require.Equal(t, 100+param.UnbondingBlocks, info.UnbondingEntries[1].MatureHeight)
+	require.Nil(t, ctx.GetAccount(retired.RewardTo))
+	require.Equal(t, uint64(100), ctx.GetAccount(delegator).Balance().Uint64()) // the pending coins are refunded
+	require.Equal(t, uint256.NewInt(0).Sub(stakingAcc.Balance(), uint256.NewInt(100)),
+		ctx.GetAccount(StakingContractAddress).Balance())
+
+	// the misbehaviour found later is slashed from the unbonding coins
+	var consAddr [20]byte
+	copy(consAddr[:], consAddrs[1])
+	logs = slashUnbondingAndLog(ctx, &info, consAddr, uint256.NewInt(Uint64_1e18), SlashReasonDuplicateSig, nil)
+	require.Len(t, logs, 1)
+	require.Equal(t, common.Hash(HashOfEventSlash), logs[0].Topics[0])
+	require.Equal(t, common.BytesToHash(retired.Address[:]), logs[0].Topics[1])
+	require.Len(t, slashUnbondingAndLog(ctx, &info, [20]byte{0x99}, uint256.NewInt(Uint64_1e18), SlashReasonDuplicateSig, nil), 0)
+	require.Equal(t, uint256.NewInt(Uint64_1e18).Bytes32(), info.UnbondingEntries[0].Amount) // the delegated ones are not slashed
+	SaveStakingInfo(ctx, info)
+
+	tx := &types.TxToRun{
+		BasicTx: types.BasicTx{
+			From: retired.Address,
+			Gas:  GasOfValidatorOp,
+		},
+	}
+	status, _, _, outData := withdrawUnbonded(ctx, tx)
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, NoMatureUnbonding.Error(), string(outData))
+
+	ctx.SetCurrentHeight(100 + param.UnbondingBlocks)
+	status, logs, _, _ = withdrawUnbonded(ctx, tx)
+	require.Equal(t, StatusSuccess, status)
+	require.Len(t, logs, 2)
+	require.Equal(t, common.Hash(HashOfEventWithdrawUnbonded), logs[0].Topics[0])
+	require.Equal(t, Uint64_1e18+100, ctx.GetAccount(delegator).Balance().Uint64())
+	coins.Sub(coins, uint256.NewInt(Uint64_1e18))
+	require.Equal(t, coins, ctx.GetAccount(retired.RewardTo).Balance())
+	require.Len(t, LoadStakingInfo(ctx).UnbondingEntries, 0)
+	require.Equal(t, SelectorWithdrawUnbonded[:], PackWithdrawUnbonded()[:4])
+
+	// the unbonding period is governed
+	gp := loadGovernedParam(ctx, ParamUnbondingBlocks)
+	gp.Schedule(ctx.Height, 2000, ctx.Height+1)
+	saveGovernedParam(ctx, ParamUnbondingBlocks, &gp)
+	require.Equal(t, param.UnbondingBlocks, getUnbondingBlocks(ctx))
+	ctx.SetCurrentHeight(ctx.Height + 1)
+	require.Equal(t, int64(2000), getUnbondingBlocks(ctx))
+}
+
+func TestStakingViews(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(100)
+	ctx.SetStakingForkBlock(90)
+
+	validator1 := [32]byte{0x01}
+	validator2 := [32]byte{0x02}
+	BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
+	info := LoadStakingInfo(ctx)
+	for i, val := range info.Validators {
+		val.Address = [20]byte{0xad, byte(i)}
+		val.RewardTo = [20]byte{0xbb, byte(i)}
+		val.StakedCoins = MinimumStakingAmountAfterStakingFork.Bytes32()
+	}
+	info.Validators[1].IsRetiring = true
+	info.PendingRewards = []*stakingtypes.PendingReward{
+		{Address: info.Validators[0].Address, EpochNum: 1, Amount: uint256.NewInt(100).Bytes32()},
+		{Address: info.Validators[0].Address, EpochNum: 0, Amount: uint256.NewInt(20).Bytes32()},
+	}
+	SaveStakingInfo(ctx, info)
+	SaveMinGasPrice(ctx, 12345, false)
+
+	exe := NewStakingContractExecutor(nil)
+	call := func(data []byte) []byte {
+		tx := &types.TxToRun{
+			BasicTx: types.BasicTx{
+				From: [20]byte{0x01},
+				Gas:  1_000_000,
+				Data: data,
+			},
+		}
+		status, _, gasUsed, outData := exe.Execute(ctx, nil, tx)
+		require.Equal(t, StatusSuccess, status, string(outData))
+		require.Equal(t, viewGas(outData), gasUsed)
+		return outData
+	}
+
+	values, err := ABI.GetABI().Unpack("getValidator", call(PackGetValidator(common.Address{0xad, 1})))
+	require.NoError(t, err)
+	require.Equal(t, common.Address{0xbb, 1}, values[0].(common.Address))
+	require.Equal(t, validator2, values[1].([32]byte))
+	require.Equal(t, MinimumStakingAmountAfterStakingFork.ToBig(), values[2].(*big.Int))
+	require.Equal(t, true, values[4].(bool))
+	require.Equal(t, false, values[5].(bool))
+
+	values, err = ABI.GetABI().Unpack("getActiveValidators", call(PackGetActiveValidators()))
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{{0xad, 0}}, values[0].([]common.Address))
+	require.Equal(t, uint64(120), uint256.NewInt(0).SetBytes(call(PackGetPendingRewards(common.Address{0xad, 0}))).Uint64())
+	require.Equal(t, uint64(1), uint256.NewInt(0).SetBytes(call(PackGetCurrEpochNum())).Uint64())
+	require.Equal(t, uint64(12345), uint256.NewInt(0).SetBytes(call(PackGetMinGasPrice())).Uint64())
+
+	tx := &types.TxToRun{BasicTx: types.BasicTx{Gas: StakingViewBaseGas, Data: PackGetActiveValidators()}}
+	status, _, gasUsed, outData := exe.Execute(ctx, nil, tx)
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, StakingViewBaseGas, gasUsed)
+	require.Equal(t, ErrOutOfGas.Error(), string(outData))
+	tx.Gas, tx.Data = 1_000_000, PackGetValidator(common.Address{0x99})
+	_, _, _, outData = exe.Execute(ctx, nil, tx)
+	require.Equal(t, NoSuchValidator.Error(), string(outData))
+
+	// the contracts read the snapshot taken by the engine, instead of the latest states
+	UpdateReadonlyStakingView(ctx)
+	SaveMinGasPrice(ctx, 54321, false)
+	input := PackGetMinGasPrice()
+	outData, err = exe.Run(input)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12345), uint256.NewInt(0).SetBytes(outData).Uint64())
+	require.Equal(t, viewGas(outData), exe.RequiredGas(input))
+	outData, err = exe.Run(PackGetActiveValidators())
+	require.NoError(t, err)
+	require.Len(t, outData, 96)
+	_, err = exe.Run(PackGetPendingRewards(common.Address{0xad, 0})[:20])
+	require.Equal(t, InvalidCallData, err)
+	readonlyStakingView = nil
+	_, err = exe.Run(input)
+	require.Equal(t, InvalidArgument, err) // not allowed before StakingViewForkHeight
+}
+
+func TestRotateConsensusKey(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(100)
+	ctx.SetStakingForkBlock(90)
+
+	validator1 := [32]byte{0x01}
+	validator2 := [32]byte{0x02}
+	newPubkey := [32]byte{0x03}
+	consAddrs := BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
+	info := LoadStakingInfo(ctx)
+	for i, val := range info.Validators {
+		val.Address = [20]byte{0xad, byte(i)}
+	}
+	SaveStakingInfo(ctx, info)
+
+	newTx := func(from [20]byte, pubkey [32]byte) *types.TxToRun {
+		return &types.TxToRun{
+			BasicTx: types.BasicTx{
+				From: from,
+				Gas:  GasOfValidatorOp,
+				Data: PackRotateConsensusKey(pubkey),
+			},
+		}
+	}
+	_, _, _, outData := rotateConsensusKey(ctx, newTx([20]byte{0x99}, newPubkey))
+	require.Equal(t, NoSuchValidator.Error(), string(outData))
+	_, _, _, outData = rotateConsensusKey(ctx, newTx(info.Validators[0].Address, validator2))
+	require.Equal(t, stakingtypes.ValidatorPubkeyAlreadyExists.Error(), string(outData))
+	_, _, _, outData = rotateConsensusKey(ctx, newTx(info.Validators[0].Address, [32]byte{}))
+	require.Equal(t, InvalidArgument.Error(), string(outData))
+	status, logs, _, _ := rotateConsensusKey(ctx, newTx(info.Validators[0].Address, newPubkey))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventRotateConsensusKey), logs[0].Topics[0])
+	require.Equal(t, SelectorRotateConsensusKey[:], PackRotateConsensusKey(newPubkey)[:4])
+
+	// the key is changed at epoch switch
+	info = LoadStakingInfo(ctx)
+	require.Equal(t, validator1, info.Validators[0].Pubkey)
+	require.Equal(t, newPubkey, info.Validators[0].KeyRotation.NextPubkey)
+	info.ApplyKeyRotations()
+	require.Equal(t, newPubkey, info.Validators[0].Pubkey)
+
+	// the old key is still recognized
+	pubkeyMapByConsAddr := make(map[[20]byte][32]byte)
+	addOldConsAddrs(&info, pubkeyMapByConsAddr)
+	require.Equal(t, newPubkey, pubkeyMapByConsAddr[consensusAddress(validator1)])
+	voters := replaceOldConsAddrs(&info, consAddrs)
+	require.Equal(t, common.Address(consensusAddress(newPubkey)), common.BytesToAddress(voters[0]))
+	require.Equal(t, consAddrs[1], voters[1])
+	nominations, posVotes := replaceOldPubkeysInVotes(&info,
+		[]*stakingtypes.Nomination{{Pubkey: validator1, NominatedCount: 5}, {Pubkey: validator2, NominatedCount: 6}},
+		map[[32]byte]int64{validator1: 10, newPubkey: 20})
+	require.Equal(t, newPubkey, nominations[0].Pubkey)
+	require.Equal(t, validator2, nominations[1].Pubkey)
+	require.Equal(t, map[[32]byte]int64{newPubkey: 30}, posVotes)
+}
+
+func TestRewardLedger(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(100)
+	ctx.SetStakingForkBlock(90)
+	stakingAcc := types.ZeroAccountInfo()
+	stakingAcc.UpdateBalance(uint256.NewInt(0).Mul(uint256.NewInt(100), uint256.NewInt(Uint64_1e18)))
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+
+	validator1 := [32]byte{0x01}
+	validator2 := [32]byte{0x02}
+	BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
+	info := LoadStakingInfo(ctx)
+	coins := uint256.NewInt(0).Mul(uint256.NewInt(40), uint256.NewInt(Uint64_1e18))
+	for i, val := range info.Validators {
+		val.Address = [20]byte{0xad, byte(i)}
+		val.RewardTo = [20]byte{0xbb, byte(i)}
+		val.StakedCoins = coins.Bytes32()
+	}
+	addr1, addr2 := info.Validators[0].Address, info.Validators[1].Address
+
+	// validator1 proposes, validator2 collects, and both of them vote
+	bookings := &RewardBookings{}
+	DistributeFee(ctx, stakingAcc, &info, uint256.NewInt(10000), validator2, validator1, [][32]byte{validator1, validator2}, bookings)
+	require.Len(t, bookings.Ledgers, 1)
+	ledger := bookings.Ledgers[0]
+	require.Equal(t, info.CurrEpochNum, ledger.EpochNum)
+	require.Len(t, ledger.Entries, 2)
+	entry1, entry2 := ledger.GetEntry(addr1), ledger.GetEntry(addr2)
+	require.NotEqual(t, [32]byte{}, entry1.ProposerReward)
+	require.Equal(t, [32]byte{}, entry1.CollectorReward)
+	require.Equal(t, [32]byte{}, entry1.VoterReward)
+	require.Equal(t, [32]byte{}, entry2.ProposerReward)
+	require.NotEqual(t, [32]byte{}, entry2.CollectorReward)
+	require.NotEqual(t, [32]byte{}, entry2.VoterReward)
+	// the booked rewards are the same as the pending rewards
+	booked := uint256.NewInt(0)
+	for _, amount := range [][32]byte{entry1.ProposerReward, entry2.CollectorReward, entry2.VoterReward} {
+		booked.Add(booked, uint256.NewInt(0).SetBytes32(amount[:]))
+	}
+	require.Equal(t, uint256.NewInt(5000), booked)
+	pending1, pending2 := uint256.NewInt(0), uint256.NewInt(0)
+	for _, rwd := range info.PendingRewards {
+		if rwd.Address == addr1 {
+			pending1.Add(pending1, uint256.NewInt(0).SetBytes32(rwd.Amount[:]))
+		} else {
+			pending2.Add(pending2, uint256.NewInt(0).SetBytes32(rwd.Amount[:]))
+		}
+	}
+	require.Equal(t, uint256.NewInt(0).SetBytes32(entry1.ProposerReward[:]), pending1)
+
+	// the slashed coins include the cleared pending rewards, and they are booked by the Slash events
+	logs := slashAndLog(ctx, &info, validator2, uint256.NewInt(100), SlashReasonDuplicateSig, nil)
+	totalSlashed := uint256.NewInt(0).Add(pending2, uint256.NewInt(100))
+	bookings.bookSlashEvents(ctx, &info, logs)
+	require.Equal(t, totalSlashed.Bytes32(), ledger.GetEntry(addr2).SlashedAmount)
+	require.Len(t, bookings.SlashEvents, 1)
+	require.Equal(t, stakingtypes.SlashEvent{Height: 100, Validator: addr2, Reason: SlashReasonDuplicateSig,
+		Amount: totalSlashed.Bytes32()}, *bookings.SlashEvents[0])
+
+	// the mature rewards are booked into the ledger of the new epoch
+	info.CurrEpochNum += param.EpochCountBeforeRewardMature + 1
+	logs = deliverMintRewardInEpoch(ctx, ctx.GetAccount(StakingContractAddress), &info, bookings)
+	require.Len(t, logs, 2) // the slashed validator2 gets a DeliverReward event of zero
+	require.Len(t, bookings.Ledgers, 2)
+	ledger = bookings.Ledgers[1]
+	require.Equal(t, info.CurrEpochNum, ledger.EpochNum)
+	require.Len(t, ledger.Entries, 1)
+	require.Equal(t, pending1.Bytes32(), ledger.GetEntry(addr1).MintedReward)
+	require.Nil(t, ledger.GetEntry(addr2))
+
+	// nothing is booked into nil bookings
+	DistributeFee(ctx, stakingAcc, &info, uint256.NewInt(10000), validator2, validator1, [][32]byte{validator1, validator2}, nil)
+	var nilBookings *RewardBookings
+	nilBookings.bookSlashEvents(ctx, &info, logs)
+}
+
+func TestSimulateSwitchEpoch(t *testing.T) {
+	validators := [][32]byte{{0x01}, {0x02}, {0x03}}
+	epoch := &stakingtypes.Epoch{
+		StartHeight: 1000,
+		Nominations: []*stakingtypes.Nomination{
+			{Pubkey: validators[0], NominatedCount: 3},
+			{Pubkey: validators[1], NominatedCount: 2},
+			{Pubkey: validators[2], NominatedCount: 1},
+		},
+	}
+	newCtx := func() *types.Context {
+		r := rabbit.NewRabbitStore(store.NewMockRootStore())
+		ctx := types.NewContext(&r, nil)
+		ctx.SetCurrentHeight(100)
+		ctx.SetStakingForkBlock(90)
+		ctx.SetAccount(StakingContractAddress, types.ZeroAccountInfo())
+		BuildAndSaveStakingInfo(ctx, validators)
+		info := LoadStakingInfo(ctx)
+		for i, val := range info.Validators {
+			val.Address = [20]byte{0xad, byte(i)}
+			val.StakedCoins = uint256.NewInt(0).Mul(uint256.NewInt(40), uint256.NewInt(Uint64_1e18)).Bytes32()
+		}
+		SaveStakingInfo(ctx, info)
+		return ctx
+	}
+
+	res := SimulateSwitchEpoch(newCtx(), epoch, nil, uint256.NewInt(9000), log.NewNopLogger())
+	require.True(t, res.Valid)
+	require.Equal(t, int64(2), res.EpochNum)
+	require.Len(t, res.Validators, 3)
+	require.Equal(t, uint256.NewInt(3000), res.Validators[0].Reward)
+	require.Equal(t, int64(3), epoch.Nominations[0].NominatedCount) // the epoch is not changed
+
+	ctx := newCtx()
+	ApplySimParams(ctx, SimParams{MaxActiveValidatorCount: 2, VotingPowerPerBCH: 10})
+	res = SimulateSwitchEpoch(ctx, epoch, nil, uint256.NewInt(9000), log.NewNopLogger())
+	require.True(t, res.Valid)
+	require.Len(t, res.Validators, 2)
+	require.Equal(t, validators[0], res.Validators[0].Pubkey)
+	require.Equal(t, validators[1], res.Validators[1].Pubkey)
+	require.Equal(t, int64(400), res.Validators[0].VotingPower)
+	require.Equal(t, uint256.NewInt(4500), res.Validators[1].Reward)
+	require.Equal(t, 2, GetMaxActiveValidatorCount(ctx))
+
+	// the other contexts and the package-level params are not affected
+	ctx = newCtx()
+	require.Equal(t, param.MaxActiveValidatorCount, GetMaxActiveValidatorCount(ctx))
+	require.Equal(t, int64(100), getVotingPowerPerBCH(ctx))
+	require.Equal(t, int64(100), VotingPowerPerBCH)
+}
@@ -110,7 +110,13 @@ func TestStaking(t *testing.T) {
 	// test create validator
 	c := buildCreateValCallEntry(sender, 101, 11, 1)
 	require.True(t, e.IsSystemContract(c.Address))
-	e.Execute(ctx, nil, c.Tx)
+	status, logs, _, _ := e.Execute(ctx, nil, c.Tx)
+	require.Equal(t, staking.StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(staking.HashOfEventCreateValidator), logs[0].Topics[0])
+	require.Equal(t, common.BytesToHash(sender[:]), logs[0].Topics[1])
+	require.Equal(t, common.BytesToHash([]byte{101}), logs[0].Topics[2])
+	require.Equal(t, uint64(100), uint256.NewInt(0).SetBytes(logs[0].Data[32:64]).Uint64())
 	stakingAcc, info := staking.LoadStakingAccAndInfo(ctx)
 	require.Equal(t, 1+1 /*include app.testValidatorPubKey*/, len(info.Validators))
 	require.True(t, bytes.Equal(sender.Bytes(), info.Validators[1].Address[:]))
@@ -276,7 +282,7 @@ func TestSwitchEpoch(t *testing.T) {
 	voters[0] = pubkey
 	voters[1] = info.Validators[1].Pubkey
 	stakingAcc, info := staking.LoadStakingAccAndInfo(ctx)
-	staking.DistributeFee(ctx, stakingAcc, &info, collectedFee, pubkey, pubkey, voters)
+	staking.DistributeFee(ctx, stakingAcc, &info, collectedFee, pubkey, pubkey, voters, nil)
 
 	var voterReward *types2.PendingReward
 	var proposerReward *types2.PendingReward
@@ -294,20 +300,31 @@ func TestSwitchEpoch(t *testing.T) {
 	info.PendingRewards = []*types2.PendingReward{proposerReward}
 	staking.SaveStakingInfo(ctx, info)
 	rewardTo := info.Validators[0].RewardTo
-	staking.SwitchEpoch(ctx, e, nil, log.NewNopLogger())
+	_, logs := staking.SwitchEpoch(ctx, e, nil, nil, log.NewNopLogger())
+	require.Equal(t, 2, len(logs))
+	require.Equal(t, common.Hash(staking.HashOfEventSwitchEpoch), logs[0].Topics[0])
+	require.Equal(t, common.BigToHash(big.NewInt(1)), logs[0].Topics[1])
+	require.Equal(t, common.Hash(staking.HashOfEventUnbond), logs[1].Topics[0])
 	stakingAcc, info = staking.LoadStakingAccAndInfo(ctx)
-	require.Equal(t, uint64(10000/2 /*pending reward not transfer to validator as of EpochCountBeforeRewardMature*/), stakingAcc.Balance().Uint64())
+	//pending reward not transfer to validator as of EpochCountBeforeRewardMature, and staking coins are unbonding
+	require.Equal(t, uint64(10000/2+100), stakingAcc.Balance().Uint64())
 	acc = ctx.GetAccount(sender)
 	//if validator retire in current epoch,
 	//he can only exit on next epoch when there has no pending reward on his address,
-	//otherwise exit on next next epoch, and staking coins return back to rewardTo acc
+	//otherwise exit on next next epoch, and staking coins are put into the unbonding queue
 	require.Equal(t, uint64(9999900), acc.Balance().Uint64())
-	rewardAcc := ctx.GetAccount(rewardTo)
-	require.Equal(t, uint64(100), rewardAcc.Balance().Uint64())
+	require.Equal(t, 1, len(info.UnbondingEntries))
+	require.Equal(t, sender, common.Address(info.UnbondingEntries[0].Validator))
+	require.Equal(t, rewardTo, info.UnbondingEntries[0].Receiver)
+	require.Equal(t, uint64(100), uint256.NewInt(0).SetBytes32(info.UnbondingEntries[0].Amount[:]).Uint64())
 
-	staking.SwitchEpoch(ctx, e, nil, log.NewNopLogger())
+	_, logs = staking.SwitchEpoch(ctx, e, nil, nil, log.NewNopLogger())
 	stakingAcc, info = staking.LoadStakingAccAndInfo(ctx)
-	require.Equal(t, uint64((10000-1500-8500*15/100)/2/2), stakingAcc.Balance().Uint64())
+	require.Equal(t, uint64((10000-1500-8500*15/100)/2/2+100), stakingAcc.Balance().Uint64())
+	require.Equal(t, 2, len(logs))
+	require.Equal(t, common.Hash(staking.HashOfEventDeliverReward), logs[1].Topics[0])
+	require.Equal(t, common.BytesToHash(rewardTo[:]), logs[1].Topics[1])
+	require.Equal(t, uint64(10000/2-(10000-1500-8500*15/100)/2/2), uint256.NewInt(0).SetBytes(logs[1].Data).Uint64())
 }
 
 func TestSlash(t *testing.T) {
@@ -352,18 +369,35 @@ func TestSlashAndReward(t *testing.T) {
 	copy(valAddress1[:], ed25519.PubKey(validator1[:]).Address().Bytes())
 	copy(valAddress2[:], ed25519.PubKey(validator2[:]).Address().Bytes())
 	staking.BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
-	currValidators, newValidators, _ := staking.SlashAndReward(ctx, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil)
+	currValidators, newValidators, _, logs := staking.SlashAndReward(ctx, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil, nil)
 	require.Equal(t, 2, len(currValidators))
 	require.Equal(t, 2, len(newValidators))
+	require.Equal(t, 0, len(logs))
 	onlineInfos := staking.LoadOnlineInfo(ctx)
 	require.Equal(t, int64(11207601), onlineInfos.StartHeight)
 	require.Equal(t, 2, len(onlineInfos.OnlineInfos))
 	require.Equal(t, valAddress1, onlineInfos.OnlineInfos[0].ValidatorConsensusAddress)
 
 	ctx.SetCurrentHeight(11207601 + 7200)
-	currValidators, newValidators, _ = staking.SlashAndReward(ctx, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil)
+	currValidators, newValidators, _, logs = staking.SlashAndReward(ctx, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil, nil)
 	require.Equal(t, 2, len(currValidators))
 	require.Equal(t, 0, len(newValidators))
+	// a Jail event follows each Slash event since JailForkHeight
+	require.Equal(t, 4, len(logs))
+	for i := 0; i < len(logs); i += 2 {
+		l := logs[i]
+		require.Equal(t, common.Hash(staking.HashOfEventSlash), l.Topics[0])
+		require.Equal(t, common.BigToHash(big.NewInt(int64(staking.SlashReasonNotOnline))), l.Topics[2])
+		require.Equal(t, uint256.NewInt(0).Mul(uint256.NewInt(2), uint256.NewInt(staking.Uint64_1e18)).Uint64(),
+			uint256.NewInt(0).SetBytes(l.Data).Uint64())
+		l = logs[i+1]
+		require.Equal(t, common.Hash(staking.HashOfEventJail), l.Topics[0])
+		require.Equal(t, uint64(11207601+7200+param.JailCooldownBlocks), uint256.NewInt(0).SetBytes(l.Data).Uint64())
+	}
+	for _, val := range staking.LoadStakingInfo(ctx).Validators {
+		require.True(t, val.IsJailed)
+		require.False(t, val.IsRetiring)
+	}
 	onlineInfos = staking.LoadOnlineInfo(ctx)
 	require.Equal(t, int64(11207601+7200), onlineInfos.StartHeight)
 	require.Equal(t, 0, len(onlineInfos.OnlineInfos))
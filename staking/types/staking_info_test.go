@@ -3,6 +3,7 @@ package types
 import (
 	"testing"
 
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,6 +60,72 @@ func TestGetUselessValidators(t *testing.T) {
 	require.Contains(t, vs, [20]byte{0xad, 0x08})
 }
 
+func TestJailedValidator(t *testing.T) {
+	si := &StakingInfo{
+		Validators: []*Validator{
+			{Address: [20]byte{0xad, 0x01}, VotingPower: 0},
+			{Address: [20]byte{0xad, 0x02}, VotingPower: 0, IsJailed: true, UnjailHeight: 100},
+		},
+	}
+	vs := si.GetUselessValidators()
+	require.Len(t, vs, 1)
+	require.Contains(t, vs, [20]byte{0xad, 0x01})
+
+	// the jail fields are omitted when empty, such that the old encoding is kept
+	bz, err := si.Validators[0].MarshalMsg(nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x87), bz[0]) // a map with 7 fields
+	bz, err = si.Validators[1].MarshalMsg(nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x89), bz[0])
+	var val Validator
+	_, err = val.UnmarshalMsg(bz)
+	require.NoError(t, err)
+	require.True(t, val.IsJailed)
+	require.Equal(t, int64(100), val.UnjailHeight)
+}
+
+func TestKeyRotation(t *testing.T) {
+	si := &StakingInfo{}
+	addr1, addr2 := [20]byte{0xad, 0x01}, [20]byte{0xad, 0x02}
+	pubkey1, pubkey2, pubkey3 := [32]byte{0xbe, 0x01}, [32]byte{0xbe, 0x02}, [32]byte{0xbe, 0x03}
+	require.NoError(t, si.AddValidator(addr1, pubkey1, "val1", [32]byte{}, addr1))
+	require.NoError(t, si.AddValidator(addr2, pubkey2, "val2", [32]byte{}, addr2))
+	// the key rotation is omitted when nil, such that the old encoding is kept
+	bz, err := si.Validators[0].MarshalMsg(nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x87), bz[0])
+
+	for _, val := range si.Validators {
+		val.VotingPower = 10
+	}
+	currValidators := []*Validator{}
+	for _, val := range si.Validators {
+		v := *val
+		currValidators = append(currValidators, &v)
+	}
+	si.Validators[0].KeyRotation = &KeyRotation{NextPubkey: pubkey3}
+	require.True(t, si.IsPubkeyUsed(pubkey3))
+	require.False(t, si.IsPubkeyUsed([32]byte{}))
+	require.Equal(t, ValidatorPubkeyAlreadyExists, si.AddValidator([20]byte{0xad, 0x03}, pubkey3, "val3", [32]byte{}, addr1))
+
+	rotated := si.ApplyKeyRotations()
+	require.Len(t, rotated, 1)
+	require.Equal(t, pubkey3, si.Validators[0].Pubkey)
+	require.Equal(t, pubkey1, si.Validators[0].KeyRotation.OldPubkey)
+	require.Equal(t, [32]byte{}, si.Validators[0].KeyRotation.NextPubkey)
+	require.Len(t, si.ApplyKeyRotations(), 0)
+	require.True(t, si.IsPubkeyUsed(pubkey1))
+
+	// tendermint removes the old key and adds the new one
+	updates := GetUpdateValidatorSet(currValidators, si.Validators)
+	require.Len(t, updates, 2)
+	require.Equal(t, pubkey1, updates[0].Pubkey)
+	require.Equal(t, int64(0), updates[0].VotingPower)
+	require.Equal(t, pubkey3, updates[1].Pubkey)
+	require.Equal(t, int64(10), updates[1].VotingPower)
+}
+
 func TestClearRewardsOf(t *testing.T) {
 	si := &StakingInfo{
 		CurrEpochNum: 99,
@@ -81,3 +148,168 @@ func TestClearRewardsOf(t *testing.T) {
 	require.Equal(t, [20]byte{0xad, 0x03}, si.PendingRewards[2].Address)
 	require.Equal(t, [20]byte{0xad, 0x04}, si.PendingRewards[3].Address)
 }
+
+func TestAddRewards(t *testing.T) {
+	pr := &PendingReward{}
+	pr.AddRewards(uint256.NewInt(1000))
+	require.Equal(t, uint64(1000), uint256.NewInt(0).SetBytes32(pr.Amount[:]).Uint64())
+	require.True(t, pr.DelegatorsAmount().IsZero())
+
+	pr.Delegators = &DelegatorsReward{Share: ShareDenominator / 4}
+	pr.AddRewards(uint256.NewInt(1000))
+	require.Equal(t, uint64(1750), uint256.NewInt(0).SetBytes32(pr.Amount[:]).Uint64())
+	require.Equal(t, uint64(250), pr.DelegatorsAmount().Uint64())
+
+	si := &StakingInfo{PendingRewards: []*PendingReward{pr}}
+	require.Equal(t, uint64(2000), si.ClearRewardsOf(pr.Address).Uint64())
+}
+
+func TestUnbondingEntries(t *testing.T) {
+	si := &StakingInfo{}
+	// the unbonding entries are omitted when empty, such that the old encoding is kept
+	bz, err := si.MarshalMsg(nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x85), bz[0]) // a map with 5 fields
+
+	val1 := &Validator{Address: [20]byte{0xad, 0x01}, Pubkey: [32]byte{0xbe, 0x01}, RewardTo: [20]byte{0xad, 0x11}}
+	val2 := &Validator{Address: [20]byte{0xad, 0x02}, Pubkey: [32]byte{0xbe, 0x02}, RewardTo: [20]byte{0xad, 0x12}}
+	val1.StakedCoins = uint256.NewInt(100).Bytes32()
+	si.AddUnbondingEntry(val1, 10)
+	val2.StakedCoins = uint256.NewInt(200).Bytes32()
+	si.AddUnbondingEntry(val2, 10)
+	val1.StakedCoins = uint256.NewInt(300).Bytes32()
+	si.AddUnbondingEntry(val1, 20)
+	bz, err = si.MarshalMsg(nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x86), bz[0])
+
+	require.Len(t, si.GetUnbondingEntriesOf([20]byte{0xad, 0x01}), 2)
+	require.Len(t, si.GetUnbondingEntriesOf([20]byte{0xad, 0x12}), 1)
+	require.Len(t, si.GetUnbondingEntriesOf([20]byte{0xad, 0x99}), 0)
+
+	// the earlier entry is slashed first, and it is removed when empty
+	slashed := si.SlashUnbondingEntries([32]byte{0xbe, 0x01}, uint256.NewInt(150))
+	require.Equal(t, uint64(150), slashed.Uint64())
+	require.Len(t, si.UnbondingEntries, 2)
+	require.Equal(t, uint256.NewInt(250).Bytes32(), si.UnbondingEntries[1].Amount)
+	slashed = si.SlashUnbondingEntries([32]byte{0xbe, 0x02}, uint256.NewInt(500))
+	require.Equal(t, uint64(200), slashed.Uint64())
+	require.Len(t, si.UnbondingEntries, 1)
+
+	require.Len(t, si.RemoveMatureUnbondingEntries([20]byte{0xad, 0x01}, 19), 0)
+	matured := si.RemoveMatureUnbondingEntries([20]byte{0xad, 0x01}, 20)
+	require.Len(t, matured, 1)
+	require.Equal(t, [20]byte{0xad, 0x11}, matured[0].Receiver)
+	require.Len(t, si.UnbondingEntries, 0)
+
+	// the delegated coins are not slashed
+	entry := si.AddUnbondingEntryFor(val2, [20]byte{0xde, 0x01}, uint256.NewInt(400).Bytes32(), 30)
+	entry.Delegated = true
+	require.True(t, si.SlashUnbondingEntries([32]byte{0xbe, 0x02}, uint256.NewInt(100)).IsZero())
+	require.Equal(t, uint256.NewInt(400).Bytes32(), si.UnbondingEntries[0].Amount)
+}
+
+func TestRewardLedger(t *testing.T) {
+	ledger := RewardLedger{EpochNum: 5}
+	require.Nil(t, ledger.GetEntry([20]byte{0xad, 0x01}))
+	entry := ledger.GetOrAddEntry([20]byte{0xad, 0x01})
+	entry.VoterReward = [32]byte{0x01}
+	require.Len(t, ledger.Entries, 1)
+	require.Equal(t, entry, ledger.GetOrAddEntry([20]byte{0xad, 0x01}))
+	ledger.GetOrAddEntry([20]byte{0xad, 0x02})
+	require.Len(t, ledger.Entries, 2)
+	require.Equal(t, [32]byte{0x01}, ledger.GetEntry([20]byte{0xad, 0x01}).VoterReward)
+
+	bz, err := ledger.MarshalMsg(nil)
+	require.NoError(t, err)
+	var ledger2 RewardLedger
+	_, err = ledger2.UnmarshalMsg(bz)
+	require.NoError(t, err)
+	require.Equal(t, ledger, ledger2)
+}
+
+func TestDelegationInfo(t *testing.T) {
+	di := &DelegationInfo{CommissionRate: 1000, NextCommissionRate: 2000}
+	alice, bob := [20]byte{0xad, 0x01}, [20]byte{0xad, 0x02}
+	di.GetOrAddDelegation(alice).Amount = uint256.NewInt(300).Bytes32()
+	di.GetOrAddDelegation(bob).PendingAmount = uint256.NewInt(100).Bytes32()
+	require.Len(t, di.Delegations, 2)
+	require.Equal(t, uint64(300), di.TotalAmount().Uint64())
+
+	// bob's pending coins are not snapshotted, delegators' share: 300/400*(1-10%)
+	share := di.TakeSnapshot(5, 4, uint256.NewInt(100))
+	require.Equal(t, ShareDenominator*3/4*9/10, share)
+	require.Len(t, di.Snapshots, 1)
+	require.Len(t, di.Snapshots[0].Amounts, 1)
+
+	// the coins delegated after the snapshot do not share the rewards of epoch 5
+	di.GetOrAddDelegation(bob).Amount = uint256.NewInt(300).Bytes32()
+	require.Equal(t, uint64(675), di.SplitReward(4, uint256.NewInt(675)).Uint64())
+	require.Equal(t, uint64(0), di.SplitReward(5, uint256.NewInt(675)).Uint64())
+	require.Equal(t, uint256.NewInt(675).Bytes32(), di.GetDelegation(alice).Rewards)
+	require.Equal(t, [32]byte{}, di.GetDelegation(bob).Rewards)
+	di.GetOrAddDelegation(bob).Amount = [32]byte{}
+
+	di.Activate()
+	require.Equal(t, uint64(2000), di.CommissionRate)
+	require.Equal(t, uint64(400), di.TotalAmount().Uint64())
+	require.Equal(t, [32]byte{}, di.GetDelegation(bob).PendingAmount)
+	di.TakeSnapshot(6, 6, uint256.NewInt(100))
+	require.Len(t, di.Snapshots, 1)
+	require.Equal(t, int64(6), di.Snapshots[0].EpochNum)
+
+	di.GetDelegation(bob).PendingAmount = uint256.NewInt(50).Bytes32()
+	require.Equal(t, uint64(150), di.GetDelegation(bob).TotalAmount().Uint64())
+	_, err := di.Undelegate(bob, uint256.NewInt(151))
+	require.Equal(t, DelegationAmountNotEnough, err)
+	fromActive, err := di.Undelegate(bob, uint256.NewInt(70))
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), fromActive.Uint64())
+	require.Equal(t, [32]byte{}, di.GetDelegation(bob).PendingAmount)
+	require.Equal(t, uint256.NewInt(80).Bytes32(), di.GetDelegation(bob).Amount)
+	fromActive, err = di.Undelegate(bob, uint256.NewInt(80))
+	require.NoError(t, err)
+	require.Equal(t, uint64(80), fromActive.Uint64())
+	_, err = di.Undelegate([20]byte{0xad, 0x03}, uint256.NewInt(1))
+	require.Equal(t, NoSuchDelegation, err)
+
+	di.RemoveEmptyDelegations()
+	require.Len(t, di.Delegations, 1)
+	require.Nil(t, di.GetDelegation(bob))
+	require.NotNil(t, di.GetDelegation(alice))
+}
+
+func TestParamProposal(t *testing.T) {
+	p := &ParamProposal{ParamId: 1, Value: 30}
+	p.AddVote([20]byte{0xad, 0x01}, true)
+	p.AddVote([20]byte{0xad, 0x02}, false)
+	p.AddVote([20]byte{0xad, 0x03}, true) // not active
+	require.Len(t, p.Votes, 3)
+
+	powers := map[[20]byte]int64{
+		{0xad, 0x01}: 60,
+		{0xad, 0x02}: 30,
+		{0xad, 0x04}: 10,
+	}
+	require.False(t, p.IsPassed(powers))
+	p.AddVote([20]byte{0xad, 0x04}, true)
+	require.True(t, p.IsPassed(powers))
+	p.AddVote([20]byte{0xad, 0x01}, false)
+	require.Len(t, p.Votes, 4)
+	require.False(t, p.IsPassed(powers))
+	require.False(t, p.IsPassed(nil))
+}
+
+func TestGovernedParam(t *testing.T) {
+	gp := &GovernedParam{Value: 50}
+	require.Equal(t, uint64(50), gp.ValueAt(100))
+	gp.Schedule(100, 30, 200)
+	require.Equal(t, uint64(50), gp.ValueAt(199))
+	require.Equal(t, uint64(30), gp.ValueAt(200))
+	gp.Schedule(150, 40, 300) // overrides the one not activated
+	require.Equal(t, uint64(50), gp.ValueAt(250))
+	require.Equal(t, uint64(40), gp.ValueAt(300))
+	gp.Schedule(400, 20, 500)
+	require.Equal(t, uint64(40), gp.ValueAt(450))
+	require.Equal(t, uint64(20), gp.ValueAt(500))
+}
@@ -96,6 +96,29 @@ type Validator struct {
 	Introduction string   `msgp:"introduction"` // a short introduction
 	StakedCoins  [32]byte `msgp:"staked_coins"`
 	IsRetiring   bool     `msgp:"is_retiring"` // whether this validator is in a retiring process
+	// a validator is jailed for being not online, it cannot be elected until it unjails itself,
+	// which is allowed since UnjailHeight. They are omitted when empty to keep the old encoding.
+	IsJailed     bool  `msg:"IsJailed,omitempty"`
+	UnjailHeight int64 `msg:"UnjailHeight,omitempty"`
+	// it is nil until the validator rotates its consensus key, and omitted when nil to keep the old encoding
+	KeyRotation *KeyRotation `msg:"KeyRotation,omitempty"`
+}
+
+// A KeyRotation records the changes of a validator's consensus key
+type KeyRotation struct {
+	NextPubkey [32]byte `msgp:"next_pubkey"` // it replaces Pubkey at next epoch switch, zero if no change is scheduled
+	OldPubkey  [32]byte `msgp:"old_pubkey"`  // replaced by the last rotation, the signatures and evidences of it are still accepted
+}
+
+// Whether 'pubkey' is used by the validator, as its current, next or old consensus key
+func (v *Validator) UsesPubkey(pubkey [32]byte) bool {
+	if v.Pubkey == pubkey {
+		return true
+	}
+	if v.KeyRotation == nil || pubkey == [32]byte{} {
+		return false
+	}
+	return v.KeyRotation.NextPubkey == pubkey || v.KeyRotation.OldPubkey == pubkey
 }
 
 // Because EpochCountBeforeRewardMature >= 1, some rewards will be pending for a while before mature
@@ -103,6 +126,38 @@ type PendingReward struct {
 	Address  [20]byte `msgp:"address"`   // Validator's operator address in smartbch chain
 	EpochNum int64    `msgp:"epoch_num"` // During which epoch were the rewards got?
 	Amount   [32]byte `msgp:"amount"`    // amount of rewards
+	// Since DelegationForkHeight, the delegators' part of the rewards is split out into it. It is nil if
+	// nothing is delegated to the validator, and omitted when nil to keep the old encoding.
+	Delegators *DelegatorsReward `msg:"Delegators,omitempty"`
+}
+
+// The delegators' part of a validator's rewards got in an epoch, which is split among them by the snapshot
+// of this epoch when mature
+type DelegatorsReward struct {
+	Share  uint64   `msgp:"share"` // in ShareDenominator
+	Amount [32]byte `msgp:"amount"`
+}
+
+// Add the rewards got by the validator, the delegators' share is split out
+func (pr *PendingReward) AddRewards(coins *uint256.Int) {
+	toDelegators := uint256.NewInt(0)
+	if pr.Delegators != nil {
+		toDelegators.Mul(coins, uint256.NewInt(pr.Delegators.Share))
+		toDelegators.Div(toDelegators, uint256.NewInt(ShareDenominator))
+		amount := uint256.NewInt(0).SetBytes32(pr.Delegators.Amount[:])
+		pr.Delegators.Amount = amount.Add(amount, toDelegators).Bytes32()
+	}
+	amount := uint256.NewInt(0).SetBytes32(pr.Amount[:])
+	amount.Add(amount, coins)
+	pr.Amount = amount.Sub(amount, toDelegators).Bytes32()
+}
+
+// The delegators' part of the rewards
+func (pr *PendingReward) DelegatorsAmount() *uint256.Int {
+	if pr.Delegators == nil {
+		return uint256.NewInt(0)
+	}
+	return uint256.NewInt(0).SetBytes32(pr.Delegators.Amount[:])
 }
 
 // This struct is stored in the world state.
@@ -113,6 +168,20 @@ type StakingInfo struct {
 	Validators                []*Validator     `msgp:"validators"`
 	ValidatorsUpdate          []*Validator     `msgp:"validators_update"`
 	PendingRewards            []*PendingReward `msgp:"pending_rewards"`
+	// the staked coins of the removed validators, which can still be slashed before they are mature.
+	// It is omitted when empty to keep the old encoding.
+	UnbondingEntries []*UnbondingEntry `msg:"UnbondingEntries,omitempty"`
+}
+
+// When a validator is removed, its staked coins are kept in stakingAcc until MatureHeight, then they
+// can be withdrawn to Receiver, which is the validator's RewardTo when it was removed.
+type UnbondingEntry struct {
+	Validator    [20]byte `msgp:"validator"`
+	Pubkey       [32]byte `msgp:"pubkey"` // the validator's pubkey, which is used to find the entry when slashing
+	Receiver     [20]byte `msgp:"receiver"`
+	Amount       [32]byte `msgp:"amount"`
+	MatureHeight int64    `msgp:"mature_height"`
+	Delegated    bool     `msg:"Delegated,omitempty"` // the coins delegated to the validator, which are not slashed
 }
 
 // Change si.Validators into a map with pubkeys as keys
@@ -150,7 +219,7 @@ func (si *StakingInfo) AddValidator(addr [20]byte, pubkey [32]byte, intro string
 		if bytes.Equal(addr[:], val.Address[:]) {
 			return ValidatorAddressAlreadyExists
 		}
-		if bytes.Equal(pubkey[:], val.Pubkey[:]) {
+		if val.UsesPubkey(pubkey) {
 			return ValidatorPubkeyAlreadyExists
 		}
 	}
@@ -196,7 +265,7 @@ func (si *StakingInfo) GetValidatorByPubkey(pubkey [32]byte) *Validator {
 func (si *StakingInfo) GetUselessValidators() map[[20]byte]struct{} {
 	res := make(map[[20]byte]struct{})
 	for _, val := range si.Validators {
-		if val.VotingPower == 0 {
+		if val.VotingPower == 0 && !val.IsJailed { // jailed validators wait for unjailing, or retiring after the cooldown
 			res[val.Address] = struct{}{}
 		}
 	}
@@ -214,8 +283,12 @@ func (si *StakingInfo) ClearRewardsOf(addr [20]byte) (totalCleared *uint256.Int)
 		if bytes.Equal(rwd.Address[:], addr[:]) {
 			coins := uint256.NewInt(0).SetBytes32(rwd.Amount[:])
 			totalCleared.Add(totalCleared, coins)
+			totalCleared.Add(totalCleared, rwd.DelegatorsAmount())
 			if rwd.EpochNum == si.CurrEpochNum { // we still need this entry
-				rwd.Amount = [32]byte{}        // just clear the amount
+				rwd.Amount = [32]byte{} // just clear the amounts
+				if rwd.Delegators != nil {
+					rwd.Delegators.Amount = [32]byte{}
+				}
 				rwdList = append(rwdList, rwd) // the entry is kept
 			}
 		} else { // rewards of other validators
@@ -226,6 +299,104 @@ func (si *StakingInfo) ClearRewardsOf(addr [20]byte) (totalCleared *uint256.Int)
 	return totalCleared
 }
 
+// Whether 'pubkey' is used by any validator, as its current, next or old consensus key
+func (si *StakingInfo) IsPubkeyUsed(pubkey [32]byte) bool {
+	for _, val := range si.Validators {
+		if val.UsesPubkey(pubkey) {
+			return true
+		}
+	}
+	return false
+}
+
+// The scheduled consensus keys replace the current ones, and the replaced ones are kept as the old keys.
+// Return the validators whose keys are rotated.
+func (si *StakingInfo) ApplyKeyRotations() (rotated []*Validator) {
+	for _, val := range si.Validators {
+		if val.KeyRotation == nil || val.KeyRotation.NextPubkey == [32]byte{} {
+			continue
+		}
+		val.KeyRotation.OldPubkey = val.Pubkey
+		val.Pubkey = val.KeyRotation.NextPubkey
+		val.KeyRotation.NextPubkey = [32]byte{}
+		rotated = append(rotated, val)
+	}
+	return
+}
+
+// Put the staked coins of 'val' into the unbonding queue, which are mature at 'matureHeight'
+func (si *StakingInfo) AddUnbondingEntry(val *Validator, matureHeight int64) *UnbondingEntry {
+	return si.AddUnbondingEntryFor(val, val.RewardTo, val.StakedCoins, matureHeight)
+}
+
+// Put 'amount' of coins backing 'val' into the unbonding queue, which are mature at 'matureHeight' and then
+// withdrawn to 'receiver'
+func (si *StakingInfo) AddUnbondingEntryFor(val *Validator, receiver [20]byte, amount [32]byte, matureHeight int64) *UnbondingEntry {
+	entry := &UnbondingEntry{
+		Validator:    val.Address,
+		Pubkey:       val.Pubkey,
+		Receiver:     receiver,
+		Amount:       amount,
+		MatureHeight: matureHeight,
+	}
+	si.UnbondingEntries = append(si.UnbondingEntries, entry)
+	return entry
+}
+
+// Remove the entries whose validator or receiver is 'addr' and which are mature at 'height' from the queue
+// and return them
+func (si *StakingInfo) RemoveMatureUnbondingEntries(addr [20]byte, height int64) (matured []*UnbondingEntry) {
+	entries := make([]*UnbondingEntry, 0, len(si.UnbondingEntries))
+	for _, entry := range si.UnbondingEntries {
+		if (entry.Validator == addr || entry.Receiver == addr) && entry.MatureHeight <= height {
+			matured = append(matured, entry)
+		} else {
+			entries = append(entries, entry)
+		}
+	}
+	si.UnbondingEntries = entries
+	return
+}
+
+// Slash at most 'amount' of coins from the unbonding entries with 'pubkey', the earlier ones are slashed first.
+// The delegated coins are never slashed, neither active nor unbonding, so the delegated entries are skipped.
+// The entries become empty are removed. Return the slashed amount.
+func (si *StakingInfo) SlashUnbondingEntries(pubkey [32]byte, amount *uint256.Int) (totalSlashed *uint256.Int) {
+	totalSlashed = uint256.NewInt(0)
+	remained := amount.Clone()
+	entries := make([]*UnbondingEntry, 0, len(si.UnbondingEntries))
+	for _, entry := range si.UnbondingEntries {
+		if entry.Pubkey == pubkey && !entry.Delegated && !remained.IsZero() {
+			coins := uint256.NewInt(0).SetBytes32(entry.Amount[:])
+			slashed := coins.Clone()
+			if remained.Lt(coins) {
+				slashed.Set(remained)
+			}
+			coins.Sub(coins, slashed)
+			remained.Sub(remained, slashed)
+			totalSlashed.Add(totalSlashed, slashed)
+			entry.Amount = coins.Bytes32()
+			if coins.IsZero() {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+	si.UnbondingEntries = entries
+	return
+}
+
+// Return the unbonding entries whose validator or receiver is 'addr'
+func (si *StakingInfo) GetUnbondingEntriesOf(addr [20]byte) []*UnbondingEntry {
+	res := make([]*UnbondingEntry, 0)
+	for _, entry := range si.UnbondingEntries {
+		if entry.Validator == addr || entry.Receiver == addr {
+			res = append(res, entry)
+		}
+	}
+	return res
+}
+
 func GetUpdateValidatorSet(currentValidators, newValidators []*Validator) []*Validator {
 	if newValidators == nil {
 		return nil
@@ -240,6 +411,12 @@ func GetUpdateValidatorSet(currentValidators, newValidators []*Validator) []*Val
 			removedV := *v
 			removedV.VotingPower = 0
 			updatedList = append(updatedList, &removedV)
+		} else if v.Pubkey != newValMap[v.Address].Pubkey { // the consensus key is rotated
+			removedV := *v
+			removedV.VotingPower = 0
+			updatedV := *newValMap[v.Address]
+			updatedList = append(updatedList, &removedV, &updatedV)
+			delete(newValMap, v.Address)
 		} else if v.VotingPower != newValMap[v.Address].VotingPower {
 			updatedV := *newValMap[v.Address]
 			updatedList = append(updatedList, &updatedV)
@@ -253,11 +430,341 @@ func GetUpdateValidatorSet(currentValidators, newValidators []*Validator) []*Val
 		updatedList = append(updatedList, &addedV)
 	}
 	sort.Slice(updatedList, func(i, j int) bool {
-		return bytes.Compare(updatedList[i].Address[:], updatedList[j].Address[:]) < 0
+		if c := bytes.Compare(updatedList[i].Address[:], updatedList[j].Address[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(updatedList[i].Pubkey[:], updatedList[j].Pubkey[:]) < 0 // a rotated validator
 	})
 	return updatedList
 }
 
+// The commission rates of validators are in basis points
+const MaxCommissionRate uint64 = 10000
+
+// The denominator of DelegatorsReward.Share
+const ShareDenominator uint64 = 1_000_000_000
+
+var (
+	NoSuchDelegation          = errors.New("no such delegation")
+	DelegationAmountNotEnough = errors.New("delegated amount is not enough")
+)
+
+// Delegation records the coins a delegator delegates to a validator
+type Delegation struct {
+	Delegator     [20]byte `msgp:"delegator"`
+	Amount        [32]byte `msgp:"amount"`         // counted in voting power and reward splitting
+	PendingAmount [32]byte `msgp:"pending_amount"` // delegated in current epoch, added to Amount at next epoch switch
+	Rewards       [32]byte `msgp:"rewards"`        // the delivered rewards which can be withdrawn
+}
+
+// DelegationInfo contains all the delegations to a validator, it is stored in the world state
+// separately from StakingInfo, one for each validator which has ever been delegated to.
+type DelegationInfo struct {
+	Validator [20]byte `msgp:"validator"`
+	// the commission taken from delegators' rewards, NextCommissionRate is set by the validator
+	// and takes effect at next epoch switch
+	CommissionRate     uint64        `msgp:"commission_rate"`
+	NextCommissionRate uint64        `msgp:"next_commission_rate"`
+	Delegations        []*Delegation `msgp:"delegations"`
+	// the snapshots of the epochs whose rewards are not mature yet
+	Snapshots []*DelegationSnapshot `msgp:"snapshots"`
+}
+
+// DelegationSnapshot records the delegated amounts which are active in an epoch, the delegators' part of
+// the rewards got in this epoch is split by them when mature
+type DelegationSnapshot struct {
+	EpochNum int64              `msgp:"epoch_num"`
+	Amounts  []*DelegatedAmount `msgp:"amounts"`
+}
+
+type DelegatedAmount struct {
+	Delegator [20]byte `msgp:"delegator"`
+	Amount    [32]byte `msgp:"amount"`
+}
+
+// Find the delegation of a delegator, or nil if there is not one
+func (di *DelegationInfo) GetDelegation(delegator [20]byte) *Delegation {
+	for _, d := range di.Delegations {
+		if d.Delegator == delegator {
+			return d
+		}
+	}
+	return nil
+}
+
+// Find the delegation of a delegator, or append a new one if there is not one
+func (di *DelegationInfo) GetOrAddDelegation(delegator [20]byte) *Delegation {
+	d := di.GetDelegation(delegator)
+	if d == nil {
+		d = &Delegation{Delegator: delegator}
+		di.Delegations = append(di.Delegations, d)
+	}
+	return d
+}
+
+// Remove the delegations which have neither delegated coins nor rewards
+func (di *DelegationInfo) RemoveEmptyDelegations() {
+	delegations := di.Delegations[:0]
+	for _, d := range di.Delegations {
+		if d.Amount != [32]byte{} || d.PendingAmount != [32]byte{} || d.Rewards != [32]byte{} {
+			delegations = append(delegations, d)
+		}
+	}
+	di.Delegations = delegations
+}
+
+// The sum of the delegated coins which are counted in voting power
+func (di *DelegationInfo) TotalAmount() *uint256.Int {
+	total := uint256.NewInt(0)
+	for _, d := range di.Delegations {
+		total.Add(total, uint256.NewInt(0).SetBytes32(d.Amount[:]))
+	}
+	return total
+}
+
+// Undelegate 'amount' of coins, the pending ones first. Return the amount taken from the active ones,
+// which are counted in voting power until next epoch switch.
+func (di *DelegationInfo) Undelegate(delegator [20]byte, amount *uint256.Int) (fromActive *uint256.Int, err error) {
+	d := di.GetDelegation(delegator)
+	if d == nil {
+		return nil, NoSuchDelegation
+	}
+	pending := uint256.NewInt(0).SetBytes32(d.PendingAmount[:])
+	effective := uint256.NewInt(0).SetBytes32(d.Amount[:])
+	if uint256.NewInt(0).Add(pending, effective).Lt(amount) {
+		return nil, DelegationAmountNotEnough
+	}
+	fromActive = uint256.NewInt(0)
+	if pending.Lt(amount) {
+		fromActive.Sub(amount, pending)
+		effective.Sub(effective, fromActive)
+		pending.Clear()
+	} else {
+		pending.Sub(pending, amount)
+	}
+	d.PendingAmount = pending.Bytes32()
+	d.Amount = effective.Bytes32()
+	return fromActive, nil
+}
+
+// The delegated coins, including the pending ones
+func (d *Delegation) TotalAmount() *uint256.Int {
+	total := uint256.NewInt(0).SetBytes32(d.Amount[:])
+	return total.Add(total, uint256.NewInt(0).SetBytes32(d.PendingAmount[:]))
+}
+
+// Called at epoch switch: the pending delegations and the new commission rate take effect
+func (di *DelegationInfo) Activate() {
+	for _, d := range di.Delegations {
+		amount := uint256.NewInt(0).SetBytes32(d.Amount[:])
+		amount.Add(amount, uint256.NewInt(0).SetBytes32(d.PendingAmount[:]))
+		d.Amount = amount.Bytes32()
+		d.PendingAmount = [32]byte{}
+	}
+	di.CommissionRate = di.NextCommissionRate
+}
+
+// TakeSnapshot records the delegated amounts active in epoch 'epochNum', and returns the delegators'
+// share (in ShareDenominator) of the rewards got in this epoch, pro rata to selfStake and the delegated
+// amounts, after the validator takes the commission. The snapshots older than 'keptEpochNum' are removed.
+func (di *DelegationInfo) TakeSnapshot(epochNum, keptEpochNum int64, selfStake *uint256.Int) (share uint64) {
+	snapshots := di.Snapshots[:0]
+	for _, snapshot := range di.Snapshots {
+		if snapshot.EpochNum >= keptEpochNum {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	di.Snapshots = snapshots
+	delegated := di.TotalAmount()
+	if delegated.IsZero() {
+		return 0
+	}
+	snapshot := &DelegationSnapshot{EpochNum: epochNum}
+	for _, d := range di.Delegations {
+		if d.Amount != [32]byte{} {
+			snapshot.Amounts = append(snapshot.Amounts, &DelegatedAmount{Delegator: d.Delegator, Amount: d.Amount})
+		}
+	}
+	di.Snapshots = append(di.Snapshots, snapshot)
+	totalStake := uint256.NewInt(0).Add(delegated, selfStake)
+	s := uint256.NewInt(0).Mul(delegated, uint256.NewInt(ShareDenominator*(MaxCommissionRate-di.CommissionRate)))
+	s.Div(s, totalStake)
+	s.Div(s, uint256.NewInt(MaxCommissionRate))
+	return s.Uint64()
+}
+
+// SplitReward splits the delegators' part of the mature rewards got in epoch 'epochNum' among the delegators,
+// pro rata to the snapshot of this epoch, and adds their parts to their Rewards. The remained dust, or the
+// whole amount if there is no such snapshot, is returned to the validator.
+func (di *DelegationInfo) SplitReward(epochNum int64, toDelegators *uint256.Int) (toValidator *uint256.Int) {
+	toValidator = toDelegators.Clone()
+	var snapshot *DelegationSnapshot
+	for _, sn := range di.Snapshots {
+		if sn.EpochNum == epochNum {
+			snapshot = sn
+		}
+	}
+	if snapshot == nil {
+		return
+	}
+	delegated := uint256.NewInt(0)
+	for _, a := range snapshot.Amounts {
+		delegated.Add(delegated, uint256.NewInt(0).SetBytes32(a.Amount[:]))
+	}
+	if delegated.IsZero() {
+		return
+	}
+	for _, a := range snapshot.Amounts {
+		share := uint256.NewInt(0).Mul(toDelegators, uint256.NewInt(0).SetBytes32(a.Amount[:]))
+		share.Div(share, delegated)
+		d := di.GetOrAddDelegation(a.Delegator) // the delegator may have undelegated all its coins
+		rewards := uint256.NewInt(0).SetBytes32(d.Rewards[:])
+		rewards.Add(rewards, share)
+		d.Rewards = rewards.Bytes32()
+		toValidator.Sub(toValidator, share)
+	}
+	return
+}
+
+// A ParamProposal proposes to change a governed consensus parameter to Value since ActivationHeight.
+// The active validators vote on it until Deadline, and it passes with more than 2/3 of voting power.
+type ParamProposal struct {
+	ParamId          uint64       `msgp:"param_id"`
+	Value            uint64       `msgp:"value"`
+	ActivationHeight int64        `msgp:"activation_height"`
+	Deadline         uint64       `msgp:"deadline"` // a timestamp
+	Proposer         [20]byte     `msgp:"proposer"`
+	Votes            []*ParamVote `msgp:"votes"`
+}
+
+type ParamVote struct {
+	Voter   [20]byte `msgp:"voter"`
+	Approve bool     `msgp:"approve"`
+}
+
+// Record a validator's vote, a later vote overrides the earlier one
+func (p *ParamProposal) AddVote(voter [20]byte, approve bool) {
+	for _, v := range p.Votes {
+		if v.Voter == voter {
+			v.Approve = approve
+			return
+		}
+	}
+	p.Votes = append(p.Votes, &ParamVote{Voter: voter, Approve: approve})
+}
+
+// IsPassed counts the approvals with the current voting powers of the active validators, the votes
+// from the others are ignored.
+func (p *ParamProposal) IsPassed(votingPowers map[[20]byte]int64) bool {
+	var approved, total int64
+	for _, power := range votingPowers {
+		total += power
+	}
+	for _, v := range p.Votes {
+		if v.Approve {
+			approved += votingPowers[v.Voter]
+		}
+	}
+	return total > 0 && approved*3 > total*2
+}
+
+// GovernedParam is the stored value of a consensus parameter which has been changed by proposals
+type GovernedParam struct {
+	Value            uint64 `msgp:"value"`
+	NextValue        uint64 `msgp:"next_value"`
+	ActivationHeight int64  `msgp:"activation_height"` // NextValue takes effect since this height
+}
+
+// The effective value at 'height'
+func (gp *GovernedParam) ValueAt(height int64) uint64 {
+	if gp.ActivationHeight != 0 && height >= gp.ActivationHeight {
+		return gp.NextValue
+	}
+	return gp.Value
+}
+
+// Schedule a new value since 'activationHeight', which overrides the scheduled one not activated yet
+func (gp *GovernedParam) Schedule(currHeight int64, value uint64, activationHeight int64) {
+	gp.Value = gp.ValueAt(currHeight)
+	gp.NextValue = value
+	gp.ActivationHeight = activationHeight
+}
+
+// A RewardLedger records the rewards and slashed coins of the validators in an epoch, for them to reconcile
+// their incomes. It is kept in the app's side index instead of world state.
+type RewardLedger struct {
+	EpochNum int64                `msgp:"epoch_num"`
+	Entries  []*RewardLedgerEntry `msgp:"entries"`
+}
+
+// The amounts booked for a validator in an epoch. The fee rewards are distributed into PendingRewards in
+// each block, and MintedReward is the mature pending rewards delivered to RewardTo at the epoch's switch.
+type RewardLedgerEntry struct {
+	Validator       [20]byte `msgp:"validator"`
+	ProposerReward  [32]byte `msgp:"proposer_reward"`
+	CollectorReward [32]byte `msgp:"collector_reward"`
+	VoterReward     [32]byte `msgp:"voter_reward"`
+	MintedReward    [32]byte `msgp:"minted_reward"`
+	SlashedAmount   [32]byte `msgp:"slashed_amount"`
+}
+
+// Get the entry of 'validator', a new entry is appended if it is not found
+func (l *RewardLedger) GetOrAddEntry(validator [20]byte) *RewardLedgerEntry {
+	for _, entry := range l.Entries {
+		if entry.Validator == validator {
+			return entry
+		}
+	}
+	entry := &RewardLedgerEntry{Validator: validator}
+	l.Entries = append(l.Entries, entry)
+	return entry
+}
+
+// Get the entry of 'validator', or nil if it is not found
+func (l *RewardLedger) GetEntry(validator [20]byte) *RewardLedgerEntry {
+	for _, entry := range l.Entries {
+		if entry.Validator == validator {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Add the amounts booked in 'other' to the entries of the same validators
+func (l *RewardLedger) Merge(other *RewardLedger) {
+	for _, o := range other.Entries {
+		entry := l.GetOrAddEntry(o.Validator)
+		for _, pair := range [][2]*[32]byte{
+			{&entry.ProposerReward, &o.ProposerReward},
+			{&entry.CollectorReward, &o.CollectorReward},
+			{&entry.VoterReward, &o.VoterReward},
+			{&entry.MintedReward, &o.MintedReward},
+			{&entry.SlashedAmount, &o.SlashedAmount},
+		} {
+			sum := uint256.NewInt(0).SetBytes32(pair[0][:])
+			sum.Add(sum, uint256.NewInt(0).SetBytes32(pair[1][:]))
+			*pair[0] = sum.Bytes32()
+		}
+	}
+}
+
+// The fee rewards booked for the validator as the proposer, the collector and a voter
+func (e *RewardLedgerEntry) FeeReward() *uint256.Int {
+	total := uint256.NewInt(0)
+	for _, amount := range [][32]byte{e.ProposerReward, e.CollectorReward, e.VoterReward} {
+		total.Add(total, uint256.NewInt(0).SetBytes32(amount[:]))
+	}
+	return total
+}
+
+// A SlashEvent records the coins slashed from a validator in a block, it is kept in the app's side index
+type SlashEvent struct {
+	Height    int64    `msgp:"height"`
+	Validator [20]byte `msgp:"validator"`
+	Reason    uint64   `msgp:"reason"`
+	Amount    [32]byte `msgp:"amount"`
+}
+
 type ValidatorOnlineInfos struct {
 	// todo: refresh StartHeight to the block height staking fork enabled!
 	StartHeight int64         `msgp:"start_height"`
@@ -7,7 +7,7 @@ import (
 )
 
 // DecodeMsg implements msgp.Decodable
-func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *DelegatedAmount) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -24,84 +24,18 @@ func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Number":
-			z.Number, err = dc.ReadInt64()
-			if err != nil {
-				err = msgp.WrapError(err, "Number")
-				return
-			}
-		case "StartHeight":
-			z.StartHeight, err = dc.ReadInt64()
-			if err != nil {
-				err = msgp.WrapError(err, "StartHeight")
-				return
-			}
-		case "EndTime":
-			z.EndTime, err = dc.ReadInt64()
+		case "Delegator":
+			err = dc.ReadExactBytes((z.Delegator)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "EndTime")
+				err = msgp.WrapError(err, "Delegator")
 				return
 			}
-		case "Nominations":
-			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Nominations")
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
-			if cap(z.Nominations) >= int(zb0002) {
-				z.Nominations = (z.Nominations)[:zb0002]
-			} else {
-				z.Nominations = make([]*Nomination, zb0002)
-			}
-			for za0001 := range z.Nominations {
-				if dc.IsNil() {
-					err = dc.ReadNil()
-					if err != nil {
-						err = msgp.WrapError(err, "Nominations", za0001)
-						return
-					}
-					z.Nominations[za0001] = nil
-				} else {
-					if z.Nominations[za0001] == nil {
-						z.Nominations[za0001] = new(Nomination)
-					}
-					var zb0003 uint32
-					zb0003, err = dc.ReadMapHeader()
-					if err != nil {
-						err = msgp.WrapError(err, "Nominations", za0001)
-						return
-					}
-					for zb0003 > 0 {
-						zb0003--
-						field, err = dc.ReadMapKeyPtr()
-						if err != nil {
-							err = msgp.WrapError(err, "Nominations", za0001)
-							return
-						}
-						switch msgp.UnsafeString(field) {
-						case "Pubkey":
-							err = dc.ReadExactBytes((z.Nominations[za0001].Pubkey)[:])
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
-								return
-							}
-						case "NominatedCount":
-							z.Nominations[za0001].NominatedCount, err = dc.ReadInt64()
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
-								return
-							}
-						default:
-							err = dc.Skip()
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001)
-								return
-							}
-						}
-					}
-				}
-			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -114,74 +48,138 @@ func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *Epoch) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
-	// write "Number"
-	err = en.Append(0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
-	if err != nil {
-		return
-	}
-	err = en.WriteInt64(z.Number)
+func (z *DelegatedAmount) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Delegator"
+	err = en.Append(0x82, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
 	if err != nil {
-		err = msgp.WrapError(err, "Number")
 		return
 	}
-	// write "StartHeight"
-	err = en.Append(0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	err = en.WriteBytes((z.Delegator)[:])
 	if err != nil {
+		err = msgp.WrapError(err, "Delegator")
 		return
 	}
-	err = en.WriteInt64(z.StartHeight)
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	if err != nil {
-		err = msgp.WrapError(err, "StartHeight")
 		return
 	}
-	// write "EndTime"
-	err = en.Append(0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
+	err = en.WriteBytes((z.Amount)[:])
 	if err != nil {
+		err = msgp.WrapError(err, "Amount")
 		return
 	}
-	err = en.WriteInt64(z.EndTime)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *DelegatedAmount) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Delegator"
+	o = append(o, 0x82, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Delegator)[:])
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DelegatedAmount) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
-		err = msgp.WrapError(err, "EndTime")
+		err = msgp.WrapError(err)
 		return
 	}
-	// write "Nominations"
-	err = en.Append(0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
-	if err != nil {
-		return
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Delegator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Delegator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Delegator")
+				return
+			}
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Nominations)))
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *DelegatedAmount) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *Delegation) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
 	if err != nil {
-		err = msgp.WrapError(err, "Nominations")
+		err = msgp.WrapError(err)
 		return
 	}
-	for za0001 := range z.Nominations {
-		if z.Nominations[za0001] == nil {
-			err = en.WriteNil()
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Delegator":
+			err = dc.ReadExactBytes((z.Delegator)[:])
 			if err != nil {
+				err = msgp.WrapError(err, "Delegator")
 				return
 			}
-		} else {
-			// map header, size 2
-			// write "Pubkey"
-			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
 			if err != nil {
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
-			err = en.WriteBytes((z.Nominations[za0001].Pubkey)[:])
+		case "PendingAmount":
+			err = dc.ReadExactBytes((z.PendingAmount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
+				err = msgp.WrapError(err, "PendingAmount")
 				return
 			}
-			// write "NominatedCount"
-			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+		case "Rewards":
+			err = dc.ReadExactBytes((z.Rewards)[:])
 			if err != nil {
+				err = msgp.WrapError(err, "Rewards")
 				return
 			}
-			err = en.WriteInt64(z.Nominations[za0001].NominatedCount)
+		default:
+			err = dc.Skip()
 			if err != nil {
-				err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
+				err = msgp.WrapError(err)
 				return
 			}
 		}
@@ -189,40 +187,73 @@ func (z *Epoch) EncodeMsg(en *msgp.Writer) (err error) {
 	return
 }
 
+// EncodeMsg implements msgp.Encodable
+func (z *Delegation) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Delegator"
+	err = en.Append(0x84, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Delegator)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Delegator")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Amount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// write "PendingAmount"
+	err = en.Append(0xad, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.PendingAmount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "PendingAmount")
+		return
+	}
+	// write "Rewards"
+	err = en.Append(0xa7, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Rewards)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Rewards")
+		return
+	}
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
-func (z *Epoch) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *Delegation) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 4
-	// string "Number"
-	o = append(o, 0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
-	o = msgp.AppendInt64(o, z.Number)
-	// string "StartHeight"
-	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendInt64(o, z.StartHeight)
-	// string "EndTime"
-	o = append(o, 0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
-	o = msgp.AppendInt64(o, z.EndTime)
-	// string "Nominations"
-	o = append(o, 0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Nominations)))
-	for za0001 := range z.Nominations {
-		if z.Nominations[za0001] == nil {
-			o = msgp.AppendNil(o)
-		} else {
-			// map header, size 2
-			// string "Pubkey"
-			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-			o = msgp.AppendBytes(o, (z.Nominations[za0001].Pubkey)[:])
-			// string "NominatedCount"
-			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-			o = msgp.AppendInt64(o, z.Nominations[za0001].NominatedCount)
-		}
-	}
+	// string "Delegator"
+	o = append(o, 0x84, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Delegator)[:])
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	// string "PendingAmount"
+	o = append(o, 0xad, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.PendingAmount)[:])
+	// string "Rewards"
+	o = append(o, 0xa7, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	o = msgp.AppendBytes(o, (z.Rewards)[:])
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *Delegation) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -239,83 +270,30 @@ func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Number":
-			z.Number, bts, err = msgp.ReadInt64Bytes(bts)
+		case "Delegator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Delegator)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Number")
+				err = msgp.WrapError(err, "Delegator")
 				return
 			}
-		case "StartHeight":
-			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "StartHeight")
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
-		case "EndTime":
-			z.EndTime, bts, err = msgp.ReadInt64Bytes(bts)
+		case "PendingAmount":
+			bts, err = msgp.ReadExactBytes(bts, (z.PendingAmount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "EndTime")
+				err = msgp.WrapError(err, "PendingAmount")
 				return
 			}
-		case "Nominations":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "Rewards":
+			bts, err = msgp.ReadExactBytes(bts, (z.Rewards)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Nominations")
+				err = msgp.WrapError(err, "Rewards")
 				return
 			}
-			if cap(z.Nominations) >= int(zb0002) {
-				z.Nominations = (z.Nominations)[:zb0002]
-			} else {
-				z.Nominations = make([]*Nomination, zb0002)
-			}
-			for za0001 := range z.Nominations {
-				if msgp.IsNil(bts) {
-					bts, err = msgp.ReadNilBytes(bts)
-					if err != nil {
-						return
-					}
-					z.Nominations[za0001] = nil
-				} else {
-					if z.Nominations[za0001] == nil {
-						z.Nominations[za0001] = new(Nomination)
-					}
-					var zb0003 uint32
-					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Nominations", za0001)
-						return
-					}
-					for zb0003 > 0 {
-						zb0003--
-						field, bts, err = msgp.ReadMapKeyZC(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Nominations", za0001)
-							return
-						}
-						switch msgp.UnsafeString(field) {
-						case "Pubkey":
-							bts, err = msgp.ReadExactBytes(bts, (z.Nominations[za0001].Pubkey)[:])
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
-								return
-							}
-						case "NominatedCount":
-							z.Nominations[za0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
-								return
-							}
-						default:
-							bts, err = msgp.Skip(bts)
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001)
-								return
-							}
-						}
-					}
-				}
-			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -329,20 +307,13 @@ func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *Epoch) Msgsize() (s int) {
-	s = 1 + 7 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize
-	for za0001 := range z.Nominations {
-		if z.Nominations[za0001] == nil {
-			s += msgp.NilSize
-		} else {
-			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
-		}
-	}
+func (z *Delegation) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 14 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 8 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *DelegationInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -359,18 +330,86 @@ func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Pubkey":
-			err = dc.ReadExactBytes((z.Pubkey)[:])
+		case "Validator":
+			err = dc.ReadExactBytes((z.Validator)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Pubkey")
+				err = msgp.WrapError(err, "Validator")
 				return
 			}
-		case "NominatedCount":
-			z.NominatedCount, err = dc.ReadInt64()
+		case "CommissionRate":
+			z.CommissionRate, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "NominatedCount")
+				err = msgp.WrapError(err, "CommissionRate")
+				return
+			}
+		case "NextCommissionRate":
+			z.NextCommissionRate, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "NextCommissionRate")
+				return
+			}
+		case "Delegations":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Delegations")
+				return
+			}
+			if cap(z.Delegations) >= int(zb0002) {
+				z.Delegations = (z.Delegations)[:zb0002]
+			} else {
+				z.Delegations = make([]*Delegation, zb0002)
+			}
+			for za0002 := range z.Delegations {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Delegations", za0002)
+						return
+					}
+					z.Delegations[za0002] = nil
+				} else {
+					if z.Delegations[za0002] == nil {
+						z.Delegations[za0002] = new(Delegation)
+					}
+					err = z.Delegations[za0002].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Delegations", za0002)
+						return
+					}
+				}
+			}
+		case "Snapshots":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Snapshots")
 				return
 			}
+			if cap(z.Snapshots) >= int(zb0003) {
+				z.Snapshots = (z.Snapshots)[:zb0003]
+			} else {
+				z.Snapshots = make([]*DelegationSnapshot, zb0003)
+			}
+			for za0003 := range z.Snapshots {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Snapshots", za0003)
+						return
+					}
+					z.Snapshots[za0003] = nil
+				} else {
+					if z.Snapshots[za0003] == nil {
+						z.Snapshots[za0003] = new(DelegationSnapshot)
+					}
+					err = z.Snapshots[za0003].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Snapshots", za0003)
+						return
+					}
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -383,46 +422,135 @@ func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *Nomination) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
-	// write "Pubkey"
-	err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+func (z *DelegationInfo) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "Validator"
+	err = en.Append(0x85, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.Pubkey)[:])
+	err = en.WriteBytes((z.Validator)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "Pubkey")
+		err = msgp.WrapError(err, "Validator")
 		return
 	}
-	// write "NominatedCount"
-	err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	// write "CommissionRate"
+	err = en.Append(0xae, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.NominatedCount)
+	err = en.WriteUint64(z.CommissionRate)
 	if err != nil {
-		err = msgp.WrapError(err, "NominatedCount")
+		err = msgp.WrapError(err, "CommissionRate")
+		return
+	}
+	// write "NextCommissionRate"
+	err = en.Append(0xb2, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.NextCommissionRate)
+	if err != nil {
+		err = msgp.WrapError(err, "NextCommissionRate")
+		return
+	}
+	// write "Delegations"
+	err = en.Append(0xab, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Delegations)))
+	if err != nil {
+		err = msgp.WrapError(err, "Delegations")
+		return
+	}
+	for za0002 := range z.Delegations {
+		if z.Delegations[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Delegations[za0002].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Delegations", za0002)
+				return
+			}
+		}
+	}
+	// write "Snapshots"
+	err = en.Append(0xa9, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Snapshots)))
+	if err != nil {
+		err = msgp.WrapError(err, "Snapshots")
 		return
 	}
+	for za0003 := range z.Snapshots {
+		if z.Snapshots[za0003] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Snapshots[za0003].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Snapshots", za0003)
+				return
+			}
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *Nomination) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *DelegationInfo) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "Pubkey"
-	o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-	o = msgp.AppendBytes(o, (z.Pubkey)[:])
-	// string "NominatedCount"
-	o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-	o = msgp.AppendInt64(o, z.NominatedCount)
+	// map header, size 5
+	// string "Validator"
+	o = append(o, 0x85, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Validator)[:])
+	// string "CommissionRate"
+	o = append(o, 0xae, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65)
+	o = msgp.AppendUint64(o, z.CommissionRate)
+	// string "NextCommissionRate"
+	o = append(o, 0xb2, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65)
+	o = msgp.AppendUint64(o, z.NextCommissionRate)
+	// string "Delegations"
+	o = append(o, 0xab, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Delegations)))
+	for za0002 := range z.Delegations {
+		if z.Delegations[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Delegations[za0002].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Delegations", za0002)
+				return
+			}
+		}
+	}
+	// string "Snapshots"
+	o = append(o, 0xa9, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Snapshots)))
+	for za0003 := range z.Snapshots {
+		if z.Snapshots[za0003] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Snapshots[za0003].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Snapshots", za0003)
+				return
+			}
+		}
+	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *DelegationInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -439,18 +567,84 @@ func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Pubkey":
-			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+		case "Validator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Validator)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Pubkey")
+				err = msgp.WrapError(err, "Validator")
 				return
 			}
-		case "NominatedCount":
-			z.NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
+		case "CommissionRate":
+			z.CommissionRate, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "NominatedCount")
+				err = msgp.WrapError(err, "CommissionRate")
+				return
+			}
+		case "NextCommissionRate":
+			z.NextCommissionRate, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NextCommissionRate")
+				return
+			}
+		case "Delegations":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Delegations")
+				return
+			}
+			if cap(z.Delegations) >= int(zb0002) {
+				z.Delegations = (z.Delegations)[:zb0002]
+			} else {
+				z.Delegations = make([]*Delegation, zb0002)
+			}
+			for za0002 := range z.Delegations {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Delegations[za0002] = nil
+				} else {
+					if z.Delegations[za0002] == nil {
+						z.Delegations[za0002] = new(Delegation)
+					}
+					bts, err = z.Delegations[za0002].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Delegations", za0002)
+						return
+					}
+				}
+			}
+		case "Snapshots":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Snapshots")
 				return
 			}
+			if cap(z.Snapshots) >= int(zb0003) {
+				z.Snapshots = (z.Snapshots)[:zb0003]
+			} else {
+				z.Snapshots = make([]*DelegationSnapshot, zb0003)
+			}
+			for za0003 := range z.Snapshots {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Snapshots[za0003] = nil
+				} else {
+					if z.Snapshots[za0003] == nil {
+						z.Snapshots[za0003] = new(DelegationSnapshot)
+					}
+					bts, err = z.Snapshots[za0003].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Snapshots", za0003)
+						return
+					}
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -464,110 +658,125 @@ func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *Nomination) Msgsize() (s int) {
-	s = 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+func (z *DelegationInfo) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 15 + msgp.Uint64Size + 19 + msgp.Uint64Size + 12 + msgp.ArrayHeaderSize
+	for za0002 := range z.Delegations {
+		if z.Delegations[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Delegations[za0002].Msgsize()
+		}
+	}
+	s += 10 + msgp.ArrayHeaderSize
+	for za0003 := range z.Snapshots {
+		if z.Snapshots[za0003] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Snapshots[za0003].Msgsize()
+		}
+	}
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *NominationHeap) DecodeMsg(dc *msgp.Reader) (err error) {
-	var zb0003 uint32
-	zb0003, err = dc.ReadArrayHeader()
-	if err != nil {
-		err = msgp.WrapError(err)
+func (z *DelegationSnapshot) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
 		return
 	}
-	if cap((*z)) >= int(zb0003) {
-		(*z) = (*z)[:zb0003]
-	} else {
-		(*z) = make(NominationHeap, zb0003)
-	}
-	for zb0001 := range *z {
-		if dc.IsNil() {
-			err = dc.ReadNil()
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "EpochNum":
+			z.EpochNum, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, zb0001)
+				err = msgp.WrapError(err, "EpochNum")
 				return
 			}
-			(*z)[zb0001] = nil
-		} else {
-			if (*z)[zb0001] == nil {
-				(*z)[zb0001] = new(Nomination)
-			}
-			var field []byte
-			_ = field
-			var zb0004 uint32
-			zb0004, err = dc.ReadMapHeader()
+		case "Amounts":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, zb0001)
+				err = msgp.WrapError(err, "Amounts")
 				return
 			}
-			for zb0004 > 0 {
-				zb0004--
-				field, err = dc.ReadMapKeyPtr()
-				if err != nil {
-					err = msgp.WrapError(err, zb0001)
-					return
-				}
-				switch msgp.UnsafeString(field) {
-				case "Pubkey":
-					err = dc.ReadExactBytes(((*z)[zb0001].Pubkey)[:])
+			if cap(z.Amounts) >= int(zb0002) {
+				z.Amounts = (z.Amounts)[:zb0002]
+			} else {
+				z.Amounts = make([]*DelegatedAmount, zb0002)
+			}
+			for za0001 := range z.Amounts {
+				if dc.IsNil() {
+					err = dc.ReadNil()
 					if err != nil {
-						err = msgp.WrapError(err, zb0001, "Pubkey")
+						err = msgp.WrapError(err, "Amounts", za0001)
 						return
 					}
-				case "NominatedCount":
-					(*z)[zb0001].NominatedCount, err = dc.ReadInt64()
-					if err != nil {
-						err = msgp.WrapError(err, zb0001, "NominatedCount")
-						return
+					z.Amounts[za0001] = nil
+				} else {
+					if z.Amounts[za0001] == nil {
+						z.Amounts[za0001] = new(DelegatedAmount)
 					}
-				default:
-					err = dc.Skip()
+					err = z.Amounts[za0001].DecodeMsg(dc)
 					if err != nil {
-						err = msgp.WrapError(err, zb0001)
+						err = msgp.WrapError(err, "Amounts", za0001)
 						return
 					}
 				}
 			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
 		}
 	}
 	return
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z NominationHeap) EncodeMsg(en *msgp.Writer) (err error) {
-	err = en.WriteArrayHeader(uint32(len(z)))
+func (z *DelegationSnapshot) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "EpochNum"
+	err = en.Append(0x82, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
 	if err != nil {
-		err = msgp.WrapError(err)
 		return
 	}
-	for zb0005 := range z {
-		if z[zb0005] == nil {
+	err = en.WriteInt64(z.EpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "EpochNum")
+		return
+	}
+	// write "Amounts"
+	err = en.Append(0xa7, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Amounts)))
+	if err != nil {
+		err = msgp.WrapError(err, "Amounts")
+		return
+	}
+	for za0001 := range z.Amounts {
+		if z.Amounts[za0001] == nil {
 			err = en.WriteNil()
 			if err != nil {
 				return
 			}
 		} else {
-			// map header, size 2
-			// write "Pubkey"
-			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-			if err != nil {
-				return
-			}
-			err = en.WriteBytes((z[zb0005].Pubkey)[:])
-			if err != nil {
-				err = msgp.WrapError(err, zb0005, "Pubkey")
-				return
-			}
-			// write "NominatedCount"
-			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-			if err != nil {
-				return
-			}
-			err = en.WriteInt64(z[zb0005].NominatedCount)
+			err = z.Amounts[za0001].EncodeMsg(en)
 			if err != nil {
-				err = msgp.WrapError(err, zb0005, "NominatedCount")
+				err = msgp.WrapError(err, "Amounts", za0001)
 				return
 			}
 		}
@@ -576,85 +785,89 @@ func (z NominationHeap) EncodeMsg(en *msgp.Writer) (err error) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z NominationHeap) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *DelegationSnapshot) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	o = msgp.AppendArrayHeader(o, uint32(len(z)))
-	for zb0005 := range z {
-		if z[zb0005] == nil {
+	// map header, size 2
+	// string "EpochNum"
+	o = append(o, 0x82, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.EpochNum)
+	// string "Amounts"
+	o = append(o, 0xa7, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Amounts)))
+	for za0001 := range z.Amounts {
+		if z.Amounts[za0001] == nil {
 			o = msgp.AppendNil(o)
 		} else {
-			// map header, size 2
-			// string "Pubkey"
-			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-			o = msgp.AppendBytes(o, (z[zb0005].Pubkey)[:])
-			// string "NominatedCount"
-			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-			o = msgp.AppendInt64(o, z[zb0005].NominatedCount)
+			o, err = z.Amounts[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Amounts", za0001)
+				return
+			}
 		}
 	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *NominationHeap) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var zb0003 uint32
-	zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+func (z *DelegationSnapshot) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
 		err = msgp.WrapError(err)
 		return
 	}
-	if cap((*z)) >= int(zb0003) {
-		(*z) = (*z)[:zb0003]
-	} else {
-		(*z) = make(NominationHeap, zb0003)
-	}
-	for zb0001 := range *z {
-		if msgp.IsNil(bts) {
-			bts, err = msgp.ReadNilBytes(bts)
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "EpochNum":
+			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
 				return
 			}
-			(*z)[zb0001] = nil
-		} else {
-			if (*z)[zb0001] == nil {
-				(*z)[zb0001] = new(Nomination)
-			}
-			var field []byte
-			_ = field
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+		case "Amounts":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, zb0001)
+				err = msgp.WrapError(err, "Amounts")
 				return
 			}
-			for zb0004 > 0 {
-				zb0004--
-				field, bts, err = msgp.ReadMapKeyZC(bts)
-				if err != nil {
-					err = msgp.WrapError(err, zb0001)
-					return
-				}
-				switch msgp.UnsafeString(field) {
-				case "Pubkey":
-					bts, err = msgp.ReadExactBytes(bts, ((*z)[zb0001].Pubkey)[:])
+			if cap(z.Amounts) >= int(zb0002) {
+				z.Amounts = (z.Amounts)[:zb0002]
+			} else {
+				z.Amounts = make([]*DelegatedAmount, zb0002)
+			}
+			for za0001 := range z.Amounts {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
 					if err != nil {
-						err = msgp.WrapError(err, zb0001, "Pubkey")
 						return
 					}
-				case "NominatedCount":
-					(*z)[zb0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
-					if err != nil {
-						err = msgp.WrapError(err, zb0001, "NominatedCount")
-						return
+					z.Amounts[za0001] = nil
+				} else {
+					if z.Amounts[za0001] == nil {
+						z.Amounts[za0001] = new(DelegatedAmount)
 					}
-				default:
-					bts, err = msgp.Skip(bts)
+					bts, err = z.Amounts[za0001].UnmarshalMsg(bts)
 					if err != nil {
-						err = msgp.WrapError(err, zb0001)
+						err = msgp.WrapError(err, "Amounts", za0001)
 						return
 					}
 				}
 			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
 		}
 	}
 	o = bts
@@ -662,20 +875,20 @@ func (z *NominationHeap) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z NominationHeap) Msgsize() (s int) {
-	s = msgp.ArrayHeaderSize
-	for zb0005 := range z {
-		if z[zb0005] == nil {
+func (z *DelegationSnapshot) Msgsize() (s int) {
+	s = 1 + 9 + msgp.Int64Size + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Amounts {
+		if z.Amounts[za0001] == nil {
 			s += msgp.NilSize
 		} else {
-			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+			s += z.Amounts[za0001].Msgsize()
 		}
 	}
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *DelegatorsReward) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -692,22 +905,16 @@ func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "ValidatorConsensusAddress":
-			err = dc.ReadExactBytes((z.ValidatorConsensusAddress)[:])
-			if err != nil {
-				err = msgp.WrapError(err, "ValidatorConsensusAddress")
-				return
-			}
-		case "SignatureCount":
-			z.SignatureCount, err = dc.ReadInt32()
+		case "Share":
+			z.Share, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "SignatureCount")
+				err = msgp.WrapError(err, "Share")
 				return
 			}
-		case "HeightOfLastSignature":
-			z.HeightOfLastSignature, err = dc.ReadInt64()
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "HeightOfLastSignature")
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
 		default:
@@ -722,59 +929,46 @@ func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *OnlineInfo) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "ValidatorConsensusAddress"
-	err = en.Append(0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
-	if err != nil {
-		return
-	}
-	err = en.WriteBytes((z.ValidatorConsensusAddress)[:])
-	if err != nil {
-		err = msgp.WrapError(err, "ValidatorConsensusAddress")
-		return
-	}
-	// write "SignatureCount"
-	err = en.Append(0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+func (z *DelegatorsReward) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Share"
+	err = en.Append(0x82, 0xa5, 0x53, 0x68, 0x61, 0x72, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt32(z.SignatureCount)
+	err = en.WriteUint64(z.Share)
 	if err != nil {
-		err = msgp.WrapError(err, "SignatureCount")
+		err = msgp.WrapError(err, "Share")
 		return
 	}
-	// write "HeightOfLastSignature"
-	err = en.Append(0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.HeightOfLastSignature)
+	err = en.WriteBytes((z.Amount)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "HeightOfLastSignature")
+		err = msgp.WrapError(err, "Amount")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *OnlineInfo) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *DelegatorsReward) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "ValidatorConsensusAddress"
-	o = append(o, 0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
-	o = msgp.AppendBytes(o, (z.ValidatorConsensusAddress)[:])
-	// string "SignatureCount"
-	o = append(o, 0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-	o = msgp.AppendInt32(o, z.SignatureCount)
-	// string "HeightOfLastSignature"
-	o = append(o, 0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
-	o = msgp.AppendInt64(o, z.HeightOfLastSignature)
+	// map header, size 2
+	// string "Share"
+	o = append(o, 0x82, 0xa5, 0x53, 0x68, 0x61, 0x72, 0x65)
+	o = msgp.AppendUint64(o, z.Share)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *DelegatorsReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -791,22 +985,16 @@ func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "ValidatorConsensusAddress":
-			bts, err = msgp.ReadExactBytes(bts, (z.ValidatorConsensusAddress)[:])
+		case "Share":
+			z.Share, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorConsensusAddress")
+				err = msgp.WrapError(err, "Share")
 				return
 			}
-		case "SignatureCount":
-			z.SignatureCount, bts, err = msgp.ReadInt32Bytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "SignatureCount")
-				return
-			}
-		case "HeightOfLastSignature":
-			z.HeightOfLastSignature, bts, err = msgp.ReadInt64Bytes(bts)
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "HeightOfLastSignature")
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
 		default:
@@ -822,13 +1010,13 @@ func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *OnlineInfo) Msgsize() (s int) {
-	s = 1 + 26 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 15 + msgp.Int32Size + 22 + msgp.Int64Size
+func (z *DelegatorsReward) Msgsize() (s int) {
+	s = 1 + 6 + msgp.Uint64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -845,24 +1033,84 @@ func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Address":
-			err = dc.ReadExactBytes((z.Address)[:])
+		case "Number":
+			z.Number, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "Address")
+				err = msgp.WrapError(err, "Number")
 				return
 			}
-		case "EpochNum":
-			z.EpochNum, err = dc.ReadInt64()
+		case "StartHeight":
+			z.StartHeight, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "EpochNum")
+				err = msgp.WrapError(err, "StartHeight")
 				return
 			}
-		case "Amount":
-			err = dc.ReadExactBytes((z.Amount)[:])
+		case "EndTime":
+			z.EndTime, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "Amount")
+				err = msgp.WrapError(err, "EndTime")
 				return
 			}
+		case "Nominations":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Nominations")
+				return
+			}
+			if cap(z.Nominations) >= int(zb0002) {
+				z.Nominations = (z.Nominations)[:zb0002]
+			} else {
+				z.Nominations = make([]*Nomination, zb0002)
+			}
+			for za0001 := range z.Nominations {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Nominations", za0001)
+						return
+					}
+					z.Nominations[za0001] = nil
+				} else {
+					if z.Nominations[za0001] == nil {
+						z.Nominations[za0001] = new(Nomination)
+					}
+					var zb0003 uint32
+					zb0003, err = dc.ReadMapHeader()
+					if err != nil {
+						err = msgp.WrapError(err, "Nominations", za0001)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, err = dc.ReadMapKeyPtr()
+						if err != nil {
+							err = msgp.WrapError(err, "Nominations", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Pubkey":
+							err = dc.ReadExactBytes((z.Nominations[za0001].Pubkey)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
+								return
+							}
+						case "NominatedCount":
+							z.Nominations[za0001].NominatedCount, err = dc.ReadInt64()
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
+								return
+							}
+						default:
+							err = dc.Skip()
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001)
+								return
+							}
+						}
+					}
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -875,59 +1123,115 @@ func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *PendingReward) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "Address"
-	err = en.Append(0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+func (z *Epoch) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Number"
+	err = en.Append(0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.Address)[:])
+	err = en.WriteInt64(z.Number)
 	if err != nil {
-		err = msgp.WrapError(err, "Address")
+		err = msgp.WrapError(err, "Number")
 		return
 	}
-	// write "EpochNum"
-	err = en.Append(0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	// write "StartHeight"
+	err = en.Append(0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.EpochNum)
+	err = en.WriteInt64(z.StartHeight)
 	if err != nil {
-		err = msgp.WrapError(err, "EpochNum")
+		err = msgp.WrapError(err, "StartHeight")
 		return
 	}
-	// write "Amount"
-	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	// write "EndTime"
+	err = en.Append(0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.Amount)[:])
+	err = en.WriteInt64(z.EndTime)
 	if err != nil {
-		err = msgp.WrapError(err, "Amount")
+		err = msgp.WrapError(err, "EndTime")
+		return
+	}
+	// write "Nominations"
+	err = en.Append(0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Nominations)))
+	if err != nil {
+		err = msgp.WrapError(err, "Nominations")
 		return
 	}
+	for za0001 := range z.Nominations {
+		if z.Nominations[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Pubkey"
+			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z.Nominations[za0001].Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
+				return
+			}
+			// write "NominatedCount"
+			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+			if err != nil {
+				return
+			}
+			err = en.WriteInt64(z.Nominations[za0001].NominatedCount)
+			if err != nil {
+				err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
+				return
+			}
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *PendingReward) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *Epoch) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "Address"
-	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
-	o = msgp.AppendBytes(o, (z.Address)[:])
-	// string "EpochNum"
-	o = append(o, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
-	o = msgp.AppendInt64(o, z.EpochNum)
-	// string "Amount"
-	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
-	o = msgp.AppendBytes(o, (z.Amount)[:])
+	// map header, size 4
+	// string "Number"
+	o = append(o, 0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
+	o = msgp.AppendInt64(o, z.Number)
+	// string "StartHeight"
+	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.StartHeight)
+	// string "EndTime"
+	o = append(o, 0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
+	o = msgp.AppendInt64(o, z.EndTime)
+	// string "Nominations"
+	o = append(o, 0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Nominations)))
+	for za0001 := range z.Nominations {
+		if z.Nominations[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Pubkey"
+			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+			o = msgp.AppendBytes(o, (z.Nominations[za0001].Pubkey)[:])
+			// string "NominatedCount"
+			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+			o = msgp.AppendInt64(o, z.Nominations[za0001].NominatedCount)
+		}
+	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *PendingReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -944,75 +1248,2747 @@ func (z *PendingReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Address":
-			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+		case "Number":
+			z.Number, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Address")
+				err = msgp.WrapError(err, "Number")
 				return
 			}
-		case "EpochNum":
-			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+		case "StartHeight":
+			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "EpochNum")
+				err = msgp.WrapError(err, "StartHeight")
+				return
+			}
+		case "EndTime":
+			z.EndTime, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EndTime")
+				return
+			}
+		case "Nominations":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nominations")
+				return
+			}
+			if cap(z.Nominations) >= int(zb0002) {
+				z.Nominations = (z.Nominations)[:zb0002]
+			} else {
+				z.Nominations = make([]*Nomination, zb0002)
+			}
+			for za0001 := range z.Nominations {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Nominations[za0001] = nil
+				} else {
+					if z.Nominations[za0001] == nil {
+						z.Nominations[za0001] = new(Nomination)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Nominations", za0001)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Nominations", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Pubkey":
+							bts, err = msgp.ReadExactBytes(bts, (z.Nominations[za0001].Pubkey)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
+								return
+							}
+						case "NominatedCount":
+							z.Nominations[za0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Epoch) Msgsize() (s int) {
+	s = 1 + 7 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize
+	for za0001 := range z.Nominations {
+		if z.Nominations[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *GovernedParam) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Value":
+			z.Value, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "NextValue":
+			z.NextValue, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "NextValue")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z GovernedParam) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Value"
+	err = en.Append(0x83, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Value)
+	if err != nil {
+		err = msgp.WrapError(err, "Value")
+		return
+	}
+	// write "NextValue"
+	err = en.Append(0xa9, 0x4e, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.NextValue)
+	if err != nil {
+		err = msgp.WrapError(err, "NextValue")
+		return
+	}
+	// write "ActivationHeight"
+	err = en.Append(0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ActivationHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "ActivationHeight")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z GovernedParam) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Value"
+	o = append(o, 0x83, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o = msgp.AppendUint64(o, z.Value)
+	// string "NextValue"
+	o = append(o, 0xa9, 0x4e, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o = msgp.AppendUint64(o, z.NextValue)
+	// string "ActivationHeight"
+	o = append(o, 0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.ActivationHeight)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *GovernedParam) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Value":
+			z.Value, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "NextValue":
+			z.NextValue, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NextValue")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z GovernedParam) Msgsize() (s int) {
+	s = 1 + 6 + msgp.Uint64Size + 10 + msgp.Uint64Size + 17 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *KeyRotation) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "NextPubkey":
+			err = dc.ReadExactBytes((z.NextPubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "NextPubkey")
+				return
+			}
+		case "OldPubkey":
+			err = dc.ReadExactBytes((z.OldPubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "OldPubkey")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *KeyRotation) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "NextPubkey"
+	err = en.Append(0x82, 0xaa, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.NextPubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "NextPubkey")
+		return
+	}
+	// write "OldPubkey"
+	err = en.Append(0xa9, 0x4f, 0x6c, 0x64, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.OldPubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "OldPubkey")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *KeyRotation) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "NextPubkey"
+	o = append(o, 0x82, 0xaa, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.NextPubkey)[:])
+	// string "OldPubkey"
+	o = append(o, 0xa9, 0x4f, 0x6c, 0x64, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.OldPubkey)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *KeyRotation) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "NextPubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.NextPubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "NextPubkey")
+				return
+			}
+		case "OldPubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.OldPubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "OldPubkey")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *KeyRotation) Msgsize() (s int) {
+	s = 1 + 11 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 10 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "NominatedCount":
+			z.NominatedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "NominatedCount")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *Nomination) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Pubkey"
+	err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "NominatedCount"
+	err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.NominatedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "NominatedCount")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Nomination) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Pubkey"
+	o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "NominatedCount"
+	o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.NominatedCount)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "NominatedCount":
+			z.NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NominatedCount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Nomination) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *NominationHeap) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0003 uint32
+	zb0003, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if cap((*z)) >= int(zb0003) {
+		(*z) = (*z)[:zb0003]
+	} else {
+		(*z) = make(NominationHeap, zb0003)
+	}
+	for zb0001 := range *z {
+		if dc.IsNil() {
+			err = dc.ReadNil()
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			(*z)[zb0001] = nil
+		} else {
+			if (*z)[zb0001] == nil {
+				(*z)[zb0001] = new(Nomination)
+			}
+			var field []byte
+			_ = field
+			var zb0004 uint32
+			zb0004, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			for zb0004 > 0 {
+				zb0004--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Pubkey":
+					err = dc.ReadExactBytes(((*z)[zb0001].Pubkey)[:])
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "Pubkey")
+						return
+					}
+				case "NominatedCount":
+					(*z)[zb0001].NominatedCount, err = dc.ReadInt64()
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "NominatedCount")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, zb0001)
+						return
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z NominationHeap) EncodeMsg(en *msgp.Writer) (err error) {
+	err = en.WriteArrayHeader(uint32(len(z)))
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0005 := range z {
+		if z[zb0005] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Pubkey"
+			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z[zb0005].Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, zb0005, "Pubkey")
+				return
+			}
+			// write "NominatedCount"
+			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+			if err != nil {
+				return
+			}
+			err = en.WriteInt64(z[zb0005].NominatedCount)
+			if err != nil {
+				err = msgp.WrapError(err, zb0005, "NominatedCount")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z NominationHeap) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendArrayHeader(o, uint32(len(z)))
+	for zb0005 := range z {
+		if z[zb0005] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Pubkey"
+			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+			o = msgp.AppendBytes(o, (z[zb0005].Pubkey)[:])
+			// string "NominatedCount"
+			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+			o = msgp.AppendInt64(o, z[zb0005].NominatedCount)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NominationHeap) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0003 uint32
+	zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if cap((*z)) >= int(zb0003) {
+		(*z) = (*z)[:zb0003]
+	} else {
+		(*z) = make(NominationHeap, zb0003)
+	}
+	for zb0001 := range *z {
+		if msgp.IsNil(bts) {
+			bts, err = msgp.ReadNilBytes(bts)
+			if err != nil {
+				return
+			}
+			(*z)[zb0001] = nil
+		} else {
+			if (*z)[zb0001] == nil {
+				(*z)[zb0001] = new(Nomination)
+			}
+			var field []byte
+			_ = field
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			for zb0004 > 0 {
+				zb0004--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Pubkey":
+					bts, err = msgp.ReadExactBytes(bts, ((*z)[zb0001].Pubkey)[:])
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "Pubkey")
+						return
+					}
+				case "NominatedCount":
+					(*z)[zb0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "NominatedCount")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, zb0001)
+						return
+					}
+				}
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z NominationHeap) Msgsize() (s int) {
+	s = msgp.ArrayHeaderSize
+	for zb0005 := range z {
+		if z[zb0005] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ValidatorConsensusAddress":
+			err = dc.ReadExactBytes((z.ValidatorConsensusAddress)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorConsensusAddress")
+				return
+			}
+		case "SignatureCount":
+			z.SignatureCount, err = dc.ReadInt32()
+			if err != nil {
+				err = msgp.WrapError(err, "SignatureCount")
+				return
+			}
+		case "HeightOfLastSignature":
+			z.HeightOfLastSignature, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "HeightOfLastSignature")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *OnlineInfo) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "ValidatorConsensusAddress"
+	err = en.Append(0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.ValidatorConsensusAddress)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "ValidatorConsensusAddress")
+		return
+	}
+	// write "SignatureCount"
+	err = en.Append(0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt32(z.SignatureCount)
+	if err != nil {
+		err = msgp.WrapError(err, "SignatureCount")
+		return
+	}
+	// write "HeightOfLastSignature"
+	err = en.Append(0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.HeightOfLastSignature)
+	if err != nil {
+		err = msgp.WrapError(err, "HeightOfLastSignature")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OnlineInfo) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "ValidatorConsensusAddress"
+	o = append(o, 0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.ValidatorConsensusAddress)[:])
+	// string "SignatureCount"
+	o = append(o, 0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt32(o, z.SignatureCount)
+	// string "HeightOfLastSignature"
+	o = append(o, 0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	o = msgp.AppendInt64(o, z.HeightOfLastSignature)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ValidatorConsensusAddress":
+			bts, err = msgp.ReadExactBytes(bts, (z.ValidatorConsensusAddress)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorConsensusAddress")
+				return
+			}
+		case "SignatureCount":
+			z.SignatureCount, bts, err = msgp.ReadInt32Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignatureCount")
+				return
+			}
+		case "HeightOfLastSignature":
+			z.HeightOfLastSignature, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "HeightOfLastSignature")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OnlineInfo) Msgsize() (s int) {
+	s = 1 + 26 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 15 + msgp.Int32Size + 22 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ParamProposal) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ParamId":
+			z.ParamId, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ParamId")
+				return
+			}
+		case "Value":
+			z.Value, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		case "Deadline":
+			z.Deadline, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Deadline")
+				return
+			}
+		case "Proposer":
+			err = dc.ReadExactBytes((z.Proposer)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Proposer")
+				return
+			}
+		case "Votes":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Votes")
+				return
+			}
+			if cap(z.Votes) >= int(zb0002) {
+				z.Votes = (z.Votes)[:zb0002]
+			} else {
+				z.Votes = make([]*ParamVote, zb0002)
+			}
+			for za0002 := range z.Votes {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Votes", za0002)
+						return
+					}
+					z.Votes[za0002] = nil
+				} else {
+					if z.Votes[za0002] == nil {
+						z.Votes[za0002] = new(ParamVote)
+					}
+					var zb0003 uint32
+					zb0003, err = dc.ReadMapHeader()
+					if err != nil {
+						err = msgp.WrapError(err, "Votes", za0002)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, err = dc.ReadMapKeyPtr()
+						if err != nil {
+							err = msgp.WrapError(err, "Votes", za0002)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Voter":
+							err = dc.ReadExactBytes((z.Votes[za0002].Voter)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Voter")
+								return
+							}
+						case "Approve":
+							z.Votes[za0002].Approve, err = dc.ReadBool()
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Approve")
+								return
+							}
+						default:
+							err = dc.Skip()
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ParamProposal) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "ParamId"
+	err = en.Append(0x86, 0xa7, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x49, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ParamId)
+	if err != nil {
+		err = msgp.WrapError(err, "ParamId")
+		return
+	}
+	// write "Value"
+	err = en.Append(0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Value)
+	if err != nil {
+		err = msgp.WrapError(err, "Value")
+		return
+	}
+	// write "ActivationHeight"
+	err = en.Append(0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ActivationHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "ActivationHeight")
+		return
+	}
+	// write "Deadline"
+	err = en.Append(0xa8, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Deadline)
+	if err != nil {
+		err = msgp.WrapError(err, "Deadline")
+		return
+	}
+	// write "Proposer"
+	err = en.Append(0xa8, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Proposer)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Proposer")
+		return
+	}
+	// write "Votes"
+	err = en.Append(0xa5, 0x56, 0x6f, 0x74, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Votes)))
+	if err != nil {
+		err = msgp.WrapError(err, "Votes")
+		return
+	}
+	for za0002 := range z.Votes {
+		if z.Votes[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Voter"
+			err = en.Append(0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z.Votes[za0002].Voter)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Votes", za0002, "Voter")
+				return
+			}
+			// write "Approve"
+			err = en.Append(0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+			if err != nil {
+				return
+			}
+			err = en.WriteBool(z.Votes[za0002].Approve)
+			if err != nil {
+				err = msgp.WrapError(err, "Votes", za0002, "Approve")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ParamProposal) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "ParamId"
+	o = append(o, 0x86, 0xa7, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x49, 0x64)
+	o = msgp.AppendUint64(o, z.ParamId)
+	// string "Value"
+	o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o = msgp.AppendUint64(o, z.Value)
+	// string "ActivationHeight"
+	o = append(o, 0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.ActivationHeight)
+	// string "Deadline"
+	o = append(o, 0xa8, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65)
+	o = msgp.AppendUint64(o, z.Deadline)
+	// string "Proposer"
+	o = append(o, 0xa8, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72)
+	o = msgp.AppendBytes(o, (z.Proposer)[:])
+	// string "Votes"
+	o = append(o, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Votes)))
+	for za0002 := range z.Votes {
+		if z.Votes[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Voter"
+			o = append(o, 0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+			o = msgp.AppendBytes(o, (z.Votes[za0002].Voter)[:])
+			// string "Approve"
+			o = append(o, 0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+			o = msgp.AppendBool(o, z.Votes[za0002].Approve)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ParamProposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ParamId":
+			z.ParamId, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ParamId")
+				return
+			}
+		case "Value":
+			z.Value, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		case "Deadline":
+			z.Deadline, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Deadline")
+				return
+			}
+		case "Proposer":
+			bts, err = msgp.ReadExactBytes(bts, (z.Proposer)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Proposer")
+				return
+			}
+		case "Votes":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Votes")
+				return
+			}
+			if cap(z.Votes) >= int(zb0002) {
+				z.Votes = (z.Votes)[:zb0002]
+			} else {
+				z.Votes = make([]*ParamVote, zb0002)
+			}
+			for za0002 := range z.Votes {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Votes[za0002] = nil
+				} else {
+					if z.Votes[za0002] == nil {
+						z.Votes[za0002] = new(ParamVote)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Votes", za0002)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Votes", za0002)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Voter":
+							bts, err = msgp.ReadExactBytes(bts, (z.Votes[za0002].Voter)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Voter")
+								return
+							}
+						case "Approve":
+							z.Votes[za0002].Approve, bts, err = msgp.ReadBoolBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Approve")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ParamProposal) Msgsize() (s int) {
+	s = 1 + 8 + msgp.Uint64Size + 6 + msgp.Uint64Size + 17 + msgp.Int64Size + 9 + msgp.Uint64Size + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 6 + msgp.ArrayHeaderSize
+	for za0002 := range z.Votes {
+		if z.Votes[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 6 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 8 + msgp.BoolSize
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ParamVote) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Voter":
+			err = dc.ReadExactBytes((z.Voter)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Voter")
+				return
+			}
+		case "Approve":
+			z.Approve, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Approve")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ParamVote) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Voter"
+	err = en.Append(0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Voter)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Voter")
+		return
+	}
+	// write "Approve"
+	err = en.Append(0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Approve)
+	if err != nil {
+		err = msgp.WrapError(err, "Approve")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ParamVote) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Voter"
+	o = append(o, 0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+	o = msgp.AppendBytes(o, (z.Voter)[:])
+	// string "Approve"
+	o = append(o, 0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+	o = msgp.AppendBool(o, z.Approve)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ParamVote) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Voter":
+			bts, err = msgp.ReadExactBytes(bts, (z.Voter)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Voter")
+				return
+			}
+		case "Approve":
+			z.Approve, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Approve")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ParamVote) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 8 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "Delegators":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Delegators")
+					return
+				}
+				z.Delegators = nil
+			} else {
+				if z.Delegators == nil {
+					z.Delegators = new(DelegatorsReward)
+				}
+				var zb0002 uint32
+				zb0002, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Delegators")
+					return
+				}
+				for zb0002 > 0 {
+					zb0002--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Delegators")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Share":
+						z.Delegators.Share, err = dc.ReadUint64()
+						if err != nil {
+							err = msgp.WrapError(err, "Delegators", "Share")
+							return
+						}
+					case "Amount":
+						err = dc.ReadExactBytes((z.Delegators.Amount)[:])
+						if err != nil {
+							err = msgp.WrapError(err, "Delegators", "Amount")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Delegators")
+							return
+						}
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PendingReward) EncodeMsg(en *msgp.Writer) (err error) {
+	// omitempty: check for empty values
+	zb0001Len := uint32(4)
+	var zb0001Mask uint8 /* 4 bits */
+	if z.Delegators == nil {
+		zb0001Len--
+		zb0001Mask |= 0x8
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+	if zb0001Len == 0 {
+		return
+	}
+	// write "Address"
+	err = en.Append(0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "EpochNum"
+	err = en.Append(0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.EpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "EpochNum")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Amount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	if (zb0001Mask & 0x8) == 0 { // if not empty
+		// write "Delegators"
+		err = en.Append(0xaa, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x73)
+		if err != nil {
+			return
+		}
+		if z.Delegators == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Share"
+			err = en.Append(0x82, 0xa5, 0x53, 0x68, 0x61, 0x72, 0x65)
+			if err != nil {
+				return
+			}
+			err = en.WriteUint64(z.Delegators.Share)
+			if err != nil {
+				err = msgp.WrapError(err, "Delegators", "Share")
+				return
+			}
+			// write "Amount"
+			err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z.Delegators.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Delegators", "Amount")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PendingReward) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// omitempty: check for empty values
+	zb0001Len := uint32(4)
+	var zb0001Mask uint8 /* 4 bits */
+	if z.Delegators == nil {
+		zb0001Len--
+		zb0001Mask |= 0x8
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+	if zb0001Len == 0 {
+		return
+	}
+	// string "Address"
+	o = append(o, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "EpochNum"
+	o = append(o, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.EpochNum)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	if (zb0001Mask & 0x8) == 0 { // if not empty
+		// string "Delegators"
+		o = append(o, 0xaa, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x73)
+		if z.Delegators == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Share"
+			o = append(o, 0x82, 0xa5, 0x53, 0x68, 0x61, 0x72, 0x65)
+			o = msgp.AppendUint64(o, z.Delegators.Share)
+			// string "Amount"
+			o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			o = msgp.AppendBytes(o, (z.Delegators.Amount)[:])
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PendingReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "Delegators":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Delegators = nil
+			} else {
+				if z.Delegators == nil {
+					z.Delegators = new(DelegatorsReward)
+				}
+				var zb0002 uint32
+				zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Delegators")
+					return
+				}
+				for zb0002 > 0 {
+					zb0002--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Delegators")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Share":
+						z.Delegators.Share, bts, err = msgp.ReadUint64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Delegators", "Share")
+							return
+						}
+					case "Amount":
+						bts, err = msgp.ReadExactBytes(bts, (z.Delegators.Amount)[:])
+						if err != nil {
+							err = msgp.WrapError(err, "Delegators", "Amount")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Delegators")
+							return
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PendingReward) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 11
+	if z.Delegators == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 6 + msgp.Uint64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *RewardLedger) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "EpochNum":
+			z.EpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Entries":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Entries")
+				return
+			}
+			if cap(z.Entries) >= int(zb0002) {
+				z.Entries = (z.Entries)[:zb0002]
+			} else {
+				z.Entries = make([]*RewardLedgerEntry, zb0002)
+			}
+			for za0001 := range z.Entries {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Entries", za0001)
+						return
+					}
+					z.Entries[za0001] = nil
+				} else {
+					if z.Entries[za0001] == nil {
+						z.Entries[za0001] = new(RewardLedgerEntry)
+					}
+					err = z.Entries[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Entries", za0001)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *RewardLedger) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "EpochNum"
+	err = en.Append(0x82, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.EpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "EpochNum")
+		return
+	}
+	// write "Entries"
+	err = en.Append(0xa7, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Entries)))
+	if err != nil {
+		err = msgp.WrapError(err, "Entries")
+		return
+	}
+	for za0001 := range z.Entries {
+		if z.Entries[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Entries[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Entries", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *RewardLedger) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "EpochNum"
+	o = append(o, 0x82, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.EpochNum)
+	// string "Entries"
+	o = append(o, 0xa7, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Entries)))
+	for za0001 := range z.Entries {
+		if z.Entries[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Entries[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Entries", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *RewardLedger) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "EpochNum":
+			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Entries":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Entries")
+				return
+			}
+			if cap(z.Entries) >= int(zb0002) {
+				z.Entries = (z.Entries)[:zb0002]
+			} else {
+				z.Entries = make([]*RewardLedgerEntry, zb0002)
+			}
+			for za0001 := range z.Entries {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Entries[za0001] = nil
+				} else {
+					if z.Entries[za0001] == nil {
+						z.Entries[za0001] = new(RewardLedgerEntry)
+					}
+					bts, err = z.Entries[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Entries", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *RewardLedger) Msgsize() (s int) {
+	s = 1 + 9 + msgp.Int64Size + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Entries {
+		if z.Entries[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Entries[za0001].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *RewardLedgerEntry) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Validator":
+			err = dc.ReadExactBytes((z.Validator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Validator")
+				return
+			}
+		case "ProposerReward":
+			err = dc.ReadExactBytes((z.ProposerReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ProposerReward")
+				return
+			}
+		case "CollectorReward":
+			err = dc.ReadExactBytes((z.CollectorReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "CollectorReward")
+				return
+			}
+		case "VoterReward":
+			err = dc.ReadExactBytes((z.VoterReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "VoterReward")
+				return
+			}
+		case "MintedReward":
+			err = dc.ReadExactBytes((z.MintedReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "MintedReward")
+				return
+			}
+		case "SlashedAmount":
+			err = dc.ReadExactBytes((z.SlashedAmount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "SlashedAmount")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *RewardLedgerEntry) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "Validator"
+	err = en.Append(0x86, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Validator)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Validator")
+		return
+	}
+	// write "ProposerReward"
+	err = en.Append(0xae, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.ProposerReward)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "ProposerReward")
+		return
+	}
+	// write "CollectorReward"
+	err = en.Append(0xaf, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.CollectorReward)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "CollectorReward")
+		return
+	}
+	// write "VoterReward"
+	err = en.Append(0xab, 0x56, 0x6f, 0x74, 0x65, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.VoterReward)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "VoterReward")
+		return
+	}
+	// write "MintedReward"
+	err = en.Append(0xac, 0x4d, 0x69, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.MintedReward)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "MintedReward")
+		return
+	}
+	// write "SlashedAmount"
+	err = en.Append(0xad, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.SlashedAmount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "SlashedAmount")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *RewardLedgerEntry) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "Validator"
+	o = append(o, 0x86, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Validator)[:])
+	// string "ProposerReward"
+	o = append(o, 0xae, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o = msgp.AppendBytes(o, (z.ProposerReward)[:])
+	// string "CollectorReward"
+	o = append(o, 0xaf, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o = msgp.AppendBytes(o, (z.CollectorReward)[:])
+	// string "VoterReward"
+	o = append(o, 0xab, 0x56, 0x6f, 0x74, 0x65, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o = msgp.AppendBytes(o, (z.VoterReward)[:])
+	// string "MintedReward"
+	o = append(o, 0xac, 0x4d, 0x69, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o = msgp.AppendBytes(o, (z.MintedReward)[:])
+	// string "SlashedAmount"
+	o = append(o, 0xad, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.SlashedAmount)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *RewardLedgerEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Validator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Validator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Validator")
+				return
+			}
+		case "ProposerReward":
+			bts, err = msgp.ReadExactBytes(bts, (z.ProposerReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ProposerReward")
+				return
+			}
+		case "CollectorReward":
+			bts, err = msgp.ReadExactBytes(bts, (z.CollectorReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "CollectorReward")
+				return
+			}
+		case "VoterReward":
+			bts, err = msgp.ReadExactBytes(bts, (z.VoterReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "VoterReward")
+				return
+			}
+		case "MintedReward":
+			bts, err = msgp.ReadExactBytes(bts, (z.MintedReward)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "MintedReward")
+				return
+			}
+		case "SlashedAmount":
+			bts, err = msgp.ReadExactBytes(bts, (z.SlashedAmount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "SlashedAmount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *RewardLedgerEntry) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 15 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 16 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 13 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 14 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SlashEvent) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Height":
+			z.Height, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Validator":
+			err = dc.ReadExactBytes((z.Validator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Validator")
+				return
+			}
+		case "Reason":
+			z.Reason, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Reason")
+				return
+			}
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SlashEvent) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Height"
+	err = en.Append(0x84, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "Validator"
+	err = en.Append(0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Validator)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Validator")
+		return
+	}
+	// write "Reason"
+	err = en.Append(0xa6, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Reason)
+	if err != nil {
+		err = msgp.WrapError(err, "Reason")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Amount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SlashEvent) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Height"
+	o = append(o, 0x84, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.Height)
+	// string "Validator"
+	o = append(o, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Validator)[:])
+	// string "Reason"
+	o = append(o, 0xa6, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e)
+	o = msgp.AppendUint64(o, z.Reason)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SlashEvent) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Height":
+			z.Height, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Validator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Validator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Validator")
+				return
+			}
+		case "Reason":
+			z.Reason, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reason")
+				return
+			}
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SlashEvent) Msgsize() (s int) {
+	s = 1 + 7 + msgp.Int64Size + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.Uint64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "GenesisMainnetBlockHeight":
+			z.GenesisMainnetBlockHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+				return
+			}
+		case "CurrEpochNum":
+			z.CurrEpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "CurrEpochNum")
+				return
+			}
+		case "Validators":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Validators")
+				return
+			}
+			if cap(z.Validators) >= int(zb0002) {
+				z.Validators = (z.Validators)[:zb0002]
+			} else {
+				z.Validators = make([]*Validator, zb0002)
+			}
+			for za0001 := range z.Validators {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+					z.Validators[za0001] = nil
+				} else {
+					if z.Validators[za0001] == nil {
+						z.Validators[za0001] = new(Validator)
+					}
+					err = z.Validators[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+				}
+			}
+		case "ValidatorsUpdate":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorsUpdate")
+				return
+			}
+			if cap(z.ValidatorsUpdate) >= int(zb0003) {
+				z.ValidatorsUpdate = (z.ValidatorsUpdate)[:zb0003]
+			} else {
+				z.ValidatorsUpdate = make([]*Validator, zb0003)
+			}
+			for za0002 := range z.ValidatorsUpdate {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+						return
+					}
+					z.ValidatorsUpdate[za0002] = nil
+				} else {
+					if z.ValidatorsUpdate[za0002] == nil {
+						z.ValidatorsUpdate[za0002] = new(Validator)
+					}
+					err = z.ValidatorsUpdate[za0002].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+						return
+					}
+				}
+			}
+		case "PendingRewards":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewards")
+				return
+			}
+			if cap(z.PendingRewards) >= int(zb0004) {
+				z.PendingRewards = (z.PendingRewards)[:zb0004]
+			} else {
+				z.PendingRewards = make([]*PendingReward, zb0004)
+			}
+			for za0003 := range z.PendingRewards {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewards", za0003)
+						return
+					}
+					z.PendingRewards[za0003] = nil
+				} else {
+					if z.PendingRewards[za0003] == nil {
+						z.PendingRewards[za0003] = new(PendingReward)
+					}
+					err = z.PendingRewards[za0003].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewards", za0003)
+						return
+					}
+				}
+			}
+		case "UnbondingEntries":
+			var zb0005 uint32
+			zb0005, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "UnbondingEntries")
+				return
+			}
+			if cap(z.UnbondingEntries) >= int(zb0005) {
+				z.UnbondingEntries = (z.UnbondingEntries)[:zb0005]
+			} else {
+				z.UnbondingEntries = make([]*UnbondingEntry, zb0005)
+			}
+			for za0004 := range z.UnbondingEntries {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "UnbondingEntries", za0004)
+						return
+					}
+					z.UnbondingEntries[za0004] = nil
+				} else {
+					if z.UnbondingEntries[za0004] == nil {
+						z.UnbondingEntries[za0004] = new(UnbondingEntry)
+					}
+					err = z.UnbondingEntries[za0004].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "UnbondingEntries", za0004)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *StakingInfo) EncodeMsg(en *msgp.Writer) (err error) {
+	// omitempty: check for empty values
+	zb0001Len := uint32(6)
+	var zb0001Mask uint8 /* 6 bits */
+	if z.UnbondingEntries == nil {
+		zb0001Len--
+		zb0001Mask |= 0x20
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+	if zb0001Len == 0 {
+		return
+	}
+	// write "GenesisMainnetBlockHeight"
+	err = en.Append(0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.GenesisMainnetBlockHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+		return
+	}
+	// write "CurrEpochNum"
+	err = en.Append(0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.CurrEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "CurrEpochNum")
+		return
+	}
+	// write "Validators"
+	err = en.Append(0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Validators)))
+	if err != nil {
+		err = msgp.WrapError(err, "Validators")
+		return
+	}
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Validators[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators", za0001)
+				return
+			}
+		}
+	}
+	// write "ValidatorsUpdate"
+	err = en.Append(0xb0, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.ValidatorsUpdate)))
+	if err != nil {
+		err = msgp.WrapError(err, "ValidatorsUpdate")
+		return
+	}
+	for za0002 := range z.ValidatorsUpdate {
+		if z.ValidatorsUpdate[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.ValidatorsUpdate[za0002].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+				return
+			}
+		}
+	}
+	// write "PendingRewards"
+	err = en.Append(0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.PendingRewards)))
+	if err != nil {
+		err = msgp.WrapError(err, "PendingRewards")
+		return
+	}
+	for za0003 := range z.PendingRewards {
+		if z.PendingRewards[za0003] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.PendingRewards[za0003].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewards", za0003)
+				return
+			}
+		}
+	}
+	if (zb0001Mask & 0x20) == 0 { // if not empty
+		// write "UnbondingEntries"
+		err = en.Append(0xb0, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteArrayHeader(uint32(len(z.UnbondingEntries)))
+		if err != nil {
+			err = msgp.WrapError(err, "UnbondingEntries")
+			return
+		}
+		for za0004 := range z.UnbondingEntries {
+			if z.UnbondingEntries[za0004] == nil {
+				err = en.WriteNil()
+				if err != nil {
+					return
+				}
+			} else {
+				err = z.UnbondingEntries[za0004].EncodeMsg(en)
+				if err != nil {
+					err = msgp.WrapError(err, "UnbondingEntries", za0004)
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *StakingInfo) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// omitempty: check for empty values
+	zb0001Len := uint32(6)
+	var zb0001Mask uint8 /* 6 bits */
+	if z.UnbondingEntries == nil {
+		zb0001Len--
+		zb0001Mask |= 0x20
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+	if zb0001Len == 0 {
+		return
+	}
+	// string "GenesisMainnetBlockHeight"
+	o = append(o, 0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.GenesisMainnetBlockHeight)
+	// string "CurrEpochNum"
+	o = append(o, 0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.CurrEpochNum)
+	// string "Validators"
+	o = append(o, 0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Validators)))
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Validators[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators", za0001)
 				return
 			}
-		case "Amount":
-			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+		}
+	}
+	// string "ValidatorsUpdate"
+	o = append(o, 0xb0, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidatorsUpdate)))
+	for za0002 := range z.ValidatorsUpdate {
+		if z.ValidatorsUpdate[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.ValidatorsUpdate[za0002].MarshalMsg(o)
 			if err != nil {
-				err = msgp.WrapError(err, "Amount")
+				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
 				return
 			}
-		default:
-			bts, err = msgp.Skip(bts)
+		}
+	}
+	// string "PendingRewards"
+	o = append(o, 0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.PendingRewards)))
+	for za0003 := range z.PendingRewards {
+		if z.PendingRewards[za0003] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.PendingRewards[za0003].MarshalMsg(o)
 			if err != nil {
-				err = msgp.WrapError(err)
+				err = msgp.WrapError(err, "PendingRewards", za0003)
 				return
 			}
 		}
 	}
-	o = bts
-	return
-}
-
-// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *PendingReward) Msgsize() (s int) {
-	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	if (zb0001Mask & 0x20) == 0 { // if not empty
+		// string "UnbondingEntries"
+		o = append(o, 0xb0, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73)
+		o = msgp.AppendArrayHeader(o, uint32(len(z.UnbondingEntries)))
+		for za0004 := range z.UnbondingEntries {
+			if z.UnbondingEntries[za0004] == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o, err = z.UnbondingEntries[za0004].MarshalMsg(o)
+				if err != nil {
+					err = msgp.WrapError(err, "UnbondingEntries", za0004)
+					return
+				}
+			}
+		}
+	}
 	return
 }
 
-// DecodeMsg implements msgp.Decodable
-func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
-	zb0001, err = dc.ReadMapHeader()
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
 		err = msgp.WrapError(err)
 		return
 	}
 	for zb0001 > 0 {
 		zb0001--
-		field, err = dc.ReadMapKeyPtr()
+		field, bts, err = msgp.ReadMapKeyZC(bts)
 		if err != nil {
 			err = msgp.WrapError(err)
 			return
 		}
 		switch msgp.UnsafeString(field) {
 		case "GenesisMainnetBlockHeight":
-			z.GenesisMainnetBlockHeight, err = dc.ReadInt64()
+			z.GenesisMainnetBlockHeight, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
 				return
 			}
 		case "CurrEpochNum":
-			z.CurrEpochNum, err = dc.ReadInt64()
+			z.CurrEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "CurrEpochNum")
 				return
 			}
 		case "Validators":
 			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Validators")
 				return
@@ -1023,10 +3999,9 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 				z.Validators = make([]*Validator, zb0002)
 			}
 			for za0001 := range z.Validators {
-				if dc.IsNil() {
-					err = dc.ReadNil()
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
 					if err != nil {
-						err = msgp.WrapError(err, "Validators", za0001)
 						return
 					}
 					z.Validators[za0001] = nil
@@ -1034,7 +4009,7 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 					if z.Validators[za0001] == nil {
 						z.Validators[za0001] = new(Validator)
 					}
-					err = z.Validators[za0001].DecodeMsg(dc)
+					bts, err = z.Validators[za0001].UnmarshalMsg(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Validators", za0001)
 						return
@@ -1043,7 +4018,7 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 			}
 		case "ValidatorsUpdate":
 			var zb0003 uint32
-			zb0003, err = dc.ReadArrayHeader()
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "ValidatorsUpdate")
 				return
@@ -1054,10 +4029,9 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 				z.ValidatorsUpdate = make([]*Validator, zb0003)
 			}
 			for za0002 := range z.ValidatorsUpdate {
-				if dc.IsNil() {
-					err = dc.ReadNil()
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
 					if err != nil {
-						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
 						return
 					}
 					z.ValidatorsUpdate[za0002] = nil
@@ -1065,7 +4039,7 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 					if z.ValidatorsUpdate[za0002] == nil {
 						z.ValidatorsUpdate[za0002] = new(Validator)
 					}
-					err = z.ValidatorsUpdate[za0002].DecodeMsg(dc)
+					bts, err = z.ValidatorsUpdate[za0002].UnmarshalMsg(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
 						return
@@ -1074,7 +4048,7 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 			}
 		case "PendingRewards":
 			var zb0004 uint32
-			zb0004, err = dc.ReadArrayHeader()
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "PendingRewards")
 				return
@@ -1085,10 +4059,9 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 				z.PendingRewards = make([]*PendingReward, zb0004)
 			}
 			for za0003 := range z.PendingRewards {
-				if dc.IsNil() {
-					err = dc.ReadNil()
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
 					if err != nil {
-						err = msgp.WrapError(err, "PendingRewards", za0003)
 						return
 					}
 					z.PendingRewards[za0003] = nil
@@ -1096,13 +4069,146 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 					if z.PendingRewards[za0003] == nil {
 						z.PendingRewards[za0003] = new(PendingReward)
 					}
-					err = z.PendingRewards[za0003].DecodeMsg(dc)
+					bts, err = z.PendingRewards[za0003].UnmarshalMsg(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "PendingRewards", za0003)
 						return
 					}
 				}
 			}
+		case "UnbondingEntries":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UnbondingEntries")
+				return
+			}
+			if cap(z.UnbondingEntries) >= int(zb0005) {
+				z.UnbondingEntries = (z.UnbondingEntries)[:zb0005]
+			} else {
+				z.UnbondingEntries = make([]*UnbondingEntry, zb0005)
+			}
+			for za0004 := range z.UnbondingEntries {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.UnbondingEntries[za0004] = nil
+				} else {
+					if z.UnbondingEntries[za0004] == nil {
+						z.UnbondingEntries[za0004] = new(UnbondingEntry)
+					}
+					bts, err = z.UnbondingEntries[za0004].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "UnbondingEntries", za0004)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *StakingInfo) Msgsize() (s int) {
+	s = 1 + 26 + msgp.Int64Size + 13 + msgp.Int64Size + 11 + msgp.ArrayHeaderSize
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Validators[za0001].Msgsize()
+		}
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0002 := range z.ValidatorsUpdate {
+		if z.ValidatorsUpdate[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.ValidatorsUpdate[za0002].Msgsize()
+		}
+	}
+	s += 15 + msgp.ArrayHeaderSize
+	for za0003 := range z.PendingRewards {
+		if z.PendingRewards[za0003] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.PendingRewards[za0003].Msgsize()
+		}
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0004 := range z.UnbondingEntries {
+		if z.UnbondingEntries[za0004] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.UnbondingEntries[za0004].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *UnbondingEntry) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Validator":
+			err = dc.ReadExactBytes((z.Validator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Validator")
+				return
+			}
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "Receiver":
+			err = dc.ReadExactBytes((z.Receiver)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Receiver")
+				return
+			}
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "MatureHeight":
+			z.MatureHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "MatureHeight")
+				return
+			}
+		case "Delegated":
+			z.Delegated, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Delegated")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -1115,160 +4221,127 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *StakingInfo) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 5
-	// write "GenesisMainnetBlockHeight"
-	err = en.Append(0x85, 0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+func (z *UnbondingEntry) EncodeMsg(en *msgp.Writer) (err error) {
+	// omitempty: check for empty values
+	zb0001Len := uint32(6)
+	var zb0001Mask uint8 /* 6 bits */
+	if z.Delegated == false {
+		zb0001Len--
+		zb0001Mask |= 0x20
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.GenesisMainnetBlockHeight)
-	if err != nil {
-		err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+	if zb0001Len == 0 {
 		return
 	}
-	// write "CurrEpochNum"
-	err = en.Append(0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	// write "Validator"
+	err = en.Append(0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.CurrEpochNum)
+	err = en.WriteBytes((z.Validator)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "CurrEpochNum")
+		err = msgp.WrapError(err, "Validator")
 		return
 	}
-	// write "Validators"
-	err = en.Append(0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	// write "Pubkey"
+	err = en.Append(0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Validators)))
+	err = en.WriteBytes((z.Pubkey)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "Validators")
+		err = msgp.WrapError(err, "Pubkey")
 		return
 	}
-	for za0001 := range z.Validators {
-		if z.Validators[za0001] == nil {
-			err = en.WriteNil()
-			if err != nil {
-				return
-			}
-		} else {
-			err = z.Validators[za0001].EncodeMsg(en)
-			if err != nil {
-				err = msgp.WrapError(err, "Validators", za0001)
-				return
-			}
-		}
-	}
-	// write "ValidatorsUpdate"
-	err = en.Append(0xb0, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
+	// write "Receiver"
+	err = en.Append(0xa8, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.ValidatorsUpdate)))
+	err = en.WriteBytes((z.Receiver)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "ValidatorsUpdate")
+		err = msgp.WrapError(err, "Receiver")
 		return
 	}
-	for za0002 := range z.ValidatorsUpdate {
-		if z.ValidatorsUpdate[za0002] == nil {
-			err = en.WriteNil()
-			if err != nil {
-				return
-			}
-		} else {
-			err = z.ValidatorsUpdate[za0002].EncodeMsg(en)
-			if err != nil {
-				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
-				return
-			}
-		}
-	}
-	// write "PendingRewards"
-	err = en.Append(0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.PendingRewards)))
+	err = en.WriteBytes((z.Amount)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "PendingRewards")
+		err = msgp.WrapError(err, "Amount")
 		return
 	}
-	for za0003 := range z.PendingRewards {
-		if z.PendingRewards[za0003] == nil {
-			err = en.WriteNil()
-			if err != nil {
-				return
-			}
-		} else {
-			err = z.PendingRewards[za0003].EncodeMsg(en)
-			if err != nil {
-				err = msgp.WrapError(err, "PendingRewards", za0003)
-				return
-			}
+	// write "MatureHeight"
+	err = en.Append(0xac, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.MatureHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "MatureHeight")
+		return
+	}
+	if (zb0001Mask & 0x20) == 0 { // if not empty
+		// write "Delegated"
+		err = en.Append(0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteBool(z.Delegated)
+		if err != nil {
+			err = msgp.WrapError(err, "Delegated")
+			return
 		}
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *StakingInfo) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *UnbondingEntry) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 5
-	// string "GenesisMainnetBlockHeight"
-	o = append(o, 0x85, 0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendInt64(o, z.GenesisMainnetBlockHeight)
-	// string "CurrEpochNum"
-	o = append(o, 0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
-	o = msgp.AppendInt64(o, z.CurrEpochNum)
-	// string "Validators"
-	o = append(o, 0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Validators)))
-	for za0001 := range z.Validators {
-		if z.Validators[za0001] == nil {
-			o = msgp.AppendNil(o)
-		} else {
-			o, err = z.Validators[za0001].MarshalMsg(o)
-			if err != nil {
-				err = msgp.WrapError(err, "Validators", za0001)
-				return
-			}
-		}
-	}
-	// string "ValidatorsUpdate"
-	o = append(o, 0xb0, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidatorsUpdate)))
-	for za0002 := range z.ValidatorsUpdate {
-		if z.ValidatorsUpdate[za0002] == nil {
-			o = msgp.AppendNil(o)
-		} else {
-			o, err = z.ValidatorsUpdate[za0002].MarshalMsg(o)
-			if err != nil {
-				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
-				return
-			}
-		}
+	// omitempty: check for empty values
+	zb0001Len := uint32(6)
+	var zb0001Mask uint8 /* 6 bits */
+	if z.Delegated == false {
+		zb0001Len--
+		zb0001Mask |= 0x20
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+	if zb0001Len == 0 {
+		return
 	}
-	// string "PendingRewards"
-	o = append(o, 0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.PendingRewards)))
-	for za0003 := range z.PendingRewards {
-		if z.PendingRewards[za0003] == nil {
-			o = msgp.AppendNil(o)
-		} else {
-			o, err = z.PendingRewards[za0003].MarshalMsg(o)
-			if err != nil {
-				err = msgp.WrapError(err, "PendingRewards", za0003)
-				return
-			}
-		}
+	// string "Validator"
+	o = append(o, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Validator)[:])
+	// string "Pubkey"
+	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "Receiver"
+	o = append(o, 0xa8, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72)
+	o = msgp.AppendBytes(o, (z.Receiver)[:])
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	// string "MatureHeight"
+	o = append(o, 0xac, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.MatureHeight)
+	if (zb0001Mask & 0x20) == 0 { // if not empty
+		// string "Delegated"
+		o = append(o, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
+		o = msgp.AppendBool(o, z.Delegated)
 	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *UnbondingEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1285,107 +4358,41 @@ func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "GenesisMainnetBlockHeight":
-			z.GenesisMainnetBlockHeight, bts, err = msgp.ReadInt64Bytes(bts)
+		case "Validator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Validator)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+				err = msgp.WrapError(err, "Validator")
 				return
 			}
-		case "CurrEpochNum":
-			z.CurrEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "CurrEpochNum")
+				err = msgp.WrapError(err, "Pubkey")
 				return
 			}
-		case "Validators":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "Receiver":
+			bts, err = msgp.ReadExactBytes(bts, (z.Receiver)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Validators")
+				err = msgp.WrapError(err, "Receiver")
 				return
 			}
-			if cap(z.Validators) >= int(zb0002) {
-				z.Validators = (z.Validators)[:zb0002]
-			} else {
-				z.Validators = make([]*Validator, zb0002)
-			}
-			for za0001 := range z.Validators {
-				if msgp.IsNil(bts) {
-					bts, err = msgp.ReadNilBytes(bts)
-					if err != nil {
-						return
-					}
-					z.Validators[za0001] = nil
-				} else {
-					if z.Validators[za0001] == nil {
-						z.Validators[za0001] = new(Validator)
-					}
-					bts, err = z.Validators[za0001].UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Validators", za0001)
-						return
-					}
-				}
-			}
-		case "ValidatorsUpdate":
-			var zb0003 uint32
-			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorsUpdate")
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
-			if cap(z.ValidatorsUpdate) >= int(zb0003) {
-				z.ValidatorsUpdate = (z.ValidatorsUpdate)[:zb0003]
-			} else {
-				z.ValidatorsUpdate = make([]*Validator, zb0003)
-			}
-			for za0002 := range z.ValidatorsUpdate {
-				if msgp.IsNil(bts) {
-					bts, err = msgp.ReadNilBytes(bts)
-					if err != nil {
-						return
-					}
-					z.ValidatorsUpdate[za0002] = nil
-				} else {
-					if z.ValidatorsUpdate[za0002] == nil {
-						z.ValidatorsUpdate[za0002] = new(Validator)
-					}
-					bts, err = z.ValidatorsUpdate[za0002].UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
-						return
-					}
-				}
-			}
-		case "PendingRewards":
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "MatureHeight":
+			z.MatureHeight, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "PendingRewards")
+				err = msgp.WrapError(err, "MatureHeight")
 				return
 			}
-			if cap(z.PendingRewards) >= int(zb0004) {
-				z.PendingRewards = (z.PendingRewards)[:zb0004]
-			} else {
-				z.PendingRewards = make([]*PendingReward, zb0004)
-			}
-			for za0003 := range z.PendingRewards {
-				if msgp.IsNil(bts) {
-					bts, err = msgp.ReadNilBytes(bts)
-					if err != nil {
-						return
-					}
-					z.PendingRewards[za0003] = nil
-				} else {
-					if z.PendingRewards[za0003] == nil {
-						z.PendingRewards[za0003] = new(PendingReward)
-					}
-					bts, err = z.PendingRewards[za0003].UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "PendingRewards", za0003)
-						return
-					}
-				}
+		case "Delegated":
+			z.Delegated, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Delegated")
+				return
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -1400,31 +4407,8 @@ func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *StakingInfo) Msgsize() (s int) {
-	s = 1 + 26 + msgp.Int64Size + 13 + msgp.Int64Size + 11 + msgp.ArrayHeaderSize
-	for za0001 := range z.Validators {
-		if z.Validators[za0001] == nil {
-			s += msgp.NilSize
-		} else {
-			s += z.Validators[za0001].Msgsize()
-		}
-	}
-	s += 17 + msgp.ArrayHeaderSize
-	for za0002 := range z.ValidatorsUpdate {
-		if z.ValidatorsUpdate[za0002] == nil {
-			s += msgp.NilSize
-		} else {
-			s += z.ValidatorsUpdate[za0002].Msgsize()
-		}
-	}
-	s += 15 + msgp.ArrayHeaderSize
-	for za0003 := range z.PendingRewards {
-		if z.PendingRewards[za0003] == nil {
-			s += msgp.NilSize
-		} else {
-			s += z.PendingRewards[za0003].Msgsize()
-		}
-	}
+func (z *UnbondingEntry) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 13 + msgp.Int64Size + 10 + msgp.BoolSize
 	return
 }
 
@@ -1488,6 +4472,36 @@ func (z *Validator) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "IsRetiring")
 				return
 			}
+		case "IsJailed":
+			z.IsJailed, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "IsJailed")
+				return
+			}
+		case "UnjailHeight":
+			z.UnjailHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "UnjailHeight")
+				return
+			}
+		case "KeyRotation":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "KeyRotation")
+					return
+				}
+				z.KeyRotation = nil
+			} else {
+				if z.KeyRotation == nil {
+					z.KeyRotation = new(KeyRotation)
+				}
+				err = z.KeyRotation.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "KeyRotation")
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -1501,9 +4515,31 @@ func (z *Validator) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *Validator) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+	// omitempty: check for empty values
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
+	if z.IsJailed == false {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
+	if z.UnjailHeight == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x100
+	}
+	if z.KeyRotation == nil {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+	if zb0001Len == 0 {
+		return
+	}
 	// write "Address"
-	err = en.Append(0x87, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	err = en.Append(0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
 	if err != nil {
 		return
 	}
@@ -1572,15 +4608,77 @@ func (z *Validator) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "IsRetiring")
 		return
 	}
+	if (zb0001Mask & 0x80) == 0 { // if not empty
+		// write "IsJailed"
+		err = en.Append(0xa8, 0x49, 0x73, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteBool(z.IsJailed)
+		if err != nil {
+			err = msgp.WrapError(err, "IsJailed")
+			return
+		}
+	}
+	if (zb0001Mask & 0x100) == 0 { // if not empty
+		// write "UnjailHeight"
+		err = en.Append(0xac, 0x55, 0x6e, 0x6a, 0x61, 0x69, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt64(z.UnjailHeight)
+		if err != nil {
+			err = msgp.WrapError(err, "UnjailHeight")
+			return
+		}
+	}
+	if (zb0001Mask & 0x200) == 0 { // if not empty
+		// write "KeyRotation"
+		err = en.Append(0xab, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+		if err != nil {
+			return
+		}
+		if z.KeyRotation == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.KeyRotation.EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "KeyRotation")
+				return
+			}
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *Validator) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// omitempty: check for empty values
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
+	if z.IsJailed == false {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
+	if z.UnjailHeight == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x100
+	}
+	if z.KeyRotation == nil {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+	if zb0001Len == 0 {
+		return
+	}
 	// string "Address"
-	o = append(o, 0x87, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = append(o, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
 	o = msgp.AppendBytes(o, (z.Address)[:])
 	// string "Pubkey"
 	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
@@ -1600,6 +4698,29 @@ func (z *Validator) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "IsRetiring"
 	o = append(o, 0xaa, 0x49, 0x73, 0x52, 0x65, 0x74, 0x69, 0x72, 0x69, 0x6e, 0x67)
 	o = msgp.AppendBool(o, z.IsRetiring)
+	if (zb0001Mask & 0x80) == 0 { // if not empty
+		// string "IsJailed"
+		o = append(o, 0xa8, 0x49, 0x73, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x64)
+		o = msgp.AppendBool(o, z.IsJailed)
+	}
+	if (zb0001Mask & 0x100) == 0 { // if not empty
+		// string "UnjailHeight"
+		o = append(o, 0xac, 0x55, 0x6e, 0x6a, 0x61, 0x69, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+		o = msgp.AppendInt64(o, z.UnjailHeight)
+	}
+	if (zb0001Mask & 0x200) == 0 { // if not empty
+		// string "KeyRotation"
+		o = append(o, 0xab, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+		if z.KeyRotation == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.KeyRotation.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "KeyRotation")
+				return
+			}
+		}
+	}
 	return
 }
 
@@ -1663,6 +4784,35 @@ func (z *Validator) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "IsRetiring")
 				return
 			}
+		case "IsJailed":
+			z.IsJailed, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IsJailed")
+				return
+			}
+		case "UnjailHeight":
+			z.UnjailHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UnjailHeight")
+				return
+			}
+		case "KeyRotation":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.KeyRotation = nil
+			} else {
+				if z.KeyRotation == nil {
+					z.KeyRotation = new(KeyRotation)
+				}
+				bts, err = z.KeyRotation.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "KeyRotation")
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1677,7 +4827,12 @@ func (z *Validator) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *Validator) Msgsize() (s int) {
-	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 12 + msgp.Int64Size + 13 + msgp.StringPrefixSize + len(z.Introduction) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 11 + msgp.BoolSize
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 12 + msgp.Int64Size + 13 + msgp.StringPrefixSize + len(z.Introduction) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 11 + msgp.BoolSize + 9 + msgp.BoolSize + 13 + msgp.Int64Size + 12
+	if z.KeyRotation == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.KeyRotation.Msgsize()
+	}
 	return
 }
 
@@ -0,0 +1,77 @@
+package staking
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// Since UnbondingForkHeight, the staked coins of a removed validator are not returned at once. They are put
+// into an unbonding queue in StakingInfo for the governed ParamUnbondingBlocks (UnbondingBlocks by default), during
+// which the misbehaviour discovered later can still be slashed from them. After that, the validator calls
+// withdrawUnbonded() to send them to RewardTo. The coins undelegated from a validator are unbonded in the same
+// way, and so are the ones delegated to a removed validator. Either the validator or the delegator can call
+// withdrawUnbonded() to send them to the delegator. The delegated coins are not slashed, only the validators' are.
+
+func isUnbondingFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.UnbondingForkHeight
+}
+
+// send the mature unbonding coins, whose validator or receiver is the sender, to their receivers
+func withdrawUnbonded(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	matured := info.RemoveMatureUnbondingEntries(tx.From, ctx.Height)
+	if len(matured) == 0 {
+		outData = []byte(NoMatureUnbonding.Error())
+		return
+	}
+	for _, entry := range matured {
+		coins := uint256.NewInt(0).SetBytes32(entry.Amount[:])
+		transferFromStakingAcc(ctx, entry.Receiver, coins)
+		logs = append(logs, buildWithdrawUnbondedEvmLog(entry.Validator, entry.Receiver, coins))
+	}
+	SaveStakingInfo(ctx, info)
+	status = StatusSuccess
+	return
+}
+
+// =========================================================================================
+// Following functions are called by the engine
+
+// put the staked coins of a removed validator into the unbonding queue, they are still kept in stakingAcc
+func unbondStakedCoins(ctx *mevmtypes.Context, info *types.StakingInfo, val *types.Validator) (logs []mevmtypes.EvmLog) {
+	coins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+	if coins.IsZero() {
+		return
+	}
+	entry := info.AddUnbondingEntry(val, ctx.Height+getUnbondingBlocks(ctx))
+	return append(logs, buildUnbondEvmLog(entry.Validator, entry.Receiver, coins, entry.MatureHeight))
+}
+
+// slashUnbondingAndLog slashes the unbonding coins of a removed validator, whose consensus address is 'consAddr',
+// and appends a Slash event to logs
+func slashUnbondingAndLog(ctx *mevmtypes.Context, info *types.StakingInfo, consAddr [20]byte, amount *uint256.Int,
+	reason uint64, logs []mevmtypes.EvmLog) []mevmtypes.EvmLog {
+	var entryConsAddr [20]byte
+	for _, entry := range info.UnbondingEntries {
+		copy(entryConsAddr[:], ed25519.PubKey(entry.Pubkey[:]).Address().Bytes())
+		if entryConsAddr != consAddr {
+			continue
+		}
+		totalSlashed := info.SlashUnbondingEntries(entry.Pubkey, amount)
+		transferSlashedCoins(ctx, totalSlashed)
+		return append(logs, buildSlashEvmLog(entry.Validator, reason, totalSlashed))
+	}
+	return logs
+}
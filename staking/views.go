@@ -0,0 +1,177 @@
+package staking
+
+import (
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// Since StakingViewForkHeight, the staking states can be read by read-only functions. EOAs (and eth_call)
+// call them through Execute, which reads the latest states. Other contracts call them through Run, which
+// reads a snapshot taken by the engine before the transactions in a block are executed, such that the
+// results do not depend on the order of parallel execution.
+
+var (
+	StakingViewBaseGas    uint64 = 5000
+	StakingViewGasPerByte uint64 = 25 // charged for each byte of the returned data
+
+	// the returned data of a precompiled contract are truncated to this size by the EVM
+	maxPrecompiledOutputSize = 2048
+)
+
+// the snapshot of staking states read by Run, it is nil before StakingViewForkHeight
+var readonlyStakingView *stakingView
+
+func isStakingViewFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.StakingViewForkHeight
+}
+
+func isViewSelector(selector [4]byte) bool {
+	switch selector {
+	case SelectorGetValidator, SelectorGetActiveValidators, SelectorGetPendingRewards,
+		SelectorGetCurrEpochNum, SelectorGetMinGasPrice:
+		return true
+	}
+	return false
+}
+
+type stakingView struct {
+	info             types.StakingInfo
+	activeValidators []*types.Validator
+	minGasPrice      uint64
+}
+
+func loadStakingView(ctx *mevmtypes.Context) *stakingView {
+	info := LoadStakingInfo(ctx)
+	return &stakingView{
+		info:             info,
+		activeValidators: GetActiveValidators(ctx, info.Validators),
+		minGasPrice:      LoadMinGasPrice(ctx, false),
+	}
+}
+
+// UpdateReadonlyStakingView takes a snapshot of the staking states for Run. It is called by the engine
+// after all the staking states are updated at the end of a block.
+func UpdateReadonlyStakingView(ctx *mevmtypes.Context) {
+	if isStakingViewFork(ctx) {
+		readonlyStakingView = loadStakingView(ctx)
+	} else {
+		readonlyStakingView = nil
+	}
+}
+
+// gas needed by a view function, which is proportional to the size of the returned data
+func viewGas(outData []byte) uint64 {
+	return StakingViewBaseGas + uint64(len(outData))*StakingViewGasPerByte
+}
+
+// call a view function with 'input' (selector and arguments) and return the ABI-encoded result
+func (v *stakingView) call(input []byte) ([]byte, error) {
+	var selector [4]byte
+	copy(selector[:], input[:4])
+	callData := input[4:]
+	switch selector {
+	case SelectorGetValidator:
+		//getValidator(address validator) returns (address rewardTo, bytes32 pubkey, uint stakedCoins,
+		//  uint votingPower, bool isRetiring, bool isJailed)
+		if len(callData) != 32 {
+			return nil, InvalidCallData
+		}
+		var addr [20]byte
+		copy(addr[:], callData[12:])
+		val := v.info.GetValidatorByAddr(addr)
+		if val == nil {
+			return nil, NoSuchValidator
+		}
+		outData := make([]byte, 32*6)
+		copy(outData[12:32], val.RewardTo[:])
+		copy(outData[32:64], val.Pubkey[:])
+		copy(outData[64:96], val.StakedCoins[:])
+		uint256.NewInt(uint64(val.VotingPower)).WriteToSlice(outData[96:128])
+		boolToUint256(val.IsRetiring).WriteToSlice(outData[128:160])
+		boolToUint256(val.IsJailed).WriteToSlice(outData[160:192])
+		return outData, nil
+	case SelectorGetActiveValidators:
+		//getActiveValidators() returns (address[] validators)
+		outData := make([]byte, 64+32*len(v.activeValidators))
+		uint256.NewInt(32).WriteToSlice(outData[:32]) // offset
+		uint256.NewInt(uint64(len(v.activeValidators))).WriteToSlice(outData[32:64])
+		for i, val := range v.activeValidators {
+			copy(outData[64+32*i+12:64+32*(i+1)], val.Address[:])
+		}
+		return outData, nil
+	case SelectorGetPendingRewards:
+		//getPendingRewards(address validator) returns (uint amount)
+		if len(callData) != 32 {
+			return nil, InvalidCallData
+		}
+		var addr [20]byte
+		copy(addr[:], callData[12:])
+		total := uint256.NewInt(0)
+		for _, rwd := range v.info.PendingRewards {
+			if rwd.Address == addr {
+				total.Add(total, uint256.NewInt(0).SetBytes32(rwd.Amount[:]))
+			}
+		}
+		outData := total.Bytes32()
+		return outData[:], nil
+	case SelectorGetCurrEpochNum:
+		//getCurrEpochNum() returns (uint)
+		outData := uint256.NewInt(uint64(v.info.CurrEpochNum)).Bytes32()
+		return outData[:], nil
+	case SelectorGetMinGasPrice:
+		//getMinGasPrice() returns (uint)
+		outData := uint256.NewInt(v.minGasPrice).Bytes32()
+		return outData[:], nil
+	}
+	return nil, InvalidSelector
+}
+
+// call a view function from an EOA, the gas is charged according to the size of the returned data
+func callView(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = StakingViewBaseGas
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	outData, err := loadStakingView(ctx).call(tx.Data)
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	gasUsed = viewGas(outData)
+	if tx.Gas < gasUsed {
+		outData = []byte(ErrOutOfGas.Error())
+		gasUsed = tx.Gas
+		return
+	}
+	status = StatusSuccess
+	return
+}
+
+// whether 'input' calls a view function through Run, which is allowed since StakingViewForkHeight
+func isReadonlyViewCall(input []byte) bool {
+	if readonlyStakingView == nil || len(input) < 4 {
+		return false
+	}
+	var selector [4]byte
+	copy(selector[:], input[:4])
+	return isViewSelector(selector)
+}
+
+// the required gas and the result of a view function called by other contracts through Run
+func runView(input []byte) (gas uint64, outData []byte, err error) {
+	outData, err = readonlyStakingView.call(input)
+	if err != nil {
+		return StakingViewBaseGas, nil, err
+	}
+	if len(outData) > maxPrecompiledOutputSize {
+		return StakingViewBaseGas, nil, InvalidArgument // too large to be returned without truncation
+	}
+	return viewGas(outData), outData, nil
+}
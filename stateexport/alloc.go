@@ -0,0 +1,149 @@
+package stateexport
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	gethcore "github.com/ethereum/go-ethereum/core"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+)
+
+// NewStateEntry decodes a KV pair of the world state, which is written by moeingevm, into a StateEntry.
+// The KV pairs it does not recognize are kept as KindRaw entries.
+func NewStateEntry(key, value []byte) *StateEntry {
+	switch {
+	case len(key) == 2 && key[0] == mevmtypes.CREATION_COUNTER_KEY && len(value) == 8:
+		return &StateEntry{Kind: KindCreationCounter, Key: key[1:], Value: value}
+	case len(key) == 21 && key[0] == mevmtypes.ACCOUNT_KEY && len(value) == 49:
+		e := &StateEntry{Kind: KindAccount}
+		copy(e.Address[:], key[1:])
+		info := mevmtypes.NewAccountInfo(value)
+		copy(e.Balance[:], info.BalanceSlice())
+		e.Nonce = info.Nonce()
+		e.Sequence = info.Sequence()
+		return e
+	case len(key) == 21 && key[0] == mevmtypes.BYTECODE_KEY && len(value) > 33:
+		e := &StateEntry{Kind: KindBytecode, Value: mevmtypes.NewBytecodeInfo(value).BytecodeSlice()}
+		copy(e.Address[:], key[1:])
+		return e
+	case len(key) == 41 && key[0] == mevmtypes.VALUE_KEY:
+		return &StateEntry{
+			Kind:     KindStorage,
+			Sequence: binary.BigEndian.Uint64(key[1:9]),
+			Key:      key[9:],
+			Value:    value,
+		}
+	}
+	return &StateEntry{Kind: KindRaw, Key: key, Value: value}
+}
+
+// AllocStats counts what ToGenesisAlloc has converted and skipped
+type AllocStats struct {
+	Height          int64
+	Accounts        int
+	Contracts       int
+	Slots           int
+	SkippedAccounts int // the system and precompiled contracts
+	SkippedSlots    int // the slots which are not 32 bytes long or not owned by a contract with bytecode
+}
+
+// The system and precompiled contracts (staking, SEP206, ...) live at the addresses below 0x10000, the
+// new chain creates them by itself
+func isSystemAddress(addr [20]byte) bool {
+	for _, b := range addr[:18] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ToGenesisAlloc reads an export and converts its accounts, bytecodes and storage slots into a
+// GenesisAlloc. The new chain assigns new sequences to the contracts, so the slots are regrouped by
+// the owner's address. GenesisAlloc can only hold 32-byte slots, so the slots of the system
+// contracts (staking, cc, ...), which use variable-length values and have no bytecode, are skipped,
+// as well as the system contracts' accounts and the staking and cc info. The new chain builds its own
+// staking info from the genesis validators.
+func ToGenesisAlloc(r Reader) (gethcore.GenesisAlloc, *AllocStats, error) {
+	stats := &AllocStats{}
+	alloc := make(gethcore.GenesisAlloc)
+	seqToAddr := make(map[uint64]gethcmn.Address)
+	slots := make(map[uint64]map[gethcmn.Hash]gethcmn.Hash)
+	gotHeader := false
+	for {
+		e, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+		if !gotHeader {
+			if e.Kind != KindHeader {
+				return nil, nil, errors.New("the export does not start with a header")
+			}
+			gotHeader = true
+			stats.Height = e.Height
+			continue
+		}
+		if (e.Kind == KindAccount || e.Kind == KindBytecode) && isSystemAddress(e.Address) {
+			if e.Kind == KindAccount {
+				stats.SkippedAccounts++
+			}
+			continue
+		}
+		switch e.Kind {
+		case KindAccount:
+			acc := alloc[e.Address]
+			acc.Balance = new(big.Int).SetBytes(e.Balance[:])
+			acc.Nonce = e.Nonce
+			alloc[e.Address] = acc
+			seqToAddr[e.Sequence] = e.Address
+		case KindBytecode:
+			acc := alloc[e.Address]
+			acc.Code = e.Value
+			alloc[e.Address] = acc
+		case KindStorage:
+			if len(e.Key) != 32 {
+				return nil, nil, fmt.Errorf("invalid storage key %x", e.Key)
+			}
+			if len(e.Value) != 32 {
+				stats.SkippedSlots++
+				continue
+			}
+			if slots[e.Sequence] == nil {
+				slots[e.Sequence] = make(map[gethcmn.Hash]gethcmn.Hash)
+			}
+			slots[e.Sequence][gethcmn.BytesToHash(e.Key)] = gethcmn.BytesToHash(e.Value)
+		}
+	}
+	if !gotHeader {
+		return nil, nil, errors.New("empty export")
+	}
+	for seq, storage := range slots {
+		addr, ok := seqToAddr[seq]
+		if !ok || len(alloc[addr].Code) == 0 {
+			stats.SkippedSlots += len(storage)
+			continue
+		}
+		acc := alloc[addr]
+		acc.Storage = storage
+		alloc[addr] = acc
+		stats.Slots += len(storage)
+	}
+	for addr, acc := range alloc {
+		if acc.Balance == nil { // a bytecode without account
+			acc.Balance = new(big.Int)
+			alloc[addr] = acc
+		}
+		if len(acc.Code) != 0 {
+			stats.Contracts++
+		}
+	}
+	stats.Accounts = len(alloc)
+	return alloc, stats, nil
+}
@@ -0,0 +1,119 @@
+package stateexport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+)
+
+var (
+	eoa      = gethcmn.HexToAddress("0x1111111111111111111111111111111111111111")
+	contract = gethcmn.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+func testEntries() []*StateEntry {
+	eoaInfo := mevmtypes.ZeroAccountInfo()
+	eoaInfo.UpdateBalance(uint256.NewInt(1000))
+	eoaInfo.UpdateNonce(3)
+	eoaInfo.UpdateSequence(math.MaxUint64)
+	contractInfo := mevmtypes.ZeroAccountInfo()
+	contractInfo.UpdateBalance(uint256.NewInt(5))
+	contractInfo.UpdateNonce(1)
+	contractInfo.UpdateSequence(0x0122)
+	code := make([]byte, 33+3)
+	copy(code[33:], []byte{0x60, 0x00, 0xf3})
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, 1)
+	slot := bytes.Repeat([]byte{0x01}, 32)
+	value := bytes.Repeat([]byte{0x02}, 32)
+
+	return []*StateEntry{
+		{Kind: KindHeader, Height: 100},
+		NewStateEntry(mevmtypes.GetValueKey(0x0122, string(slot)), value),
+		NewStateEntry(mevmtypes.GetAccountKey(eoa), eoaInfo.Bytes()),
+		NewStateEntry(mevmtypes.GetAccountKey(contract), contractInfo.Bytes()),
+		NewStateEntry(mevmtypes.GetBytecodeKey(contract), code),
+		NewStateEntry(mevmtypes.GetCreationCounterKey(0x22), counter),
+		NewStateEntry(mevmtypes.GetAccountKey(gethcmn.HexToAddress("0x2710")), contractInfo.Bytes()),
+		NewStateEntry(mevmtypes.GetValueKey(math.MaxUint64-2, string(slot)), []byte{1, 2, 3}),
+		NewStateEntry([]byte{mevmtypes.CURR_BLOCK_KEY}, []byte{4, 5, 6}),
+		{Kind: KindStakingInfo, Value: []byte{0x80}},
+	}
+}
+
+func TestNewStateEntry(t *testing.T) {
+	entries := testEntries()
+	require.Equal(t, KindStorage, entries[1].Kind)
+	require.Equal(t, uint64(0x0122), entries[1].Sequence)
+	require.Equal(t, KindAccount, entries[2].Kind)
+	require.Equal(t, [20]byte(eoa), entries[2].Address)
+	require.Equal(t, uint64(3), entries[2].Nonce)
+	require.Equal(t, uint64(1000), new(big.Int).SetBytes(entries[2].Balance[:]).Uint64())
+	require.Equal(t, KindBytecode, entries[4].Kind)
+	require.Equal(t, []byte{0x60, 0x00, 0xf3}, entries[4].Value)
+	require.Equal(t, KindCreationCounter, entries[5].Kind)
+	require.Equal(t, []byte{0x22}, entries[5].Key)
+	require.Equal(t, KindRaw, entries[8].Kind)
+}
+
+func TestWriteAndRead(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatMsgpack} {
+		entries := testEntries()
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, format)
+		require.NoError(t, err)
+		for _, e := range entries {
+			require.NoError(t, w.Write(e))
+		}
+		require.NoError(t, w.Flush())
+
+		r, err := NewReader(&buf, format)
+		require.NoError(t, err)
+		for _, e := range entries {
+			e2, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, e.Kind, e2.Kind)
+			require.Equal(t, e.Address, e2.Address)
+			require.Equal(t, e.Balance, e2.Balance)
+			require.Equal(t, e.Sequence, e2.Sequence)
+			require.Equal(t, []byte(e.Value), []byte(e2.Value))
+		}
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+	}
+	_, err := NewWriter(nil, "xml")
+	require.Error(t, err)
+}
+
+func TestToGenesisAlloc(t *testing.T) {
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, FormatMsgpack)
+	for _, e := range testEntries() {
+		require.NoError(t, w.Write(e))
+	}
+	require.NoError(t, w.Flush())
+	r, _ := NewReader(&buf, FormatMsgpack)
+	alloc, stats, err := ToGenesisAlloc(r)
+	require.NoError(t, err)
+	require.Equal(t, &AllocStats{Height: 100, Accounts: 2, Contracts: 1, Slots: 1, SkippedAccounts: 1, SkippedSlots: 1}, stats)
+	require.Equal(t, uint64(1000), alloc[eoa].Balance.Uint64())
+	require.Equal(t, uint64(3), alloc[eoa].Nonce)
+	require.Nil(t, alloc[eoa].Storage)
+	require.Equal(t, []byte{0x60, 0x00, 0xf3}, alloc[contract].Code)
+	require.Equal(t, uint64(1), alloc[contract].Nonce)
+	require.Equal(t, gethcmn.BytesToHash(bytes.Repeat([]byte{0x02}, 32)),
+		alloc[contract].Storage[gethcmn.BytesToHash(bytes.Repeat([]byte{0x01}, 32))])
+
+	r, _ = NewReader(bytes.NewReader(nil), FormatJSON)
+	_, _, err = ToGenesisAlloc(r)
+	require.Error(t, err)
+}
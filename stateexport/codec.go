@@ -0,0 +1,150 @@
+package stateexport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// An export is a stream of StateEntry records, which can be encoded as JSON lines (one JSON object
+// per line, human-readable) or as concatenated msgpack objects (compact). Both of them can be
+// written and read incrementally, so the whole world state never needs to be held in memory.
+
+const (
+	FormatJSON    = "json"
+	FormatMsgpack = "msgpack"
+)
+
+// Writer writes StateEntry records into an export
+type Writer interface {
+	Write(e *StateEntry) error
+	Flush() error
+}
+
+// Reader reads StateEntry records from an export, it returns io.EOF after the last record
+type Reader interface {
+	Read() (*StateEntry, error)
+}
+
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonWriter{enc: json.NewEncoder(w)}, nil
+	case FormatMsgpack:
+		return &msgpWriter{w: msgp.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format: %s", format)
+}
+
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonReader{dec: json.NewDecoder(r)}, nil
+	case FormatMsgpack:
+		return &msgpReader{r: msgp.NewReader(r)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format: %s", format)
+}
+
+// jsonStateEntry is the JSON form of StateEntry, in which the fields unused by its kind are omitted
+type jsonStateEntry struct {
+	Kind     string           `json:"kind"`
+	Height   int64            `json:"height,omitempty"`
+	Address  *gethcmn.Address `json:"address,omitempty"`
+	Balance  *hexutil.Big     `json:"balance,omitempty"`
+	Nonce    hexutil.Uint64   `json:"nonce,omitempty"`
+	Sequence hexutil.Uint64   `json:"sequence,omitempty"`
+	Key      hexutil.Bytes    `json:"key,omitempty"`
+	Value    hexutil.Bytes    `json:"value,omitempty"`
+}
+
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonWriter) Write(e *StateEntry) error {
+	je := &jsonStateEntry{
+		Kind:     e.Kind,
+		Height:   e.Height,
+		Nonce:    hexutil.Uint64(e.Nonce),
+		Sequence: hexutil.Uint64(e.Sequence),
+		Key:      e.Key,
+		Value:    e.Value,
+	}
+	switch e.Kind {
+	case KindAccount:
+		addr := gethcmn.Address(e.Address)
+		je.Address = &addr
+		je.Balance = (*hexutil.Big)(gethcmn.BytesToHash(e.Balance[:]).Big())
+	case KindBytecode:
+		addr := gethcmn.Address(e.Address)
+		je.Address = &addr
+	}
+	return w.enc.Encode(je)
+}
+
+func (w *jsonWriter) Flush() error {
+	return nil // json.Encoder does not buffer
+}
+
+type jsonReader struct {
+	dec *json.Decoder
+}
+
+func (r *jsonReader) Read() (*StateEntry, error) {
+	var je jsonStateEntry
+	if err := r.dec.Decode(&je); err != nil {
+		return nil, err
+	}
+	e := &StateEntry{
+		Kind:     je.Kind,
+		Height:   je.Height,
+		Nonce:    uint64(je.Nonce),
+		Sequence: uint64(je.Sequence),
+		Key:      je.Key,
+		Value:    je.Value,
+	}
+	if je.Address != nil {
+		e.Address = *je.Address
+	}
+	if je.Balance != nil {
+		if je.Balance.ToInt().Sign() < 0 || je.Balance.ToInt().BitLen() > 256 {
+			return nil, errors.New("invalid balance")
+		}
+		je.Balance.ToInt().FillBytes(e.Balance[:])
+	}
+	return e, nil
+}
+
+type msgpWriter struct {
+	w *msgp.Writer
+}
+
+func (w *msgpWriter) Write(e *StateEntry) error {
+	return e.EncodeMsg(w.w)
+}
+
+func (w *msgpWriter) Flush() error {
+	return w.w.Flush()
+}
+
+type msgpReader struct {
+	r *msgp.Reader
+}
+
+func (r *msgpReader) Read() (*StateEntry, error) {
+	// distinguish the normal end of the stream from a truncated record
+	if _, err := r.r.R.Peek(1); err != nil {
+		return nil, err
+	}
+	e := &StateEntry{}
+	if err := e.DecodeMsg(r.r); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
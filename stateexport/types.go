@@ -0,0 +1,27 @@
+package stateexport
+
+//go:generate msgp
+
+// The kinds of StateEntry
+const (
+	KindHeader          = "header"           // the first entry of an export, only Height is used
+	KindAccount         = "account"          // Address, Balance, Nonce and Sequence are used
+	KindBytecode        = "bytecode"         // Address is used, Value is the bytecode
+	KindStorage         = "storage"          // Sequence is the contract's sequence, Key is the 32-byte slot
+	KindCreationCounter = "creation_counter" // Key is the lsb of addresses, Value is the 8-byte counter
+	KindStakingInfo     = "staking_info"     // Value is the msgp-encoded StakingInfo
+	KindCCInfo          = "cc_info"          // Value is the msgp-encoded CCInfo
+	KindRaw             = "raw"              // a KV pair which is not written by the EVM
+)
+
+// StateEntry is one record in an export of the world state
+type StateEntry struct {
+	Kind     string   `msgp:"kind"`
+	Height   int64    `msgp:"height"`
+	Address  [20]byte `msgp:"address"`
+	Balance  [32]byte `msgp:"balance"` // big-endian
+	Nonce    uint64   `msgp:"nonce"`
+	Sequence uint64   `msgp:"sequence"`
+	Key      []byte   `msgp:"key"`
+	Value    []byte   `msgp:"value"`
+}
@@ -0,0 +1,289 @@
+// Package stateproof verifies the proofs returned by eth_getProof, which prove the KV pairs of the world
+// state against the app hash committed in the block header.
+//
+// The world state is stored in MoeingADS, which splits the KV pairs into 8 shards, each of which has
+// a datatree (a Merkle tree over twigs of entries). The app hash is the root of a binary sha256
+// tree over the 8 shard roots:
+//
+//	n4[i] = sha256(shardRoot[2i] + shardRoot[2i+1])
+//	n2[i] = sha256(n4[2i] + n4[2i+1])
+//	appHash = sha256(n2[0] + n2[1])
+//
+// The KV pairs are not stored in MoeingADS with their original keys. The "rabbit" layer hashes an
+// original key into an 8-byte short key and stores the original key together with the value. When the
+// short key is taken by another original key, it jumps to the next short key (the hash of the previous
+// hash), until it meets a missing short key, or an entry of another original key which no key ever
+// jumped over (its passby number is zero). A datatree entry is proven by the serialized entry and the
+// Merkle path from it to the root of its shard, and a proof is encoded as:
+//
+//	entryCount(4 bytes, LE) + entryCount*(entryLen(4 bytes, LE) + entry + pathLen(4 bytes, LE) + path) +
+//	shardRoots(8*32 bytes)
+//
+// where entry is the serialized datatree entry (the leaf), path is the datatree ProofPath of the
+// entry, and shardRoots are the roots of all the shards. The entries are the ones on the rabbit path of
+// the original key. The last entry holds the original key if the KV pair exists, otherwise it shows
+// where the rabbit path ends: an entry of another key whose passby number is zero, an emptied entry of
+// the key, or, if the last short key is missing, the entry of its predecessor whose NextKey is greater
+// than the short key.
+package stateproof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartbch/moeingads/datatree"
+	"github.com/smartbch/moeingads/store/rabbit"
+	adstypes "github.com/smartbch/moeingads/types"
+	mevmtypes "github.com/smartbch/moeingevm/types"
+)
+
+const ShardCount = adstypes.ShardCount
+
+var (
+	ErrInvalidProof  = errors.New("invalid proof")
+	ErrRootMismatch  = errors.New("proof does not match the app hash")
+	ErrInactiveEntry = errors.New("the proven entry is not active")
+	ErrKeyMismatch   = errors.New("the proven entry does not hold the key")
+)
+
+// EntryProof proves an entry of a datatree
+type EntryProof struct {
+	Entry []byte // serialized datatree entry, including the list of deactivated serial numbers
+	Path  []byte // datatree.ProofPath.ToBytes()
+}
+
+// Proof proves a KV pair of the world state, or the absence of a key
+type Proof struct {
+	Entries    []EntryProof
+	ShardRoots [ShardCount][32]byte
+}
+
+func (p *Proof) ToBytes() []byte {
+	size := 4 + ShardCount*32
+	for _, e := range p.Entries {
+		size += 4 + len(e.Entry) + 4 + len(e.Path)
+	}
+	bz := make([]byte, 4, size)
+	binary.LittleEndian.PutUint32(bz, uint32(len(p.Entries)))
+	for _, e := range p.Entries {
+		bz = appendWithLen(bz, e.Entry)
+		bz = appendWithLen(bz, e.Path)
+	}
+	for _, root := range p.ShardRoots {
+		bz = append(bz, root[:]...)
+	}
+	return bz
+}
+
+func appendWithLen(bz, data []byte) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(len(data)))
+	return append(append(bz, buf[:]...), data...)
+}
+
+func BytesToProof(bz []byte) (*Proof, error) {
+	if len(bz) < 4 {
+		return nil, ErrInvalidProof
+	}
+	count := int(binary.LittleEndian.Uint32(bz[:4]))
+	bz = bz[4:]
+	if count == 0 || count > rabbit.MaxFindDepth+1 {
+		return nil, ErrInvalidProof
+	}
+	p := &Proof{Entries: make([]EntryProof, count)}
+	var ok bool
+	for i := range p.Entries {
+		if p.Entries[i].Entry, bz, ok = readWithLen(bz); !ok {
+			return nil, ErrInvalidProof
+		}
+		if p.Entries[i].Path, bz, ok = readWithLen(bz); !ok {
+			return nil, ErrInvalidProof
+		}
+	}
+	if len(bz) != ShardCount*32 {
+		return nil, ErrInvalidProof
+	}
+	for i := range p.ShardRoots {
+		copy(p.ShardRoots[i][:], bz[i*32:])
+	}
+	return p, nil
+}
+
+func readWithLen(bz []byte) (data, remained []byte, ok bool) {
+	if len(bz) < 4 {
+		return nil, nil, false
+	}
+	n := int(binary.LittleEndian.Uint32(bz[:4]))
+	if len(bz) < 4+n {
+		return nil, nil, false
+	}
+	return bz[4 : 4+n], bz[4+n:], true
+}
+
+// AppHashOfShardRoots calculates the app hash in the same way as MoeingADS
+func AppHashOfShardRoots(roots [ShardCount][32]byte) [32]byte {
+	level := roots[:]
+	for len(level) > 1 {
+		next := make([][32]byte, len(level)/2)
+		for i := range next {
+			next[i] = sha256.Sum256(append(append([]byte{}, level[2*i][:]...), level[2*i+1][:]...))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// ShortKeyPath returns the short keys that the rabbit layer tries one by one for an original key
+func ShortKeyPath(key []byte, depth int) [][rabbit.KeySize]byte {
+	path := make([][rabbit.KeySize]byte, depth)
+	hash := sha256.Sum256(key)
+	for i := range path {
+		copy(path[i][:], hash[:])
+		path[i][0] = adstypes.LimitRange(path[i][0]) // like rabbit, avoid the start&end guards
+		hash = sha256.Sum256(hash[:])
+	}
+	return path
+}
+
+// Verify checks the proof against appHash and returns the value of key in the world state, or nil if
+// the proof shows that key is missing
+func Verify(appHash []byte, key []byte, proofBz []byte) ([]byte, error) {
+	p, err := BytesToProof(proofBz)
+	if err != nil {
+		return nil, err
+	}
+	if root := AppHashOfShardRoots(p.ShardRoots); !bytes.Equal(root[:], appHash) {
+		return nil, ErrRootMismatch
+	}
+	shortKeys := ShortKeyPath(key, len(p.Entries))
+	for i, ep := range p.Entries {
+		entry, err := verifyEntry(p.ShardRoots, ep)
+		if err != nil {
+			return nil, err
+		}
+		isLast := i == len(p.Entries)-1
+		shortKey := shortKeys[i][:]
+		if !bytes.Equal(entry.Key, shortKey) {
+			// the short key is missing, so the entry must be its predecessor
+			if isLast && bytes.Compare(entry.Key, shortKey) < 0 && bytes.Compare(shortKey, entry.NextKey) < 0 {
+				return nil, nil
+			}
+			return nil, ErrKeyMismatch
+		}
+		if len(entry.Value) < rabbit.KeyStart {
+			return nil, ErrInvalidProof
+		}
+		cv := rabbit.BytesToCachedValue(entry.Value)
+		if bytes.Equal(cv.GetKey(), key) {
+			if !isLast {
+				return nil, ErrInvalidProof
+			}
+			if cv.IsEmpty() {
+				return nil, nil
+			}
+			return cv.GetValue(), nil
+		}
+		// the short key is taken by another original key, the rabbit path ends here if no key jumped over it
+		passbyNum := binary.LittleEndian.Uint32(entry.Value[rabbit.PassbyNumIndex : rabbit.PassbyNumIndex+4])
+		if passbyNum == 0 {
+			if !isLast {
+				return nil, ErrInvalidProof
+			}
+			return nil, nil
+		}
+	}
+	return nil, ErrKeyMismatch // the proof stops in the middle of the rabbit path
+}
+
+// verifyEntry checks an entry against the root of its shard and decodes it
+func verifyEntry(roots [ShardCount][32]byte, ep EntryProof) (*datatree.Entry, error) {
+	path, err := datatree.BytesToProofPath(ep.Path)
+	if err != nil || len(path.UpperPath) == 0 {
+		return nil, ErrInvalidProof
+	}
+	if sha256.Sum256(ep.Entry) != path.LeftOfTwig[0].SelfHash {
+		return nil, ErrInvalidProof
+	}
+	if err = path.Check(true); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+	// the first node of the right path is the 256 active bits containing this entry's bit
+	offset := path.SerialNum & datatree.TwigMask
+	if path.RightOfTwig[0].SelfHash[(offset%256)/8]&(1<<(offset&7)) == 0 {
+		return nil, ErrInactiveEntry
+	}
+	entry, err := decodeEntry(ep.Entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry.SerialNum != path.SerialNum || len(entry.Key) != rabbit.KeySize {
+		return nil, ErrInvalidProof
+	}
+	if roots[adstypes.GetShardID(entry.Key)] != path.Root {
+		return nil, ErrRootMismatch
+	}
+	return entry, nil
+}
+
+// decodeEntry decodes the raw bytes of an entry read from the entry file, which are:
+// numOfDeactivedSN(1 byte) + length(3 bytes) + positions of the overwritten magic bytes + payload
+func decodeEntry(raw []byte) (entry *datatree.Entry, err error) {
+	if len(raw) < 8 {
+		return nil, ErrInvalidProof
+	}
+	defer func() { // a malformed entry makes the decoding functions of datatree panic
+		if recover() != nil {
+			entry, err = nil, ErrInvalidProof
+		}
+	}()
+	numberOfSN := int(raw[0])
+	b := append([]byte{}, raw[4:]...)
+	n := 0
+	for ; n+4 <= len(b); n += 4 { // recover the magic bytes in payload, like datatree's recoverMagicBytes
+		pos := binary.LittleEndian.Uint32(b[n : n+4])
+		if pos == ^(uint32(0)) {
+			n += 4
+			break
+		}
+		copy(b[int(pos)+4:int(pos)+12], datatree.MagicBytes[:])
+	}
+	entry, _ = datatree.EntryFromBytes(b[n:], numberOfSN)
+	return entry, nil
+}
+
+// VerifyAccount checks an account proof and returns the account's balance, nonce and sequence, or nil if
+// the account does not exist
+func VerifyAccount(appHash []byte, addr gethcmn.Address, proof []byte) (*mevmtypes.AccountInfo, error) {
+	value, err := Verify(appHash, mevmtypes.GetAccountKey(addr), proof)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	if len(value) != 49 {
+		return nil, ErrInvalidProof
+	}
+	return mevmtypes.NewAccountInfo(value), nil
+}
+
+// VerifyCodeHash checks a bytecode proof and returns the keccak256 hash of the contract's bytecode, or a
+// zero hash if the bytecode does not exist
+func VerifyCodeHash(appHash []byte, addr gethcmn.Address, proof []byte) (gethcmn.Hash, error) {
+	value, err := Verify(appHash, mevmtypes.GetBytecodeKey(addr), proof)
+	if err != nil || value == nil {
+		return gethcmn.Hash{}, err
+	}
+	if len(value) < 33 {
+		return gethcmn.Hash{}, ErrInvalidProof
+	}
+	return gethcmn.BytesToHash(mevmtypes.NewBytecodeInfo(value).CodeHashSlice()), nil
+}
+
+// VerifyStorage checks a storage proof and returns the value of the slot, or nil if it is missing. Storage slots are indexed by the
+// sequence of the contract, which can be proven with VerifyAccount
+func VerifyStorage(appHash []byte, sequence uint64, slot gethcmn.Hash, proof []byte) ([]byte, error) {
+	return Verify(appHash, mevmtypes.GetValueKey(sequence, string(slot[:])), proof)
+}
@@ -0,0 +1,259 @@
+package stateproof
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"os"
+	"testing"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/moeingads/datatree"
+	mevmtypes "github.com/smartbch/moeingevm/types"
+)
+
+var (
+	alice = gethcmn.HexToAddress("0x1111111111111111111111111111111111111111")
+	slot  = gethcmn.HexToHash("0x0102")
+)
+
+// the same layout as rabbit.CachedValue.ToBytes
+func cachedValueBytes(key, value []byte) []byte {
+	return cachedValueBytesWithPassby(key, value, 0)
+}
+
+// a nil value marks the cached value as empty
+func cachedValueBytesWithPassby(key, value []byte, passbyNum uint32) []byte {
+	bz := make([]byte, 9, 9+len(key)+len(value))
+	if value == nil {
+		bz[0] = 1
+	}
+	binary.LittleEndian.PutUint32(bz[1:5], passbyNum)
+	binary.LittleEndian.PutUint32(bz[5:9], uint32(len(key)))
+	return append(append(bz, key...), value...)
+}
+
+type testEntry struct {
+	key     []byte // the short key
+	value   []byte
+	nextKey []byte
+}
+
+type testState struct {
+	appHash []byte
+	roots   [ShardCount][32]byte
+	entries []EntryProof
+}
+
+// proof returns the proof made of the test entries at idxList
+func (st *testState) proof(idxList ...int) []byte {
+	p := &Proof{ShardRoots: st.roots}
+	for _, i := range idxList {
+		p.Entries = append(p.Entries, st.entries[i])
+	}
+	return p.ToBytes()
+}
+
+// buildTestState puts the entries into the datatrees of their shards and generates their entry proofs
+func buildTestState(t *testing.T, entries []testEntry, deactivated int) *testState {
+	dir, err := os.MkdirTemp("", "stateproof")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	st := &testState{entries: make([]EntryProof, len(entries))}
+	for shard := 0; shard < ShardCount; shard++ {
+		tree := datatree.NewEmptyTree(datatree.SmallBufferSize, 8*4096*32, dir, string(rune('0'+shard)))
+		var idxList, posList []int64
+		var sn int64
+		for i, e := range entries {
+			if int(e.key[7])/(256/ShardCount) != shard {
+				continue
+			}
+			pos := tree.AppendEntry(&datatree.Entry{
+				Key:       e.key,
+				Value:     e.value,
+				NextKey:   e.nextKey,
+				Height:    10,
+				SerialNum: sn,
+			})
+			if i == deactivated {
+				tree.DeactiviateEntry(sn)
+			}
+			idxList, posList = append(idxList, int64(i)), append(posList, pos)
+			sn++
+		}
+		// the datatree cannot calculate its root until it has more than one twig
+		for n := 0; n < datatree.LeafCountInTwig; n, sn = n+1, sn+1 {
+			tree.AppendEntry(&datatree.Entry{
+				Key:       []byte{0, 0, 0, 0, 0, byte(n >> 8), byte(n), byte(shard * 32)},
+				Value:     cachedValueBytes([]byte("padding"), []byte{1}),
+				NextKey:   []byte{0, 0, 0, 0, 0, byte((n + 1) >> 8), byte(n + 1), byte(shard * 32)},
+				Height:    10,
+				SerialNum: sn,
+			})
+		}
+		st.roots[shard] = tree.EndBlock()
+		tree.WaitForFlushing()
+		for j, i := range idxList {
+			st.entries[i] = EntryProof{
+				Entry: tree.ReadEntryBytesForProof(posList[j]),
+				Path:  tree.GetProof(int64(j)).ToBytes(),
+			}
+		}
+		tree.Close()
+	}
+	appHash := AppHashOfShardRoots(st.roots)
+	st.appHash = appHash[:]
+	return st
+}
+
+// kvEntry is the entry of a KV pair stored at the first short key of its rabbit path
+func kvEntry(key, value []byte) testEntry {
+	shortKey := ShortKeyPath(key, 1)[0]
+	return testEntry{key: shortKey[:], value: cachedValueBytes(key, value), nextKey: nextKey(shortKey[:])}
+}
+
+func nextKey(k []byte) []byte {
+	next := append([]byte{}, k...)
+	next[0]++
+	return next
+}
+
+func TestAppHashOfShardRoots(t *testing.T) {
+	var roots [ShardCount][32]byte
+	for i := range roots {
+		roots[i][31] = byte(i)
+	}
+	var n4 [4][32]byte
+	for i := range n4 {
+		n4[i] = sha256Of(roots[2*i][:], roots[2*i+1][:])
+	}
+	n20, n21 := sha256Of(n4[0][:], n4[1][:]), sha256Of(n4[2][:], n4[3][:])
+	n1 := sha256Of(n20[:], n21[:])
+	require.Equal(t, n1, AppHashOfShardRoots(roots))
+}
+
+func sha256Of(a, b []byte) [32]byte {
+	return sha256.Sum256(append(append([]byte{}, a...), b...))
+}
+
+func TestVerify(t *testing.T) {
+	acc := mevmtypes.ZeroAccountInfo()
+	acc.UpdateBalance(uint256.NewInt(12345))
+	acc.UpdateNonce(7)
+	acc.UpdateSequence(math.MaxUint64)
+	code := make([]byte, 33+2)
+	code[1] = 0xcc
+	code[33], code[34] = 0x60, 0x00
+	// the magic bytes in the value must be recovered when decoding the entry
+	magicValue := append(datatree.MagicBytes[:], 1, 2, 3)
+	st := buildTestState(t, []testEntry{
+		kvEntry(mevmtypes.GetAccountKey(alice), acc.Bytes()),
+		kvEntry(mevmtypes.GetBytecodeKey(alice), code),
+		kvEntry(mevmtypes.GetValueKey(0x0111, string(slot[:])), magicValue),
+	}, -1)
+
+	info, err := VerifyAccount(st.appHash, alice, st.proof(0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(12345), info.Balance().Uint64())
+	require.Equal(t, uint64(7), info.Nonce())
+	require.Equal(t, uint64(math.MaxUint64), info.Sequence())
+
+	codeHash, err := VerifyCodeHash(st.appHash, alice, st.proof(1))
+	require.NoError(t, err)
+	require.Equal(t, byte(0xcc), codeHash[0])
+
+	value, err := VerifyStorage(st.appHash, 0x0111, slot, st.proof(2))
+	require.NoError(t, err)
+	require.Equal(t, magicValue, value)
+
+	// the proof of another key
+	_, err = VerifyStorage(st.appHash, 0x0112, slot, st.proof(2))
+	require.ErrorIs(t, err, ErrKeyMismatch)
+	// another app hash
+	badHash := append([]byte{}, st.appHash...)
+	badHash[0] ^= 1
+	_, err = VerifyAccount(badHash, alice, st.proof(0))
+	require.ErrorIs(t, err, ErrRootMismatch)
+	// a tampered proof
+	badProof := st.proof(0)
+	badProof[len(badProof)-ShardCount*32-200] ^= 1
+	_, err = VerifyAccount(st.appHash, alice, badProof)
+	require.Error(t, err)
+	// a truncated proof
+	_, err = VerifyAccount(st.appHash, alice, st.proof(0)[:100])
+	require.ErrorIs(t, err, ErrInvalidProof)
+	// an entry after the end of the rabbit path
+	_, err = VerifyAccount(st.appHash, alice, st.proof(0, 1))
+	require.ErrorIs(t, err, ErrInvalidProof)
+}
+
+func TestVerifyInactiveEntry(t *testing.T) {
+	st := buildTestState(t, []testEntry{
+		kvEntry(mevmtypes.GetValueKey(1, string(slot[:])), []byte{1}),
+		kvEntry(mevmtypes.GetValueKey(2, string(slot[:])), []byte{2}),
+	}, 0)
+	_, err := VerifyStorage(st.appHash, 1, slot, st.proof(0))
+	require.ErrorIs(t, err, ErrInactiveEntry)
+	value, err := VerifyStorage(st.appHash, 2, slot, st.proof(1))
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, value)
+}
+
+func TestVerifyRabbitPath(t *testing.T) {
+	key := mevmtypes.GetValueKey(1, string(slot[:]))
+	shortKeys := ShortKeyPath(key, 2)
+	st := buildTestState(t, []testEntry{
+		// the first short key is taken by another key which the key jumped over
+		{key: shortKeys[0][:], value: cachedValueBytesWithPassby([]byte("other"), []byte{1}, 1), nextKey: nextKey(shortKeys[0][:])},
+		{key: shortKeys[1][:], value: cachedValueBytes(key, []byte{2}), nextKey: nextKey(shortKeys[1][:])},
+	}, -1)
+	value, err := VerifyStorage(st.appHash, 1, slot, st.proof(0, 1))
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, value)
+	// the proof stops in the middle of the rabbit path
+	_, err = VerifyStorage(st.appHash, 1, slot, st.proof(0))
+	require.ErrorIs(t, err, ErrKeyMismatch)
+	// the rabbit path cannot skip a short key
+	_, err = VerifyStorage(st.appHash, 1, slot, st.proof(1))
+	require.ErrorIs(t, err, ErrKeyMismatch)
+}
+
+func TestVerifyMissingKey(t *testing.T) {
+	missingShortKey := ShortKeyPath(mevmtypes.GetAccountKey(alice), 1)[0]
+	prevKey := append([]byte{}, missingShortKey[:]...)
+	prevKey[0]--
+	takenShortKey := ShortKeyPath(mevmtypes.GetBytecodeKey(alice), 1)[0]
+	emptiedShortKey := ShortKeyPath(mevmtypes.GetValueKey(1, string(slot[:])), 1)[0]
+	st := buildTestState(t, []testEntry{
+		// the predecessor of the missing short key
+		{key: prevKey, value: cachedValueBytes([]byte("prev"), []byte{1}), nextKey: nextKey(missingShortKey[:])},
+		// taken by another key which no key jumped over
+		{key: takenShortKey[:], value: cachedValueBytes([]byte("other"), []byte{1}), nextKey: nextKey(takenShortKey[:])},
+		// the emptied entry of the key
+		{key: emptiedShortKey[:], value: cachedValueBytesWithPassby(mevmtypes.GetValueKey(1, string(slot[:])), nil, 1),
+			nextKey: nextKey(emptiedShortKey[:])},
+		// the predecessor whose next key is the missing short key
+		{key: prevKey, value: cachedValueBytes([]byte("prev"), []byte{1}), nextKey: missingShortKey[:]},
+	}, -1)
+
+	info, err := VerifyAccount(st.appHash, alice, st.proof(0))
+	require.NoError(t, err)
+	require.Nil(t, info)
+	codeHash, err := VerifyCodeHash(st.appHash, alice, st.proof(1))
+	require.NoError(t, err)
+	require.Equal(t, gethcmn.Hash{}, codeHash)
+	value, err := VerifyStorage(st.appHash, 1, slot, st.proof(2))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// the short key is not between the predecessor and its next key
+	_, err = VerifyAccount(st.appHash, alice, st.proof(3))
+	require.ErrorIs(t, err, ErrKeyMismatch)
+	// the entry of another key is not a predecessor
+	_, err = VerifyStorage(st.appHash, 2, slot, st.proof(1))
+	require.ErrorIs(t, err, ErrKeyMismatch)
+}
@@ -0,0 +1,272 @@
+package watcher
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/tendermint/tendermint/libs/log"
+
+	cctypes "github.com/smartbch/smartbch/crosschain/types"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/watcher/types"
+)
+
+const (
+	// the maximum size of a block record, larger records are considered as corrupted data
+	MaxBlkRecordSize = 2 * 1000 * 1000 * 1000
+)
+
+// the location of a block inside the blkNNNNN.dat files
+type blkIndexEntry struct {
+	header  wire.BlockHeader
+	height  int64
+	fileNum int
+	offset  int64 // offset of the serialized block, after the magic and the size
+}
+
+// BlkFileClient reads BCH blocks directly from the blkNNNNN.dat files written by a BCH fullnode
+// (such as BCHN), without talking to it through JSON-RPC. Each record in these files is made of
+// 4 bytes of network magic, 4 bytes of size (little endian) and the serialized block. Blocks may
+// be written out of order, so the chain is rebuilt by following the parent hashes from the genesis
+// block, and pruned block directories are not supported.
+type BlkFileClient struct {
+	dir    string
+	logger log.Logger
+
+	mtx        sync.Mutex
+	index      map[chainhash.Hash]*blkIndexEntry
+	orphans    map[chainhash.Hash][]*blkIndexEntry // keyed by the parent hash
+	mainChain  []chainhash.Hash                    // height => block hash
+	fileNum    int                                 // the file being scanned
+	fileOffset int64                               // the scanned bytes of the file being scanned
+}
+
+var _ types.RpcClient = (*BlkFileClient)(nil)
+
+func NewBlkFileClient(dir string, logger log.Logger) *BlkFileClient {
+	if dir == "" {
+		return nil
+	}
+	return &BlkFileClient{
+		dir:     dir,
+		logger:  logger,
+		index:   make(map[chainhash.Hash]*blkIndexEntry),
+		orphans: make(map[chainhash.Hash][]*blkIndexEntry),
+	}
+}
+
+func blkFileName(dir string, fileNum int) string {
+	return filepath.Join(dir, fmt.Sprintf("blk%05d.dat", fileNum))
+}
+
+func (client *BlkFileClient) GetLatestHeight(retry bool) int64 {
+	for {
+		client.mtx.Lock()
+		err := client.scan()
+		height := int64(len(client.mainChain)) - 1
+		client.mtx.Unlock()
+		if err != nil {
+			client.logger.Debug("scanning blk files failed", err.Error())
+		}
+		if height >= 0 || !retry {
+			return height
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
+
+func (client *BlkFileClient) GetBlockByHeight(height int64, retry bool) *types.BCHBlock {
+	for {
+		blk, err := client.getBCHBlock(height)
+		if err == nil {
+			return blk
+		}
+		if !retry {
+			return nil
+		}
+		client.logger.Debug(fmt.Sprintf("getBCHBlock %d failed", height), err.Error())
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// The blk files know nothing about smartBCH's epochs, they can only be fetched from a smartBCH node
+func (client *BlkFileClient) GetEpochs(start, end uint64) []*stakingtypes.Epoch {
+	return nil
+}
+
+func (client *BlkFileClient) GetCCEpochs(start, end uint64) []*cctypes.CCEpoch {
+	return nil
+}
+
+func (client *BlkFileClient) getBCHBlock(height int64) (*types.BCHBlock, error) {
+	client.mtx.Lock()
+	if height >= int64(len(client.mainChain)) {
+		if err := client.scan(); err != nil {
+			client.mtx.Unlock()
+			return nil, err
+		}
+	}
+	if height < 0 || height >= int64(len(client.mainChain)) {
+		client.mtx.Unlock()
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	entry := client.index[client.mainChain[height]]
+	client.mtx.Unlock()
+
+	f, err := os.Open(blkFileName(client.dir, entry.fileNum))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	_, err = f.Seek(entry.offset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	var blk wire.MsgBlock
+	err = blk.DeserializeNoWitness(bufio.NewReader(f))
+	if err != nil {
+		return nil, err
+	}
+	if len(blk.Transactions) == 0 {
+		return nil, fmt.Errorf("block at height %d has no coinbase", height)
+	}
+	bchBlock := newBCHBlock(&blk.Header, height, blk.Transactions[0])
+	if height > 0 {
+		txs := make([]types.TxInfo, len(blk.Transactions))
+		for i, tx := range blk.Transactions {
+			txs[i] = msgTxToTxInfo(tx)
+		}
+		collectCCInfos(bchBlock, txs)
+	}
+	return bchBlock, nil
+}
+
+// scan reads the newly-written records from the blk files, starting where the last scan stopped.
+// A file is considered finished only after the next file is created by the fullnode.
+func (client *BlkFileClient) scan() error {
+	for {
+		err := client.scanFile()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stat(blkFileName(client.dir, client.fileNum+1))
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		// the next file exists, scan the current one once more in case it was appended before being finished
+		if err = client.scanFile(); err != nil {
+			return err
+		}
+		client.fileNum++
+		client.fileOffset = 0
+	}
+}
+
+func (client *BlkFileClient) scanFile() error {
+	f, err := os.Open(blkFileName(client.dir, client.fileNum))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Seek(client.fileOffset, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(f)
+	var prefix [8]byte
+	var headerBz [wire.MaxBlockHeaderPayload]byte
+	for {
+		// stop at the end of file or at a partially-written record, which will be read in next scan
+		if _, err = io.ReadFull(r, prefix[:]); err != nil {
+			break
+		}
+		magic := binary.LittleEndian.Uint32(prefix[:4])
+		size := int64(binary.LittleEndian.Uint32(prefix[4:]))
+		if magic == 0 { // the pre-allocated space of the file
+			break
+		}
+		if size < wire.MaxBlockHeaderPayload || size > MaxBlkRecordSize {
+			return fmt.Errorf("invalid block record in %s at %d", blkFileName(client.dir, client.fileNum), client.fileOffset)
+		}
+		if _, err = io.ReadFull(r, headerBz[:]); err != nil {
+			break
+		}
+		if _, err = r.Discard(int(size) - wire.MaxBlockHeaderPayload); err != nil {
+			break
+		}
+		entry := &blkIndexEntry{fileNum: client.fileNum, offset: client.fileOffset + 8}
+		if err = entry.header.Deserialize(bytes.NewReader(headerBz[:])); err != nil {
+			return err
+		}
+		client.fileOffset += 8 + size
+		client.addEntry(entry)
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	return err
+}
+
+// addEntry connects the block and its orphan descendants to the index, and switches the main chain
+// to the longest one
+func (client *BlkFileClient) addEntry(entry *blkIndexEntry) {
+	hash := entry.header.BlockHash()
+	if _, ok := client.index[hash]; ok {
+		return
+	}
+	if entry.header.PrevBlock == (chainhash.Hash{}) {
+		entry.height = 0
+	} else if parent, ok := client.index[entry.header.PrevBlock]; ok {
+		entry.height = parent.height + 1
+	} else {
+		client.orphans[entry.header.PrevBlock] = append(client.orphans[entry.header.PrevBlock], entry)
+		return
+	}
+	queue := []*blkIndexEntry{entry}
+	for len(queue) != 0 {
+		e := queue[0]
+		queue = queue[1:]
+		h := e.header.BlockHash()
+		client.index[h] = e
+		if e.height >= int64(len(client.mainChain)) {
+			client.switchMainChain(h, e)
+		}
+		for _, child := range client.orphans[h] {
+			child.height = e.height + 1
+			queue = append(queue, child)
+		}
+		delete(client.orphans, h)
+	}
+}
+
+func (client *BlkFileClient) switchMainChain(tipHash chainhash.Hash, tip *blkIndexEntry) {
+	for int64(len(client.mainChain)) <= tip.height {
+		client.mainChain = append(client.mainChain, chainhash.Hash{})
+	}
+	hash, e := tipHash, tip
+	for {
+		if client.mainChain[e.height] == hash {
+			return
+		}
+		client.mainChain[e.height] = hash
+		if e.height == 0 {
+			return
+		}
+		hash = e.header.PrevBlock
+		e = client.index[hash]
+	}
+}
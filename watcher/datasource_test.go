@@ -0,0 +1,423 @@
+package watcher
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/watcher/types"
+)
+
+func buildCoinbaseTx(height int64, pubKey *[32]byte) *wire.MsgTx {
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte{0x03, byte(height), byte(height >> 8), byte(height >> 16)},
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	tx.AddTxOut(wire.NewTxOut(625000000, []byte{txscript.OP_TRUE}))
+	if pubKey != nil {
+		data, _ := hex.DecodeString(types.Identifier + types.Version)
+		script, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).AddData(append(data, pubKey[:]...)).Script()
+		tx.AddTxOut(wire.NewTxOut(0, script))
+	}
+	return tx
+}
+
+func buildRawBlock(height int64, parent chainhash.Hash, nonce uint32, pubKey *[32]byte) *wire.MsgBlock {
+	coinbase := buildCoinbaseTx(height, pubKey)
+	blk := wire.NewMsgBlock(wire.NewBlockHeader(1, &parent, &chainhash.Hash{}, 0x207fffff, nonce))
+	blk.Header.Timestamp = time.Unix(1600000000+height*600, 0)
+	blk.Header.MerkleRoot = coinbase.TxHash()
+	_ = blk.AddTransaction(coinbase)
+	return blk
+}
+
+// builds a chain of n blocks from the genesis block
+func buildRawChain(n int, pubKey *[32]byte) []*wire.MsgBlock {
+	blocks := make([]*wire.MsgBlock, n)
+	parent := chainhash.Hash{}
+	for i := range blocks {
+		blocks[i] = buildRawBlock(int64(i), parent, 0, pubKey)
+		parent = blocks[i].BlockHash()
+	}
+	return blocks
+}
+
+func appendBlkRecords(t *testing.T, fileName string, blocks ...*wire.MsgBlock) {
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+	for _, blk := range blocks {
+		var buf bytes.Buffer
+		require.NoError(t, blk.Serialize(&buf))
+		var prefix [8]byte
+		binary.LittleEndian.PutUint32(prefix[:4], uint32(wire.MainNet))
+		binary.LittleEndian.PutUint32(prefix[4:], uint32(buf.Len()))
+		_, err = f.Write(append(prefix[:], buf.Bytes()...))
+		require.NoError(t, err)
+	}
+}
+
+func TestMsgTxToTxInfo(t *testing.T) {
+	pubKey := [32]byte{0x12, 0x34}
+	info := msgTxToTxInfo(buildCoinbaseTx(100, &pubKey))
+	require.Len(t, info.VinList, 1)
+	require.Contains(t, info.VinList[0], "coinbase")
+	require.Len(t, info.VoutList, 2)
+	require.Equal(t, 6.25, info.VoutList[0].Value)
+	require.Equal(t, 1, info.VoutList[1].N)
+	pk, ok := info.GetValidatorPubKey()
+	require.True(t, ok)
+	require.Equal(t, pubKey, pk)
+
+	info = msgTxToTxInfo(buildCoinbaseTx(100, nil))
+	_, ok = info.GetValidatorPubKey()
+	require.False(t, ok)
+}
+
+func buildDepositTx(scriptSig []byte, receiver *[20]byte) *wire.MsgTx {
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}},
+		SignatureScript:  scriptSig,
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	tx.AddTxOut(wire.NewTxOut(100000, []byte{txscript.OP_TRUE}))
+	shaGate, _ := hex.DecodeString(types.ShaGateAddress)
+	script, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).AddData(shaGate).AddOp(txscript.OP_EQUAL).Script()
+	tx.AddTxOut(wire.NewTxOut(123456789, script))
+	if receiver != nil {
+		data, _ := hex.DecodeString(types.Identifier + types.CCReceiverFlag)
+		script, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).AddData(append(data, receiver[:]...)).Script()
+		tx.AddTxOut(wire.NewTxOut(0, script))
+	}
+	return tx
+}
+
+func TestGetCCTransferInfos(t *testing.T) {
+	key1, _ := btcec.NewPrivateKey(btcec.S256())
+	key2, _ := btcec.NewPrivateKey(btcec.S256())
+	sig := bytes.Repeat([]byte{0x30}, 71)
+	var pubkey1 [33]byte
+	copy(pubkey1[:], key1.PubKey().SerializeCompressed())
+
+	// P2PKH with a compressed pubkey
+	scriptSig, _ := txscript.NewScriptBuilder().AddData(sig).AddData(pubkey1[:]).Script()
+	tx := buildDepositTx(scriptSig, nil)
+	infos := msgTxToTxInfo(tx).GetCCTransferInfos()
+	require.Len(t, infos, 1)
+	require.Equal(t, uint64(123456789), infos[0].Amount)
+	require.Equal(t, tx.TxHash().String(), hex.EncodeToString(infos[0].UTXO[:32]))
+	require.Equal(t, uint32(1), binary.BigEndian.Uint32(infos[0].UTXO[32:]))
+	require.Equal(t, pubkey1, infos[0].SenderPubkey)
+	require.Equal(t, [20]byte{}, infos[0].Receiver)
+
+	// P2PKH with an uncompressed pubkey
+	scriptSig, _ = txscript.NewScriptBuilder().AddData(sig).AddData(key1.PubKey().SerializeUncompressed()).Script()
+	infos = msgTxToTxInfo(buildDepositTx(scriptSig, nil)).GetCCTransferInfos()
+	require.Equal(t, pubkey1, infos[0].SenderPubkey)
+
+	// P2SH multisig has no sender pubkey, with or without a receiver
+	redeemScript, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_1).
+		AddData(pubkey1[:]).AddData(key2.PubKey().SerializeCompressed()).
+		AddOp(txscript.OP_2).AddOp(txscript.OP_CHECKMULTISIG).Script()
+	scriptSig, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(sig).AddData(redeemScript).Script()
+	infos = msgTxToTxInfo(buildDepositTx(scriptSig, nil)).GetCCTransferInfos()
+	require.Equal(t, [33]byte{}, infos[0].SenderPubkey)
+	receiver := [20]byte{0xab, 0xcd}
+	infos = msgTxToTxInfo(buildDepositTx(scriptSig, &receiver)).GetCCTransferInfos()
+	require.Equal(t, [33]byte{}, infos[0].SenderPubkey)
+	require.Equal(t, receiver, infos[0].Receiver)
+
+	// unknown sender
+	scriptSig, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(sig).AddData([]byte{txscript.OP_TRUE}).Script()
+	infos = msgTxToTxInfo(buildDepositTx(scriptSig, nil)).GetCCTransferInfos()
+	require.Len(t, infos, 1)
+	require.Equal(t, [33]byte{}, infos[0].SenderPubkey)
+
+	// not a deposit
+	require.Len(t, msgTxToTxInfo(buildCoinbaseTx(100, nil)).GetCCTransferInfos(), 0)
+}
+
+func TestGetCCSpendInfos(t *testing.T) {
+	redeemScript := []byte{txscript.OP_TRUE}
+	scriptHash := btcutil.Hash160(redeemScript)
+	sig := bytes.Repeat([]byte{0x30}, 71)
+	tx := wire.NewMsgTx(1)
+	scriptSig, _ := txscript.NewScriptBuilder().AddData(sig).AddData(redeemScript).Script()
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}, Index: 2}, SignatureScript: scriptSig})
+	// an input of another address
+	scriptSig, _ = txscript.NewScriptBuilder().AddData(sig).AddData([]byte{txscript.OP_FALSE}).Script()
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x02}}, SignatureScript: scriptSig})
+	pkh := bytes.Repeat([]byte{0x11}, 20)
+	script, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).AddData(pkh).
+		AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG).Script()
+	tx.AddTxOut(wire.NewTxOut(5000, script))
+	script, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).AddData(scriptHash).AddOp(txscript.OP_EQUAL).Script()
+	tx.AddTxOut(wire.NewTxOut(300, script))
+	tx.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN}))
+
+	infos := msgTxToTxInfo(tx).GetSpendInfosOf(scriptHash)
+	require.Len(t, infos, 1)
+	require.Equal(t, chainhash.Hash{0x01}.String(), hex.EncodeToString(infos[0].UTXO[:32]))
+	require.Equal(t, uint32(2), binary.BigEndian.Uint32(infos[0].UTXO[32:]))
+	require.Equal(t, tx.TxHash().String(), hex.EncodeToString(infos[0].MainnetTxId[:]))
+	require.Len(t, infos[0].Payouts, 2)
+	var target [21]byte
+	copy(target[1:], pkh)
+	require.Equal(t, target, infos[0].Payouts[0].Target)
+	require.Equal(t, uint64(5000), infos[0].Payouts[0].Amount)
+	target[0] = 1
+	copy(target[1:], scriptHash)
+	require.Equal(t, target, infos[0].Payouts[1].Target)
+	require.Equal(t, uint64(300), infos[0].Payouts[1].Amount)
+
+	// not spending the ShaGate address
+	require.Len(t, msgTxToTxInfo(tx).GetCCSpendInfos(), 0)
+}
+
+func TestNewBCHBlock(t *testing.T) {
+	pubKey := [32]byte{0x56}
+	raw := buildRawBlock(5, chainhash.Hash{0x01, 0x02}, 0, &pubKey)
+	blk := newBCHBlock(&raw.Header, 5, raw.Transactions[0])
+	require.Equal(t, int64(5), blk.Height)
+	require.Equal(t, int64(1600000000+5*600), blk.Timestamp)
+	// the same byte order as the hex strings of BCHN's JSON-RPC
+	require.Equal(t, raw.BlockHash().String(), hex.EncodeToString(blk.HashId[:]))
+	require.Equal(t, byte(0x02), blk.ParentBlk[30])
+	require.Equal(t, byte(0x01), blk.ParentBlk[31])
+	require.Len(t, blk.Nominations, 1)
+	require.Equal(t, pubKey, blk.Nominations[0].Pubkey)
+	require.Equal(t, int64(1), blk.Nominations[0].NominatedCount)
+
+	// nominations in the genesis block are ignored
+	blk = newBCHBlock(&raw.Header, 0, raw.Transactions[0])
+	require.Len(t, blk.Nominations, 0)
+}
+
+func TestBlkFileClient(t *testing.T) {
+	dir := t.TempDir()
+	pubKey := [32]byte{0x78}
+	blocks := buildRawChain(6, &pubKey)
+
+	client := NewBlkFileClient(dir, log.NewNopLogger())
+	require.Equal(t, int64(-1), client.GetLatestHeight(false))
+	require.Nil(t, client.GetBlockByHeight(0, false))
+
+	// blocks are written out of order
+	appendBlkRecords(t, blkFileName(dir, 0), blocks[0], blocks[2], blocks[1])
+	require.Equal(t, int64(2), client.GetLatestHeight(false))
+	appendBlkRecords(t, blkFileName(dir, 0), blocks[3])
+	appendBlkRecords(t, blkFileName(dir, 1), blocks[5])
+	require.Equal(t, int64(3), client.GetLatestHeight(false))
+
+	// a partially-written record is read after it is finished
+	var buf bytes.Buffer
+	require.NoError(t, blocks[4].Serialize(&buf))
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:4], uint32(wire.MainNet))
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(buf.Len()))
+	record := append(prefix[:], buf.Bytes()...)
+	f, err := os.OpenFile(blkFileName(dir, 1), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(record[:50])
+	require.NoError(t, err)
+	require.Equal(t, int64(3), client.GetLatestHeight(false))
+	_, err = f.Write(record[50:])
+	require.NoError(t, err)
+	// the pre-allocated space
+	_, err = f.Write(make([]byte, 100))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, int64(5), client.GetLatestHeight(false))
+
+	for i, raw := range blocks {
+		blk := client.GetBlockByHeight(int64(i), false)
+		require.NotNil(t, blk)
+		require.True(t, newBCHBlock(&raw.Header, int64(i), raw.Transactions[0]).Equal(blk))
+		if i > 0 {
+			require.Len(t, blk.Nominations, 1)
+			require.Equal(t, pubKey, blk.Nominations[0].Pubkey)
+		}
+	}
+	require.Nil(t, client.GetBlockByHeight(6, false))
+
+	// a longer fork from height 4 becomes the main chain
+	fork4 := buildRawBlock(4, blocks[3].BlockHash(), 1, nil)
+	fork5 := buildRawBlock(5, fork4.BlockHash(), 1, nil)
+	fork6 := buildRawBlock(6, fork5.BlockHash(), 1, nil)
+	appendBlkRecords(t, blkFileName(dir, 2), fork6, fork5, fork4)
+	require.Equal(t, int64(6), client.GetLatestHeight(false))
+	require.Equal(t, hashToDisplayBytes(blocks[3].BlockHash()), client.GetBlockByHeight(3, false).HashId)
+	require.Equal(t, hashToDisplayBytes(fork4.BlockHash()), client.GetBlockByHeight(4, false).HashId)
+	blk := client.GetBlockByHeight(6, false)
+	require.Equal(t, hashToDisplayBytes(fork6.BlockHash()), blk.HashId)
+	require.Len(t, blk.Nominations, 0)
+}
+
+// a minimal electrum server which serves the given blocks
+func startMockElectrumServer(t *testing.T, blocks []*wire.MsgBlock) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	txs := make(map[string]*wire.MsgTx)
+	for _, blk := range blocks {
+		for _, tx := range blk.Transactions {
+			txs[tx.TxHash().String()] = tx
+		}
+	}
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadBytes('\n')
+			if err != nil {
+				return
+			}
+			var req struct {
+				Id     uint64            `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if json.Unmarshal(line, &req) != nil {
+				return
+			}
+			resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.Id}
+			var height int64
+			if len(req.Params) != 0 {
+				_ = json.Unmarshal(req.Params[0], &height)
+			}
+			switch req.Method {
+			case "server.version":
+				resp["result"] = []string{"MockServer 1.0", ElectrumProtocolVersion}
+			case "blockchain.headers.subscribe":
+				resp["result"] = map[string]interface{}{"height": len(blocks) - 1, "hex": ""}
+				// a notification before the response, which must be skipped
+				_, _ = conn.Write([]byte(`{"jsonrpc":"2.0","method":"blockchain.headers.subscribe","params":[{"height":1,"hex":""}]}` + "\n"))
+			case "blockchain.block.header":
+				if height >= int64(len(blocks)) {
+					resp["error"] = map[string]interface{}{"code": 1, "message": "height out of range"}
+					break
+				}
+				var buf bytes.Buffer
+				_ = blocks[height].Header.Serialize(&buf)
+				resp["result"] = hex.EncodeToString(buf.Bytes())
+			case "blockchain.transaction.id_from_pos":
+				var pos int
+				_ = json.Unmarshal(req.Params[1], &pos)
+				if pos >= len(blocks[height].Transactions) {
+					resp["error"] = map[string]interface{}{"code": 1, "message": "no tx at position"}
+					break
+				}
+				resp["result"] = blocks[height].Transactions[pos].TxHash().String()
+			case "blockchain.transaction.get":
+				var txid string
+				_ = json.Unmarshal(req.Params[0], &txid)
+				var buf bytes.Buffer
+				_ = txs[txid].Serialize(&buf)
+				resp["result"] = hex.EncodeToString(buf.Bytes())
+			default:
+				resp["error"] = map[string]interface{}{"code": -32601, "message": "unknown method"}
+			}
+			bz, _ := json.Marshal(resp)
+			if _, err = conn.Write(append(bz, '\n')); err != nil {
+				return
+			}
+		}
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+	return "tcp://" + ln.Addr().String()
+}
+
+func TestElectrumClient(t *testing.T) {
+	pubKey := [32]byte{0x9a}
+	blocks := buildRawChain(4, &pubKey)
+	url := startMockElectrumServer(t, blocks)
+
+	client := NewElectrumClient(url, log.NewNopLogger())
+	require.Equal(t, int64(3), client.GetLatestHeight(false))
+	for i, raw := range blocks {
+		blk := client.GetBlockByHeight(int64(i), false)
+		require.NotNil(t, blk)
+		require.True(t, newBCHBlock(&raw.Header, int64(i), raw.Transactions[0]).Equal(blk))
+		if i > 0 {
+			require.Len(t, blk.Nominations, 1)
+			require.Equal(t, pubKey, blk.Nominations[0].Pubkey)
+		}
+	}
+	require.Nil(t, client.GetBlockByHeight(4, false))
+	// reconnects after errors
+	require.Equal(t, int64(3), client.GetLatestHeight(false))
+
+	// the deposits in non-coinbase txs are collected
+	key, _ := btcec.NewPrivateKey(btcec.S256())
+	scriptSig, _ := txscript.NewScriptBuilder().AddData(bytes.Repeat([]byte{0x30}, 71)).
+		AddData(key.PubKey().SerializeCompressed()).Script()
+	deposit := buildDepositTx(scriptSig, nil)
+	withDeposit := buildRawBlock(1, blocks[0].BlockHash(), 1, &pubKey)
+	_ = withDeposit.AddTransaction(deposit)
+	merkles := blockchain.BuildMerkleTreeStore([]*btcutil.Tx{
+		btcutil.NewTx(withDeposit.Transactions[0]), btcutil.NewTx(deposit)}, false)
+	withDeposit.Header.MerkleRoot = *merkles[len(merkles)-1]
+	client = NewElectrumClient(startMockElectrumServer(t, []*wire.MsgBlock{blocks[0], withDeposit}), log.NewNopLogger())
+	blk := client.GetBlockByHeight(1, false)
+	require.NotNil(t, blk)
+	require.Len(t, blk.Nominations, 1)
+	require.Len(t, blk.CCTransferInfos, 1)
+	require.Equal(t, uint64(123456789), blk.CCTransferInfos[0].Amount)
+
+	// a block whose txs do not match its merkle root is rejected
+	withDeposit.Header.MerkleRoot = withDeposit.Transactions[0].TxHash()
+	client = NewElectrumClient(startMockElectrumServer(t, []*wire.MsgBlock{blocks[0], withDeposit}), log.NewNopLogger())
+	require.Nil(t, client.GetBlockByHeight(1, false))
+
+	client = NewElectrumClient("http://"+url[len("tcp://"):], log.NewNopLogger())
+	require.Equal(t, int64(-1), client.GetLatestHeight(false))
+}
+
+func TestNewMainnetRpcClient(t *testing.T) {
+	config := param.DefaultAppConfigWithHome("")
+	config.MainnetRPCUrl = ""
+	require.Nil(t, newMainnetRpcClient(config, log.NewNopLogger()))
+	config.MainnetRPCUrl = "http://127.0.0.1:8332"
+	require.IsType(t, &RpcClient{}, newMainnetRpcClient(config, log.NewNopLogger()))
+
+	config.MainnetDataSource = param.MainnetDataSourceElectrum
+	config.MainnetElectrumUrl = ""
+	require.Nil(t, newMainnetRpcClient(config, log.NewNopLogger()))
+	config.MainnetElectrumUrl = "ssl://127.0.0.1:50002"
+	require.IsType(t, &ElectrumClient{}, newMainnetRpcClient(config, log.NewNopLogger()))
+
+	config.MainnetDataSource = param.MainnetDataSourceBlkFiles
+	config.MainnetBlocksDir = filepath.Join(t.TempDir(), "blocks")
+	require.IsType(t, &BlkFileClient{}, newMainnetRpcClient(config, log.NewNopLogger()))
+
+	config.MainnetDataSource = "unknown"
+	require.Panics(t, func() { newMainnetRpcClient(config, log.NewNopLogger()) })
+}
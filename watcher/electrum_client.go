@@ -0,0 +1,290 @@
+package watcher
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/tendermint/tendermint/libs/log"
+
+	cctypes "github.com/smartbch/smartbch/crosschain/types"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/watcher/types"
+)
+
+const (
+	ElectrumClientName      = "smartbchd"
+	ElectrumProtocolVersion = "1.4"
+
+	electrumDialTimeout = 10 * time.Second
+	electrumReadTimeout = 60 * time.Second
+)
+
+// ElectrumClient fetches BCH blocks from an Electrum server (such as Fulcrum) using the
+// newline-delimited JSON-RPC protocol over TCP or TLS. Electrum servers do not serve full blocks,
+// so the txs of a block are fetched one by one by their positions, and checked against the merkle
+// root in the header.
+type ElectrumClient struct {
+	url    string
+	logger log.Logger
+
+	mtx    sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	nextId uint64
+}
+
+var _ types.RpcClient = (*ElectrumClient)(nil)
+
+func NewElectrumClient(url string, logger log.Logger) *ElectrumClient {
+	if url == "" {
+		return nil
+	}
+	return &ElectrumClient{
+		url:    url,
+		logger: logger,
+	}
+}
+
+type electrumRequest struct {
+	Id     uint64        `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+type electrumError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *electrumError) Error() string {
+	return fmt.Sprintf("code:%d, msg:%s", e.Code, e.Message)
+}
+
+type electrumResponse struct {
+	Id     *uint64         `json:"id"`
+	Method string          `json:"method"` // non-empty for notifications
+	Result json.RawMessage `json:"result"`
+	Error  *electrumError  `json:"error"`
+}
+
+type electrumHeaderNotification struct {
+	Height int64  `json:"height"`
+	Hex    string `json:"hex"`
+}
+
+func (client *ElectrumClient) GetLatestHeight(retry bool) int64 {
+	for {
+		var header electrumHeaderNotification
+		err := client.call("blockchain.headers.subscribe", nil, &header)
+		if err == nil {
+			return header.Height
+		}
+		if !retry {
+			return -1
+		}
+		client.logger.Debug("GetLatestHeight failed", err.Error())
+		time.Sleep(10 * time.Second)
+	}
+}
+
+func (client *ElectrumClient) GetBlockByHeight(height int64, retry bool) *types.BCHBlock {
+	for {
+		blk, err := client.getBCHBlock(height)
+		if err == nil {
+			return blk
+		}
+		if !retry {
+			return nil
+		}
+		client.logger.Debug(fmt.Sprintf("getBCHBlock %d failed", height), err.Error())
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// Electrum servers know nothing about smartBCH's epochs, they can only be fetched from a smartBCH node
+func (client *ElectrumClient) GetEpochs(start, end uint64) []*stakingtypes.Epoch {
+	return nil
+}
+
+func (client *ElectrumClient) GetCCEpochs(start, end uint64) []*cctypes.CCEpoch {
+	return nil
+}
+
+func (client *ElectrumClient) getBCHBlock(height int64) (*types.BCHBlock, error) {
+	var headerHex string
+	err := client.call("blockchain.block.header", []interface{}{height}, &headerHex)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return nil, err
+	}
+	var header wire.BlockHeader
+	err = header.Deserialize(bytes.NewReader(bz))
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return newBCHBlock(&header, height, nil), nil
+	}
+	txs, err := client.getBlockTxs(height)
+	if err != nil {
+		return nil, err
+	}
+	// the server tells no tx count, so a merkle root mismatch means some txs are missing
+	utilTxs := make([]*btcutil.Tx, len(txs))
+	for i, tx := range txs {
+		utilTxs[i] = btcutil.NewTx(tx)
+	}
+	merkles := blockchain.BuildMerkleTreeStore(utilTxs, false)
+	if !header.MerkleRoot.IsEqual(merkles[len(merkles)-1]) {
+		return nil, fmt.Errorf("merkle root mismatch of the %d txs in block %d", len(txs), height)
+	}
+	bchBlock := newBCHBlock(&header, height, txs[0])
+	txInfos := make([]types.TxInfo, len(txs))
+	for i, tx := range txs {
+		txInfos[i] = msgTxToTxInfo(tx)
+	}
+	collectCCInfos(bchBlock, txInfos)
+	return bchBlock, nil
+}
+
+// getBlockTxs fetches the txs of a block one by one, until the server reports no tx at the next position
+func (client *ElectrumClient) getBlockTxs(height int64) ([]*wire.MsgTx, error) {
+	var txs []*wire.MsgTx
+	for pos := 0; ; pos++ {
+		var txid string
+		err := client.call("blockchain.transaction.id_from_pos", []interface{}{height, pos}, &txid)
+		var electrumErr *electrumError
+		if pos > 0 && errors.As(err, &electrumErr) {
+			return txs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var txHex string
+		err = client.call("blockchain.transaction.get", []interface{}{txid, false}, &txHex)
+		if err != nil {
+			return nil, err
+		}
+		bz, err := hex.DecodeString(txHex)
+		if err != nil {
+			return nil, err
+		}
+		tx := &wire.MsgTx{}
+		err = tx.Deserialize(bytes.NewReader(bz))
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+}
+
+// call sends a request and waits for its response. Requests are serialized, and the connection
+// is dropped when any error occurs, such that the next call starts from a clean connection.
+func (client *ElectrumClient) call(method string, params []interface{}, result interface{}) error {
+	client.mtx.Lock()
+	defer client.mtx.Unlock()
+	if client.conn == nil {
+		if err := client.connect(); err != nil {
+			return err
+		}
+	}
+	err := client.roundTrip(method, params, result)
+	if err != nil {
+		client.close()
+	}
+	return err
+}
+
+func (client *ElectrumClient) connect() error {
+	u, err := url.Parse(client.url)
+	if err != nil {
+		return err
+	}
+	dialer := &net.Dialer{Timeout: electrumDialTimeout}
+	switch u.Scheme {
+	case "tcp":
+		client.conn, err = dialer.Dial("tcp", u.Host)
+	case "ssl", "tls":
+		client.conn, err = tls.DialWithDialer(dialer, "tcp", u.Host, &tls.Config{ServerName: u.Hostname()})
+	default:
+		return fmt.Errorf("unsupported scheme of electrum url: %s", client.url)
+	}
+	if err != nil {
+		client.conn = nil
+		return err
+	}
+	client.reader = bufio.NewReader(client.conn)
+	// the server may refuse any other request before the protocol version is negotiated
+	err = client.roundTrip("server.version", []interface{}{ElectrumClientName, ElectrumProtocolVersion}, nil)
+	if err != nil {
+		client.close()
+	}
+	return err
+}
+
+func (client *ElectrumClient) close() {
+	if client.conn != nil {
+		_ = client.conn.Close()
+	}
+	client.conn = nil
+	client.reader = nil
+}
+
+func (client *ElectrumClient) roundTrip(method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	client.nextId++
+	id := client.nextId
+	reqData, err := json.Marshal(electrumRequest{Id: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	err = client.conn.SetDeadline(time.Now().Add(electrumReadTimeout))
+	if err != nil {
+		return err
+	}
+	_, err = client.conn.Write(append(reqData, '\n'))
+	if err != nil {
+		return err
+	}
+	for {
+		line, err := client.reader.ReadBytes('\n')
+		if err != nil {
+			return err
+		}
+		var resp electrumResponse
+		err = json.Unmarshal(line, &resp)
+		if err != nil {
+			return err
+		}
+		if resp.Id == nil || *resp.Id != id {
+			continue // notifications of subscriptions, or stale responses
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("%s error, %w", method, resp.Error)
+		}
+		if result == nil {
+			return nil
+		}
+		if len(resp.Result) == 0 {
+			return errors.New(method + " returns empty result")
+		}
+		return json.Unmarshal(resp.Result, result)
+	}
+}
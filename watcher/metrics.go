@@ -0,0 +1,61 @@
+package watcher
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this package.
+	MetricsSubsystem = "watcher"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of BCH mainnet reorganisations deeper than blockFinalizeNumber.
+	DeepReorgs metrics.Counter
+	// Depth of the latest deep reorganisation, counted in finalized blocks.
+	DeepReorgDepth metrics.Gauge
+	// Height of the latest finalized BCH mainnet block.
+	LatestFinalizedHeight metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library, which are
+// registered into the given registerer.
+func PrometheusMetrics(registerer stdprometheus.Registerer, namespace string) *Metrics {
+	deepReorgs := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "deep_reorgs",
+		Help:      "Number of BCH mainnet reorganisations which replaced finalized blocks.",
+	}, nil)
+	deepReorgDepth := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "deep_reorg_depth",
+		Help:      "Number of finalized blocks replaced by the latest deep reorganisation.",
+	}, nil)
+	latestFinalizedHeight := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "latest_finalized_height",
+		Help:      "Height of the latest finalized BCH mainnet block.",
+	}, nil)
+	registerer.MustRegister(deepReorgs, deepReorgDepth, latestFinalizedHeight)
+	return &Metrics{
+		DeepReorgs:            prometheus.NewCounter(deepReorgs),
+		DeepReorgDepth:        prometheus.NewGauge(deepReorgDepth),
+		LatestFinalizedHeight: prometheus.NewGauge(latestFinalizedHeight),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		DeepReorgs:            discard.NewCounter(),
+		DeepReorgDepth:        discard.NewGauge(),
+		LatestFinalizedHeight: discard.NewGauge(),
+	}
+}
@@ -0,0 +1,89 @@
+package watcher
+
+import (
+	"encoding/hex"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/smartbch/smartbch/watcher/types"
+)
+
+const satoshisPerBCH = 1e8
+
+// The helpers in this file convert raw BCH data structures, which are obtained from the data sources
+// other than BCHN's JSON-RPC, into the same types used by RpcClient, such that the parsing logic in
+// TxInfo is shared by all the data sources.
+
+// converts a block hash to the byte order used by BCHBlock.HashId, i.e., the displaying order of BCHN
+func hashToDisplayBytes(hash chainhash.Hash) (out [32]byte) {
+	for i := 0; i < chainhash.HashSize; i++ {
+		out[i] = hash[chainhash.HashSize-1-i]
+	}
+	return
+}
+
+func newBCHBlock(header *wire.BlockHeader, height int64, coinbase *wire.MsgTx) *types.BCHBlock {
+	bchBlock := &types.BCHBlock{
+		Height:    height,
+		Timestamp: header.Timestamp.Unix(),
+		HashId:    hashToDisplayBytes(header.BlockHash()),
+		ParentBlk: hashToDisplayBytes(header.PrevBlock),
+	}
+	if height > 0 && coinbase != nil {
+		nomination := getNomination(msgTxToTxInfo(coinbase))
+		if nomination != nil {
+			bchBlock.Nominations = append(bchBlock.Nominations, *nomination)
+		}
+	}
+	return bchBlock
+}
+
+// msgTxToTxInfo fills the fields of TxInfo like 'getrawtransaction <txid> true' of BCHN
+func msgTxToTxInfo(tx *wire.MsgTx) types.TxInfo {
+	txid := tx.TxHash().String()
+	info := types.TxInfo{
+		TxID:     txid,
+		Hash:     txid,
+		Version:  int(tx.Version),
+		Size:     tx.SerializeSize(),
+		Locktime: int(tx.LockTime),
+		VinList:  make([]map[string]interface{}, 0, len(tx.TxIn)),
+		VoutList: make([]types.Vout, 0, len(tx.TxOut)),
+	}
+	isCoinbase := len(tx.TxIn) == 1 && tx.TxIn[0].PreviousOutPoint.Index == wire.MaxPrevOutIndex &&
+		tx.TxIn[0].PreviousOutPoint.Hash == (chainhash.Hash{})
+	for _, in := range tx.TxIn {
+		if isCoinbase {
+			info.VinList = append(info.VinList, map[string]interface{}{
+				"coinbase": hex.EncodeToString(in.SignatureScript),
+				"sequence": float64(in.Sequence),
+			})
+			continue
+		}
+		asm, _ := txscript.DisasmString(in.SignatureScript)
+		info.VinList = append(info.VinList, map[string]interface{}{
+			"txid": in.PreviousOutPoint.Hash.String(),
+			"vout": float64(in.PreviousOutPoint.Index),
+			"scriptSig": map[string]interface{}{
+				"asm": asm,
+				"hex": hex.EncodeToString(in.SignatureScript),
+			},
+			"sequence": float64(in.Sequence),
+		})
+	}
+	for n, out := range tx.TxOut {
+		// DisasmString returns the script disassembled so far when it meets an error
+		asm, _ := txscript.DisasmString(out.PkScript)
+		info.VoutList = append(info.VoutList, types.Vout{
+			Value: float64(out.Value) / satoshisPerBCH,
+			N:     n,
+			ScriptPubKey: map[string]interface{}{
+				"asm": asm,
+				"hex": hex.EncodeToString(out.PkScript),
+			},
+		})
+	}
+	return info
+}
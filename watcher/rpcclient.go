@@ -156,7 +156,7 @@ func (client *RpcClient) getBCHBlock(hash string) (*types.BCHBlock, error) {
 		if nomination != nil {
 			bchBlock.Nominations = append(bchBlock.Nominations, *nomination)
 		}
-		//bchBlock.CCTransferInfos = append(bchBlock.CCTransferInfos, client.getCCTransferInfos(bi)...)
+		collectCCInfos(bchBlock, bi.Tx)
 	}
 	return bchBlock, nil
 }
@@ -172,13 +172,13 @@ func getNomination(coinbase types.TxInfo) *stakingtypes.Nomination {
 	return nil
 }
 
-//func (client *RpcClient) getCCTransferInfos(bi *types.BlockInfo) []*cctypes.CCTransferInfo {
-//	var ccInfos []*cctypes.CCTransferInfo
-//	for _, info := range bi.Tx {
-//		ccInfos = append(ccInfos, info.GetCCTransferInfos()...)
-//	}
-//	return ccInfos
-//}
+// collectCCInfos fills the deposits to and the spends from the ShaGate address in the txs of a block
+func collectCCInfos(bchBlock *types.BCHBlock, txs []types.TxInfo) {
+	for _, info := range txs {
+		bchBlock.CCTransferInfos = append(bchBlock.CCTransferInfos, info.GetCCTransferInfos()...)
+		bchBlock.CCSpendInfos = append(bchBlock.CCSpendInfos, info.GetCCSpendInfos()...)
+	}
+}
 
 func (client *RpcClient) getCurrHeight() int64 {
 	var respData []byte
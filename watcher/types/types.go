@@ -1,11 +1,16 @@
 package types
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
-	"fmt"
+	"math"
 	"strings"
 
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
 )
@@ -13,6 +18,8 @@ import (
 const (
 	Identifier = "73424348" // ascii code for 'sBCH'
 	Version    = "00"
+	// follows Identifier in the OP_RETURN output which specifies the smartBCH receiver of a cross chain deposit
+	CCReceiverFlag = "01"
 
 	ShaGateAddress = "14f8c7e99fd4e867c34cbd5968e35575fd5919a4"
 )
@@ -33,6 +40,7 @@ type BCHBlock struct {
 	ParentBlk       [32]byte
 	Nominations     []stakingtypes.Nomination
 	CCTransferInfos []*cctypes.CCTransferInfo
+	CCSpendInfos    []*cctypes.CCSpendInfo
 }
 
 // not check Nominations
@@ -140,6 +148,10 @@ func (ti TxInfo) GetValidatorPubKey() (pubKey [32]byte, success bool) {
 	return
 }
 
+// GetCCTransferInfos returns the outputs paying to the ShaGate address. The sender of a deposit is the owner of
+// the pubkey found in the scriptSig of the first input, and an OP_RETURN output can specify another receiver
+// on smartBCH. The deposits are returned even if neither is found, and it is up to the cross chain contract
+// to decide how to handle them.
 func (ti TxInfo) GetCCTransferInfos() (infos []*cctypes.CCTransferInfo) {
 	for n, vOut := range ti.VoutList {
 		asm, ok := vOut.ScriptPubKey["asm"]
@@ -154,37 +166,190 @@ func (ti TxInfo) GetCCTransferInfos() (infos []*cctypes.CCTransferInfo) {
 		if script != target {
 			continue
 		}
+		// the txid is displayed in the reversed byte order by BCHN, and we keep this order like BCHBlock.HashId
 		var info cctypes.CCTransferInfo
-		info.Amount = uint64(vOut.Value * (10e8))
-		copy(info.UTXO[:32], ti.Hash)
-		var vOutIndex [4]byte
-		binary.BigEndian.PutUint32(vOutIndex[:], uint32(n))
-		copy(info.UTXO[32:], vOutIndex[:])
+		if !decodeTxid(ti.TxID, info.UTXO[:32]) {
+			return nil
+		}
+		info.Amount = uint64(math.Round(vOut.Value * 1e8))
+		binary.BigEndian.PutUint32(info.UTXO[32:], uint32(n))
 		infos = append(infos, &info)
 	}
-	if len(infos) != 0 {
-		vIn := ti.VinList[0]
-		//todo: modify this to match real rules, for test now
-		value, exist := vIn["test"]
-		if !exist || value == nil {
-			return nil
+	if len(infos) == 0 {
+		return
+	}
+	pubkey, hasPubkey := ti.GetSenderPubkey()
+	receiver, hasReceiver := ti.GetCCReceiver()
+	for _, info := range infos {
+		if hasPubkey {
+			info.SenderPubkey = pubkey
+		}
+		if hasReceiver {
+			info.Receiver = receiver
 		}
-		pubkeyString, ok := value.(string)
+	}
+	return
+}
+
+// GetCCSpendInfos returns the infos of this tx if it spends UTXOs of the ShaGate address
+func (ti TxInfo) GetCCSpendInfos() []*cctypes.CCSpendInfo {
+	shaGateHash, _ := hex.DecodeString(ShaGateAddress)
+	return ti.GetSpendInfosOf(shaGateHash)
+}
+
+// GetSpendInfosOf returns the infos of this tx if it spends UTXOs of the P2SH address 'scriptHash', which are
+// recognized by the redeem scripts in the scriptSigs
+func (ti TxInfo) GetSpendInfosOf(scriptHash []byte) (infos []*cctypes.CCSpendInfo) {
+	for _, vIn := range ti.VinList {
+		scriptSig, ok := vIn["scriptSig"].(map[string]interface{})
 		if !ok {
-			return nil
+			continue
+		}
+		hexStr, _ := scriptSig["hex"].(string)
+		txid, _ := vIn["txid"].(string)
+		vout, _ := vIn["vout"].(float64)
+		bz, err := hex.DecodeString(hexStr)
+		if err != nil || !txscript.IsPushOnlyScript(bz) {
+			continue
 		}
-		pubkeyBytes, err := hex.DecodeString(pubkeyString)
+		pushes, err := txscript.PushedData(bz)
+		if err != nil || len(pushes) == 0 || !bytes.Equal(btcutil.Hash160(pushes[len(pushes)-1]), scriptHash) {
+			continue
+		}
+		var info cctypes.CCSpendInfo
+		if !decodeTxid(txid, info.UTXO[:32]) || !decodeTxid(ti.TxID, info.MainnetTxId[:]) {
+			continue
+		}
+		binary.BigEndian.PutUint32(info.UTXO[32:], uint32(vout))
+		infos = append(infos, &info)
+	}
+	if len(infos) == 0 {
+		return
+	}
+	var payouts []*cctypes.CCPayout
+	for _, vOut := range ti.VoutList {
+		hexStr, _ := vOut.ScriptPubKey["hex"].(string)
+		pkScript, err := hex.DecodeString(hexStr)
 		if err != nil {
-			return nil
+			continue
 		}
-		var pubkey [33]byte
-		copy(pubkey[:], pubkeyBytes)
-		fmt.Printf("get cc infos:\n")
-		for _, info := range infos {
-			info.SenderPubkey = pubkey
-			fmt.Printf("info.pubkey:%v, info.amount:%d, info.utxo:%v\n", info.SenderPubkey, info.Amount, info.UTXO)
+		if target, ok := GetPayoutTarget(pkScript); ok {
+			payouts = append(payouts, &cctypes.CCPayout{Target: target, Amount: uint64(math.Round(vOut.Value * 1e8))})
+		}
+	}
+	for _, info := range infos {
+		info.Payouts = payouts
+	}
+	return
+}
+
+// decode a txid in hex into 'out' in the displayed byte order
+func decodeTxid(txid string, out []byte) bool {
+	bz, err := hex.DecodeString(txid)
+	if err != nil || len(bz) != 32 {
+		return false
+	}
+	copy(out, bz)
+	return true
+}
+
+// GetPayoutTarget returns the cashaddr type byte followed by the hash if 'pkScript' is P2PKH or P2SH
+func GetPayoutTarget(pkScript []byte) (target [21]byte, success bool) {
+	switch txscript.GetScriptClass(pkScript) {
+	case txscript.PubKeyHashTy: // OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG
+		copy(target[1:], pkScript[3:23])
+	case txscript.ScriptHashTy: // OP_HASH160 <hash> OP_EQUAL
+		target[0] = 1
+		copy(target[1:], pkScript[2:22])
+	default:
+		return
+	}
+	success = true
+	return
+}
+
+// GetCCReceiver returns the smartBCH address specified by an OP_RETURN output
+func (ti TxInfo) GetCCReceiver() (receiver [20]byte, success bool) {
+	for _, vout := range ti.VoutList {
+		hexStr, ok := vout.ScriptPubKey["hex"].(string)
+		if !ok {
+			continue
 		}
+		pkScript, err := hex.DecodeString(hexStr)
+		if err != nil {
+			continue
+		}
+		receiver, success = GetCCReceiverFromPkScript(pkScript)
+		if success {
+			break
+		}
+	}
+	return
+}
+
+// GetCCReceiverFromPkScript returns the smartBCH address if 'pkScript' is an OP_RETURN output specifying it
+func GetCCReceiverFromPkScript(pkScript []byte) (receiver [20]byte, success bool) {
+	if len(pkScript) == 0 || pkScript[0] != txscript.OP_RETURN {
+		return
+	}
+	pushes, err := txscript.PushedData(pkScript[1:])
+	if err != nil || len(pushes) != 1 {
+		return
+	}
+	prefix, _ := hex.DecodeString(Identifier + CCReceiverFlag)
+	if len(pushes[0]) != len(prefix)+20 || !bytes.HasPrefix(pushes[0], prefix) {
+		return
+	}
+	copy(receiver[:], pushes[0][len(prefix):])
+	success = true
+	return
+}
+
+// GetSenderPubkey returns the compressed pubkey which signs the first input, which must spend a P2PKH output.
+// A P2SH multisig output is controlled by several keys, none of which can be credited alone, so the deposits
+// from it have no sender pubkey and are parked unless a receiver is specified.
+func (ti TxInfo) GetSenderPubkey() (pubkey [33]byte, success bool) {
+	if len(ti.VinList) == 0 {
+		return
+	}
+	scriptSig, ok := ti.VinList[0]["scriptSig"].(map[string]interface{})
+	if !ok {
+		return // a coinbase input has no scriptSig
+	}
+	hexStr, ok := scriptSig["hex"].(string)
+	if !ok {
+		return
+	}
+	bz, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return
+	}
+	return GetPubkeyFromScriptSig(bz)
+}
+
+// GetPubkeyFromScriptSig returns the compressed pubkey in a scriptSig spending a P2PKH output
+func GetPubkeyFromScriptSig(scriptSig []byte) (pubkey [33]byte, success bool) {
+	if !txscript.IsPushOnlyScript(scriptSig) {
+		return
+	}
+	pushes, err := txscript.PushedData(scriptSig)
+	if err != nil {
+		return
+	}
+	// P2PKH: <sig> <pubkey>
+	if len(pushes) != 2 {
+		return
+	}
+	return compressPubkey(pushes[1])
+}
+
+func compressPubkey(bz []byte) (pubkey [33]byte, success bool) {
+	key, err := btcec.ParsePubKey(bz, btcec.S256())
+	if err != nil {
+		return
 	}
+	copy(pubkey[:], key.SerializeCompressed())
+	success = true
 	return
 }
 
@@ -22,6 +22,7 @@ const (
 	NumBlocksToClearMemory = 1000
 	WaitingBlockDelayTime  = 2
 	blockFinalizeNumber    = 9
+	maxReorgRetries        = 10
 )
 
 // A watcher watches the new blocks generated on bitcoin cash's mainnet, and
@@ -37,6 +38,7 @@ type Watcher struct {
 	heightToFinalizedBlock map[int64]*types.BCHBlock
 
 	catchupChan chan bool
+	quitChan    chan struct{}
 
 	EpochChan          chan *stakingtypes.Epoch
 	epochList          []*stakingtypes.Epoch
@@ -49,12 +51,14 @@ type Watcher struct {
 	numBlocksInCCEpoch   int64
 	ccEpochList          []*cctypes.CCEpoch
 	lastKnownCCEpochNum  int64
+	ccEpochEnabled       bool
 
 	numBlocksToClearMemory int
 	waitingBlockDelayTime  int
 	parallelNum            int
 
 	chainConfig *param.ChainConfig
+	metrics     *Metrics
 
 	currentMainnetBlockTimestamp int64
 }
@@ -69,6 +73,7 @@ func NewWatcher(logger log.Logger, lastHeight, lastCCEpochEndHeight int64, lastK
 		lastKnownEpochNum:     lastKnownEpochNum,
 
 		catchupChan: make(chan bool, 1),
+		quitChan:    make(chan struct{}),
 
 		heightToFinalizedBlock: make(map[int64]*types.BCHBlock),
 		epochList:              make([]*stakingtypes.Epoch, 0, 10),
@@ -83,22 +88,54 @@ func NewWatcher(logger log.Logger, lastHeight, lastCCEpochEndHeight int64, lastK
 		ccEpochList:          make([]*cctypes.CCEpoch, 0, 40),
 		lastCCEpochEndHeight: lastCCEpochEndHeight,
 		numBlocksInCCEpoch:   param.BlocksInCCEpoch,
+		ccEpochEnabled:       param.ShaGateSwitch,
 
 		parallelNum: 10,
 		chainConfig: chainConfig,
+		metrics:     NopMetrics(),
 		// set big enough for single node startup when no BCH node connected. it will be updated when mainnet block finalize.
 		currentMainnetBlockTimestamp: math.MaxInt64 - 14*24*3600,
 	}
 	if !chainConfig.AppConfig.DisableBchClient {
-		w.rpcClient = NewRpcClient(chainConfig.AppConfig.MainnetRPCUrl, chainConfig.AppConfig.MainnetRPCUsername, chainConfig.AppConfig.MainnetRPCPassword, "text/plain;", logger)
+		w.rpcClient = newMainnetRpcClient(chainConfig.AppConfig, logger)
 	}
 	return w
 }
 
+// newMainnetRpcClient returns a client of the data source selected by AppConfig.MainnetDataSource,
+// or nil if the data source is not configured.
+func newMainnetRpcClient(config *param.AppConfig, logger log.Logger) types.RpcClient {
+	switch config.MainnetDataSource {
+	case param.MainnetDataSourceElectrum:
+		if c := NewElectrumClient(config.MainnetElectrumUrl, logger); c != nil {
+			return c
+		}
+	case param.MainnetDataSourceBlkFiles:
+		if c := NewBlkFileClient(config.MainnetBlocksDir, logger); c != nil {
+			return c
+		}
+	case param.MainnetDataSourceBCHN, "":
+		if c := NewRpcClient(config.MainnetRPCUrl, config.MainnetRPCUsername, config.MainnetRPCPassword, "text/plain;", logger); c != nil {
+			return c
+		}
+	default:
+		panic("unknown mainnet data source: " + config.MainnetDataSource)
+	}
+	return nil
+}
+
 func (watcher *Watcher) SetNumBlocksInEpoch(n int64) {
 	watcher.numBlocksInEpoch = n
 }
 
+func (watcher *Watcher) SetNumBlocksInCCEpoch(n int64) {
+	watcher.numBlocksInCCEpoch = n
+}
+
+func (watcher *Watcher) SetCCEpochEnabled(enabled bool) {
+	watcher.ccEpochEnabled = enabled
+}
+
 func (watcher *Watcher) SetNumBlocksToClearMemory(n int) {
 	watcher.numBlocksToClearMemory = n
 }
@@ -107,13 +144,32 @@ func (watcher *Watcher) SetWaitingBlockDelayTime(n int) {
 	watcher.waitingBlockDelayTime = n
 }
 
+func (watcher *Watcher) SetMetrics(m *Metrics) {
+	watcher.metrics = m
+}
+
 func (watcher *Watcher) WaitCatchup() {
 	<-watcher.catchupChan
 }
 
+// Stop makes fetchBlocks return before fetching the next block, the epochs already sent to the channels
+// are not affected
+func (watcher *Watcher) Stop() {
+	close(watcher.quitChan)
+}
+
+func (watcher *Watcher) stopped() bool {
+	select {
+	case <-watcher.quitChan:
+		return true
+	default:
+		return false
+	}
+}
+
 // The main function to do a watcher's job. It must be run as a goroutine
 func (watcher *Watcher) Run() {
-	if watcher.rpcClient == (*RpcClient)(nil) {
+	if watcher.rpcClient == nil {
 		//for ut
 		if !watcher.chainConfig.AppConfig.DisableBchClient {
 			watcher.catchupChan <- true
@@ -132,6 +188,10 @@ func (watcher *Watcher) Run() {
 
 func (watcher *Watcher) fetchBlocks() {
 	catchedUp := false
+	if watcher.ccEpochEnabled && watcher.lastCCEpochEndHeight < watcher.latestFinalizedHeight {
+		// the blocks after the last cc epoch are needed to build the next one
+		watcher.latestFinalizedHeight = watcher.lastCCEpochEndHeight
+	}
 	latestMainnetHeight := watcher.rpcClient.GetLatestHeight(true)
 	heightWanted := watcher.latestFinalizedHeight + 1
 	// parallel fetch blocks when startup
@@ -141,9 +201,9 @@ func (watcher *Watcher) fetchBlocks() {
 		heightWanted = watcher.latestFinalizedHeight + 1
 	}
 	// normal catchup
-	for {
+	for !watcher.stopped() {
 		latestMainnetHeight = watcher.rpcClient.GetLatestHeight(true)
-		for heightWanted+blockFinalizeNumber <= latestMainnetHeight {
+		for heightWanted+blockFinalizeNumber <= latestMainnetHeight && !watcher.stopped() {
 			watcher.addFinalizedBlock(watcher.rpcClient.GetBlockByHeight(heightWanted, true))
 			heightWanted++
 			latestMainnetHeight = watcher.rpcClient.GetLatestHeight(true)
@@ -261,27 +321,105 @@ func (watcher *Watcher) ccEpochSpeedup() {
 		watcher.ccEpochList = append(watcher.ccEpochList, epochs...)
 		for _, e := range epochs {
 			watcher.CCEpochChan <- e
+			watcher.lastCCEpochEndHeight = e.StartHeight + watcher.numBlocksInCCEpoch - 1
 		}
+		watcher.lastKnownCCEpochNum += int64(len(epochs))
 		start = start + uint64(len(epochs))
 	}
 }
 
 func (watcher *Watcher) suspended(delayDuration time.Duration) {
-	time.Sleep(delayDuration)
+	select {
+	case <-time.After(delayDuration):
+	case <-watcher.quitChan:
+	}
 }
 
 // Record new block and if the blocks for a new epoch is all ready, output the new epoch
 func (watcher *Watcher) addFinalizedBlock(blk *types.BCHBlock) {
+	// the parent of a newly finalized block must be the last finalized one, otherwise the mainnet
+	// has been reorganised deeper than blockFinalizeNumber. The retries are bounded: the blocks used by
+	// the emitted epochs can not be replaced, so the watcher backs off and waits for the mainnet to
+	// settle, and at last it keeps going on the new chain.
+	for retries := 0; ; retries++ {
+		parent, ok := watcher.heightToFinalizedBlock[blk.Height-1]
+		if !ok || parent.HashId == blk.ParentBlk {
+			break
+		}
+		if retries == maxReorgRetries {
+			watcher.logger.Error("BCH mainnet reorganisation can not be recovered, the new block is accepted",
+				"height", blk.Height, "retries", retries)
+			break
+		}
+		if blk.Height-1 <= watcher.lastEmittedHeight() {
+			watcher.metrics.DeepReorgs.Add(1)
+			watcher.logger.Error("BCH mainnet reorganisation reaches the blocks of an emitted epoch",
+				"height", blk.Height, "lastEmittedHeight", watcher.lastEmittedHeight(), "retries", retries)
+			watcher.suspended(time.Duration(watcher.waitingBlockDelayTime) * time.Second)
+		} else {
+			watcher.recoverFromReorg(blk.Height - 1)
+		}
+		if watcher.stopped() {
+			return
+		}
+		blk = watcher.rpcClient.GetBlockByHeight(blk.Height, true)
+	}
 	watcher.heightToFinalizedBlock[blk.Height] = blk
 	watcher.latestFinalizedHeight++
+	watcher.metrics.LatestFinalizedHeight.Set(float64(watcher.latestFinalizedHeight))
 	watcher.currentMainnetBlockTimestamp = blk.Timestamp
 
 	if watcher.latestFinalizedHeight-watcher.lastEpochEndHeight == watcher.numBlocksInEpoch {
+		// an epoch must not be built from the blocks which are no longer on the best chain
+		watcher.recoverFromReorg(watcher.latestFinalizedHeight)
 		watcher.generateNewEpoch()
 	}
-	//if watcher.latestFinalizedHeight-watcher.lastCCEpochEndHeight == watcher.numBlocksInCCEpoch {
-	//	watcher.generateNewCCEpoch()
-	//}
+	if watcher.ccEpochEnabled && watcher.latestFinalizedHeight-watcher.lastCCEpochEndHeight == watcher.numBlocksInCCEpoch {
+		watcher.recoverFromReorg(watcher.latestFinalizedHeight)
+		watcher.generateNewCCEpoch()
+	}
+}
+
+// the blocks up to this height have been used by the emitted epochs
+func (watcher *Watcher) lastEmittedHeight() int64 {
+	if watcher.ccEpochEnabled && watcher.lastCCEpochEndHeight > watcher.lastEpochEndHeight {
+		return watcher.lastCCEpochEndHeight
+	}
+	return watcher.lastEpochEndHeight
+}
+
+// recoverFromReorg compares the finalized blocks with the ones on the best chain, from the given
+// height down to the fork point, and replaces the orphaned ones. Blocks which have been used by the
+// emitted epochs can not be replaced, so a reorg reaching them is only reported.
+func (watcher *Watcher) recoverFromReorg(height int64) {
+	emittedHeight := watcher.lastEmittedHeight()
+	forkHeight := height
+	for ; forkHeight > emittedHeight; forkHeight-- {
+		old, ok := watcher.heightToFinalizedBlock[forkHeight]
+		if !ok {
+			break
+		}
+		blk := watcher.rpcClient.GetBlockByHeight(forkHeight, true)
+		if blk.HashId == old.HashId {
+			break
+		}
+		watcher.heightToFinalizedBlock[forkHeight] = blk
+	}
+	depth := height - forkHeight
+	if depth == 0 {
+		return
+	}
+	watcher.metrics.DeepReorgs.Add(1)
+	watcher.metrics.DeepReorgDepth.Set(float64(depth))
+	watcher.logger.Error("BCH mainnet reorganised deeper than the finalize depth",
+		"forkHeight", forkHeight, "replacedBlocks", depth, "finalizeDepth", blockFinalizeNumber)
+	if forkHeight == emittedHeight {
+		old, ok := watcher.heightToFinalizedBlock[forkHeight]
+		if ok && watcher.rpcClient.GetBlockByHeight(forkHeight, true).HashId != old.HashId {
+			watcher.logger.Error("BCH mainnet reorganisation reaches the blocks of an emitted epoch",
+				"lastEmittedHeight", emittedHeight)
+		}
+	}
 }
 
 // Generate a new block's information
@@ -323,6 +461,35 @@ func (watcher *Watcher) buildNewEpoch() *stakingtypes.Epoch {
 	return epoch
 }
 
+// Generate a new cc epoch with the cross chain txs in the finalized blocks
+func (watcher *Watcher) generateNewCCEpoch() {
+	epoch := watcher.buildNewCCEpoch()
+	watcher.ccEpochList = append(watcher.ccEpochList, epoch)
+	watcher.logger.Debug("Generate new cc epoch", "startHeight", epoch.StartHeight,
+		"transferInfos", len(epoch.TransferInfos), "spendInfos", len(epoch.SpendInfos))
+	watcher.CCEpochChan <- epoch
+	watcher.lastCCEpochEndHeight = watcher.latestFinalizedHeight
+	watcher.lastKnownCCEpochNum++
+}
+
+func (watcher *Watcher) buildNewCCEpoch() *cctypes.CCEpoch {
+	epoch := &cctypes.CCEpoch{
+		StartHeight: watcher.lastCCEpochEndHeight + 1,
+	}
+	for i := epoch.StartHeight; i <= watcher.latestFinalizedHeight; i++ {
+		blk, ok := watcher.heightToFinalizedBlock[i]
+		if !ok {
+			panic("Missing Block")
+		}
+		if epoch.EndTime < blk.Timestamp {
+			epoch.EndTime = blk.Timestamp
+		}
+		epoch.TransferInfos = append(epoch.TransferInfos, blk.CCTransferInfos...)
+		epoch.SpendInfos = append(epoch.SpendInfos, blk.CCSpendInfos...)
+	}
+	return epoch
+}
+
 func (watcher *Watcher) GetCurrEpoch() *stakingtypes.Epoch {
 	return watcher.buildNewEpoch()
 }
@@ -380,7 +547,7 @@ func (watcher *Watcher) ClearOldData() {
 		watcher.epochList = watcher.epochList[elLen-5:]
 	}
 	ccEpochLen := len(watcher.ccEpochList)
-	if ccEpochLen > 5*int(param.StakingNumBlocksInEpoch/param.BlocksInCCEpoch) {
-		watcher.epochList = watcher.epochList[ccEpochLen-5:]
+	if maxLen := 5 * int(param.StakingNumBlocksInEpoch/param.BlocksInCCEpoch); ccEpochLen > maxLen {
+		watcher.ccEpochList = watcher.ccEpochList[ccEpochLen-maxLen:]
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/log"
 
@@ -22,8 +23,8 @@ type MockBCHNode struct {
 }
 
 var testValidatorPubkey1 = [32]byte{0x1}
+var testValidatorPubkey2 = [32]byte{0x2}
 
-//var testValidatorPubkey2 = [32]byte{0x2}
 //var testValidatorPubkey3 = [32]byte{0x3}
 
 func buildMockBCHNodeWithOnlyValidator1() *MockBCHNode {
@@ -140,6 +141,24 @@ func TestRun(t *testing.T) {
 	require.Equal(t, int64(91), w.latestFinalizedHeight)
 }
 
+func TestStop(t *testing.T) {
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
+	w.rpcClient = MockRpcClient{node: buildMockBCHNodeWithOnlyValidator1()}
+	w.SetWaitingBlockDelayTime(3600)
+	done := make(chan bool)
+	go func() {
+		w.Run()
+		done <- true
+	}()
+	w.WaitCatchup()
+	w.Stop()
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("watcher is not stopped")
+	}
+}
+
 func TestRunWithNewEpoch(t *testing.T) {
 	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
 	w.rpcClient = MockRpcClient{node: buildMockBCHNodeWithOnlyValidator1()}
@@ -163,6 +182,42 @@ func TestRunWithNewEpoch(t *testing.T) {
 	}
 }
 
+func TestRunWithNewCCEpoch(t *testing.T) {
+	// the blocks before the staking epoch's end are fetched again to build the cc epochs
+	w := NewWatcher(log.NewNopLogger(), 20, 0, 0, param.DefaultConfig())
+	node := buildMockBCHNodeWithOnlyValidator1()
+	node.blocks[4].CCTransferInfos = []*cctypes.CCTransferInfo{{UTXO: [36]byte{0x05}, Amount: 100}}
+	node.blocks[11].CCSpendInfos = []*cctypes.CCSpendInfo{{UTXO: [36]byte{0x05}, MainnetTxId: [32]byte{0x0c}}}
+	w.rpcClient = MockRpcClient{node: node}
+	w.SetNumBlocksInEpoch(1000)
+	w.SetNumBlocksInCCEpoch(10)
+	w.SetCCEpochEnabled(true)
+	go w.Run()
+	w.WaitCatchup()
+	require.Equal(t, int64(91), w.latestFinalizedHeight)
+	require.Len(t, w.CCEpochChan, 9)
+	for i := 0; i < 9; i++ {
+		e := <-w.CCEpochChan
+		require.Equal(t, int64(i*10)+1, e.StartHeight)
+		require.Equal(t, int64((i*10+9)*10*60), e.EndTime)
+		switch i {
+		case 0:
+			require.Len(t, e.TransferInfos, 1)
+			require.Equal(t, [36]byte{0x05}, e.TransferInfos[0].UTXO)
+			require.Len(t, e.SpendInfos, 0)
+		case 1:
+			require.Len(t, e.TransferInfos, 0)
+			require.Len(t, e.SpendInfos, 1)
+			require.Equal(t, [32]byte{0x0c}, e.SpendInfos[0].MainnetTxId)
+		default:
+			require.Len(t, e.TransferInfos, 0)
+			require.Len(t, e.SpendInfos, 0)
+		}
+	}
+	require.Equal(t, int64(90), w.lastCCEpochEndHeight)
+	require.Equal(t, int64(9), w.lastKnownCCEpochNum)
+}
+
 func TestRunWithFork(t *testing.T) {
 	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
 	w.rpcClient = MockRpcClient{node: buildMockBCHNodeWithReorg()}
@@ -176,6 +231,135 @@ func TestRunWithFork(t *testing.T) {
 	require.Equal(t, int64(91), w.latestFinalizedHeight)
 }
 
+// blocks from height 95 are replaced by a fork whose nominations are for validator 2
+func buildMockBCHNodeWithDeepReorg(m *MockBCHNode) {
+	for h := int64(95); h <= m.height; h++ {
+		m.blocks[h-1] = &types.BCHBlock{
+			Height:      h,
+			Timestamp:   (h - 1) * 10 * 60,
+			HashId:      [32]byte{byte(h), 0xff},
+			ParentBlk:   [32]byte{byte(h - 1), 0xff},
+			Nominations: []stakingtypes.Nomination{{Pubkey: testValidatorPubkey2, NominatedCount: 1}},
+		}
+	}
+	m.blocks[94].ParentBlk = [32]byte{94}
+}
+
+func TestAddFinalizedBlockWithDeepReorg(t *testing.T) {
+	node := buildMockBCHNodeWithOnlyValidator1()
+	node.height = 110
+	for h := int64(101); h <= node.height; h++ {
+		node.blocks = append(node.blocks, &types.BCHBlock{
+			Height:      h,
+			Timestamp:   (h - 1) * 10 * 60,
+			HashId:      [32]byte{byte(h)},
+			ParentBlk:   [32]byte{byte(h - 1)},
+			Nominations: []stakingtypes.Nomination{{Pubkey: testValidatorPubkey1, NominatedCount: 1}},
+		})
+	}
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
+	w.rpcClient = MockRpcClient{node: node}
+	w.SetNumBlocksInEpoch(100)
+	reorgs, depth, height := generic.NewCounter("reorgs"), generic.NewGauge("depth"), generic.NewGauge("height")
+	w.SetMetrics(&Metrics{DeepReorgs: reorgs, DeepReorgDepth: depth, LatestFinalizedHeight: height})
+	go func() {
+		for range w.EpochChan {
+		}
+	}()
+	for h := int64(1); h <= 98; h++ {
+		w.addFinalizedBlock(node.blocks[h-1])
+	}
+	require.Equal(t, 0.0, reorgs.Value())
+	require.Equal(t, 98.0, height.Value())
+
+	// the parent of the new block at height 99 is not the finalized one
+	buildMockBCHNodeWithDeepReorg(node)
+	w.addFinalizedBlock(node.blocks[98])
+	require.Equal(t, 1.0, reorgs.Value())
+	require.Equal(t, 4.0, depth.Value())
+	require.Equal(t, int64(99), w.latestFinalizedHeight)
+	for h := int64(95); h <= 99; h++ {
+		require.Equal(t, node.blocks[h-1].HashId, w.heightToFinalizedBlock[h].HashId)
+	}
+	require.Equal(t, [32]byte{94}, w.heightToFinalizedBlock[94].HashId)
+
+	// the epoch is built from the blocks on the best chain
+	w.addFinalizedBlock(node.blocks[99])
+	require.Equal(t, 1, len(w.epochList))
+	require.Equal(t, 2, len(w.epochList[0].Nominations))
+	require.Equal(t, testValidatorPubkey1, w.epochList[0].Nominations[0].Pubkey)
+	// the first nomination of a pubkey is counted twice by buildNewEpoch
+	require.Equal(t, int64(94+1), w.epochList[0].Nominations[0].NominatedCount)
+	require.Equal(t, testValidatorPubkey2, w.epochList[0].Nominations[1].Pubkey)
+	require.Equal(t, int64(6+1), w.epochList[0].Nominations[1].NominatedCount)
+}
+
+func TestEpochWithReorgBeforeEmitting(t *testing.T) {
+	node := buildMockBCHNodeWithOnlyValidator1()
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
+	w.rpcClient = MockRpcClient{node: node}
+	w.SetNumBlocksInEpoch(100)
+	reorgs, depth := generic.NewCounter("reorgs"), generic.NewGauge("depth")
+	w.SetMetrics(&Metrics{DeepReorgs: reorgs, DeepReorgDepth: depth, LatestFinalizedHeight: generic.NewGauge("height")})
+	go func() {
+		for range w.EpochChan {
+		}
+	}()
+	for h := int64(1); h <= 99; h++ {
+		w.addFinalizedBlock(node.blocks[h-1])
+	}
+	// the last block of the epoch comes from the new chain, and the orphaned blocks must not be used
+	buildMockBCHNodeWithDeepReorg(node)
+	w.addFinalizedBlock(node.blocks[99])
+	require.Equal(t, 1.0, reorgs.Value())
+	require.Equal(t, 1, len(w.epochList))
+	require.Equal(t, testValidatorPubkey2, w.epochList[0].Nominations[1].Pubkey)
+	require.Equal(t, int64(6+1), w.epochList[0].Nominations[1].NominatedCount)
+}
+
+func TestAddFinalizedBlockWithReorgOfEmittedEpoch(t *testing.T) {
+	node := buildMockBCHNodeWithOnlyValidator1()
+	node.height = 101
+	node.blocks = append(node.blocks, &types.BCHBlock{
+		Height:      101,
+		Timestamp:   100 * 10 * 60,
+		HashId:      [32]byte{101},
+		ParentBlk:   [32]byte{100, 0xff}, // the last block of the emitted epoch is orphaned
+		Nominations: []stakingtypes.Nomination{{Pubkey: testValidatorPubkey2, NominatedCount: 1}},
+	})
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
+	w.rpcClient = MockRpcClient{node: node}
+	w.SetNumBlocksInEpoch(100)
+	w.SetWaitingBlockDelayTime(0)
+	reorgs, depth := generic.NewCounter("reorgs"), generic.NewGauge("depth")
+	w.SetMetrics(&Metrics{DeepReorgs: reorgs, DeepReorgDepth: depth, LatestFinalizedHeight: generic.NewGauge("height")})
+	go func() {
+		for range w.EpochChan {
+		}
+	}()
+	for h := int64(1); h <= 100; h++ {
+		w.addFinalizedBlock(node.blocks[h-1])
+	}
+	require.Equal(t, 1, len(w.epochList))
+	require.Equal(t, int64(100), w.lastEmittedHeight())
+
+	// the emitted blocks can not be replaced, the watcher backs off for a bounded number of times
+	done := make(chan struct{})
+	go func() {
+		w.addFinalizedBlock(node.blocks[100])
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("addFinalizedBlock does not return")
+	}
+	require.Equal(t, float64(maxReorgRetries), reorgs.Value())
+	require.Equal(t, int64(101), w.latestFinalizedHeight)
+	require.Equal(t, [32]byte{100}, w.heightToFinalizedBlock[100].HashId)
+	require.Equal(t, [32]byte{101}, w.heightToFinalizedBlock[101].HashId)
+}
+
 func TestEpochSort(t *testing.T) {
 	epoch := &stakingtypes.Epoch{
 		Nominations: make([]*stakingtypes.Nomination, 100),